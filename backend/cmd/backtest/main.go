@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/bankai-project/backend/internal/backtest"
+	"github.com/bankai-project/backend/internal/config"
+	"github.com/bankai-project/backend/internal/db"
+	"github.com/bankai-project/backend/internal/polymarket/clob"
+)
+
+func main() {
+	marketsFlag := flag.String("markets", "", "comma-separated condition ids to replay (required)")
+	fromFlag := flag.String("from", "", "start of the window (RFC3339 or YYYY-MM-DD)")
+	toFlag := flag.String("to", "", "end of the window (RFC3339 or YYYY-MM-DD, default now)")
+	strategyName := flag.String("strategy", "mean-reversion", "strategy to run: "+strings.Join(backtest.StrategyNames(), ", "))
+	cash := flag.Float64("cash", 10000, "starting cash (USDC)")
+	size := flag.Float64("size", 100, "shares per entry")
+	slippageBps := flag.Float64("slippage-bps", 50, "slippage applied to fills without a recorded book")
+	feeBps := flag.Float64("fee-bps", 0, "fee rate in bps (CLOB formula)")
+	candle := flag.Duration("candle", 0, "aggregate ticks and trades into candles of this interval (e.g. 5m)")
+	noBooks := flag.Bool("no-books", false, "ignore recorded order books; fill at last price with slippage")
+	bookMaxAge := flag.Duration("book-max-age", 5*time.Minute, "ignore books older than this when filling")
+	tradesCSV := flag.String("trades-csv", "", "write simulated trades to this CSV file")
+	backfill := flag.Bool("backfill", false, "fetch CLOB price history for the window into price_history first")
+	fidelity := flag.Int("fidelity", 1, "backfill resolution in minutes")
+	flag.Parse()
+
+	var marketIDs []string
+	for _, id := range strings.Split(*marketsFlag, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			marketIDs = append(marketIDs, id)
+		}
+	}
+	if len(marketIDs) == 0 {
+		log.Fatal("-markets is required")
+	}
+	from, err := parseTime(*fromFlag)
+	if err != nil {
+		log.Fatalf("invalid -from: %v", err)
+	}
+	to, err := parseTime(*toFlag)
+	if err != nil {
+		log.Fatalf("invalid -to: %v", err)
+	}
+
+	strategy, err := backtest.NewStrategy(*strategyName, *size)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+
+	pgDB, err := db.ConnectPostgres(cfg)
+	if err != nil {
+		log.Fatalf("failed to connect to postgres: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *backfill {
+		rows, err := backtest.Backfill(ctx, pgDB, clob.NewClient(cfg), marketIDs, from, to, *fidelity)
+		if err != nil {
+			log.Fatalf("backfill failed: %v", err)
+		}
+		log.Printf("Backfilled %d price points", rows)
+	}
+
+	markets, events, err := backtest.NewPostgresSource(pgDB).Load(ctx, backtest.Query{
+		MarketIDs: marketIDs,
+		From:      from,
+		To:        to,
+		Candle:    *candle,
+		NoBooks:   *noBooks,
+	})
+	if err != nil {
+		log.Fatalf("failed to load history: %v", err)
+	}
+	if len(events) == 0 {
+		log.Fatal("no history stored for the requested markets and window (try -backfill)")
+	}
+
+	engine := backtest.NewEngine(backtest.Config{
+		StartingCash: *cash,
+		SlippageBps:  *slippageBps,
+		FeeRateBps:   *feeBps,
+		BookMaxAge:   *bookMaxAge,
+	})
+	result, err := engine.Run(markets, events, strategy)
+	if err != nil {
+		log.Fatalf("backtest failed: %v", err)
+	}
+
+	fmt.Print(result.Summary())
+
+	if *tradesCSV != "" {
+		f, err := os.Create(*tradesCSV)
+		if err != nil {
+			log.Fatalf("failed to create %s: %v", *tradesCSV, err)
+		}
+		defer f.Close()
+		if err := backtest.WriteTradesCSV(f, result.Trades); err != nil {
+			log.Fatalf("failed to write trades: %v", err)
+		}
+		log.Printf("Wrote %d trades to %s", len(result.Trades), *tradesCSV)
+	}
+}
+
+func parseTime(raw string) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t.UTC(), nil
+	}
+	return time.Parse("2006-01-02", raw)
+}
@@ -2,7 +2,11 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
 	miniredis "github.com/alicebob/miniredis/v2"
 	"github.com/bankai-project/backend/internal/config"
@@ -14,7 +18,14 @@ import (
 )
 
 func main() {
-	log.Println("🚀 Starting manual market sync from Gamma...")
+	full := flag.Bool("full", false, "rewrite every fetched market instead of only those with a newer market_updated_at")
+	dryRun := flag.Bool("dry-run", false, "fetch and classify markets without writing to Postgres")
+	noArchive := flag.Bool("no-archive", false, "do not archive stored markets missing from Gamma's active set")
+	pageSize := flag.Int("page-size", 100, "Gamma events per page")
+	maxPages := flag.Int("max-pages", 0, "stop after this many pages (0 = no limit); archiving is skipped when reached")
+	fresh := flag.Bool("fresh", true, "also sync the fresh drops lane")
+	history := flag.Int("history", 0, "print the last N sync runs and exit")
+	flag.Parse()
 
 	cfg, err := config.Load()
 	if err != nil {
@@ -35,19 +46,53 @@ func main() {
 	redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
 	gammaClient := gamma.NewClient(cfg)
 	service := services.NewMarketService(pgDB, redisClient, gammaClient, nil)
+	engine := services.NewMarketSyncEngine(pgDB, service)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *history > 0 {
+		runs, err := engine.GetRecentRuns(ctx, *history)
+		if err != nil {
+			log.Fatalf("failed to load sync runs: %v", err)
+		}
+		for _, run := range runs {
+			log.Printf("%s %-11s %-9s %-8s %6dms pages=%d seen=%d created=%d updated=%d archived=%d errors=%d",
+				run.StartedAt.Format("2006-01-02 15:04:05"), run.Mode, run.Status, run.Trigger, run.DurationMs,
+				run.Pages, run.MarketsSeen, run.MarketsCreated, run.MarketsUpdated, run.MarketsArchived, run.ErrorCount)
+		}
+		return
+	}
 
-	ctx := context.Background()
+	log.Println("🚀 Starting manual market sync from Gamma...")
+
+	run, err := engine.Run(ctx, services.MarketSyncOptions{
+		Trigger:     services.MarketSyncTriggerCLI,
+		Full:        *full,
+		PageSize:    *pageSize,
+		MaxPages:    *maxPages,
+		DryRun:      *dryRun,
+		SkipArchive: *noArchive,
+	})
+	if err != nil {
+		log.Fatalf("market sync failed: %v", err)
+	}
 
-	if err := service.SyncActiveMarkets(ctx); err != nil {
-		log.Fatalf("active market sync failed: %v", err)
+	log.Printf("Sync %s (%s) in %dms: pages=%d events=%d markets=%d created=%d updated=%d unchanged=%d archived=%d",
+		run.Status, run.Mode, run.DurationMs, run.Pages, run.EventsSeen, run.MarketsSeen,
+		run.MarketsCreated, run.MarketsUpdated, run.MarketsUnchanged, run.MarketsArchived)
+	for _, msg := range run.Errors {
+		log.Printf("⚠️ %s", msg)
 	}
 
-	if err := service.SyncFreshDrops(ctx); err != nil {
-		log.Printf("fresh drops sync failed: %v", err)
+	if *fresh && !*dryRun {
+		if err := service.SyncFreshDrops(ctx); err != nil {
+			log.Printf("fresh drops sync failed: %v", err)
+		}
 	}
 
 	var activeCount int64
-	if err := pgDB.Model(&models.Market{}).Where("active = ?", true).Count(&activeCount).Error; err == nil {
+	if err := pgDB.Model(&models.Market{}).Where("active = ? AND archived = ?", true, false).Count(&activeCount).Error; err == nil {
 		log.Printf("✅ Active markets stored in Postgres: %d", activeCount)
 	} else {
 		log.Printf("⚠️ Failed to count active markets: %v", err)
@@ -5,6 +5,17 @@
  * 1. Ingesting Real-Time Data (RTDS) from Polymarket via WebSocket.
  * 2. Processing background jobs (if queue is added later).
  * 3. Syncing active markets list to keep subscriptions fresh.
+ * 4. Evaluating saved screener alerts.
+ * 5. Running incremental Gamma → Postgres market syncs.
+ * 6. Firing server-held conditional (stop-loss / take-profit) orders from live prices.
+ * 7. Reconciling OCO / bracket order groups missed by the event-driven path.
+ * 8. Releasing TWAP / iceberg child orders.
+ * 9. Notifying Safe vault owners about winnings ready to redeem.
+ * 10. Matching resting paper-trading orders against live market data.
+ * 11. Recording the trade tape and sampled order books for backtests.
+ * 12. Alerting users whose resting orders drift out of the liquidity rewards band.
+ * 13. Detecting USDC deposits into user vaults from on-chain Transfer logs.
+ * 14. Following relayed transactions to confirmation or failure.
  *
  * @dependencies
  * - backend/internal/config
@@ -28,12 +39,21 @@ import (
 	"github.com/bankai-project/backend/internal/config"
 	"github.com/bankai-project/backend/internal/db"
 	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/polymarket/clob"
+	"github.com/bankai-project/backend/internal/polymarket/data_api"
 	"github.com/bankai-project/backend/internal/polymarket/gamma"
+	"github.com/bankai-project/backend/internal/polymarket/relayer"
 	"github.com/bankai-project/backend/internal/polymarket/rtds"
+	"github.com/bankai-project/backend/internal/secrets"
 	"github.com/bankai-project/backend/internal/services"
 )
 
-const maxTrackedAssets = 800
+const (
+	maxTrackedAssets = 800
+	// fullSyncEvery forces a full rewrite every Nth market sync so volume/liquidity stay fresh
+	// even when Gamma does not bump market_updated_at.
+	fullSyncEvery = 6
+)
 
 func main() {
 	logger.Info("🔥 Starting Bankai Worker...")
@@ -58,7 +78,40 @@ func main() {
 	// 3. Initialize Services
 	gammaClient := gamma.NewClient(cfg)
 	marketService := services.NewMarketService(pgDB, redisClient, gammaClient, nil)
+	screenerService := services.NewScreenerService(pgDB, marketService)
+	syncEngine := services.NewMarketSyncEngine(pgDB, marketService)
+
+	var secretBox *secrets.Box
+	if box, err := secrets.NewBox(cfg.Services.EncryptionKey); err == nil {
+		secretBox = box
+	} else {
+		logger.Info("Server-held secrets disabled: %v", err)
+	}
+	clobClient := clob.NewClient(cfg)
+	dataAPIClient := data_api.NewClient(cfg)
+	tradeService := services.NewTradeService(pgDB, clobClient)
+	profileService := services.NewProfileService(dataAPIClient, gammaClient, clobClient, redisClient)
+	tradeService.Risk = services.NewRiskService(pgDB, redisClient, profileService)
+	conditionalOrders := services.NewConditionalOrderService(pgDB, redisClient, tradeService, secretBox)
+	orderGroups := services.NewOrderGroupService(pgDB, tradeService, conditionalOrders)
+	tradeService.Groups = orderGroups
+	executionAlgos := services.NewExecutionAlgoService(pgDB, tradeService, marketService, secretBox)
+	relayerClient := relayer.NewClient(cfg)
+	relayerTransactions := services.NewRelayerTransactionService(pgDB, relayerClient)
+	ctfService := services.NewCTFService(pgDB, redisClient, relayerClient, dataAPIClient)
+	ctfService.Transactions = relayerTransactions
+	paperTrading := services.NewPaperTradingService(pgDB, redisClient, marketService)
+	rewards := services.NewRewardsService(pgDB, redisClient, marketService)
+	var deposits *services.DepositWatcherService
+	if blockchain, err := services.NewBlockchainService(cfg); err == nil {
+		defer blockchain.Close()
+		deposits = services.NewDepositWatcherService(pgDB, redisClient, blockchain)
+	} else {
+		logger.Error("Deposit watcher disabled: %v", err)
+	}
 	msgHandler := rtds.NewMessageHandler(pgDB, redisClient)
+	historyRecorder := rtds.NewHistoryRecorder(pgDB)
+	msgHandler.Recorder = historyRecorder
 	wsClient := rtds.NewClient(cfg, msgHandler)
 
 	// 4. Context with Cancellation
@@ -75,7 +128,29 @@ func main() {
 
 	go watchStreamRequests(ctx, marketService, wsClient)
 
-	go persistMarketsLoop(ctx, marketService)
+	go marketSyncLoop(ctx, syncEngine)
+
+	go screenAlertsLoop(ctx, screenerService)
+
+	go conditionalOrders.Watch(ctx)
+
+	go orderGroupLoop(ctx, orderGroups)
+
+	go executionAlgos.Run(ctx)
+
+	go redeemSweepLoop(ctx, ctfService)
+
+	go paperTrading.Run(ctx)
+
+	go historyRecorder.Run(ctx)
+
+	go rewardsAlertLoop(ctx, rewards)
+
+	if deposits != nil {
+		go depositWatchLoop(ctx, deposits)
+	}
+
+	go relayerTransactionLoop(ctx, relayerTransactions)
 
 	// 6. Subscription Loop
 	// Periodically fetch "Active Markets" and subscribe to their tokens
@@ -83,17 +158,14 @@ func main() {
 		ticker := time.NewTicker(2 * time.Minute) // Refresh subscriptions every 2 mins
 		defer ticker.Stop()
 
-		first := true
-		// Initial sync (also persists to Postgres)
-		syncSubscriptions(ctx, marketService, wsClient, first)
-		first = false
+		syncSubscriptions(ctx, marketService, wsClient)
 
 		for {
 			select {
 			case <-ctx.Done():
 				return
 			case <-ticker.C:
-				syncSubscriptions(ctx, marketService, wsClient, first)
+				syncSubscriptions(ctx, marketService, wsClient)
 			}
 		}
 	}()
@@ -116,8 +188,8 @@ func main() {
 }
 
 // syncSubscriptions fetches active markets and subscribes to their tokens.
-// Optionally persists markets on the first run to avoid empty DB reads after restarts.
-func syncSubscriptions(ctx context.Context, ms *services.MarketService, ws *rtds.Client, persist bool) {
+// Persistence to Postgres is handled separately by marketSyncLoop.
+func syncSubscriptions(ctx context.Context, ms *services.MarketService, ws *rtds.Client) {
 	logger.Info("🔄 Syncing market subscriptions...")
 
 	// 1. Ensure our local DB has fresh data from Gamma
@@ -133,12 +205,6 @@ func syncSubscriptions(ctx context.Context, ms *services.MarketService, ws *rtds
 		// Don't return - continue with active markets even if fresh drops fail
 	}
 
-	if persist {
-		if err := ms.PersistActiveMarkets(ctx); err != nil {
-			logger.Error("PersistActiveMarkets (initial) failed: %v", err)
-		}
-	}
-
 	// 2. Get prioritised market assets (top liquidity/volume)
 	marketAssets, err := ms.GetMarketAssets(ctx, maxTrackedAssets)
 	if err != nil {
@@ -208,17 +274,143 @@ func watchStreamRequests(ctx context.Context, ms *services.MarketService, ws *rt
 	}
 }
 
-func persistMarketsLoop(ctx context.Context, ms *services.MarketService) {
+// marketSyncLoop persists Gamma deltas to Postgres. The first pass after startup is a full sync
+// so restarts never serve a stale catalogue.
+func marketSyncLoop(ctx context.Context, engine *services.MarketSyncEngine) {
 	ticker := time.NewTicker(10 * time.Minute)
 	defer ticker.Stop()
 
+	for runs := 0; ; runs++ {
+		opts := services.MarketSyncOptions{
+			Trigger: services.MarketSyncTriggerWorker,
+			Full:    runs%fullSyncEvery == 0,
+		}
+		if _, err := engine.Run(ctx, opts); err != nil {
+			logger.Error("Market sync failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func screenAlertsLoop(ctx context.Context, ss *services.ScreenerService) {
+	ticker := time.NewTicker(5 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := ss.CheckAlerts(ctx); err != nil {
+				logger.Error("Screen alert check failed: %v", err)
+			}
+		}
+	}
+}
+
+// orderGroupLoop periodically reconciles open order groups. Fills normally reconcile their group as
+// orders are synced; the sweep catches groups whose legs changed while no request touched them.
+func orderGroupLoop(ctx context.Context, og *services.OrderGroupService) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := og.ReconcileOpen(ctx); err != nil {
+				logger.Error("Order group reconciliation failed: %v", err)
+			}
+		}
+	}
+}
+
+// redeemSweepLoop tells vault owners when resolved markets leave winnings to redeem.
+// Redemption itself needs the owner's signature, so the sweep only notifies.
+func redeemSweepLoop(ctx context.Context, ctf *services.CTFService) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sent, err := ctf.SweepRedeemable(ctx)
+			if err != nil {
+				logger.Error("Redeem sweep failed: %v", err)
+			}
+			if sent > 0 {
+				logger.Info("Redeem sweep sent %d notifications", sent)
+			}
+		}
+	}
+}
+
+// rewardsAlertLoop re-checks resting orders in rewarded markets and alerts when one leaves the reward band.
+func rewardsAlertLoop(ctx context.Context, rs *services.RewardsService) {
+	ticker := time.NewTicker(2 * time.Minute)
+	defer ticker.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			if err := ms.PersistActiveMarkets(ctx); err != nil {
-				logger.Error("PersistActiveMarkets failed: %v", err)
+			sent, err := rs.CheckAlerts(ctx)
+			if err != nil {
+				logger.Error("Rewards alert check failed: %v", err)
+			}
+			if sent > 0 {
+				logger.Info("Rewards check sent %d drift alerts", sent)
+			}
+		}
+	}
+}
+
+// depositWatchLoop records confirmed USDC transfers into vaults and notifies their owners.
+func depositWatchLoop(ctx context.Context, dw *services.DepositWatcherService) {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			recorded, err := dw.Scan(ctx)
+			if err != nil {
+				logger.Error("Deposit scan failed: %v", err)
+			}
+			if recorded > 0 {
+				logger.Info("Deposit scan recorded %d deposits", recorded)
+			}
+		}
+	}
+}
+
+// relayerTransactionLoop polls the relayer for pending transactions until they confirm or fail.
+func relayerTransactionLoop(ctx context.Context, rt *services.RelayerTransactionService) {
+	ticker := time.NewTicker(15 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			settled, err := rt.PollPending(ctx)
+			if err != nil {
+				logger.Error("Relayer transaction poll failed: %v", err)
+			}
+			if settled > 0 {
+				logger.Info("Relayer poll settled %d transactions", settled)
 			}
 		}
 	}
@@ -0,0 +1,163 @@
+/**
+ * @description
+ * Market Calendar API Handlers.
+ * Serves the public market calendar and per-user iCalendar subscription feeds.
+ *
+ * @dependencies
+ * - github.com/gofiber/fiber/v2
+ * - backend/internal/services
+ * - backend/internal/api/middleware
+ */
+
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bankai-project/backend/internal/api/middleware"
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const defaultCalendarRange = 14 * 24 * time.Hour
+
+// CalendarHandler handles calendar-related requests
+type CalendarHandler struct {
+	db              *gorm.DB
+	calendarService *services.CalendarService
+}
+
+// NewCalendarHandler creates a new CalendarHandler
+func NewCalendarHandler(db *gorm.DB, calendarService *services.CalendarService) *CalendarHandler {
+	return &CalendarHandler{
+		db:              db,
+		calendarService: calendarService,
+	}
+}
+
+// GetMarketCalendar returns upcoming market end/start times grouped by day
+// GET /api/v1/markets/calendar?from=2025-06-01&to=2025-06-14&category=politics&tag=elections
+func (h *CalendarHandler) GetMarketCalendar(c *fiber.Ctx) error {
+	now := time.Now().UTC()
+
+	from := now
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := parseCalendarTime(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid from parameter (use RFC3339 or YYYY-MM-DD)"})
+		}
+		from = parsed
+	}
+
+	to := from.Add(defaultCalendarRange)
+	if raw := c.Query("to"); raw != "" {
+		parsed, err := parseCalendarTime(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid to parameter (use RFC3339 or YYYY-MM-DD)"})
+		}
+		to = parsed
+	}
+
+	calendar, err := h.calendarService.GetCalendar(c.Context(), services.CalendarParams{
+		From:     from,
+		To:       to,
+		Category: c.Query("category"),
+		Tag:      c.Query("tag"),
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCalendarRange) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		logger.Error("CalendarHandler: Failed to load market calendar: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to load market calendar"})
+	}
+
+	return c.JSON(calendar)
+}
+
+// GetCalendarFeed returns the user's iCalendar subscription URL, creating it on first use
+// GET /api/v1/user/calendar-feed
+func (h *CalendarHandler) GetCalendarFeed(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	var user models.User
+	if err := h.db.Where("clerk_id = ?", clerkID).First(&user).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User not found"})
+	}
+
+	feed, err := h.calendarService.GetOrCreateFeed(c.Context(), user.ID)
+	if err != nil {
+		logger.Error("CalendarHandler: Failed to load calendar feed: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to load calendar feed"})
+	}
+
+	return c.JSON(calendarFeedResponse(feed))
+}
+
+// RotateCalendarFeed issues a new feed token, invalidating existing subscriptions
+// POST /api/v1/user/calendar-feed/rotate
+func (h *CalendarHandler) RotateCalendarFeed(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	var user models.User
+	if err := h.db.Where("clerk_id = ?", clerkID).First(&user).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User not found"})
+	}
+
+	feed, err := h.calendarService.RotateFeed(c.Context(), user.ID)
+	if err != nil {
+		logger.Error("CalendarHandler: Failed to rotate calendar feed: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to rotate calendar feed"})
+	}
+
+	return c.JSON(calendarFeedResponse(feed))
+}
+
+// GetCalendarFeedICS serves the iCalendar document for a feed token.
+// Public so calendar apps can subscribe; the token itself authorizes access.
+// GET /api/v1/markets/calendar/feed/:token.ics
+func (h *CalendarHandler) GetCalendarFeedICS(c *fiber.Ctx) error {
+	token := strings.TrimSuffix(c.Params("token"), ".ics")
+
+	body, err := h.calendarService.RenderFeed(c.Context(), token)
+	if err != nil {
+		if errors.Is(err, services.ErrCalendarFeedNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Calendar feed not found"})
+		}
+		logger.Error("CalendarHandler: Failed to render calendar feed: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to render calendar feed"})
+	}
+
+	c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `inline; filename="bankai.ics"`)
+	c.Set(fiber.HeaderCacheControl, "private, max-age=300")
+	return c.SendString(body)
+}
+
+func calendarFeedResponse(feed *models.CalendarFeed) fiber.Map {
+	return fiber.Map{
+		"token":            feed.Token,
+		"path":             fmt.Sprintf("/api/v1/markets/calendar/feed/%s.ics", feed.Token),
+		"last_accessed_at": feed.LastAccessedAt,
+		"created_at":       feed.CreatedAt,
+	}
+}
+
+func parseCalendarTime(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t.UTC(), nil
+	}
+	return time.Parse("2006-01-02", raw)
+}
@@ -0,0 +1,159 @@
+/**
+ * @description
+ * Conditional Order API Handlers.
+ * Arms, lists and cancels server-held stop-loss / take-profit orders.
+ *
+ * @dependencies
+ * - github.com/gofiber/fiber/v2
+ * - backend/internal/services
+ * - backend/internal/api/middleware
+ */
+
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bankai-project/backend/internal/api/middleware"
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ConditionalOrderHandler handles conditional order requests
+type ConditionalOrderHandler struct {
+	db      *gorm.DB
+	service *services.ConditionalOrderService
+}
+
+// NewConditionalOrderHandler creates a new ConditionalOrderHandler
+func NewConditionalOrderHandler(db *gorm.DB, service *services.ConditionalOrderService) *ConditionalOrderHandler {
+	return &ConditionalOrderHandler{
+		db:      db,
+		service: service,
+	}
+}
+
+// CreateConditionalOrder stores a pre-signed FOK/FAK sell order to be submitted when its trigger fires
+// POST /api/v1/trade/conditional
+func (h *ConditionalOrderHandler) CreateConditionalOrder(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	var input services.ConditionalOrderInput
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	order, err := h.service.Create(c.Context(), user, input)
+	if err != nil {
+		return conditionalOrderError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(order)
+}
+
+// GetConditionalOrders lists the user's conditional orders
+// GET /api/v1/trade/conditional?status=ARMED&limit=50&offset=0
+func (h *ConditionalOrderHandler) GetConditionalOrders(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	limit, offset, err := parsePagination(c.Query("limit"), c.Query("offset"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if limit > 200 {
+		limit = 200
+	}
+
+	orders, total, err := h.service.List(c.Context(), user.ID, c.Query("status"), limit, offset)
+	if err != nil {
+		logger.Error("ConditionalOrderHandler: Failed to list orders: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch conditional orders"})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":   orders,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// CancelConditionalOrder disarms a conditional order
+// DELETE /api/v1/trade/conditional/:id
+func (h *ConditionalOrderHandler) CancelConditionalOrder(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	orderID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid conditional order ID"})
+	}
+
+	order, err := h.service.Cancel(c.Context(), user.ID, orderID)
+	if err != nil {
+		return conditionalOrderError(c, err)
+	}
+
+	return c.JSON(order)
+}
+
+func (h *ConditionalOrderHandler) fetchUserRecord(ctx context.Context, clerkID string) (*models.User, error) {
+	var user models.User
+	if err := h.db.WithContext(ctx).Where("clerk_id = ?", clerkID).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func conditionalOrderError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidConditionalOrder):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, services.ErrConditionalOrderNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Conditional order not found"})
+	case errors.Is(err, services.ErrConditionalOrderNotArmed), errors.Is(err, services.ErrConditionalOrderLimit):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, services.ErrConditionalOrdersDisabled):
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": err.Error()})
+	default:
+		logger.Error("ConditionalOrderHandler: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to process conditional order"})
+	}
+}
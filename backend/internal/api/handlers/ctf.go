@@ -0,0 +1,345 @@
+/**
+ * @description
+ * CTF API Handlers.
+ * Prepares gasless split / merge / redeem / approval Safe transactions for the user's vault, submits the signed
+ * plans through the relayer and reports relayer transaction status and trading readiness.
+ *
+ * @dependencies
+ * - github.com/gofiber/fiber/v2
+ * - backend/internal/services
+ * - backend/internal/api/middleware
+ */
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/bankai-project/backend/internal/api/middleware"
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/polymarket/relayer"
+	"github.com/bankai-project/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// CTFHandler handles split, merge, redemption and approval requests
+type CTFHandler struct {
+	db         *gorm.DB
+	service    *services.CTFService
+	Blockchain *services.BlockchainService // Optional; readiness and approvals are unavailable without it
+}
+
+// NewCTFHandler creates a new CTFHandler
+func NewCTFHandler(db *gorm.DB, service *services.CTFService) *CTFHandler {
+	return &CTFHandler{
+		db:      db,
+		service: service,
+	}
+}
+
+// PrepareRedeemRequest selects the conditions to redeem; empty means all redeemable
+type PrepareRedeemRequest struct {
+	ConditionIDs []string `json:"conditionIds"`
+}
+
+// SplitMergeRequest sizes a split (USDC.e in) or merge (full YES + NO sets in)
+type SplitMergeRequest struct {
+	ConditionID string  `json:"conditionId"`
+	Amount      float64 `json:"amount"`
+}
+
+// SubmitCTFPlanRequest carries the owner's personal_sign signature over the plan's safeTxHash
+type SubmitCTFPlanRequest struct {
+	PlanID    string `json:"planId"`
+	Signature string `json:"signature"`
+}
+
+// GetRedeemable lists redeemable positions in resolved markets for the user's vault
+// GET /api/v1/wallet/redeemable
+func (h *CTFHandler) GetRedeemable(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+	if user.VaultAddress == "" {
+		return c.JSON(fiber.Map{"data": []services.RedeemableCondition{}, "payout": 0})
+	}
+
+	conditions, err := h.service.ListRedeemable(c.Context(), user.VaultAddress)
+	if err != nil {
+		return ctfError(c, err)
+	}
+
+	payout := 0.0
+	for _, cond := range conditions {
+		payout += cond.Payout
+	}
+	return c.JSON(fiber.Map{
+		"data":   conditions,
+		"payout": payout,
+	})
+}
+
+// PrepareRedeem builds the Safe transaction and typed data for a redemption
+// POST /api/v1/wallet/redeem/prepare
+func (h *CTFHandler) PrepareRedeem(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	var req PrepareRedeemRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+		}
+	}
+
+	plan, err := h.service.PrepareRedeem(c.Context(), user, req.ConditionIDs)
+	if err != nil {
+		return ctfError(c, err)
+	}
+
+	return c.JSON(plan)
+}
+
+// PrepareSplit builds a split of USDC.e into YES + NO sets
+// POST /api/v1/wallet/ctf/split
+func (h *CTFHandler) PrepareSplit(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	var req SplitMergeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	plan, err := h.service.PrepareSplit(c.Context(), user, req.ConditionID, req.Amount)
+	if err != nil {
+		return ctfError(c, err)
+	}
+
+	return c.JSON(plan)
+}
+
+// PrepareMerge builds a merge of YES + NO sets back into USDC.e
+// POST /api/v1/wallet/ctf/merge
+func (h *CTFHandler) PrepareMerge(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	var req SplitMergeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	plan, err := h.service.PrepareMerge(c.Context(), user, req.ConditionID, req.Amount)
+	if err != nil {
+		return ctfError(c, err)
+	}
+
+	return c.JSON(plan)
+}
+
+// SubmitPlan relays a signed split, merge or redeem plan
+// POST /api/v1/wallet/ctf/submit
+func (h *CTFHandler) SubmitPlan(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	var req SubmitCTFPlanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if strings.TrimSpace(req.PlanID) == "" || strings.TrimSpace(req.Signature) == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "planId and signature are required"})
+	}
+
+	submission, err := h.service.SubmitPlan(c.Context(), user, req.PlanID, req.Signature)
+	if err != nil {
+		return ctfError(c, err)
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(submission)
+}
+
+// GetTransaction returns the relayer status of a submitted CTF transaction
+// GET /api/v1/wallet/ctf/transactions/:id
+func (h *CTFHandler) GetTransaction(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	tx, err := h.service.GetTransactionStatus(c.Context(), user, c.Params("id"))
+	if err != nil {
+		return ctfError(c, err)
+	}
+
+	return c.JSON(tx)
+}
+
+// GetReadiness reports the vault's deployment, collateral balance and missing trading approvals
+// GET /api/v1/wallet/readiness
+func (h *CTFHandler) GetReadiness(c *fiber.Ctx) error {
+	if h.Blockchain == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "Blockchain service unavailable"})
+	}
+
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+	if user.VaultAddress == "" {
+		return c.JSON(fiber.Map{
+			"vaultAddress":           "",
+			"deployed":               false,
+			"ready":                  false,
+			"missing":                relayer.RequiredTradingApprovals(),
+			"relaySupported":         false,
+			"relayUnsupportedReason": services.ErrCTFUnsupportedVault.Error(),
+		})
+	}
+
+	readiness, err := h.Blockchain.GetTradingReadiness(c.Context(), user.VaultAddress)
+	if err != nil {
+		return ctfError(c, err)
+	}
+	readiness.RelaySupported = true
+	if err := h.service.CheckWallet(user); err != nil {
+		readiness.RelaySupported = false
+		readiness.RelayUnsupportedReason = err.Error()
+	}
+
+	return c.JSON(readiness)
+}
+
+// PrepareApprovals builds one Safe transaction granting every missing trading approval
+// POST /api/v1/wallet/approvals/prepare
+func (h *CTFHandler) PrepareApprovals(c *fiber.Ctx) error {
+	if h.Blockchain == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "Blockchain service unavailable"})
+	}
+
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+	if err := h.service.CheckWallet(user); err != nil {
+		return ctfError(c, err)
+	}
+
+	readiness, err := h.Blockchain.GetTradingReadiness(c.Context(), user.VaultAddress)
+	if err != nil {
+		return ctfError(c, err)
+	}
+	if !readiness.Deployed {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Vault is not deployed yet"})
+	}
+	if len(readiness.Missing) == 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "All trading approvals are already granted"})
+	}
+
+	plan, err := h.service.PrepareApprovals(c.Context(), user, readiness.Missing)
+	if err != nil {
+		return ctfError(c, err)
+	}
+
+	return c.JSON(plan)
+}
+
+func (h *CTFHandler) fetchUserRecord(ctx context.Context, clerkID string) (*models.User, error) {
+	var user models.User
+	if err := h.db.WithContext(ctx).Where("clerk_id = ?", clerkID).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func ctfError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidCTFRequest), errors.Is(err, services.ErrCTFUnsupportedVault),
+		errors.Is(err, services.ErrCTFUnsupportedWalletType):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, services.ErrNothingToRedeem), errors.Is(err, services.ErrCTFPlanNotFound),
+		errors.Is(err, services.ErrCTFTransactionNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	default:
+		logger.Error("CTFHandler: %v", err)
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": "Failed to process CTF request"})
+	}
+}
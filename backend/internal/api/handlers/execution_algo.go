@@ -0,0 +1,225 @@
+/**
+ * @description
+ * Execution Algo API Handlers.
+ * Starts, inspects, tops up and cancels TWAP / iceberg execution algos.
+ *
+ * @dependencies
+ * - github.com/gofiber/fiber/v2
+ * - backend/internal/services
+ * - backend/internal/api/middleware
+ */
+
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bankai-project/backend/internal/api/middleware"
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/polymarket/clob"
+	"github.com/bankai-project/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ExecutionAlgoHandler handles execution algo requests
+type ExecutionAlgoHandler struct {
+	db      *gorm.DB
+	service *services.ExecutionAlgoService
+}
+
+// NewExecutionAlgoHandler creates a new ExecutionAlgoHandler
+func NewExecutionAlgoHandler(db *gorm.DB, service *services.ExecutionAlgoService) *ExecutionAlgoHandler {
+	return &ExecutionAlgoHandler{
+		db:      db,
+		service: service,
+	}
+}
+
+// CreateExecutionAlgo starts a TWAP or iceberg algo from a batch of signed child orders
+// POST /api/v1/trade/algos
+func (h *ExecutionAlgoHandler) CreateExecutionAlgo(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	var input services.ExecutionAlgoInput
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	algo, err := h.service.Create(c.Context(), user, input)
+	if err != nil {
+		return executionAlgoError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(algo)
+}
+
+// GetExecutionAlgos lists the user's execution algos
+// GET /api/v1/trade/algos?status=RUNNING&limit=50&offset=0
+func (h *ExecutionAlgoHandler) GetExecutionAlgos(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	limit, offset, err := parsePagination(c.Query("limit"), c.Query("offset"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if limit > 200 {
+		limit = 200
+	}
+
+	algos, total, err := h.service.List(c.Context(), user.ID, c.Query("status"), limit, offset)
+	if err != nil {
+		logger.Error("ExecutionAlgoHandler: Failed to list algos: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch execution algos"})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":   algos,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// GetExecutionAlgo returns progress, average fill price, remaining size and children for an algo
+// GET /api/v1/trade/algos/:id
+func (h *ExecutionAlgoHandler) GetExecutionAlgo(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	algoID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid execution algo ID"})
+	}
+
+	algo, err := h.service.Get(c.Context(), user.ID, algoID)
+	if err != nil {
+		return executionAlgoError(c, err)
+	}
+
+	return c.JSON(algo)
+}
+
+// AddExecutionAlgoChildren appends signed child orders to an active algo
+// POST /api/v1/trade/algos/:id/children
+func (h *ExecutionAlgoHandler) AddExecutionAlgoChildren(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	algoID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid execution algo ID"})
+	}
+
+	var req struct {
+		Children []clob.PostOrderRequest `json:"children"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	algo, err := h.service.AddChildren(c.Context(), user, algoID, req.Children)
+	if err != nil {
+		return executionAlgoError(c, err)
+	}
+
+	return c.JSON(algo)
+}
+
+// CancelExecutionAlgo stops an algo and drops its queued children
+// DELETE /api/v1/trade/algos/:id
+func (h *ExecutionAlgoHandler) CancelExecutionAlgo(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	algoID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid execution algo ID"})
+	}
+
+	algo, err := h.service.Cancel(c.Context(), user.ID, algoID)
+	if err != nil {
+		return executionAlgoError(c, err)
+	}
+
+	return c.JSON(algo)
+}
+
+func (h *ExecutionAlgoHandler) fetchUserRecord(ctx context.Context, clerkID string) (*models.User, error) {
+	var user models.User
+	if err := h.db.WithContext(ctx).Where("clerk_id = ?", clerkID).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func executionAlgoError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidExecutionAlgo):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, services.ErrExecutionAlgoNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Execution algo not found"})
+	case errors.Is(err, services.ErrExecutionAlgoClosed), errors.Is(err, services.ErrExecutionAlgoLimit):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, services.ErrExecutionAlgosDisabled):
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": err.Error()})
+	default:
+		logger.Error("ExecutionAlgoHandler: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to process execution algo"})
+	}
+}
@@ -0,0 +1,240 @@
+/**
+ * @description
+ * Trade Journal API Handlers.
+ * CRUD for journal entries attached to orders, markets or positions, with tag / market / status filters.
+ *
+ * @dependencies
+ * - github.com/gofiber/fiber/v2
+ * - backend/internal/services
+ * - backend/internal/api/middleware
+ */
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/bankai-project/backend/internal/api/middleware"
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// JournalHandler handles trade journal requests
+type JournalHandler struct {
+	db      *gorm.DB
+	service *services.JournalService
+}
+
+// NewJournalHandler creates a new JournalHandler
+func NewJournalHandler(db *gorm.DB, service *services.JournalService) *JournalHandler {
+	return &JournalHandler{
+		db:      db,
+		service: service,
+	}
+}
+
+// GetJournalEntries lists the user's journal entries with their realized outcomes
+// GET /api/v1/journal?tag=thesis&market_id=0x..&subject=ORDER&status=resolved&q=fed&from=2025-01-01&to=2025-02-01&limit=50&offset=0
+func (h *JournalHandler) GetJournalEntries(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	limit, offset, err := parsePagination(c.Query("limit"), c.Query("offset"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	filter := services.JournalFilter{
+		MarketID: strings.TrimSpace(c.Query("market_id")),
+		Subject:  models.JournalSubject(strings.ToUpper(strings.TrimSpace(c.Query("subject")))),
+		Status:   strings.ToLower(strings.TrimSpace(c.Query("status"))),
+		Query:    c.Query("q"),
+		Limit:    limit,
+		Offset:   offset,
+	}
+	if raw := c.Query("tag"); raw != "" {
+		filter.Tags = strings.Split(raw, ",")
+	}
+	if raw := c.Query("from"); raw != "" {
+		from, err := parseCalendarTime(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid from parameter (use RFC3339 or YYYY-MM-DD)"})
+		}
+		filter.From = &from
+	}
+	if raw := c.Query("to"); raw != "" {
+		to, err := parseCalendarTime(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid to parameter (use RFC3339 or YYYY-MM-DD)"})
+		}
+		filter.To = &to
+	}
+
+	entries, total, err := h.service.ListEntries(c.Context(), user.ID, filter)
+	if err != nil {
+		return journalError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data":   entries,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// GetJournalEntry returns a single journal entry
+// GET /api/v1/journal/:id
+func (h *JournalHandler) GetJournalEntry(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	entryID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid journal entry ID"})
+	}
+
+	entry, err := h.service.GetEntry(c.Context(), user.ID, entryID)
+	if err != nil {
+		return journalError(c, err)
+	}
+
+	return c.JSON(entry)
+}
+
+// CreateJournalEntry records a new journal entry
+// POST /api/v1/journal
+func (h *JournalHandler) CreateJournalEntry(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	var input services.JournalEntryInput
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	entry, err := h.service.CreateEntry(c.Context(), user.ID, input)
+	if err != nil {
+		return journalError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(entry)
+}
+
+// UpdateJournalEntry replaces the notes, tags, confidence, planned exit and post-mortem of an entry
+// PUT /api/v1/journal/:id
+func (h *JournalHandler) UpdateJournalEntry(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	entryID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid journal entry ID"})
+	}
+
+	var input services.JournalEntryUpdate
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	entry, err := h.service.UpdateEntry(c.Context(), user.ID, entryID, input)
+	if err != nil {
+		return journalError(c, err)
+	}
+
+	return c.JSON(entry)
+}
+
+// DeleteJournalEntry removes a journal entry
+// DELETE /api/v1/journal/:id
+func (h *JournalHandler) DeleteJournalEntry(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	entryID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid journal entry ID"})
+	}
+
+	if err := h.service.DeleteEntry(c.Context(), user.ID, entryID); err != nil {
+		return journalError(c, err)
+	}
+
+	return c.JSON(fiber.Map{"success": true})
+}
+
+func (h *JournalHandler) fetchUserRecord(ctx context.Context, clerkID string) (*models.User, error) {
+	var user models.User
+	if err := h.db.WithContext(ctx).Where("clerk_id = ?", clerkID).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func journalError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrJournalEntryNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Journal entry not found"})
+	case errors.Is(err, services.ErrInvalidJournalEntry):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	default:
+		logger.Error("JournalHandler: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to process journal request"})
+	}
+}
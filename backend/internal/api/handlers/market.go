@@ -223,6 +223,51 @@ func (h *MarketHandler) GetMarketBySlug(c *fiber.Ctx) error {
 	return c.JSON(market)
 }
 
+// GetMarketChanges returns the recorded rule, date and status edits for a market.
+// GET /api/v1/markets/:condition_id/changes?limit=50&offset=0
+func (h *MarketHandler) GetMarketChanges(c *fiber.Ctx) error {
+	conditionID := strings.TrimSpace(c.Params("condition_id"))
+	if conditionID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "condition_id param is required"})
+	}
+
+	limit, offset, err := parsePagination(c.Query("limit"), c.Query("offset"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if limit > 200 {
+		limit = 200
+	}
+
+	changes, err := h.Service.GetMarketChanges(c.Context(), conditionID, limit, offset)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch market changes"})
+	}
+
+	return c.JSON(fiber.Map{
+		"condition_id": conditionID,
+		"changes":      changes,
+		"limit":        limit,
+		"offset":       offset,
+	})
+}
+
+// GetMarketActivity returns rolling 5m/1h/24h activity windows and decayed scores for a market.
+// GET /api/v1/markets/:condition_id/activity
+func (h *MarketHandler) GetMarketActivity(c *fiber.Ctx) error {
+	conditionID := strings.TrimSpace(c.Params("condition_id"))
+	if conditionID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "condition_id param is required"})
+	}
+
+	stats, err := h.Service.GetMarketActivity(c.Context(), conditionID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch market activity"})
+	}
+
+	return c.JSON(stats)
+}
+
 // GetDepthEstimate returns an estimated execution summary for a market/token pair.
 func (h *MarketHandler) GetDepthEstimate(c *fiber.Ctx) error {
 	marketID := c.Params("condition_id")
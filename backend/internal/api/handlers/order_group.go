@@ -0,0 +1,188 @@
+/**
+ * @description
+ * Order Group API Handlers.
+ * Creates, lists and cancels OCO and bracket order groups.
+ *
+ * @dependencies
+ * - github.com/gofiber/fiber/v2
+ * - backend/internal/services
+ * - backend/internal/api/middleware
+ */
+
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bankai-project/backend/internal/api/middleware"
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OrderGroupHandler handles order group requests
+type OrderGroupHandler struct {
+	db      *gorm.DB
+	service *services.OrderGroupService
+}
+
+// NewOrderGroupHandler creates a new OrderGroupHandler
+func NewOrderGroupHandler(db *gorm.DB, service *services.OrderGroupService) *OrderGroupHandler {
+	return &OrderGroupHandler{
+		db:      db,
+		service: service,
+	}
+}
+
+// CreateOrderGroup links an entry and/or exit orders into an OCO or bracket group
+// POST /api/v1/trade/groups
+func (h *OrderGroupHandler) CreateOrderGroup(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	var input services.OrderGroupInput
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	group, err := h.service.Create(c.Context(), user, input)
+	if err != nil {
+		return orderGroupError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(group)
+}
+
+// GetOrderGroups lists the user's order groups with their legs
+// GET /api/v1/trade/groups?status=ACTIVE&limit=50&offset=0
+func (h *OrderGroupHandler) GetOrderGroups(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	limit, offset, err := parsePagination(c.Query("limit"), c.Query("offset"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if limit > 200 {
+		limit = 200
+	}
+
+	groups, total, err := h.service.List(c.Context(), user.ID, c.Query("status"), limit, offset)
+	if err != nil {
+		logger.Error("OrderGroupHandler: Failed to list groups: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch order groups"})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":   groups,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// GetOrderGroup returns a single order group
+// GET /api/v1/trade/groups/:id
+func (h *OrderGroupHandler) GetOrderGroup(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	groupID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid order group ID"})
+	}
+
+	group, err := h.service.Get(c.Context(), user.ID, groupID)
+	if err != nil {
+		return orderGroupError(c, err)
+	}
+
+	return c.JSON(group)
+}
+
+// CancelOrderGroup cancels every working leg and closes the group
+// DELETE /api/v1/trade/groups/:id
+func (h *OrderGroupHandler) CancelOrderGroup(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	groupID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid order group ID"})
+	}
+
+	group, err := h.service.Cancel(c.Context(), user.ID, groupID)
+	if err != nil {
+		return orderGroupError(c, err)
+	}
+
+	return c.JSON(group)
+}
+
+func (h *OrderGroupHandler) fetchUserRecord(ctx context.Context, clerkID string) (*models.User, error) {
+	var user models.User
+	if err := h.db.WithContext(ctx).Where("clerk_id = ?", clerkID).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func orderGroupError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidOrderGroup), errors.Is(err, services.ErrInvalidConditionalOrder):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, services.ErrOrderGroupNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Order group not found"})
+	case errors.Is(err, services.ErrOrderGroupClosed), errors.Is(err, services.ErrConditionalOrderLimit):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, services.ErrConditionalOrdersDisabled):
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": err.Error()})
+	default:
+		logger.Error("OrderGroupHandler: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to process order group"})
+	}
+}
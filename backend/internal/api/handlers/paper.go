@@ -0,0 +1,168 @@
+/**
+ * @description
+ * Paper Trading API Handlers.
+ * Exposes the user's virtual account: portfolio marked to live prices, simulated fills and resets.
+ * Orders themselves go through the regular /trade endpoints with ?mode=paper.
+ *
+ * @dependencies
+ * - github.com/gofiber/fiber/v2
+ * - backend/internal/services
+ * - backend/internal/api/middleware
+ */
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/bankai-project/backend/internal/api/middleware"
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const tradingModePaper = "paper"
+
+// PaperHandler handles paper account requests
+type PaperHandler struct {
+	db      *gorm.DB
+	service *services.PaperTradingService
+}
+
+// NewPaperHandler creates a new PaperHandler
+func NewPaperHandler(db *gorm.DB, service *services.PaperTradingService) *PaperHandler {
+	return &PaperHandler{
+		db:      db,
+		service: service,
+	}
+}
+
+// ResetPaperAccountRequest optionally sets a new starting balance
+type ResetPaperAccountRequest struct {
+	StartingBalance float64 `json:"startingBalance"`
+}
+
+// GetPaperAccount returns the paper account marked to market
+// GET /api/v1/trade/paper/account
+func (h *PaperHandler) GetPaperAccount(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	portfolio, err := h.service.Portfolio(c.Context(), user.ID)
+	if err != nil {
+		return paperError(c, err)
+	}
+
+	return c.JSON(portfolio)
+}
+
+// GetPaperFills lists simulated executions
+// GET /api/v1/trade/paper/fills
+func (h *PaperHandler) GetPaperFills(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	limit, offset, parseErr := parsePagination(c.Query("limit"), c.Query("offset"))
+	if parseErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": parseErr.Error()})
+	}
+
+	fills, total, err := h.service.ListFills(c.Context(), user.ID, limit, offset)
+	if err != nil {
+		return paperError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data":   fills,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// ResetPaperAccount clears paper orders and positions and restores the balance
+// POST /api/v1/trade/paper/reset
+func (h *PaperHandler) ResetPaperAccount(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	var req ResetPaperAccountRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+		}
+	}
+
+	account, err := h.service.ResetAccount(c.Context(), user.ID, req.StartingBalance)
+	if err != nil {
+		return paperError(c, err)
+	}
+
+	return c.JSON(account)
+}
+
+func (h *PaperHandler) fetchUserRecord(ctx context.Context, clerkID string) (*models.User, error) {
+	var user models.User
+	if err := h.db.WithContext(ctx).Where("clerk_id = ?", clerkID).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// isPaperMode reports whether a /trade request targets the paper account.
+func isPaperMode(c *fiber.Ctx) bool {
+	mode := c.Query("mode")
+	if mode == "" {
+		mode = c.Get("X-Trading-Mode")
+	}
+	return strings.EqualFold(strings.TrimSpace(mode), tradingModePaper)
+}
+
+func paperError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidPaperOrder), errors.Is(err, services.ErrInvalidPaperBalance):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, services.ErrPaperInsufficientCash), errors.Is(err, services.ErrPaperInsufficientSize),
+		errors.Is(err, services.ErrPaperOrderLimit):
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, services.ErrOrderBookUnavailable):
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": err.Error()})
+	default:
+		logger.Error("Paper trading request failed: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to process paper trading request"})
+	}
+}
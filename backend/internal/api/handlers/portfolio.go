@@ -0,0 +1,170 @@
+/**
+ * @description
+ * Portfolio API Handlers.
+ * Serves the user's positions reconciled against on-chain balances, and the tax report: cost-basis lots and
+ * realized PnL per closing trade, as JSON or CSV.
+ *
+ * @dependencies
+ * - github.com/gofiber/fiber/v2
+ * - backend/internal/services
+ * - backend/internal/api/middleware
+ */
+
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bankai-project/backend/internal/api/middleware"
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// PortfolioHandler handles portfolio reporting requests
+type PortfolioHandler struct {
+	db        *gorm.DB
+	taxLots   *services.TaxLotService
+	positions *services.PositionReconcileService
+
+	Wallets *services.UserWalletService // Optional; enables the ?wallet= selector on positions
+}
+
+// NewPortfolioHandler creates a new PortfolioHandler
+func NewPortfolioHandler(db *gorm.DB, taxLots *services.TaxLotService, positions *services.PositionReconcileService) *PortfolioHandler {
+	return &PortfolioHandler{
+		db:        db,
+		taxLots:   taxLots,
+		positions: positions,
+	}
+}
+
+// GetPositions returns the user's positions with on-chain sizes and Data API discrepancies flagged.
+// With ?wallet= the selected linked wallets are reconciled and returned side by side.
+// GET /api/v1/portfolio/positions?discrepancies=true&wallet=all
+func (h *PortfolioHandler) GetPositions(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	views, err := resolveWallets(c, h.Wallets, user)
+	if err != nil {
+		return userWalletError(c, err)
+	}
+
+	portfolios := make([]*services.ReconciledPortfolio, 0, len(views))
+	discrepancies := 0
+	for _, view := range views {
+		portfolio, err := h.positions.Reconcile(c.Context(), view)
+		if err != nil {
+			return portfolioError(c, err)
+		}
+		if c.QueryBool("discrepancies") {
+			flagged := make([]services.ReconciledPosition, 0, portfolio.Discrepancies)
+			for _, p := range portfolio.Positions {
+				if p.Status != services.PositionStatusMatched && p.Status != services.PositionStatusUnverified {
+					flagged = append(flagged, p)
+				}
+			}
+			portfolio.Positions = flagged
+		}
+		discrepancies += portfolio.Discrepancies
+		portfolios = append(portfolios, portfolio)
+	}
+
+	if c.Query("wallet") == "" {
+		return c.JSON(portfolios[0])
+	}
+	return c.JSON(fiber.Map{
+		"wallets":       portfolios,
+		"discrepancies": discrepancies,
+	})
+}
+
+// GetTaxReport returns realized PnL per closing trade for a year
+// GET /api/v1/portfolio/tax-report?year=2025&method=FIFO&format=csv
+func (h *PortfolioHandler) GetTaxReport(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	year := time.Now().UTC().Year()
+	if raw := c.Query("year"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid year parameter"})
+		}
+		year = parsed
+	}
+
+	method, err := services.ParseTaxLotMethod(c.Query("method"))
+	if err != nil {
+		return portfolioError(c, err)
+	}
+
+	report, err := h.taxLots.Report(c.Context(), user.ID, year, method)
+	if err != nil {
+		return portfolioError(c, err)
+	}
+
+	format := strings.ToLower(c.Query("format"))
+	if format == "" && strings.Contains(c.Get(fiber.HeaderAccept), "text/csv") {
+		format = "csv"
+	}
+	if format != "csv" {
+		return c.JSON(report)
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf); err != nil {
+		return portfolioError(c, err)
+	}
+	filename := fmt.Sprintf("bankai-tax-report-%d-%s.csv", report.Year, strings.ToLower(string(report.Method)))
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+	return c.Send(buf.Bytes())
+}
+
+func (h *PortfolioHandler) fetchUserRecord(ctx context.Context, clerkID string) (*models.User, error) {
+	var user models.User
+	if err := h.db.WithContext(ctx).Where("clerk_id = ?", clerkID).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func portfolioError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidTaxReport):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	default:
+		logger.Error("PortfolioHandler: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to build portfolio report"})
+	}
+}
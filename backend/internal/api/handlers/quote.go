@@ -0,0 +1,107 @@
+/**
+ * @description
+ * Quote API Handlers.
+ * Returns pre-trade quotes with fees, slippage and warnings, plus the caller's risk-limit check.
+ *
+ * @dependencies
+ * - github.com/gofiber/fiber/v2
+ * - backend/internal/services
+ * - backend/internal/api/middleware
+ */
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/bankai-project/backend/internal/api/middleware"
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// QuoteHandler handles pre-trade quote requests
+type QuoteHandler struct {
+	db      *gorm.DB
+	service *services.QuoteService
+	risk    *services.RiskService
+}
+
+// NewQuoteHandler creates a new QuoteHandler
+func NewQuoteHandler(db *gorm.DB, service *services.QuoteService, risk *services.RiskService) *QuoteHandler {
+	return &QuoteHandler{
+		db:      db,
+		service: service,
+		risk:    risk,
+	}
+}
+
+// GetQuote prices a taker order given in shares or USDC notional
+// GET /api/v1/trade/quote?tokenId=...&side=BUY&shares=100 (or &amount=50)
+func (h *QuoteHandler) GetQuote(c *fiber.Ctx) error {
+	req := services.QuoteRequest{
+		TokenID: c.Query("tokenId"),
+		Side:    c.Query("side"),
+	}
+
+	var err error
+	if raw := c.Query("shares"); raw != "" {
+		if req.Shares, err = strconv.ParseFloat(raw, 64); err != nil || req.Shares <= 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "shares must be a positive number"})
+		}
+	}
+	if raw := c.Query("amount"); raw != "" {
+		if req.Amount, err = strconv.ParseFloat(raw, 64); err != nil || req.Amount <= 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "amount must be a positive number"})
+		}
+	}
+
+	quote, err := h.service.Quote(c.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidQuote):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		case errors.Is(err, services.ErrOrderBookUnavailable):
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "Order book unavailable for this token"})
+		default:
+			logger.Error("QuoteHandler: %v", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to build quote"})
+		}
+	}
+
+	// Risk limits are advisory on quotes; a failed check never blocks the quote itself.
+	if h.risk != nil && quote.Shares > 0 {
+		if clerkID, err := middleware.GetUserID(c); err == nil {
+			if user, err := h.fetchUserRecord(c.Context(), clerkID); err == nil {
+				notional := quote.Notional
+				if quote.Side == "BUY" {
+					notional = quote.TotalCost
+				}
+				risk, err := h.risk.Check(c.Context(), user, services.RiskCheckRequest{
+					TokenID:  quote.TokenID,
+					Side:     quote.Side,
+					Notional: notional,
+				})
+				if err != nil {
+					logger.Error("QuoteHandler: risk check failed: %v", err)
+				} else {
+					quote.Risk = risk
+				}
+			}
+		}
+	}
+
+	return c.JSON(quote)
+}
+
+func (h *QuoteHandler) fetchUserRecord(ctx context.Context, clerkID string) (*models.User, error) {
+	var user models.User
+	if err := h.db.WithContext(ctx).Where("clerk_id = ?", clerkID).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
@@ -0,0 +1,125 @@
+/**
+ * @description
+ * Quote Ladder API Handlers.
+ * Plans market-maker ladders (cancels + unsigned order requests) and lists recorded adjustments.
+ *
+ * @dependencies
+ * - github.com/gofiber/fiber/v2
+ * - backend/internal/services
+ * - backend/internal/api/middleware
+ */
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/bankai-project/backend/internal/api/middleware"
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// QuoteLadderHandler handles market-maker ladder requests
+type QuoteLadderHandler struct {
+	db      *gorm.DB
+	service *services.QuoteLadderService
+}
+
+// NewQuoteLadderHandler creates a new QuoteLadderHandler
+func NewQuoteLadderHandler(db *gorm.DB, service *services.QuoteLadderService) *QuoteLadderHandler {
+	return &QuoteLadderHandler{
+		db:      db,
+		service: service,
+	}
+}
+
+// PlanQuoteLadder computes the desired ladder and the cancels / new orders needed to reach it
+// POST /api/v1/trade/ladder?dry_run=true
+func (h *QuoteLadderHandler) PlanQuoteLadder(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	var params services.QuoteLadderParams
+	if err := c.BodyParser(&params); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if c.QueryBool("dry_run") {
+		params.DryRun = true
+	}
+
+	plan, err := h.service.PlanLadder(c.Context(), user, params)
+	if err != nil {
+		return quoteLadderError(c, err)
+	}
+
+	return c.JSON(plan)
+}
+
+// GetQuoteAdjustments lists the user's applied ladder adjustments
+// GET /api/v1/trade/ladder/history?token_id=123&limit=50&offset=0
+func (h *QuoteLadderHandler) GetQuoteAdjustments(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	limit, offset, err := parsePagination(c.Query("limit"), c.Query("offset"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	adjustments, total, err := h.service.ListAdjustments(c.Context(), user.ID, strings.TrimSpace(c.Query("token_id")), limit, offset)
+	if err != nil {
+		return quoteLadderError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data":   adjustments,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+func (h *QuoteLadderHandler) fetchUserRecord(ctx context.Context, clerkID string) (*models.User, error) {
+	var user models.User
+	if err := h.db.WithContext(ctx).Where("clerk_id = ?", clerkID).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func quoteLadderError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidQuoteLadder):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, services.ErrOrderBookUnavailable):
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "Order book not available for this token"})
+	default:
+		logger.Error("QuoteLadderHandler: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to plan quote ladder"})
+	}
+}
@@ -0,0 +1,71 @@
+/**
+ * @description
+ * Liquidity Rewards API Handlers.
+ * Reports which of the user's resting orders qualify for liquidity rewards and their estimated pool share.
+ *
+ * @dependencies
+ * - github.com/gofiber/fiber/v2
+ * - backend/internal/services
+ * - backend/internal/api/middleware
+ */
+
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bankai-project/backend/internal/api/middleware"
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// RewardsHandler handles liquidity rewards requests
+type RewardsHandler struct {
+	db      *gorm.DB
+	service *services.RewardsService
+}
+
+// NewRewardsHandler creates a new RewardsHandler
+func NewRewardsHandler(db *gorm.DB, service *services.RewardsService) *RewardsHandler {
+	return &RewardsHandler{
+		db:      db,
+		service: service,
+	}
+}
+
+// GetRewardEligibility checks the user's open orders against each market's reward band
+// GET /api/v1/trade/rewards
+func (h *RewardsHandler) GetRewardEligibility(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	report, err := h.service.GetUserRewards(c.Context(), user.ID)
+	if err != nil {
+		logger.Error("RewardsHandler: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to check reward eligibility"})
+	}
+
+	return c.JSON(report)
+}
+
+func (h *RewardsHandler) fetchUserRecord(ctx context.Context, clerkID string) (*models.User, error) {
+	var user models.User
+	if err := h.db.WithContext(ctx).Where("clerk_id = ?", clerkID).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
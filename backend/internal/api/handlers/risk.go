@@ -0,0 +1,205 @@
+/**
+ * @description
+ * Risk API Handlers.
+ * Reads and updates per-user risk limits and runs the pre-trade check the frontend calls before signing.
+ *
+ * @dependencies
+ * - github.com/gofiber/fiber/v2
+ * - backend/internal/services
+ * - backend/internal/api/middleware
+ *
+ * @notes
+ * - Desk-managed (locked) limits are set by an authenticated admin. Callers must
+ *   say explicitly whether the limits are locked; the change is attributed to the caller and logged.
+ */
+
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bankai-project/backend/internal/api/middleware"
+	"github.com/bankai-project/backend/internal/config"
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RiskHandler handles risk limit and pre-trade check requests
+type RiskHandler struct {
+	db      *gorm.DB
+	service *services.RiskService
+	config  *config.Config
+}
+
+// NewRiskHandler creates a new RiskHandler
+func NewRiskHandler(db *gorm.DB, service *services.RiskService, cfg *config.Config) *RiskHandler {
+	return &RiskHandler{
+		db:      db,
+		service: service,
+		config:  cfg,
+	}
+}
+
+// ManagedRiskLimitsRequest is the body of the desk-managed limits route
+type ManagedRiskLimitsRequest struct {
+	services.RiskLimitsInput
+	Locked *bool `json:"locked"` // Required
+}
+
+// GetRiskLimits returns the user's risk limits
+// GET /api/v1/user/risk-limits
+func (h *RiskHandler) GetRiskLimits(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	limits, err := h.service.GetLimits(c.Context(), user.ID)
+	if err != nil {
+		return riskError(c, err)
+	}
+
+	return c.JSON(limits)
+}
+
+// UpdateRiskLimits replaces the user's risk limits unless they are locked by an admin
+// PUT /api/v1/user/risk-limits
+func (h *RiskHandler) UpdateRiskLimits(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	var input services.RiskLimitsInput
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	limits, err := h.service.UpdateLimits(c.Context(), user.ID, input)
+	if err != nil {
+		return riskError(c, err)
+	}
+
+	return c.JSON(limits)
+}
+
+// CheckOrder runs the pre-trade risk check for an order about to be signed
+// POST /api/v1/trade/risk/check
+func (h *RiskHandler) CheckOrder(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	var req services.RiskCheckRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	result, err := h.service.Check(c.Context(), user, req)
+	if err != nil {
+		return riskError(c, err)
+	}
+
+	return c.JSON(result)
+}
+
+// SetManagedRiskLimits sets (and optionally locks) a user's limits for shared desk accounts
+// PUT /api/v1/risk/limits/:user_id
+func (h *RiskHandler) SetManagedRiskLimits(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	actor, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+	if !actor.CanManageRiskLimits() {
+		logger.Info("RiskHandler: User %s without the admin role tried to manage risk limits from %s", actor.ID, c.IP())
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": services.ErrRiskLimitsForbidden.Error()})
+	}
+
+	userID, err := uuid.Parse(c.Params("user_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user id"})
+	}
+
+	var user models.User
+	if err := h.db.WithContext(c.Context()).Where("id = ?", userID).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User not found"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	var req ManagedRiskLimitsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if req.Locked == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "locked is required"})
+	}
+
+	limits, err := h.service.SetManagedLimits(c.Context(), actor, user.ID, req.RiskLimitsInput, *req.Locked)
+	if err != nil {
+		return riskError(c, err)
+	}
+	logger.Info("RiskHandler: Managed risk limits for user %s set by %s <%s> from %s (locked=%t)",
+		user.ID, actor.ID, actor.Email, c.IP(), *req.Locked)
+
+	return c.JSON(limits)
+}
+
+func (h *RiskHandler) fetchUserRecord(ctx context.Context, clerkID string) (*models.User, error) {
+	var user models.User
+	if err := h.db.WithContext(ctx).Where("clerk_id = ?", clerkID).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func riskError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidRiskCheck), errors.Is(err, services.ErrInvalidRiskLimit):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, services.ErrRiskLimitsLocked), errors.Is(err, services.ErrRiskLimitsForbidden):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	default:
+		logger.Error("RiskHandler: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to process risk request"})
+	}
+}
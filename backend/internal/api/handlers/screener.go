@@ -0,0 +1,230 @@
+/**
+ * @description
+ * Market Screener API Handlers.
+ * Runs ad-hoc screener expressions and manages a user's saved screens.
+ *
+ * @dependencies
+ * - github.com/gofiber/fiber/v2
+ * - backend/internal/services
+ * - backend/internal/screener
+ * - backend/internal/api/middleware
+ */
+
+package handlers
+
+import (
+	"errors"
+
+	"github.com/bankai-project/backend/internal/api/middleware"
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/screener"
+	"github.com/bankai-project/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ScreenerHandler handles screener-related requests
+type ScreenerHandler struct {
+	db              *gorm.DB
+	screenerService *services.ScreenerService
+}
+
+// NewScreenerHandler creates a new ScreenerHandler
+func NewScreenerHandler(db *gorm.DB, screenerService *services.ScreenerService) *ScreenerHandler {
+	return &ScreenerHandler{
+		db:              db,
+		screenerService: screenerService,
+	}
+}
+
+// RunScreen evaluates an expression against active markets
+// POST /api/v1/markets/screener
+func (h *ScreenerHandler) RunScreen(c *fiber.Ctx) error {
+	var req services.ScreenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if req.Limit > 500 {
+		req.Limit = 500
+	}
+
+	result, err := h.screenerService.Run(c.Context(), req)
+	if err != nil {
+		return screenerError(c, err)
+	}
+
+	return c.JSON(result)
+}
+
+// GetScreenerFields lists the fields and functions usable in expressions
+// GET /api/v1/markets/screener/fields
+func (h *ScreenerHandler) GetScreenerFields(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"fields":    screener.Fields(),
+		"operators": []string{">", ">=", "<", "<=", "=", "!=", "BETWEEN", "IN", "AND", "OR", "NOT"},
+	})
+}
+
+// GetScreens returns the user's saved screens
+// GET /api/v1/screens
+func (h *ScreenerHandler) GetScreens(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	var user models.User
+	if err := h.db.Where("clerk_id = ?", clerkID).First(&user).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User not found"})
+	}
+
+	screens, err := h.screenerService.ListScreens(c.Context(), user.ID)
+	if err != nil {
+		logger.Error("ScreenerHandler: Failed to list screens: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch saved screens"})
+	}
+
+	return c.JSON(fiber.Map{
+		"screens": screens,
+		"count":   len(screens),
+	})
+}
+
+// CreateScreen saves a new screen
+// POST /api/v1/screens
+func (h *ScreenerHandler) CreateScreen(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	var user models.User
+	if err := h.db.Where("clerk_id = ?", clerkID).First(&user).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User not found"})
+	}
+
+	var input services.SavedScreenInput
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	screen, err := h.screenerService.CreateScreen(c.Context(), user.ID, input)
+	if err != nil {
+		return screenerError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(screen)
+}
+
+// UpdateScreen replaces a saved screen's name, expression, sort and alert flag
+// PUT /api/v1/screens/:id
+func (h *ScreenerHandler) UpdateScreen(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	var user models.User
+	if err := h.db.Where("clerk_id = ?", clerkID).First(&user).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User not found"})
+	}
+
+	screenID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid screen ID"})
+	}
+
+	var input services.SavedScreenInput
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	screen, err := h.screenerService.UpdateScreen(c.Context(), user.ID, screenID, input)
+	if err != nil {
+		return screenerError(c, err)
+	}
+
+	return c.JSON(screen)
+}
+
+// DeleteScreen removes a saved screen
+// DELETE /api/v1/screens/:id
+func (h *ScreenerHandler) DeleteScreen(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	var user models.User
+	if err := h.db.Where("clerk_id = ?", clerkID).First(&user).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User not found"})
+	}
+
+	screenID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid screen ID"})
+	}
+
+	if err := h.screenerService.DeleteScreen(c.Context(), user.ID, screenID); err != nil {
+		return screenerError(c, err)
+	}
+
+	return c.JSON(fiber.Map{"success": true})
+}
+
+// RunSavedScreen evaluates a saved screen
+// GET /api/v1/screens/:id/run?limit=100&offset=0
+func (h *ScreenerHandler) RunSavedScreen(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	var user models.User
+	if err := h.db.Where("clerk_id = ?", clerkID).First(&user).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User not found"})
+	}
+
+	screenID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid screen ID"})
+	}
+
+	limit, offset, err := parsePagination(c.Query("limit"), c.Query("offset"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if limit > 500 {
+		limit = 500
+	}
+
+	result, err := h.screenerService.RunSavedScreen(c.Context(), user.ID, screenID, limit, offset)
+	if err != nil {
+		return screenerError(c, err)
+	}
+
+	return c.JSON(result)
+}
+
+// screenerError maps service errors onto HTTP responses, surfacing parse positions to the client.
+func screenerError(c *fiber.Ctx, err error) error {
+	var parseErr *screener.Error
+	switch {
+	case errors.As(err, &parseErr):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":    parseErr.Msg,
+			"position": parseErr.Pos,
+		})
+	case errors.Is(err, services.ErrScreenNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Saved screen not found"})
+	case errors.Is(err, services.ErrScreenLimitReached):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidScreen):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	default:
+		logger.Error("ScreenerHandler: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to process screen"})
+	}
+}
@@ -3,6 +3,7 @@
  * HTTP Handlers for Trade execution.
  * Handles order placement and relay to Polymarket CLOB.
  * Includes validation that the authenticated user owns the signing address.
+ * Requests with ?mode=paper (or X-Trading-Mode: paper) are routed to the user's paper account instead.
  *
  * @dependencies
  * - github.com/gofiber/fiber/v2
@@ -17,6 +18,7 @@ package handlers
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
 	"fmt"
 	"strconv"
@@ -34,6 +36,8 @@ import (
 
 type TradeHandler struct {
 	Service  *services.TradeService
+	Paper    *services.PaperTradingService
+	Wallets  *services.UserWalletService // Optional; enables the ?wallet= selector on order history
 	Config   *config.Config
 	DB       *gorm.DB
 }
@@ -57,6 +61,15 @@ type CancelTradesRequest struct {
 	OrderIDs []string `json:"orderIds"`
 }
 
+// BulkCancelRequest carries the user's L2 credentials for cancel-all / cancel-by-market.
+type BulkCancelRequest struct {
+	TokenID     string                 `json:"tokenId,omitempty"` // Market cancels only: narrow to one outcome
+	Credentials clob.APIKeyCredentials `json:"credentials"`
+}
+
+// PlaceOrderRequest is a simulated order for paper mode.
+type PlaceOrderRequest = services.PaperOrderInput
+
 // SyncOrdersRequest is used by the frontend (after fetching via the SDK) to persist orders.
 type SyncOrdersRequest struct {
 	Orders []services.SyncedOrder `json:"orders"`
@@ -77,7 +90,8 @@ var validOrderTypes = map[clob.OrderType]struct{}{
 // The frontend now uses the official Polymarket SDK directly for order creation, signing, and submission.
 // This eliminates the need for backend order relaying and ensures compatibility with the official SDK.
 
-// GetOrders returns the authenticated user's order history
+// GetOrders returns the authenticated user's order history across all linked wallets
+// GET /api/v1/trade/orders?wallet=<id|address|all>
 func (h *TradeHandler) GetOrders(c *fiber.Ctx) error {
 	clerkID, err := middleware.GetUserID(c)
 	if err != nil {
@@ -97,7 +111,42 @@ func (h *TradeHandler) GetOrders(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": parseErr.Error()})
 	}
 
-	orders, total, svcErr := h.Service.ListOrders(c.Context(), user.ID, limit, offset)
+	if isPaperMode(c) {
+		orders, total, svcErr := h.Paper.ListOrders(c.Context(), user.ID, c.Query("status"), limit, offset)
+		if svcErr != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": svcErr.Error()})
+		}
+		return c.JSON(fiber.Map{
+			"data":   orders,
+			"total":  total,
+			"limit":  limit,
+			"offset": offset,
+			"mode":   tradingModePaper,
+		})
+	}
+
+	// All wallets by default; a selector narrows history to the selected wallets' makers
+	var makers []string
+	includeUnattributed := false
+	if selector := c.Query("wallet"); selector != "" && !strings.EqualFold(selector, services.WalletSelectorAll) {
+		views, err := resolveWallets(c, h.Wallets, user)
+		if err != nil {
+			return userWalletError(c, err)
+		}
+		for _, view := range views {
+			// The users row mirrors the primary wallet, which owns orders recorded without a maker
+			if strings.EqualFold(view.EOAAddress, user.EOAAddress) {
+				includeUnattributed = true
+			}
+			for _, addr := range []string{view.VaultAddress, view.EOAAddress} {
+				if addr != "" {
+					makers = append(makers, addr)
+				}
+			}
+		}
+	}
+
+	orders, total, svcErr := h.Service.ListOrders(c.Context(), user.ID, makers, includeUnattributed, limit, offset)
 	if svcErr != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": svcErr.Error()})
 	}
@@ -132,6 +181,14 @@ func (h *TradeHandler) CancelOrder(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "orderId is required"})
 	}
 
+	if isPaperMode(c) {
+		resp, svcErr := h.Paper.CancelOrders(c.Context(), user.ID, []string{req.OrderID})
+		if svcErr != nil {
+			return paperError(c, svcErr)
+		}
+		return c.JSON(resp)
+	}
+
 	resp, svcErr := h.Service.CancelOrder(c.Context(), user, req.OrderID)
 	if svcErr != nil {
 		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": svcErr.Error()})
@@ -162,6 +219,14 @@ func (h *TradeHandler) CancelOrders(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "orderIds must include at least one id"})
 	}
 
+	if isPaperMode(c) {
+		resp, svcErr := h.Paper.CancelOrders(c.Context(), user.ID, req.OrderIDs)
+		if svcErr != nil {
+			return paperError(c, svcErr)
+		}
+		return c.JSON(resp)
+	}
+
 	resp, svcErr := h.Service.CancelOrders(c.Context(), user, req.OrderIDs)
 	if svcErr != nil {
 		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": svcErr.Error()})
@@ -170,6 +235,92 @@ func (h *TradeHandler) CancelOrders(c *fiber.Ctx) error {
 	return c.JSON(resp)
 }
 
+// CancelAllOrders cancels every open order on the user's CLOB API key
+// POST /api/v1/trade/cancel/all
+func (h *TradeHandler) CancelAllOrders(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	var req BulkCancelRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	if isPaperMode(c) {
+		result, svcErr := h.Paper.CancelAll(c.Context(), user.ID, "", "")
+		if svcErr != nil {
+			return paperError(c, svcErr)
+		}
+		return c.JSON(result)
+	}
+
+	result, svcErr := h.Service.CancelAllOrders(c.Context(), user, req.Credentials)
+	if svcErr != nil {
+		return bulkCancelError(c, svcErr)
+	}
+
+	return c.JSON(result)
+}
+
+// CancelMarketOrders cancels the user's open orders in one market
+// POST /api/v1/trade/cancel/market/:condition_id
+func (h *TradeHandler) CancelMarketOrders(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	var req BulkCancelRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	if isPaperMode(c) {
+		conditionID := strings.TrimSpace(c.Params("condition_id"))
+		if conditionID == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "condition_id is required"})
+		}
+		result, svcErr := h.Paper.CancelAll(c.Context(), user.ID, conditionID, req.TokenID)
+		if svcErr != nil {
+			return paperError(c, svcErr)
+		}
+		return c.JSON(result)
+	}
+
+	result, svcErr := h.Service.CancelMarketOrders(c.Context(), user, c.Params("condition_id"), req.TokenID, req.Credentials)
+	if svcErr != nil {
+		return bulkCancelError(c, svcErr)
+	}
+
+	return c.JSON(result)
+}
+
+func bulkCancelError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, services.ErrInvalidCancel) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	logger.Error("Bulk cancel failed: %v", err)
+	return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": err.Error()})
+}
+
 // SyncOrders persists Polymarket orders fetched via the SDK into Postgres for history/audit.
 func (h *TradeHandler) SyncOrders(c *fiber.Ctx) error {
 	clerkID, err := middleware.GetUserID(c)
@@ -185,6 +336,10 @@ func (h *TradeHandler) SyncOrders(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
 	}
 
+	if isPaperMode(c) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Paper orders are tracked by the server and cannot be synced"})
+	}
+
 	var req SyncOrdersRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
@@ -201,10 +356,43 @@ func (h *TradeHandler) SyncOrders(c *fiber.Ctx) error {
 	return c.JSON(fiber.Map{"status": "ok"})
 }
 
+// PlaceOrder simulates an order against the live book. Live orders are placed through the Polymarket SDK.
+// POST /api/v1/trade/orders?mode=paper
+func (h *TradeHandler) PlaceOrder(c *fiber.Ctx) error {
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	if !isPaperMode(c) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Live orders are placed through the Polymarket SDK; set mode=paper to simulate"})
+	}
+
+	user, err := h.fetchUserRecord(c.Context(), clerkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User profile not found. Please sync user first."})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Database error"})
+	}
+
+	var req PlaceOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	result, svcErr := h.Paper.PlaceOrder(c.Context(), user, req)
+	if svcErr != nil {
+		return paperError(c, svcErr)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(result)
+}
+
 // SyncOrdersInternal allows background workers to persist orders by maker address using JOB_SYNC_SECRET.
 func (h *TradeHandler) SyncOrdersInternal(c *fiber.Ctx) error {
 	secret := c.Get("X-Job-Secret")
-	if secret == "" || secret != h.Config.Services.SyncJobSecret {
+	if secret == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(h.Config.Services.SyncJobSecret)) != 1 {
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
 	}
 
@@ -17,13 +17,15 @@ import (
 	"github.com/bankai-project/backend/internal/api/middleware"
 	"github.com/bankai-project/backend/internal/logger"
 	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/services"
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
 type UserHandler struct {
-	DB *gorm.DB
+	DB      *gorm.DB
+	Wallets *services.UserWalletService // Optional; promotes the connected EOA when it is a linked wallet
 }
 
 func NewUserHandler(db *gorm.DB) *UserHandler {
@@ -132,7 +134,14 @@ func (h *UserHandler) SyncUser(c *fiber.Ctx) error {
 		}
 	}
 
-	// 6. Fetch full user to return (including ID and Vault Address)
+	// 6. Make the connected EOA the primary wallet if the user already linked it with a signed challenge
+	if h.Wallets != nil && shouldUpdateEOA {
+		if err := h.Wallets.SyncPrimary(c.Context(), clerkID); err != nil {
+			logger.Error("SyncUser: Failed to sync primary wallet for user %s: %v", clerkID, err)
+		}
+	}
+
+	// 7. Fetch full user to return (including ID and Vault Address)
 	var updatedUser models.User
 	if err := h.DB.Where("clerk_id = ?", clerkID).First(&updatedUser).Error; err != nil {
 		logger.Error("SyncUser: Failed to fetch user after upsert: %v", err)
@@ -1,7 +1,7 @@
 /**
  * @description
  * HTTP Handlers for Wallet management.
- * Exposes endpoints to get wallet status and trigger deployment.
+ * Exposes endpoints to get wallet status, trigger deployment and manage the wallets linked to an account.
  *
  * @dependencies
  * - github.com/gofiber/fiber/v2
@@ -13,17 +13,25 @@
 package handlers
 
 import (
+	"errors"
+	"math/big"
+	"strings"
+
 	"github.com/bankai-project/backend/internal/api/middleware"
 	"github.com/bankai-project/backend/internal/logger"
 	"github.com/bankai-project/backend/internal/models"
 	"github.com/bankai-project/backend/internal/polymarket/relayer"
 	"github.com/bankai-project/backend/internal/services"
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
 type WalletHandler struct {
-	Manager    *services.WalletManager
-	Blockchain *services.BlockchainService
+	Manager      *services.WalletManager
+	Blockchain   *services.BlockchainService
+	Transactions *services.RelayerTransactionService
+	Proxies      *services.ProxyWalletService
+	Wallets      *services.UserWalletService
 }
 
 type DeployWalletRequest struct {
@@ -31,6 +39,15 @@ type DeployWalletRequest struct {
 	Metadata  string `json:"metadata"`
 }
 
+type WalletChallengeRequest struct {
+	Address string `json:"address"`
+}
+
+type DeployProxyWalletRequest struct {
+	PlanID    string `json:"plan_id"`
+	Signature string `json:"signature"`
+}
+
 func NewWalletHandler(manager *services.WalletManager, blockchain *services.BlockchainService) *WalletHandler {
 	return &WalletHandler{
 		Manager:    manager,
@@ -116,6 +133,11 @@ func (h *WalletHandler) DeployWallet(c *fiber.Ctx) error {
 	}
 
 	resp, err := h.Manager.Relayer.DeploySafe(c.Context(), txReq)
+	if h.Transactions != nil {
+		if _, recErr := h.Transactions.Record(c.Context(), user.ID, models.RelayerTransactionDeploy, txReq, "", resp, err); recErr != nil {
+			logger.Error("Failed to record Safe deployment for user %s: %v", clerkID, recErr)
+		}
+	}
 	if err != nil {
 		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": "Relayer deployment failed: " + err.Error()})
 	}
@@ -135,6 +157,66 @@ func (h *WalletHandler) DeployWallet(c *fiber.Ctx) error {
 	})
 }
 
+// PrepareProxyDeployment returns the proxy factory batch (deploy + trading approvals) the EOA must personal_sign
+// POST /api/v1/wallet/proxy/prepare
+func (h *WalletHandler) PrepareProxyDeployment(c *fiber.Ctx) error {
+	if h.Proxies == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "Proxy wallets unavailable"})
+	}
+
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.Manager.GetUserWallet(c.Context(), clerkID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get user wallet: " + err.Error(),
+		})
+	}
+
+	plan, err := h.Proxies.PrepareDeployment(c.Context(), user)
+	if err != nil {
+		return proxyWalletError(c, err)
+	}
+	return c.JSON(plan)
+}
+
+// DeployProxyWallet relays a signed proxy deployment plan and links the proxy as the user's vault
+// POST /api/v1/wallet/proxy/deploy
+func (h *WalletHandler) DeployProxyWallet(c *fiber.Ctx) error {
+	if h.Proxies == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "Proxy wallets unavailable"})
+	}
+
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	var req DeployProxyWalletRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid body"})
+	}
+	if req.PlanID == "" || req.Signature == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "plan_id and signature are required"})
+	}
+
+	user, err := h.Manager.GetUserWallet(c.Context(), clerkID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get user wallet: " + err.Error(),
+		})
+	}
+
+	deployment, err := h.Proxies.SubmitDeployment(c.Context(), user, req.PlanID, req.Signature)
+	if err != nil {
+		return proxyWalletError(c, err)
+	}
+	return c.JSON(deployment)
+}
+
 // UpdateWallet allows the frontend to report a discovered wallet address
 // (Useful if the frontend detects the proxy via other means/libraries)
 // POST /api/v1/wallet/update
@@ -203,8 +285,8 @@ func (h *WalletHandler) GetDepositAddress(c *fiber.Ctx) error {
 	})
 }
 
-// GetBalance returns the USDC balance of the user's vault
-// GET /api/v1/wallet/balance
+// GetBalance returns the USDC balance of the user's vault, or of the selected linked wallets
+// GET /api/v1/wallet/balance?wallet=all
 func (h *WalletHandler) GetBalance(c *fiber.Ctx) error {
 	if h.Blockchain == nil {
 		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
@@ -224,43 +306,303 @@ func (h *WalletHandler) GetBalance(c *fiber.Ctx) error {
 		})
 	}
 
-	if user.VaultAddress == "" {
-		return c.JSON(fiber.Map{
+	views, err := resolveWallets(c, h.Wallets, user)
+	if err != nil {
+		return userWalletError(c, err)
+	}
+	if c.Query("wallet") == "" {
+		return c.JSON(h.vaultBalance(c, views[0].VaultAddress))
+	}
+
+	total := new(big.Int)
+	balances := make([]fiber.Map, 0, len(views))
+	for _, view := range views {
+		entry := h.vaultBalance(c, view.VaultAddress)
+		entry["eoa_address"] = view.EOAAddress
+		if units, ok := new(big.Int).SetString(entry["balance"].(string), 10); ok {
+			total.Add(total, units)
+		}
+		balances = append(balances, entry)
+	}
+	return c.JSON(fiber.Map{
+		"balance":           total.String(),
+		"balance_formatted": h.Blockchain.FormatUSDCBalance(total),
+		"token":             "USDC",
+		"wallets":           balances,
+	})
+}
+
+// vaultBalance reads one vault's USDC balance, falling back to a stale cached value on RPC errors.
+func (h *WalletHandler) vaultBalance(c *fiber.Ctx, vault string) fiber.Map {
+	if vault == "" {
+		return fiber.Map{
 			"balance":           "0",
 			"balance_formatted": "0.00",
 			"vault_address":     "",
-		})
+		}
 	}
 
-	balance, err := h.Blockchain.GetUSDCBalance(c.Context(), user.VaultAddress)
+	balance, err := h.Blockchain.GetUSDCBalance(c.Context(), vault)
 	if err != nil {
-		logger.Error("Failed to fetch USDC balance for %s: %v", user.VaultAddress, err)
-		if cached := h.Blockchain.GetCachedUSDCBalance(user.VaultAddress, true); cached != nil {
-			return c.JSON(fiber.Map{
+		logger.Error("Failed to fetch USDC balance for %s: %v", vault, err)
+		if cached := h.Blockchain.GetCachedUSDCBalance(vault, true); cached != nil {
+			return fiber.Map{
 				"balance":           cached.String(),
 				"balance_formatted": h.Blockchain.FormatUSDCBalance(cached),
-				"vault_address":     user.VaultAddress,
+				"vault_address":     vault,
 				"token":             "USDC",
 				"balance_stale":     true,
-			})
+			}
 		}
-		return c.JSON(fiber.Map{
+		return fiber.Map{
 			"balance":             "0",
 			"balance_formatted":   "0.00",
-			"vault_address":       user.VaultAddress,
+			"vault_address":       vault,
 			"token":               "USDC",
 			"balance_unavailable": true,
-		})
+		}
 	}
 
-	return c.JSON(fiber.Map{
+	return fiber.Map{
 		"balance":           balance.String(),
 		"balance_formatted": h.Blockchain.FormatUSDCBalance(balance),
-		"vault_address":     user.VaultAddress,
+		"vault_address":     vault,
 		"token":             "USDC",
+	}
+}
+
+// ListWallets returns the wallets linked to the user, primary first
+// GET /api/v1/wallet/wallets
+func (h *WalletHandler) ListWallets(c *fiber.Ctx) error {
+	if h.Wallets == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "Linked wallets unavailable"})
+	}
+
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.Manager.GetUserWallet(c.Context(), clerkID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get user wallet: " + err.Error(),
+		})
+	}
+
+	wallets, err := h.Wallets.List(c.Context(), user.ID)
+	if err != nil {
+		return userWalletError(c, err)
+	}
+	return c.JSON(fiber.Map{"data": wallets})
+}
+
+// GetWalletChallenge returns the message a wallet must personal_sign to be linked
+// POST /api/v1/wallet/wallets/challenge
+func (h *WalletHandler) GetWalletChallenge(c *fiber.Ctx) error {
+	if h.Wallets == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "Linked wallets unavailable"})
+	}
+
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	var req WalletChallengeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid body"})
+	}
+
+	user, err := h.Manager.GetUserWallet(c.Context(), clerkID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get user wallet: " + err.Error(),
+		})
+	}
+
+	challenge, err := h.Wallets.Challenge(c.Context(), user, req.Address)
+	if err != nil {
+		return userWalletError(c, err)
+	}
+	return c.JSON(challenge)
+}
+
+// LinkWallet links a wallet that signed its challenge
+// POST /api/v1/wallet/wallets
+func (h *WalletHandler) LinkWallet(c *fiber.Ctx) error {
+	if h.Wallets == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "Linked wallets unavailable"})
+	}
+
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	var req services.LinkWalletInput
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid body"})
+	}
+	if req.Address == "" || req.Signature == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "address and signature are required"})
+	}
+
+	user, err := h.Manager.GetUserWallet(c.Context(), clerkID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get user wallet: " + err.Error(),
+		})
+	}
+
+	wallet, err := h.Wallets.Link(c.Context(), user, req)
+	if err != nil {
+		return userWalletError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(wallet)
+}
+
+// UpdateLinkedWallet renames a linked wallet or makes it the primary wallet
+// PATCH /api/v1/wallet/wallets/:id
+func (h *WalletHandler) UpdateLinkedWallet(c *fiber.Ctx) error {
+	if h.Wallets == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "Linked wallets unavailable"})
+	}
+
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid wallet ID"})
+	}
+
+	var req services.UpdateWalletInput
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid body"})
+	}
+
+	user, err := h.Manager.GetUserWallet(c.Context(), clerkID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get user wallet: " + err.Error(),
+		})
+	}
+
+	wallet, err := h.Wallets.Update(c.Context(), user, id, req)
+	if err != nil {
+		return userWalletError(c, err)
+	}
+	return c.JSON(wallet)
+}
+
+// UnlinkWallet removes a linked wallet; unlinking the primary promotes the oldest remaining one
+// DELETE /api/v1/wallet/wallets/:id
+func (h *WalletHandler) UnlinkWallet(c *fiber.Ctx) error {
+	if h.Wallets == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "Linked wallets unavailable"})
+	}
+
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid wallet ID"})
+	}
+
+	user, err := h.Manager.GetUserWallet(c.Context(), clerkID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get user wallet: " + err.Error(),
+		})
+	}
+
+	if err := h.Wallets.Unlink(c.Context(), user, id); err != nil {
+		return userWalletError(c, err)
+	}
+	return c.JSON(fiber.Map{"status": "success"})
+}
+
+// GetTransactions lists the user's relayed transactions (deployments, approvals, withdrawals, redemptions)
+// GET /api/v1/wallet/transactions?type=REDEEM&limit=50&offset=0
+func (h *WalletHandler) GetTransactions(c *fiber.Ctx) error {
+	if h.Transactions == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "Transaction history unavailable"})
+	}
+
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.Manager.GetUserWallet(c.Context(), clerkID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get user wallet: " + err.Error(),
+		})
+	}
+
+	limit, offset, err := parsePagination(c.Query("limit"), c.Query("offset"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	txType := models.RelayerTransactionType(strings.ToUpper(strings.TrimSpace(c.Query("type"))))
+	txs, total, err := h.Transactions.List(c.Context(), user.ID, txType, limit, offset)
+	if err != nil {
+		logger.Error("Failed to list relayer transactions for user %s: %v", clerkID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to load transactions"})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":   txs,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
 	})
 }
 
+// GetTransaction returns one relayed transaction
+// GET /api/v1/wallet/transactions/:id
+func (h *WalletHandler) GetTransaction(c *fiber.Ctx) error {
+	if h.Transactions == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "Transaction history unavailable"})
+	}
+
+	clerkID, err := middleware.GetUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	}
+
+	user, err := h.Manager.GetUserWallet(c.Context(), clerkID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get user wallet: " + err.Error(),
+		})
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid transaction ID"})
+	}
+
+	tx, err := h.Transactions.Get(c.Context(), user.ID, id)
+	if err != nil {
+		if errors.Is(err, services.ErrRelayerTransactionNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Transaction not found"})
+		}
+		logger.Error("Failed to load relayer transaction %s: %v", id, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to load transaction"})
+	}
+
+	return c.JSON(tx)
+}
+
 // WithdrawRequest represents a withdrawal request
 type WithdrawRequest struct {
 	ToAddress string `json:"to_address"` // Destination address (EOA)
@@ -315,3 +657,41 @@ func (h *WalletHandler) Withdraw(c *fiber.Ctx) error {
 		"note":  "In production, this would submit a Safe transaction via the relayer to transfer USDC from the vault to the specified address.",
 	})
 }
+
+func proxyWalletError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidProxyRequest):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, services.ErrProxyVaultExists):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, services.ErrProxyPlanNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	default:
+		logger.Error("WalletHandler: %v", err)
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": "Proxy wallet request failed: " + err.Error()})
+	}
+}
+
+// resolveWallets applies the ?wallet= selector (wallet ID, address or "all"); without it, or without the
+// linked wallets service, the user's primary wallet is used.
+func resolveWallets(c *fiber.Ctx, wallets *services.UserWalletService, user *models.User) ([]*models.User, error) {
+	selector := c.Query("wallet")
+	if wallets == nil || selector == "" {
+		return []*models.User{user}, nil
+	}
+	return wallets.Resolve(c.Context(), user, selector)
+}
+
+func userWalletError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidUserWallet):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, services.ErrUserWalletNotFound), errors.Is(err, services.ErrWalletChallengeExpired):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	case errors.Is(err, services.ErrUserWalletExists):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	default:
+		logger.Error("WalletHandler: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Wallet request failed"})
+	}
+}
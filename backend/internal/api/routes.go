@@ -16,6 +16,8 @@
 package api
 
 import (
+	"context"
+
 	"github.com/bankai-project/backend/internal/api/handlers"
 	"github.com/bankai-project/backend/internal/api/middleware"
 	"github.com/bankai-project/backend/internal/config"
@@ -26,6 +28,7 @@ import (
 	"github.com/bankai-project/backend/internal/polymarket/data_api"
 	"github.com/bankai-project/backend/internal/polymarket/gamma"
 	"github.com/bankai-project/backend/internal/polymarket/relayer"
+	"github.com/bankai-project/backend/internal/secrets"
 	"github.com/bankai-project/backend/internal/services"
 	"github.com/gofiber/fiber/v2"
 	"github.com/redis/go-redis/v9"
@@ -53,7 +56,16 @@ func SetupRoutes(app *fiber.App, db *gorm.DB, rdb *redis.Client, cfg *config.Con
 	// 3. Initialize Services
 	marketService := services.NewMarketService(db, rdb, gammaClient, clobClient)
 	walletManager := services.NewWalletManager(db, relayerClient, gammaClient)
+	userWalletService := services.NewUserWalletService(db, rdb, relayerClient)
+	walletManager.Wallets = userWalletService
 	tradeService := services.NewTradeService(db, clobClient)
+	secretBox := loadSecretBox(cfg)
+	conditionalOrderService := services.NewConditionalOrderService(db, rdb, tradeService, secretBox)
+	orderGroupService := services.NewOrderGroupService(db, tradeService, conditionalOrderService)
+	tradeService.Groups = orderGroupService
+	executionAlgoService := services.NewExecutionAlgoService(db, tradeService, marketService, secretBox)
+	quoteService := services.NewQuoteService(marketService)
+	paperService := services.NewPaperTradingService(db, rdb, marketService)
 	oracleService := services.NewOracleService(marketService, tavilyClient, openaiClient)
 
 	// Social & Intelligence Services
@@ -61,6 +73,20 @@ func SetupRoutes(app *fiber.App, db *gorm.DB, rdb *redis.Client, cfg *config.Con
 	socialService := services.NewSocialService(db, gammaClient)
 	watchlistService := services.NewWatchlistService(db)
 	notificationService := services.NewNotificationService(db, socialService)
+	screenerService := services.NewScreenerService(db, marketService)
+	calendarService := services.NewCalendarService(db, marketService, profileService)
+	riskService := services.NewRiskService(db, rdb, profileService)
+	ctfService := services.NewCTFService(db, rdb, relayerClient, dataAPIClient)
+	relayerTransactionService := services.NewRelayerTransactionService(db, relayerClient)
+	ctfService.Transactions = relayerTransactionService
+	proxyWalletService := services.NewProxyWalletService(db, rdb, relayerClient)
+	proxyWalletService.Transactions = relayerTransactionService
+	taxLotService := services.NewTaxLotService(db)
+	taxLotService.DataAPI = dataAPIClient
+	journalService := services.NewJournalService(db)
+	rewardsService := services.NewRewardsService(db, rdb, marketService)
+	quoteLadderService := services.NewQuoteLadderService(db, marketService, profileService)
+	tradeService.Risk = riskService
 
 	// Initialize Blockchain Service
 	blockchainService, err := services.NewBlockchainService(cfg)
@@ -68,20 +94,45 @@ func SetupRoutes(app *fiber.App, db *gorm.DB, rdb *redis.Client, cfg *config.Con
 		logger.Error("Failed to initialize blockchain service: %v", err)
 		// Continue without blockchain service - balance checks will fail but app can still run
 		blockchainService = nil
+	} else {
+		// Drop cached balances when the worker detects a deposit
+		go blockchainService.WatchBalanceInvalidations(context.Background(), rdb)
 	}
 
 	// 4. Initialize Handlers
 	userHandler := handlers.NewUserHandler(db)
+	userHandler.Wallets = userWalletService
 	marketHandler := handlers.NewMarketHandler(marketService)
 	walletHandler := handlers.NewWalletHandler(walletManager, blockchainService)
+	walletHandler.Transactions = relayerTransactionService
+	walletHandler.Proxies = proxyWalletService
+	walletHandler.Wallets = userWalletService
+	ctfHandler := handlers.NewCTFHandler(db, ctfService)
+	ctfHandler.Blockchain = blockchainService
 	tradeHandler := handlers.NewTradeHandler(tradeService, cfg, db)
+	tradeHandler.Paper = paperService
+	tradeHandler.Wallets = userWalletService
+	paperHandler := handlers.NewPaperHandler(db, paperService)
+	conditionalOrderHandler := handlers.NewConditionalOrderHandler(db, conditionalOrderService)
+	orderGroupHandler := handlers.NewOrderGroupHandler(db, orderGroupService)
+	executionAlgoHandler := handlers.NewExecutionAlgoHandler(db, executionAlgoService)
+	quoteHandler := handlers.NewQuoteHandler(db, quoteService, riskService)
+	riskHandler := handlers.NewRiskHandler(db, riskService, cfg)
 	oracleHandler := handlers.NewOracleHandler(oracleService)
+	positionReconcileService := services.NewPositionReconcileService(db, profileService, blockchainService)
+	portfolioHandler := handlers.NewPortfolioHandler(db, taxLotService, positionReconcileService)
+	portfolioHandler.Wallets = userWalletService
+	journalHandler := handlers.NewJournalHandler(db, journalService)
+	rewardsHandler := handlers.NewRewardsHandler(db, rewardsService)
+	quoteLadderHandler := handlers.NewQuoteLadderHandler(db, quoteLadderService)
 
 	// Social & Intelligence Handlers
 	profileHandler := handlers.NewProfileHandler(profileService, socialService)
 	socialHandler := handlers.NewSocialHandler(db, socialService, notificationService)
 	watchlistHandler := handlers.NewWatchlistHandler(db, watchlistService)
 	holdersHandler := handlers.NewHoldersHandler(profileService)
+	screenerHandler := handlers.NewScreenerHandler(db, screenerService)
+	calendarHandler := handlers.NewCalendarHandler(db, calendarService)
 
 	// 5. Define Routes
 	// Root route for easy health checks
@@ -109,8 +160,14 @@ func SetupRoutes(app *fiber.App, db *gorm.DB, rdb *redis.Client, cfg *config.Con
 	markets.Get("/meta", marketHandler.GetActiveMarketsMeta)
 	markets.Get("/lanes", marketHandler.GetMarketLanes)
 	markets.Get("/stream", marketHandler.StreamPriceUpdates)
+	markets.Get("/screener/fields", screenerHandler.GetScreenerFields)
+	markets.Post("/screener", screenerHandler.RunScreen)
+	markets.Get("/calendar", calendarHandler.GetMarketCalendar)
+	markets.Get("/calendar/feed/:token", calendarHandler.GetCalendarFeedICS)
 	markets.Get("/:condition_id/history", marketHandler.GetPriceHistory)
 	markets.Get("/:condition_id/depth", marketHandler.GetDepthEstimate)
+	markets.Get("/:condition_id/changes", marketHandler.GetMarketChanges)
+	markets.Get("/:condition_id/activity", marketHandler.GetMarketActivity)
 	markets.Get("/:condition_id/holders", holdersHandler.GetMarketHolders) // Whale Table
 	markets.Post("/:condition_id/stream", marketHandler.RequestMarketStream)
 	markets.Get("/:slug", marketHandler.GetMarketBySlug)
@@ -131,6 +188,14 @@ func SetupRoutes(app *fiber.App, db *gorm.DB, rdb *redis.Client, cfg *config.Con
 	user := v1.Group("/user", middleware.Protected())
 	user.Post("/sync", userHandler.SyncUser)
 	user.Get("/me", userHandler.GetMe)
+	user.Get("/calendar-feed", calendarHandler.GetCalendarFeed)
+	user.Post("/calendar-feed/rotate", calendarHandler.RotateCalendarFeed)
+	user.Get("/risk-limits", riskHandler.GetRiskLimits)
+	user.Put("/risk-limits", riskHandler.UpdateRiskLimits)
+
+	// Desk Routes (Protected, admin role) for managed risk limits on shared accounts
+	risk := v1.Group("/risk", middleware.Protected())
+	risk.Put("/limits/:user_id", riskHandler.SetManagedRiskLimits)
 
 	// Wallet Routes (Protected)
 	wallet := v1.Group("/wallet", middleware.Protected())
@@ -138,19 +203,74 @@ func SetupRoutes(app *fiber.App, db *gorm.DB, rdb *redis.Client, cfg *config.Con
 	wallet.Get("", walletHandler.GetWallet)
 	wallet.Get("/deploy/typed-data", walletHandler.GetDeployTypedData)
 	wallet.Post("/deploy", walletHandler.DeployWallet)
+	wallet.Post("/proxy/prepare", walletHandler.PrepareProxyDeployment)
+	wallet.Post("/proxy/deploy", walletHandler.DeployProxyWallet)
 	wallet.Post("/update", walletHandler.UpdateWallet)
 	wallet.Get("/deposit", walletHandler.GetDepositAddress)
 	wallet.Get("/balance", walletHandler.GetBalance)
+	wallet.Get("/wallets", walletHandler.ListWallets)
+	wallet.Post("/wallets/challenge", walletHandler.GetWalletChallenge)
+	wallet.Post("/wallets", walletHandler.LinkWallet)
+	wallet.Patch("/wallets/:id", walletHandler.UpdateLinkedWallet)
+	wallet.Delete("/wallets/:id", walletHandler.UnlinkWallet)
 	wallet.Post("/withdraw", walletHandler.Withdraw)
+	wallet.Get("/transactions", walletHandler.GetTransactions)
+	wallet.Get("/transactions/:id", walletHandler.GetTransaction)
+	wallet.Get("/redeemable", ctfHandler.GetRedeemable)
+	wallet.Post("/redeem/prepare", ctfHandler.PrepareRedeem)
+	wallet.Post("/ctf/split", ctfHandler.PrepareSplit)
+	wallet.Post("/ctf/merge", ctfHandler.PrepareMerge)
+	wallet.Post("/ctf/submit", ctfHandler.SubmitPlan)
+	wallet.Get("/ctf/transactions/:id", ctfHandler.GetTransaction)
+	wallet.Get("/readiness", ctfHandler.GetReadiness)
+	wallet.Post("/approvals/prepare", ctfHandler.PrepareApprovals) // Submit via /ctf/submit
 
 	// Trade Routes (Protected)
 	trade := v1.Group("/trade", middleware.Protected())
 	// PostTrade and PostBatchTrade endpoints removed - frontend uses SDK directly
 	// GetAuthTypedData endpoint removed - SDK handles API key derivation
 	trade.Get("/orders", tradeHandler.GetOrders)
+	trade.Post("/orders", tradeHandler.PlaceOrder) // Paper mode only
+	trade.Get("/paper/account", paperHandler.GetPaperAccount)
+	trade.Get("/paper/fills", paperHandler.GetPaperFills)
+	trade.Post("/paper/reset", paperHandler.ResetPaperAccount)
+	trade.Get("/quote", quoteHandler.GetQuote)
+	trade.Get("/rewards", rewardsHandler.GetRewardEligibility)
+	trade.Post("/ladder", quoteLadderHandler.PlanQuoteLadder)
+	trade.Get("/ladder/history", quoteLadderHandler.GetQuoteAdjustments)
+	trade.Post("/risk/check", riskHandler.CheckOrder)
 	trade.Post("/cancel", tradeHandler.CancelOrder)
 	trade.Post("/cancel/batch", tradeHandler.CancelOrders)
+	trade.Post("/cancel/all", tradeHandler.CancelAllOrders)
+	trade.Post("/cancel/market/:condition_id", tradeHandler.CancelMarketOrders)
 	trade.Post("/sync", tradeHandler.SyncOrders) // Persist Polymarket orders/trades from SDK ingestion
+	trade.Get("/conditional", conditionalOrderHandler.GetConditionalOrders)
+	trade.Post("/conditional", conditionalOrderHandler.CreateConditionalOrder)
+	trade.Delete("/conditional/:id", conditionalOrderHandler.CancelConditionalOrder)
+	trade.Get("/groups", orderGroupHandler.GetOrderGroups)
+	trade.Post("/groups", orderGroupHandler.CreateOrderGroup)
+	trade.Get("/groups/:id", orderGroupHandler.GetOrderGroup)
+	trade.Delete("/groups/:id", orderGroupHandler.CancelOrderGroup)
+	trade.Get("/algos", executionAlgoHandler.GetExecutionAlgos)
+	trade.Post("/algos", executionAlgoHandler.CreateExecutionAlgo)
+	trade.Get("/algos/:id", executionAlgoHandler.GetExecutionAlgo)
+	trade.Post("/algos/:id/children", executionAlgoHandler.AddExecutionAlgoChildren)
+	trade.Delete("/algos/:id", executionAlgoHandler.CancelExecutionAlgo)
+
+	// Portfolio Routes (Protected)
+	portfolio := v1.Group("/portfolio", middleware.Protected())
+	portfolio.Get("/positions", portfolioHandler.GetPositions)
+	portfolio.Get("/tax-report", portfolioHandler.GetTaxReport)
+
+	// Journal Routes (Protected)
+	journal := v1.Group("/journal", middleware.Protected())
+	journal.Get("/", journalHandler.GetJournalEntries)
+	journal.Get("", journalHandler.GetJournalEntries)
+	journal.Post("/", journalHandler.CreateJournalEntry)
+	journal.Post("", journalHandler.CreateJournalEntry)
+	journal.Get("/:id", journalHandler.GetJournalEntry)
+	journal.Put("/:id", journalHandler.UpdateJournalEntry)
+	journal.Delete("/:id", journalHandler.DeleteJournalEntry)
 
 	// Social Routes (Protected)
 	social := v1.Group("/social", middleware.Protected())
@@ -171,6 +291,26 @@ func SetupRoutes(app *fiber.App, db *gorm.DB, rdb *redis.Client, cfg *config.Con
 	watchlist.Delete("/:market_id", watchlistHandler.RemoveBookmark)
 	watchlist.Get("/check/:market_id", watchlistHandler.CheckIsBookmarked)
 
+	// Saved Screen Routes (Protected)
+	screens := v1.Group("/screens", middleware.Protected())
+	screens.Get("/", screenerHandler.GetScreens)
+	screens.Get("", screenerHandler.GetScreens)
+	screens.Post("/", screenerHandler.CreateScreen)
+	screens.Post("", screenerHandler.CreateScreen)
+	screens.Put("/:id", screenerHandler.UpdateScreen)
+	screens.Delete("/:id", screenerHandler.DeleteScreen)
+	screens.Get("/:id/run", screenerHandler.RunSavedScreen)
+
 	// Internal sync route (secured via JOB_SYNC_SECRET header) for background workers
 	app.Post("/api/v1/trade/sync/internal", tradeHandler.SyncOrdersInternal)
 }
+
+// loadSecretBox returns the encryption box for server-held secrets, or nil when no key is configured.
+func loadSecretBox(cfg *config.Config) *secrets.Box {
+	box, err := secrets.NewBox(cfg.Services.EncryptionKey)
+	if err != nil {
+		logger.Info("Server-held secrets disabled: %v", err)
+		return nil
+	}
+	return box
+}
@@ -0,0 +1,215 @@
+/**
+ * @description
+ * Market data types replayed by the backtesting engine.
+ * An Event is a tick, trade print, candle or book snapshot for one outcome token; a Market carries the
+ * metadata strategies need (token ids, end date) and the settlement prices once resolved.
+ *
+ * @notes
+ * - Candles aggregate ticks and trades per token into OHLCV buckets; Price is the close.
+ * - Book levels are ordered best first (bids descending, asks ascending).
+ */
+
+package backtest
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bankai-project/backend/internal/models"
+)
+
+// EventKind identifies what an Event carries
+type EventKind string
+
+const (
+	EventTick   EventKind = "TICK"
+	EventTrade  EventKind = "TRADE"
+	EventCandle EventKind = "CANDLE"
+	EventBook   EventKind = "BOOK"
+)
+
+// Level is one price level of a book
+type Level struct {
+	Price float64 `json:"price"`
+	Size  float64 `json:"size"`
+}
+
+// Event is a single market data point for an outcome token
+type Event struct {
+	Time     time.Time
+	Kind     EventKind
+	MarketID string
+	TokenID  string
+
+	Price  float64 // Tick / trade price, candle close
+	Volume float64 // Trade size or candle volume (shares)
+	Side   string  // Taker side of a trade
+
+	Open float64 // Candles only
+	High float64
+	Low  float64
+
+	Bids []Level // Books only
+	Asks []Level
+}
+
+// Market is the replayed market's metadata
+type Market struct {
+	ConditionID string
+	Title       string
+	TokenIDYes  string
+	TokenIDNo   string
+	EndDate     *time.Time
+	Resolved    bool
+	Payouts     map[string]float64 // token_id -> settlement price, set when resolved
+}
+
+// Outcome returns the YES / NO label of a token in the market
+func (m *Market) Outcome(tokenID string) string {
+	switch tokenID {
+	case m.TokenIDYes:
+		return "YES"
+	case m.TokenIDNo:
+		return "NO"
+	}
+	return ""
+}
+
+// MarketFromModel builds the replay metadata for a stored market.
+// A closed market whose outcome prices are all 0 or 1 is treated as resolved.
+func MarketFromModel(market *models.Market) Market {
+	out := Market{
+		ConditionID: market.ConditionID,
+		Title:       market.Title,
+		TokenIDYes:  market.TokenIDYes,
+		TokenIDNo:   market.TokenIDNo,
+		EndDate:     market.EndDate,
+	}
+	if !market.Closed {
+		return out
+	}
+
+	prices := parseOutcomePrices(market.OutcomePrices)
+	if len(prices) != 2 {
+		return out
+	}
+	for _, p := range prices {
+		if p != 0 && p != 1 {
+			return out
+		}
+	}
+	out.Resolved = true
+	out.Payouts = map[string]float64{
+		market.TokenIDYes: prices[0],
+		market.TokenIDNo:  prices[1],
+	}
+	return out
+}
+
+// parseOutcomePrices reads Gamma's outcome prices, stored as a JSON array of strings or numbers.
+func parseOutcomePrices(raw string) []float64 {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil
+	}
+	var values []interface{}
+	if err := json.Unmarshal([]byte(raw), &values); err != nil {
+		return nil
+	}
+	prices := make([]float64, 0, len(values))
+	for _, v := range values {
+		switch val := v.(type) {
+		case float64:
+			prices = append(prices, val)
+		case string:
+			f, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return nil
+			}
+			prices = append(prices, f)
+		default:
+			return nil
+		}
+	}
+	return prices
+}
+
+// Candles aggregates tick and trade events into per-token OHLCV candles of the given interval.
+// Other events pass through unchanged. events is sorted in place; the result is sorted by time.
+func Candles(events []Event, interval time.Duration) []Event {
+	if interval <= 0 {
+		return events
+	}
+
+	type bucketKey struct {
+		token string
+		start int64
+	}
+	SortEvents(events)
+	buckets := make(map[bucketKey]*Event)
+	var out []Event
+	for _, ev := range events {
+		if ev.Kind != EventTick && ev.Kind != EventTrade {
+			out = append(out, ev)
+			continue
+		}
+		start := ev.Time.Truncate(interval)
+		key := bucketKey{token: ev.TokenID, start: start.UnixNano()}
+		candle, ok := buckets[key]
+		if !ok {
+			candle = &Event{
+				Time:     start.Add(interval),
+				Kind:     EventCandle,
+				MarketID: ev.MarketID,
+				TokenID:  ev.TokenID,
+				Open:     ev.Price,
+				High:     ev.Price,
+				Low:      ev.Price,
+			}
+			buckets[key] = candle
+		}
+		if ev.Price > candle.High {
+			candle.High = ev.Price
+		}
+		if ev.Price < candle.Low {
+			candle.Low = ev.Price
+		}
+		candle.Price = ev.Price
+		candle.Volume += ev.Volume
+	}
+
+	for _, candle := range buckets {
+		out = append(out, *candle)
+	}
+	SortEvents(out)
+	return out
+}
+
+// SortEvents orders events by time; at equal times books come first so fills see the latest book,
+// then events are ordered by token so replays are deterministic.
+func SortEvents(events []Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := &events[i], &events[j]
+		if !a.Time.Equal(b.Time) {
+			return a.Time.Before(b.Time)
+		}
+		if (a.Kind == EventBook) != (b.Kind == EventBook) {
+			return a.Kind == EventBook
+		}
+		return a.TokenID < b.TokenID
+	})
+}
+
+// sortLevels orders book levels best first.
+func sortLevels(levels []Level, bids bool) []Level {
+	sort.Slice(levels, func(i, j int) bool {
+		if bids {
+			return levels[i].Price > levels[j].Price
+		}
+		return levels[i].Price < levels[j].Price
+	})
+	return levels
+}
@@ -0,0 +1,404 @@
+/**
+ * @description
+ * Backtesting engine. Replays events in time order through a Strategy and simulates the resulting
+ * orders: against the latest recorded book when one is fresh enough, otherwise at the last price
+ * adjusted for slippage.
+ *
+ * @notes
+ * - Long-only: sells are capped at the held size and buys at the available cash.
+ * - Fees follow the CLOB formula: feeRate * min(p, 1-p) * size, charged in cash.
+ * - Liquidity taken from a book is consumed until the next snapshot of that token.
+ * - At the end, positions in resolved markets settle at their payout; others are marked to the last price.
+ */
+
+package backtest
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+// Config tunes the simulated execution
+type Config struct {
+	StartingCash float64
+	SlippageBps  float64       // Applied to fills without a usable book
+	FeeRateBps   float64       // CLOB fee rate charged on every fill
+	BookMaxAge   time.Duration // Older book snapshots are ignored for fills
+}
+
+// DefaultConfig returns the engine defaults
+func DefaultConfig() Config {
+	return Config{
+		StartingCash: 10000,
+		SlippageBps:  50,
+		BookMaxAge:   5 * time.Minute,
+	}
+}
+
+// Position is a held outcome token
+type Position struct {
+	MarketID string
+	TokenID  string
+	Size     float64
+	AvgPrice float64
+	OpenedAt time.Time
+}
+
+// State is the simulated account and market view handed to strategies
+type State struct {
+	Now  time.Time
+	Cash float64
+
+	cfg       Config
+	markets   map[string]*Market // token_id -> market
+	positions map[string]*Position
+	last      map[string]float64
+	books     map[string]*Event
+}
+
+// Market returns the market a token belongs to, or nil
+func (s *State) Market(tokenID string) *Market {
+	return s.markets[tokenID]
+}
+
+// Position returns the held position in a token (zero when flat)
+func (s *State) Position(tokenID string) Position {
+	if pos, ok := s.positions[tokenID]; ok {
+		return *pos
+	}
+	return Position{TokenID: tokenID}
+}
+
+// Positions returns every open position
+func (s *State) Positions() []Position {
+	out := make([]Position, 0, len(s.positions))
+	for _, pos := range s.positions {
+		out = append(out, *pos)
+	}
+	return out
+}
+
+// LastPrice returns the latest replayed price of a token (0 if none yet)
+func (s *State) LastPrice(tokenID string) float64 {
+	return s.last[tokenID]
+}
+
+// Book returns the latest book of a token if it is no older than the configured max age.
+func (s *State) Book(tokenID string) (bids, asks []Level, ok bool) {
+	book, found := s.books[tokenID]
+	if !found || s.Now.Sub(book.Time) > s.cfg.BookMaxAge {
+		return nil, nil, false
+	}
+	return book.Bids, book.Asks, true
+}
+
+// Equity is cash plus positions marked to their last price
+func (s *State) Equity() float64 {
+	equity := s.Cash
+	for token, pos := range s.positions {
+		price := s.last[token]
+		if price == 0 {
+			price = pos.AvgPrice
+		}
+		equity += pos.Size * price
+	}
+	return equity
+}
+
+// Engine runs backtests
+type Engine struct {
+	cfg Config
+}
+
+// NewEngine creates a new Engine, filling unset config fields with defaults
+func NewEngine(cfg Config) *Engine {
+	def := DefaultConfig()
+	if cfg.StartingCash <= 0 {
+		cfg.StartingCash = def.StartingCash
+	}
+	if cfg.SlippageBps < 0 {
+		cfg.SlippageBps = 0
+	}
+	if cfg.FeeRateBps < 0 {
+		cfg.FeeRateBps = 0
+	}
+	if cfg.BookMaxAge <= 0 {
+		cfg.BookMaxAge = def.BookMaxAge
+	}
+	return &Engine{cfg: cfg}
+}
+
+// Run replays events (sorted by time) through the strategy and returns the result.
+func (e *Engine) Run(markets []Market, events []Event, strategy Strategy) (*Result, error) {
+	if strategy == nil {
+		return nil, errors.New("strategy is required")
+	}
+	if len(markets) == 0 {
+		return nil, errors.New("no markets to replay")
+	}
+
+	state := &State{
+		Cash:      e.cfg.StartingCash,
+		cfg:       e.cfg,
+		markets:   make(map[string]*Market),
+		positions: make(map[string]*Position),
+		last:      make(map[string]float64),
+		books:     make(map[string]*Event),
+	}
+	for i := range markets {
+		m := &markets[i]
+		if m.TokenIDYes != "" {
+			state.markets[m.TokenIDYes] = m
+		}
+		if m.TokenIDNo != "" {
+			state.markets[m.TokenIDNo] = m
+		}
+	}
+
+	result := &Result{
+		Strategy:     strategy.Name(),
+		Markets:      len(markets),
+		StartingCash: e.cfg.StartingCash,
+	}
+	peak := e.cfg.StartingCash
+	track := func() {
+		equity := state.Equity()
+		if equity > peak {
+			peak = equity
+		}
+		if dd := peak - equity; dd > result.MaxDrawdown {
+			result.MaxDrawdown = dd
+			if peak > 0 {
+				result.MaxDrawdownPct = dd / peak * 100
+			}
+		}
+	}
+
+	for i := range events {
+		ev := events[i]
+		if state.markets[ev.TokenID] == nil {
+			continue
+		}
+		if i > 0 && ev.Time.Before(events[i-1].Time) {
+			return nil, errors.New("events must be sorted by time")
+		}
+		if result.Start.IsZero() {
+			result.Start = ev.Time
+		}
+		result.End = ev.Time
+		result.Events++
+		state.Now = ev.Time
+
+		if ev.Kind == EventBook {
+			book := ev
+			book.Bids = append([]Level(nil), ev.Bids...)
+			book.Asks = append([]Level(nil), ev.Asks...)
+			state.books[ev.TokenID] = &book
+		} else if ev.Price > 0 {
+			state.last[ev.TokenID] = ev.Price
+		}
+
+		for _, order := range strategy.OnEvent(state, ev) {
+			if trade, ok := e.execute(state, order); ok {
+				result.record(trade)
+			} else {
+				result.Rejected++
+			}
+		}
+		track()
+	}
+
+	e.settle(state, result)
+	track()
+
+	result.EndingCash = state.Cash
+	result.EndingEquity = state.Equity()
+	result.OpenPositions = len(state.positions)
+	for token, pos := range state.positions {
+		price := state.last[token]
+		if price == 0 {
+			price = pos.AvgPrice
+		}
+		result.UnrealizedPnL += (price - pos.AvgPrice) * pos.Size
+	}
+	result.finish()
+	return result, nil
+}
+
+// execute simulates one order, returning false when nothing could be filled.
+func (e *Engine) execute(state *State, order Order) (Trade, bool) {
+	side := strings.ToUpper(order.Side)
+	market := state.markets[order.TokenID]
+	if market == nil || order.Size <= 0 || (side != "BUY" && side != "SELL") {
+		return Trade{}, false
+	}
+
+	want := order.Size
+	if side == "SELL" {
+		pos := state.positions[order.TokenID]
+		if pos == nil {
+			return Trade{}, false
+		}
+		want = math.Min(want, pos.Size)
+	}
+
+	var filled, notional, fees float64
+	liquidity := LiquidityTick
+
+	if bids, asks, ok := state.Book(order.TokenID); ok {
+		liquidity = LiquidityBook
+		levels := asks
+		if side == "SELL" {
+			levels = bids
+		}
+		for i := range levels {
+			lvl := &levels[i]
+			if want-filled <= 0 {
+				break
+			}
+			if !withinLimit(side, lvl.Price, order.LimitPrice) {
+				break
+			}
+			if lvl.Size <= 0 {
+				continue // Emptied by an earlier order in this step
+			}
+			size := math.Min(lvl.Size, want-filled)
+			if side == "BUY" {
+				size = math.Min(size, e.affordable(state.Cash-notional-fees, lvl.Price))
+			}
+			if size <= 0 {
+				break
+			}
+			lvl.Size -= size
+			filled += size
+			notional += size * lvl.Price
+			fees += e.fee(lvl.Price, size)
+		}
+	} else {
+		last := state.last[order.TokenID]
+		if last <= 0 {
+			return Trade{}, false
+		}
+		slip := last * e.cfg.SlippageBps / 10000
+		price := last + slip
+		if side == "SELL" {
+			price = last - slip
+		}
+		price = math.Min(math.Max(price, 0.001), 0.999)
+		if !withinLimit(side, price, order.LimitPrice) {
+			return Trade{}, false
+		}
+		size := want
+		if side == "BUY" {
+			size = math.Min(size, e.affordable(state.Cash, price))
+		}
+		if size > 0 {
+			filled = size
+			notional = size * price
+			fees = e.fee(price, size)
+		}
+	}
+
+	if filled <= 1e-9 {
+		return Trade{}, false
+	}
+	price := notional / filled
+
+	trade := Trade{
+		Time:      state.Now,
+		MarketID:  market.ConditionID,
+		TokenID:   order.TokenID,
+		Outcome:   market.Outcome(order.TokenID),
+		Side:      side,
+		Price:     price,
+		Size:      filled,
+		Fee:       fees,
+		Liquidity: liquidity,
+		Reason:    order.Reason,
+	}
+	applyFill(state, &trade)
+	return trade, true
+}
+
+// settle closes positions in resolved markets at their payout.
+func (e *Engine) settle(state *State, result *Result) {
+	for token, pos := range state.positions {
+		market := state.markets[token]
+		if market == nil || !market.Resolved {
+			continue
+		}
+		payout := market.Payouts[token]
+		at := state.Now
+		if market.EndDate != nil && market.EndDate.After(at) {
+			at = *market.EndDate
+		}
+		trade := Trade{
+			Time:      at,
+			MarketID:  market.ConditionID,
+			TokenID:   token,
+			Outcome:   market.Outcome(token),
+			Side:      "SELL",
+			Price:     payout,
+			Size:      pos.Size,
+			Liquidity: LiquiditySettlement,
+			Reason:    "market resolved",
+		}
+		applyFill(state, &trade)
+		state.last[token] = payout
+		result.record(trade)
+	}
+}
+
+// applyFill moves cash and position for a fill and sets its realized PnL.
+func applyFill(state *State, trade *Trade) {
+	pos := state.positions[trade.TokenID]
+	if trade.Side == "BUY" {
+		if pos == nil {
+			pos = &Position{MarketID: trade.MarketID, TokenID: trade.TokenID, OpenedAt: trade.Time}
+			state.positions[trade.TokenID] = pos
+		}
+		cost := pos.AvgPrice*pos.Size + trade.Price*trade.Size
+		pos.Size += trade.Size
+		pos.AvgPrice = cost / pos.Size
+		state.Cash -= trade.Price*trade.Size + trade.Fee
+		trade.RealizedPnL = -trade.Fee
+		return
+	}
+
+	state.Cash += trade.Price*trade.Size - trade.Fee
+	trade.RealizedPnL = (trade.Price-pos.AvgPrice)*trade.Size - trade.Fee
+	trade.Closing = true
+	pos.Size -= trade.Size
+	if pos.Size <= 1e-9 {
+		delete(state.positions, trade.TokenID)
+	}
+}
+
+// affordable returns how many shares cash buys at price including fees.
+func (e *Engine) affordable(cash, price float64) float64 {
+	if cash <= 0 || price <= 0 {
+		return 0
+	}
+	perShare := price + e.fee(price, 1)
+	return cash / perShare
+}
+
+// fee applies the CLOB fee formula.
+func (e *Engine) fee(price, size float64) float64 {
+	if e.cfg.FeeRateBps <= 0 {
+		return 0
+	}
+	return e.cfg.FeeRateBps / 10000 * math.Min(price, 1-price) * size
+}
+
+func withinLimit(side string, price, limit float64) bool {
+	if limit <= 0 {
+		return true
+	}
+	if side == "BUY" {
+		return price <= limit+1e-9
+	}
+	return price >= limit-1e-9
+}
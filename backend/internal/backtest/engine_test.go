@@ -0,0 +1,151 @@
+package backtest
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// scripted buys on the first tick and sells on the third.
+type scripted struct{ seen int }
+
+func (s *scripted) Name() string { return "scripted" }
+
+func (s *scripted) OnEvent(state *State, ev Event) []Order {
+	if ev.Kind != EventTick {
+		return nil
+	}
+	s.seen++
+	switch s.seen {
+	case 1:
+		return []Order{{TokenID: "yes", Side: "BUY", Size: 100}}
+	case 3:
+		return []Order{{TokenID: "yes", Side: "SELL", Size: 100}}
+	}
+	return nil
+}
+
+func approx(a, b float64) bool { return math.Abs(a-b) < 1e-6 }
+
+func TestEngineBookAndTickFills(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	markets := []Market{{ConditionID: "m", TokenIDYes: "yes", TokenIDNo: "no"}}
+	events := []Event{
+		{Time: t0, Kind: EventBook, MarketID: "m", TokenID: "yes", Asks: []Level{{Price: 0.40, Size: 60}, {Price: 0.42, Size: 100}}},
+		{Time: t0, Kind: EventTick, MarketID: "m", TokenID: "yes", Price: 0.41},
+		{Time: t0.Add(10 * time.Minute), Kind: EventTick, MarketID: "m", TokenID: "yes", Price: 0.30},
+		{Time: t0.Add(20 * time.Minute), Kind: EventTick, MarketID: "m", TokenID: "yes", Price: 0.50},
+	}
+
+	result, err := NewEngine(Config{StartingCash: 1000, SlippageBps: 100, BookMaxAge: 5 * time.Minute}).Run(markets, events, &scripted{})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(result.Trades) != 2 {
+		t.Fatalf("trades = %d, want 2", len(result.Trades))
+	}
+
+	buy := result.Trades[0]
+	if buy.Liquidity != LiquidityBook || !approx(buy.Price, (60*0.40+40*0.42)/100) {
+		t.Errorf("buy = %+v, want book fill at VWAP 0.408", buy)
+	}
+	sell := result.Trades[1]
+	if sell.Liquidity != LiquidityTick || !approx(sell.Price, 0.495) {
+		t.Errorf("sell = %+v, want tick fill at 0.495 after slippage", sell)
+	}
+
+	wantPnL := (0.495 - 0.408) * 100
+	if !approx(result.PnL, wantPnL) || result.HitRate != 100 {
+		t.Errorf("pnl = %v hit rate = %v, want %v and 100", result.PnL, result.HitRate, wantPnL)
+	}
+	if !approx(result.MaxDrawdown, (0.41-0.30)*100) {
+		t.Errorf("max drawdown = %v, want %v", result.MaxDrawdown, (0.41-0.30)*100)
+	}
+}
+
+func TestEngineSettlesResolvedMarkets(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	markets := []Market{{
+		ConditionID: "m", TokenIDYes: "yes", TokenIDNo: "no",
+		Resolved: true, Payouts: map[string]float64{"yes": 1, "no": 0},
+	}}
+	events := []Event{{Time: t0, Kind: EventTick, MarketID: "m", TokenID: "yes", Price: 0.60}}
+
+	result, err := NewEngine(Config{StartingCash: 1000}).Run(markets, events, &scripted{})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	last := result.Trades[len(result.Trades)-1]
+	if last.Liquidity != LiquiditySettlement || last.Price != 1 {
+		t.Fatalf("last trade = %+v, want settlement at 1", last)
+	}
+	if result.OpenPositions != 0 || !approx(result.EndingEquity, 1000+100*(1-0.60)) {
+		t.Errorf("ending equity = %v open = %d", result.EndingEquity, result.OpenPositions)
+	}
+}
+
+// sweeper sends every order on the first tick.
+type sweeper struct {
+	orders []Order
+	done   bool
+}
+
+func (s *sweeper) Name() string { return "sweeper" }
+
+func (s *sweeper) OnEvent(state *State, ev Event) []Order {
+	if ev.Kind != EventTick || s.done {
+		return nil
+	}
+	s.done = true
+	return s.orders
+}
+
+func TestEngineWalksPastLevelsEmptiedInSameStep(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	markets := []Market{{ConditionID: "m", TokenIDYes: "yes", TokenIDNo: "no"}}
+	events := []Event{
+		{Time: t0, Kind: EventBook, MarketID: "m", TokenID: "yes", Asks: []Level{
+			{Price: 0.40, Size: 50}, {Price: 0.45, Size: 50}, {Price: 0.50, Size: 100},
+		}},
+		{Time: t0, Kind: EventTick, MarketID: "m", TokenID: "yes", Price: 0.42},
+	}
+
+	cases := []struct {
+		name   string
+		orders []Order
+		want   []Trade // Price and Size only
+	}{
+		{
+			name:   "second order skips the emptied top level",
+			orders: []Order{{TokenID: "yes", Side: "BUY", Size: 50}, {TokenID: "yes", Side: "BUY", Size: 80}},
+			want:   []Trade{{Price: 0.40, Size: 50}, {Price: (50*0.45 + 30*0.50) / 80, Size: 80}},
+		},
+		{
+			name:   "second order walks two emptied levels",
+			orders: []Order{{TokenID: "yes", Side: "BUY", Size: 100}, {TokenID: "yes", Side: "BUY", Size: 40}},
+			want:   []Trade{{Price: (50*0.40 + 50*0.45) / 100, Size: 100}, {Price: 0.50, Size: 40}},
+		},
+		{
+			name:   "limit still stops the walk",
+			orders: []Order{{TokenID: "yes", Side: "BUY", Size: 50}, {TokenID: "yes", Side: "BUY", Size: 80, LimitPrice: 0.45}},
+			want:   []Trade{{Price: 0.40, Size: 50}, {Price: 0.45, Size: 50}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := NewEngine(Config{StartingCash: 1000, BookMaxAge: 5 * time.Minute}).Run(markets, events, &sweeper{orders: tc.orders})
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if len(result.Trades) != len(tc.want) || result.Rejected != 0 {
+				t.Fatalf("trades = %d rejected = %d, want %d and 0", len(result.Trades), result.Rejected, len(tc.want))
+			}
+			for i, want := range tc.want {
+				got := result.Trades[i]
+				if got.Liquidity != LiquidityBook || !approx(got.Price, want.Price) || !approx(got.Size, want.Size) {
+					t.Errorf("trade %d = %+v, want book fill %v @ %v", i, got, want.Size, want.Price)
+				}
+			}
+		})
+	}
+}
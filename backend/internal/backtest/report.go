@@ -0,0 +1,141 @@
+/**
+ * @description
+ * Backtest results: simulated trades, PnL, drawdown and hit rate, plus CSV and text output.
+ *
+ * @notes
+ * - Hit rate counts closing trades (sells and settlements) with positive realized PnL after fees.
+ * - Buy fees are booked as negative realized PnL on the opening trade.
+ */
+
+package backtest
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Liquidity sources of a simulated fill
+const (
+	LiquidityBook       = "BOOK"
+	LiquidityTick       = "TICK"
+	LiquiditySettlement = "SETTLEMENT"
+)
+
+// Trade is a simulated fill
+type Trade struct {
+	Time        time.Time
+	MarketID    string
+	TokenID     string
+	Outcome     string
+	Side        string
+	Price       float64
+	Size        float64
+	Fee         float64
+	Liquidity   string
+	RealizedPnL float64
+	Closing     bool
+	Reason      string
+}
+
+// Result summarises a backtest run
+type Result struct {
+	Strategy string
+	Markets  int
+	Events   int
+	Start    time.Time
+	End      time.Time
+
+	StartingCash  float64
+	EndingCash    float64
+	EndingEquity  float64
+	PnL           float64
+	ReturnPct     float64
+	RealizedPnL   float64
+	UnrealizedPnL float64
+	Fees          float64
+
+	MaxDrawdown    float64
+	MaxDrawdownPct float64
+
+	Trades        []Trade
+	ClosingTrades int
+	Wins          int
+	HitRate       float64
+	Rejected      int
+	OpenPositions int
+}
+
+func (r *Result) record(trade Trade) {
+	r.Trades = append(r.Trades, trade)
+	r.Fees += trade.Fee
+	r.RealizedPnL += trade.RealizedPnL
+	if trade.Closing {
+		r.ClosingTrades++
+		if trade.RealizedPnL > 0 {
+			r.Wins++
+		}
+	}
+}
+
+func (r *Result) finish() {
+	r.PnL = r.EndingEquity - r.StartingCash
+	if r.StartingCash > 0 {
+		r.ReturnPct = r.PnL / r.StartingCash * 100
+	}
+	if r.ClosingTrades > 0 {
+		r.HitRate = float64(r.Wins) / float64(r.ClosingTrades) * 100
+	}
+}
+
+// Summary renders the result as plain text
+func (r *Result) Summary() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Strategy:        %s\n", r.Strategy)
+	fmt.Fprintf(&b, "Markets:         %d (%d events)\n", r.Markets, r.Events)
+	if !r.Start.IsZero() {
+		fmt.Fprintf(&b, "Period:          %s -> %s\n", r.Start.Format(time.RFC3339), r.End.Format(time.RFC3339))
+	}
+	fmt.Fprintf(&b, "Starting cash:   %.2f\n", r.StartingCash)
+	fmt.Fprintf(&b, "Ending equity:   %.2f\n", r.EndingEquity)
+	fmt.Fprintf(&b, "PnL:             %.2f (%.2f%%)\n", r.PnL, r.ReturnPct)
+	fmt.Fprintf(&b, "  realized:      %.2f\n", r.RealizedPnL)
+	fmt.Fprintf(&b, "  unrealized:    %.2f (%d open positions)\n", r.UnrealizedPnL, r.OpenPositions)
+	fmt.Fprintf(&b, "Fees:            %.2f\n", r.Fees)
+	fmt.Fprintf(&b, "Max drawdown:    %.2f (%.2f%%)\n", r.MaxDrawdown, r.MaxDrawdownPct)
+	fmt.Fprintf(&b, "Trades:          %d (%d closing, %d rejected orders)\n", len(r.Trades), r.ClosingTrades, r.Rejected)
+	fmt.Fprintf(&b, "Hit rate:        %.1f%% (%d/%d)\n", r.HitRate, r.Wins, r.ClosingTrades)
+	return b.String()
+}
+
+// WriteTradesCSV writes trades with a header row
+func WriteTradesCSV(w io.Writer, trades []Trade) error {
+	cw := csv.NewWriter(w)
+	header := []string{"time", "market_id", "token_id", "outcome", "side", "price", "size", "fee", "liquidity", "realized_pnl", "reason"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, t := range trades {
+		row := []string{
+			t.Time.UTC().Format(time.RFC3339),
+			t.MarketID,
+			t.TokenID,
+			t.Outcome,
+			t.Side,
+			strconv.FormatFloat(t.Price, 'f', 4, 64),
+			strconv.FormatFloat(t.Size, 'f', 4, 64),
+			strconv.FormatFloat(t.Fee, 'f', 4, 64),
+			t.Liquidity,
+			strconv.FormatFloat(t.RealizedPnL, 'f', 4, 64),
+			t.Reason,
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
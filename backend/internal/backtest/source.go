@@ -0,0 +1,255 @@
+/**
+ * @description
+ * Historical data sources for the backtesting engine.
+ * PostgresSource reads stored ticks (price_history), the recorded trade tape (market_trades) and sampled
+ * books (book_snapshots) for a set of markets; Backfill fills price_history from the CLOB prices-history API.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - backend/internal/models
+ * - backend/internal/polymarket/clob
+ *
+ * @notes
+ * - price_history rows are keyed by outcome (YES / NO) and mapped onto the market's token ids.
+ * - Books are only available from the time the worker started recording them.
+ */
+
+package backtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/polymarket/clob"
+	"gorm.io/gorm"
+)
+
+// Query selects the history to replay
+type Query struct {
+	MarketIDs []string
+	From      time.Time
+	To        time.Time
+	Candle    time.Duration // Aggregate ticks and trades into candles when set
+	NoBooks   bool          // Skip book snapshots; fills then use ticks and slippage only
+}
+
+// Source loads markets and their events for a query
+type Source interface {
+	Load(ctx context.Context, q Query) ([]Market, []Event, error)
+}
+
+// PostgresSource reads history stored by the worker
+type PostgresSource struct {
+	DB *gorm.DB
+}
+
+// NewPostgresSource creates a new PostgresSource
+func NewPostgresSource(db *gorm.DB) *PostgresSource {
+	return &PostgresSource{DB: db}
+}
+
+// Load returns the markets in q and their events, sorted by time.
+func (s *PostgresSource) Load(ctx context.Context, q Query) ([]Market, []Event, error) {
+	if len(q.MarketIDs) == 0 {
+		return nil, nil, errors.New("at least one market is required")
+	}
+	if !q.To.IsZero() && !q.From.IsZero() && !q.To.After(q.From) {
+		return nil, nil, errors.New("to must be after from")
+	}
+
+	var stored []models.Market
+	if err := s.DB.WithContext(ctx).Where("condition_id IN ?", q.MarketIDs).Find(&stored).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to load markets: %w", err)
+	}
+	if len(stored) == 0 {
+		return nil, nil, errors.New("none of the requested markets are stored")
+	}
+
+	markets := make([]Market, 0, len(stored))
+	byID := make(map[string]*Market, len(stored))
+	ids := make([]string, 0, len(stored))
+	for i := range stored {
+		markets = append(markets, MarketFromModel(&stored[i]))
+		ids = append(ids, stored[i].ConditionID)
+	}
+	for i := range markets {
+		byID[markets[i].ConditionID] = &markets[i]
+	}
+
+	var events []Event
+
+	var ticks []models.PriceHistory
+	if err := s.window(ctx, "timestamp", q).Where("market_id IN ?", ids).
+		Order("timestamp").Find(&ticks).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to load price history: %w", err)
+	}
+	for _, tick := range ticks {
+		market := byID[tick.MarketID]
+		tokenID := market.TokenIDYes
+		if tick.Outcome == "NO" {
+			tokenID = market.TokenIDNo
+		}
+		if tokenID == "" || tick.Price <= 0 {
+			continue
+		}
+		events = append(events, Event{
+			Time:     tick.Timestamp.UTC(),
+			Kind:     EventTick,
+			MarketID: tick.MarketID,
+			TokenID:  tokenID,
+			Price:    tick.Price,
+			Volume:   tick.Volume,
+		})
+	}
+
+	var trades []models.MarketTrade
+	if err := s.window(ctx, "traded_at", q).Where("market_id IN ?", ids).
+		Order("traded_at").Find(&trades).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to load market trades: %w", err)
+	}
+	for _, trade := range trades {
+		events = append(events, Event{
+			Time:     trade.TradedAt.UTC(),
+			Kind:     EventTrade,
+			MarketID: trade.MarketID,
+			TokenID:  trade.TokenID,
+			Price:    trade.Price,
+			Volume:   trade.Size,
+			Side:     trade.Side,
+		})
+	}
+
+	if !q.NoBooks {
+		var books []models.BookSnapshot
+		if err := s.window(ctx, "captured_at", q).Where("market_id IN ?", ids).
+			Order("captured_at").Find(&books).Error; err != nil {
+			return nil, nil, fmt.Errorf("failed to load book snapshots: %w", err)
+		}
+		for _, book := range books {
+			bids, err := decodeLevels(book.Bids)
+			if err != nil {
+				return nil, nil, fmt.Errorf("book snapshot %d: %w", book.ID, err)
+			}
+			asks, err := decodeLevels(book.Asks)
+			if err != nil {
+				return nil, nil, fmt.Errorf("book snapshot %d: %w", book.ID, err)
+			}
+			events = append(events, Event{
+				Time:     book.CapturedAt.UTC(),
+				Kind:     EventBook,
+				MarketID: book.MarketID,
+				TokenID:  book.TokenID,
+				Bids:     sortLevels(bids, true),
+				Asks:     sortLevels(asks, false),
+			})
+		}
+	}
+
+	if q.Candle > 0 {
+		events = Candles(events, q.Candle)
+	} else {
+		SortEvents(events)
+	}
+	return markets, events, nil
+}
+
+func (s *PostgresSource) window(ctx context.Context, column string, q Query) *gorm.DB {
+	tx := s.DB.WithContext(ctx)
+	if !q.From.IsZero() {
+		tx = tx.Where(column+" >= ?", q.From)
+	}
+	if !q.To.IsZero() {
+		tx = tx.Where(column+" < ?", q.To)
+	}
+	return tx
+}
+
+// decodeLevels parses a stored book side ([{"price":"0.5","size":"10"}]).
+func decodeLevels(raw string) ([]Level, error) {
+	var stored []struct {
+		Price string `json:"price"`
+		Size  string `json:"size"`
+	}
+	if err := json.Unmarshal([]byte(raw), &stored); err != nil {
+		return nil, fmt.Errorf("invalid book levels: %w", err)
+	}
+	levels := make([]Level, 0, len(stored))
+	for _, lvl := range stored {
+		price, err := strconv.ParseFloat(lvl.Price, 64)
+		if err != nil || price <= 0 {
+			continue
+		}
+		size, err := strconv.ParseFloat(lvl.Size, 64)
+		if err != nil || size <= 0 {
+			continue
+		}
+		levels = append(levels, Level{Price: price, Size: size})
+	}
+	return levels, nil
+}
+
+// Backfill stores CLOB price history for the markets' YES and NO tokens in price_history,
+// replacing any rows already stored in [from, to). fidelity is the resolution in minutes.
+func Backfill(ctx context.Context, db *gorm.DB, client *clob.Client, marketIDs []string, from, to time.Time, fidelity int) (int, error) {
+	if to.IsZero() {
+		to = time.Now().UTC()
+	}
+	if from.IsZero() || !to.After(from) {
+		return 0, errors.New("backfill needs a from time before to")
+	}
+
+	var stored []models.Market
+	if err := db.WithContext(ctx).Where("condition_id IN ?", marketIDs).Find(&stored).Error; err != nil {
+		return 0, fmt.Errorf("failed to load markets: %w", err)
+	}
+
+	total := 0
+	for _, market := range stored {
+		for outcome, tokenID := range map[string]string{"YES": market.TokenIDYes, "NO": market.TokenIDNo} {
+			if tokenID == "" {
+				continue
+			}
+			points, err := client.GetPriceHistory(ctx, clob.PriceHistoryParams{
+				Market:   tokenID,
+				StartTs:  from.Unix(),
+				EndTs:    to.Unix(),
+				Fidelity: fidelity,
+			})
+			if err != nil {
+				return total, fmt.Errorf("failed to fetch history for %s %s: %w", market.ConditionID, outcome, err)
+			}
+
+			rows := make([]models.PriceHistory, 0, len(points))
+			for _, point := range points {
+				rows = append(rows, models.PriceHistory{
+					MarketID:  market.ConditionID,
+					Outcome:   outcome,
+					Price:     point.Price,
+					Timestamp: time.Unix(point.Timestamp, 0).UTC(),
+				})
+			}
+
+			err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+				if err := tx.Where("market_id = ? AND outcome = ? AND timestamp >= ? AND timestamp < ?",
+					market.ConditionID, outcome, from, to).
+					Delete(&models.PriceHistory{}).Error; err != nil {
+					return err
+				}
+				if len(rows) == 0 {
+					return nil
+				}
+				return tx.CreateInBatches(rows, 1000).Error
+			})
+			if err != nil {
+				return total, fmt.Errorf("failed to store history for %s %s: %w", market.ConditionID, outcome, err)
+			}
+			total += len(rows)
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,276 @@
+/**
+ * @description
+ * Strategy interface for the backtesting engine and the built-in strategies.
+ * A strategy sees every replayed event with the account state and returns the orders to execute.
+ *
+ * @notes
+ * - mean-reversion: near resolution, buys a token that drops well below its rolling mean and sells
+ *   once it reverts (or hits a stop).
+ * - momentum: buys a token whose price jumps sharply inside a short lookback, optionally confirmed by
+ *   a volume spike, and exits on take-profit, stop-loss or after a holding period.
+ * - Both strategies are long-only; a bearish view on YES is expressed by buying NO.
+ */
+
+package backtest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Order is a strategy's instruction to trade. LimitPrice 0 accepts any price.
+type Order struct {
+	TokenID    string
+	Side       string // BUY or SELL
+	Size       float64
+	LimitPrice float64
+	Reason     string
+}
+
+// Strategy decides what to trade as history is replayed
+type Strategy interface {
+	Name() string
+	OnEvent(state *State, ev Event) []Order
+}
+
+// NewStrategy returns a built-in strategy with default parameters, trading size shares per entry.
+func NewStrategy(name string, size float64) (Strategy, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "mean-reversion":
+		return NewMeanReversion(size), nil
+	case "momentum":
+		return NewMomentum(size), nil
+	}
+	return nil, fmt.Errorf("unknown strategy %q (available: %s)", name, strings.Join(StrategyNames(), ", "))
+}
+
+// StrategyNames lists the built-in strategies
+func StrategyNames() []string {
+	names := []string{"mean-reversion", "momentum"}
+	sort.Strings(names)
+	return names
+}
+
+// isPriceEvent reports whether ev moves the token's price.
+func isPriceEvent(ev Event) bool {
+	return ev.Kind == EventTick || ev.Kind == EventTrade || ev.Kind == EventCandle
+}
+
+// MeanReversion fades sharp drops in markets close to resolution
+type MeanReversion struct {
+	Window    int           // Observations in the rolling mean
+	Threshold float64       // Enter when price is this far below the mean
+	ExitBand  float64       // Exit once price is back within this of the mean
+	StopLoss  float64       // Exit when price falls this far below entry
+	Horizon   time.Duration // Only enter markets ending within this window
+	MinPrice  float64       // Skip near-certain outcomes
+	MaxPrice  float64
+	Size      float64
+
+	history map[string][]float64
+	entries map[string]float64
+}
+
+// NewMeanReversion creates a MeanReversion strategy with default parameters
+func NewMeanReversion(size float64) *MeanReversion {
+	return &MeanReversion{
+		Window:    30,
+		Threshold: 0.05,
+		ExitBand:  0.01,
+		StopLoss:  0.10,
+		Horizon:   72 * time.Hour,
+		MinPrice:  0.05,
+		MaxPrice:  0.95,
+		Size:      size,
+		history:   make(map[string][]float64),
+		entries:   make(map[string]float64),
+	}
+}
+
+// Name implements Strategy
+func (m *MeanReversion) Name() string {
+	return "mean-reversion"
+}
+
+// OnEvent implements Strategy
+func (m *MeanReversion) OnEvent(state *State, ev Event) []Order {
+	if !isPriceEvent(ev) || ev.Price <= 0 {
+		return nil
+	}
+
+	history := m.history[ev.TokenID]
+	full := len(history) >= m.Window
+	mean := 0.0
+	for _, p := range history {
+		mean += p
+	}
+	if len(history) > 0 {
+		mean /= float64(len(history))
+	}
+
+	history = append(history, ev.Price)
+	if len(history) > m.Window {
+		history = history[len(history)-m.Window:]
+	}
+	m.history[ev.TokenID] = history
+
+	position := state.Position(ev.TokenID)
+	if position.Size > 0 {
+		entry := m.entries[ev.TokenID]
+		switch {
+		case ev.Price >= mean-m.ExitBand:
+			delete(m.entries, ev.TokenID)
+			return []Order{{TokenID: ev.TokenID, Side: "SELL", Size: position.Size, Reason: "reverted to mean"}}
+		case entry > 0 && ev.Price <= entry-m.StopLoss:
+			delete(m.entries, ev.TokenID)
+			return []Order{{TokenID: ev.TokenID, Side: "SELL", Size: position.Size, Reason: "stop loss"}}
+		}
+		return nil
+	}
+
+	if !full || ev.Price < m.MinPrice || ev.Price > m.MaxPrice || ev.Price > mean-m.Threshold {
+		return nil
+	}
+	market := state.Market(ev.TokenID)
+	if market == nil || market.EndDate == nil {
+		return nil
+	}
+	if left := market.EndDate.Sub(ev.Time); left <= 0 || left > m.Horizon {
+		return nil
+	}
+
+	m.entries[ev.TokenID] = ev.Price
+	return []Order{{
+		TokenID:    ev.TokenID,
+		Side:       "BUY",
+		Size:       m.Size,
+		LimitPrice: mean - m.Threshold/2,
+		Reason:     fmt.Sprintf("%.3f below %d-point mean %.3f", mean-ev.Price, len(history)-1, mean),
+	}}
+}
+
+// Momentum buys sharp price spikes
+type Momentum struct {
+	Lookback       time.Duration // Spike window
+	Jump           float64       // Minimum price rise inside the lookback
+	VolumeMultiple float64       // Lookback volume vs its trailing average; 0 disables the check
+	HoldFor        time.Duration // Exit after this long
+	TakeProfit     float64
+	StopLoss       float64
+	MaxPrice       float64 // Do not chase above this price
+	Size           float64
+
+	points  map[string][]momentumPoint
+	volume  map[string]*momentumVolume
+	entries map[string]momentumEntry
+}
+
+type momentumPoint struct {
+	at     time.Time
+	price  float64
+	volume float64
+}
+
+type momentumVolume struct {
+	first time.Time
+	total float64
+}
+
+type momentumEntry struct {
+	at    time.Time
+	price float64
+}
+
+// NewMomentum creates a Momentum strategy with default parameters
+func NewMomentum(size float64) *Momentum {
+	return &Momentum{
+		Lookback:       15 * time.Minute,
+		Jump:           0.08,
+		VolumeMultiple: 3,
+		HoldFor:        4 * time.Hour,
+		TakeProfit:     0.10,
+		StopLoss:       0.05,
+		MaxPrice:       0.90,
+		Size:           size,
+		points:         make(map[string][]momentumPoint),
+		volume:         make(map[string]*momentumVolume),
+		entries:        make(map[string]momentumEntry),
+	}
+}
+
+// Name implements Strategy
+func (m *Momentum) Name() string {
+	return "momentum"
+}
+
+// OnEvent implements Strategy
+func (m *Momentum) OnEvent(state *State, ev Event) []Order {
+	if !isPriceEvent(ev) || ev.Price <= 0 {
+		return nil
+	}
+
+	points := append(m.points[ev.TokenID], momentumPoint{at: ev.Time, price: ev.Price, volume: ev.Volume})
+	cutoff := ev.Time.Add(-m.Lookback)
+	for len(points) > 1 && points[0].at.Before(cutoff) {
+		points = points[1:]
+	}
+	m.points[ev.TokenID] = points
+
+	vol := m.volume[ev.TokenID]
+	if vol == nil {
+		vol = &momentumVolume{first: ev.Time}
+		m.volume[ev.TokenID] = vol
+	}
+	vol.total += ev.Volume
+
+	position := state.Position(ev.TokenID)
+	if position.Size > 0 {
+		entry, ok := m.entries[ev.TokenID]
+		if !ok {
+			return nil
+		}
+		reason := ""
+		switch {
+		case ev.Price >= entry.price+m.TakeProfit:
+			reason = "take profit"
+		case ev.Price <= entry.price-m.StopLoss:
+			reason = "stop loss"
+		case ev.Time.Sub(entry.at) >= m.HoldFor:
+			reason = "holding period elapsed"
+		}
+		if reason == "" {
+			return nil
+		}
+		delete(m.entries, ev.TokenID)
+		return []Order{{TokenID: ev.TokenID, Side: "SELL", Size: position.Size, Reason: reason}}
+	}
+
+	rise := ev.Price - points[0].price
+	if rise < m.Jump || ev.Price > m.MaxPrice {
+		return nil
+	}
+	if m.VolumeMultiple > 0 {
+		windowVolume := 0.0
+		for _, p := range points {
+			windowVolume += p.volume
+		}
+		windows := float64(ev.Time.Sub(vol.first)) / float64(m.Lookback)
+		if windows < 1 {
+			return nil // not enough history to know what a spike is
+		}
+		if windowVolume < m.VolumeMultiple*(vol.total/windows) {
+			return nil
+		}
+	}
+
+	m.entries[ev.TokenID] = momentumEntry{at: ev.Time, price: ev.Price}
+	return []Order{{
+		TokenID:    ev.TokenID,
+		Side:       "BUY",
+		Size:       m.Size,
+		LimitPrice: ev.Price + m.Jump/2,
+		Reason:     fmt.Sprintf("up %.3f in %s", rise, m.Lookback),
+	}}
+}
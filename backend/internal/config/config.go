@@ -70,6 +70,7 @@ type ServicesConfig struct {
 	OpenAIModel    string
 	PolygonRPCURL  string
 	SyncJobSecret  string
+	EncryptionKey  string // 32-byte key (hex or base64) for secrets held server-side, e.g. conditional orders
 }
 
 // Load reads .env file and populates the Config struct
@@ -105,6 +106,7 @@ func Load() (*Config, error) {
 			OpenAIModel:    getEnv("OPENAI_MODEL", "google/gemini-3-pro-preview"),
 			PolygonRPCURL:  getEnv("POLYGON_RPC_URL", ""),
 			SyncJobSecret:  getEnv("JOB_SYNC_SECRET", ""),
+			EncryptionKey:  sanitizeCredential(getEnv("BANKAI_ENCRYPTION_KEY", "")),
 		},
 	}
 
@@ -0,0 +1,39 @@
+/**
+ * @description
+ * Calendar feed model.
+ * Maps to the 'calendar_feeds' table backing per-user .ics feeds.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - github.com/google/uuid
+ */
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CalendarFeed holds the secret token that authorises a user's iCalendar feed
+type CalendarFeed struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex" json:"user_id"`
+	Token          string     `gorm:"size:64;not null;uniqueIndex" json:"token"`
+	LastAccessedAt *time.Time `gorm:"column:last_accessed_at" json:"last_accessed_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (CalendarFeed) TableName() string {
+	return "calendar_feeds"
+}
+
+func (f *CalendarFeed) BeforeCreate(tx *gorm.DB) (err error) {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,100 @@
+/**
+ * @description
+ * Conditional order model.
+ * Maps to the 'conditional_orders' table holding pre-signed stop-loss / take-profit orders.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - github.com/google/uuid
+ *
+ * @notes
+ * - EncryptedPayload is never serialized; it contains the signed order and the user's CLOB API credentials.
+ */
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ConditionalOrderKind defines which way the trigger price must be crossed
+type ConditionalOrderKind string
+
+const (
+	ConditionalOrderStopLoss   ConditionalOrderKind = "STOP_LOSS"   // fires when price <= trigger
+	ConditionalOrderTakeProfit ConditionalOrderKind = "TAKE_PROFIT" // fires when price >= trigger
+)
+
+// TriggerSource defines which live price is compared against the trigger
+type TriggerSource string
+
+const (
+	TriggerSourceBid       TriggerSource = "BID"
+	TriggerSourceMid       TriggerSource = "MID"
+	TriggerSourceLastTrade TriggerSource = "LAST_TRADE"
+)
+
+// ConditionalOrderStatus defines the lifecycle of a conditional order
+type ConditionalOrderStatus string
+
+const (
+	ConditionalOrderPending   ConditionalOrderStatus = "PENDING" // held by an order group until its entry fills
+	ConditionalOrderArmed     ConditionalOrderStatus = "ARMED"
+	ConditionalOrderTriggered ConditionalOrderStatus = "TRIGGERED" // claimed by the worker, submission in flight
+	ConditionalOrderSubmitted ConditionalOrderStatus = "SUBMITTED"
+	ConditionalOrderFailed    ConditionalOrderStatus = "FAILED"
+	ConditionalOrderCanceled  ConditionalOrderStatus = "CANCELED"
+	ConditionalOrderExpired   ConditionalOrderStatus = "EXPIRED"
+)
+
+// ConditionalOrder is a pre-signed sell order submitted to the CLOB when its trigger fires
+type ConditionalOrder struct {
+	ID               uuid.UUID              `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID           uuid.UUID              `gorm:"type:uuid;not null;index" json:"user_id"`
+	GroupID          *uuid.UUID             `gorm:"column:group_id;type:uuid" json:"group_id,omitempty"`
+	MarketID         string                 `gorm:"column:market_id;size:66;not null" json:"market_id"` // Condition ID
+	TokenID          string                 `gorm:"column:token_id;size:255;not null" json:"token_id"`
+	Outcome          string                 `gorm:"column:outcome;size:64" json:"outcome"`
+	Kind             ConditionalOrderKind   `gorm:"column:kind;size:16;not null" json:"kind"`
+	TriggerSource    TriggerSource          `gorm:"column:trigger_source;size:16;not null" json:"trigger_source"`
+	TriggerPrice     float64                `gorm:"column:trigger_price;type:decimal;not null" json:"trigger_price"`
+	OrderType        string                 `gorm:"column:order_type;size:4;not null" json:"order_type"`
+	LimitPrice       float64                `gorm:"column:limit_price;type:decimal" json:"limit_price"`
+	Size             float64                `gorm:"column:size;type:decimal" json:"size"`
+	EncryptedPayload string                 `gorm:"column:encrypted_payload;not null" json:"-"`
+	Status           ConditionalOrderStatus `gorm:"column:status;size:16;not null;default:'ARMED'" json:"status"`
+	ObservedPrice    *float64               `gorm:"column:observed_price;type:decimal" json:"observed_price,omitempty"`
+	OrderID          *uuid.UUID             `gorm:"column:order_id;type:uuid" json:"order_id,omitempty"`
+	CLOBOrderID      string                 `gorm:"column:clob_order_id" json:"clob_order_id,omitempty"`
+	ErrorMessage     string                 `gorm:"column:error_msg" json:"error_msg,omitempty"`
+	ExpiresAt        *time.Time             `gorm:"column:expires_at" json:"expires_at,omitempty"`
+	TriggeredAt      *time.Time             `gorm:"column:triggered_at" json:"triggered_at,omitempty"`
+	CompletedAt      *time.Time             `gorm:"column:completed_at" json:"completed_at,omitempty"`
+	CreatedAt        time.Time              `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt        time.Time              `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (ConditionalOrder) TableName() string {
+	return "conditional_orders"
+}
+
+func (o *ConditionalOrder) BeforeCreate(tx *gorm.DB) (err error) {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	return
+}
+
+// Crossed reports whether the observed price satisfies the trigger.
+func (o *ConditionalOrder) Crossed(price float64) bool {
+	if price <= 0 {
+		return false
+	}
+	if o.Kind == ConditionalOrderTakeProfit {
+		return price >= o.TriggerPrice
+	}
+	return price <= o.TriggerPrice
+}
@@ -0,0 +1,144 @@
+/**
+ * @description
+ * Execution algo models.
+ * Maps to the 'execution_algos' and 'execution_algo_children' tables backing TWAP / iceberg execution.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - github.com/google/uuid
+ *
+ * @notes
+ * - Children are pre-signed FOK/FAK orders; EncryptedPayload and EncryptedCredentials are never serialized.
+ * - FilledNotional / FilledSize gives the volume-weighted average fill price.
+ */
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ExecutionAlgoKind defines how child orders are released
+type ExecutionAlgoKind string
+
+const (
+	ExecutionAlgoTWAP    ExecutionAlgoKind = "TWAP"    // one child per interval
+	ExecutionAlgoIceberg ExecutionAlgoKind = "ICEBERG" // one child whenever visible size within the limit covers it
+)
+
+// ExecutionAlgoStatus defines the lifecycle of an execution algo
+type ExecutionAlgoStatus string
+
+const (
+	ExecutionAlgoRunning       ExecutionAlgoStatus = "RUNNING"
+	ExecutionAlgoPaused        ExecutionAlgoStatus = "PAUSED"         // price moved beyond the limit
+	ExecutionAlgoWaitingOrders ExecutionAlgoStatus = "WAITING_ORDERS" // size remains but no signed child is queued
+	ExecutionAlgoCompleted     ExecutionAlgoStatus = "COMPLETED"
+	ExecutionAlgoCanceled      ExecutionAlgoStatus = "CANCELED"
+	ExecutionAlgoExpired       ExecutionAlgoStatus = "EXPIRED"
+	ExecutionAlgoFailed        ExecutionAlgoStatus = "FAILED"
+)
+
+// ExecutionAlgoChildStatus defines the lifecycle of a child order
+type ExecutionAlgoChildStatus string
+
+const (
+	ExecutionAlgoChildQueued     ExecutionAlgoChildStatus = "QUEUED"
+	ExecutionAlgoChildSubmitting ExecutionAlgoChildStatus = "SUBMITTING" // claimed by a worker, being posted
+	ExecutionAlgoChildFilled     ExecutionAlgoChildStatus = "FILLED"
+	ExecutionAlgoChildPartial    ExecutionAlgoChildStatus = "PARTIAL"
+	ExecutionAlgoChildUnfilled   ExecutionAlgoChildStatus = "UNFILLED"
+	ExecutionAlgoChildFailed     ExecutionAlgoChildStatus = "FAILED"
+	ExecutionAlgoChildCanceled   ExecutionAlgoChildStatus = "CANCELED"
+)
+
+// ExecutionAlgo releases a parent order to the CLOB as a series of child orders
+type ExecutionAlgo struct {
+	ID                   uuid.UUID           `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID               uuid.UUID           `gorm:"type:uuid;not null;index" json:"user_id"`
+	Kind                 ExecutionAlgoKind   `gorm:"column:kind;size:16;not null" json:"kind"`
+	Status               ExecutionAlgoStatus `gorm:"column:status;size:16;not null" json:"status"`
+	MarketID             string              `gorm:"column:market_id;size:66;not null" json:"market_id"`
+	TokenID              string              `gorm:"column:token_id;size:255;not null" json:"token_id"`
+	Outcome              string              `gorm:"column:outcome;size:64" json:"outcome"`
+	Side                 OrderSide           `gorm:"column:side;size:4;not null" json:"side"`
+	TotalSize            float64             `gorm:"column:total_size;type:decimal;not null" json:"total_size"`
+	FilledSize           float64             `gorm:"column:filled_size;type:decimal;not null;default:0" json:"filled_size"`
+	FilledNotional       float64             `gorm:"column:filled_notional;type:decimal;not null;default:0" json:"filled_notional"`
+	LimitPrice           float64             `gorm:"column:limit_price;type:decimal" json:"limit_price,omitempty"`
+	IntervalSeconds      int                 `gorm:"column:interval_seconds;not null" json:"interval_seconds"`
+	EncryptedCredentials string              `gorm:"column:encrypted_credentials;not null" json:"-"`
+	ChildrenSubmitted    int                 `gorm:"column:children_submitted;not null;default:0" json:"children_submitted"`
+	ConsecutiveFailures  int                 `gorm:"column:consecutive_failures;not null;default:0" json:"consecutive_failures"`
+	StatusReason         string              `gorm:"column:status_reason" json:"status_reason,omitempty"`
+	NextReleaseAt        *time.Time          `gorm:"column:next_release_at" json:"next_release_at,omitempty"`
+	EndsAt               *time.Time          `gorm:"column:ends_at" json:"ends_at,omitempty"`
+	CompletedAt          *time.Time          `gorm:"column:completed_at" json:"completed_at,omitempty"`
+	CreatedAt            time.Time           `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt            time.Time           `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (ExecutionAlgo) TableName() string {
+	return "execution_algos"
+}
+
+func (a *ExecutionAlgo) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return
+}
+
+// IsActive reports whether the scheduler still manages the algo.
+func (a *ExecutionAlgo) IsActive() bool {
+	return a.Status == ExecutionAlgoRunning || a.Status == ExecutionAlgoPaused || a.Status == ExecutionAlgoWaitingOrders
+}
+
+// RemainingSize is the unfilled part of the parent order.
+func (a *ExecutionAlgo) RemainingSize() float64 {
+	if remaining := a.TotalSize - a.FilledSize; remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// AverageFillPrice is the volume-weighted price across all child fills.
+func (a *ExecutionAlgo) AverageFillPrice() float64 {
+	if a.FilledSize <= 0 {
+		return 0
+	}
+	return a.FilledNotional / a.FilledSize
+}
+
+// ExecutionAlgoChild is one pre-signed slice of an execution algo
+type ExecutionAlgoChild struct {
+	ID               uuid.UUID                `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	AlgoID           uuid.UUID                `gorm:"type:uuid;not null;index" json:"algo_id"`
+	Seq              int                      `gorm:"column:seq;not null" json:"seq"`
+	Size             float64                  `gorm:"column:size;type:decimal;not null" json:"size"`
+	LimitPrice       float64                  `gorm:"column:limit_price;type:decimal;not null" json:"limit_price"`
+	EncryptedPayload string                   `gorm:"column:encrypted_payload;not null" json:"-"`
+	Status           ExecutionAlgoChildStatus `gorm:"column:status;size:16;not null" json:"status"`
+	OrderID          *uuid.UUID               `gorm:"column:order_id;type:uuid" json:"order_id,omitempty"`
+	CLOBOrderID      string                   `gorm:"column:clob_order_id" json:"clob_order_id,omitempty"`
+	FilledSize       float64                  `gorm:"column:filled_size;type:decimal;not null;default:0" json:"filled_size"`
+	AvgPrice         *float64                 `gorm:"column:avg_price;type:decimal" json:"avg_price,omitempty"`
+	ErrorMessage     string                   `gorm:"column:error_msg" json:"error_msg,omitempty"`
+	SubmittedAt      *time.Time               `gorm:"column:submitted_at" json:"submitted_at,omitempty"`
+	CreatedAt        time.Time                `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt        time.Time                `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (ExecutionAlgoChild) TableName() string {
+	return "execution_algo_children"
+}
+
+func (c *ExecutionAlgoChild) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return
+}
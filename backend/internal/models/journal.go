@@ -0,0 +1,59 @@
+/**
+ * @description
+ * Trade journal model.
+ * Maps to the 'journal_entries' table.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - github.com/google/uuid
+ */
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// JournalSubject is what a journal entry is attached to
+type JournalSubject string
+
+const (
+	JournalSubjectOrder    JournalSubject = "ORDER"
+	JournalSubjectMarket   JournalSubject = "MARKET"
+	JournalSubjectPosition JournalSubject = "POSITION"
+)
+
+// JournalEntry records why a user took (or planned) a trade
+type JournalEntry struct {
+	ID               uuid.UUID      `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID           uuid.UUID      `gorm:"type:uuid;not null;index" json:"user_id"`
+	Subject          JournalSubject `gorm:"column:subject;type:varchar(16);not null" json:"subject"`
+	OrderID          *uuid.UUID     `gorm:"column:order_id;type:uuid" json:"order_id,omitempty"`
+	MarketID         string         `gorm:"column:market_id;not null" json:"market_id"`
+	TokenID          string         `gorm:"column:token_id" json:"token_id,omitempty"`
+	Outcome          string         `gorm:"column:outcome;type:varchar(64)" json:"outcome,omitempty"`
+	Side             string         `gorm:"column:side;type:varchar(4)" json:"side,omitempty"`
+	EntryPrice       *float64       `gorm:"column:entry_price;type:decimal" json:"entry_price,omitempty"`
+	Notes            string         `gorm:"column:notes" json:"notes"`
+	Tags             StringArray    `gorm:"column:tags;type:text[]" json:"tags"`
+	Confidence       *int           `gorm:"column:confidence" json:"confidence,omitempty"` // 0-100
+	PlannedExitPrice *float64       `gorm:"column:planned_exit_price;type:decimal" json:"planned_exit_price,omitempty"`
+	PlannedExitAt    *time.Time     `gorm:"column:planned_exit_at" json:"planned_exit_at,omitempty"`
+	PostMortem       string         `gorm:"column:post_mortem" json:"post_mortem"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (JournalEntry) TableName() string {
+	return "journal_entries"
+}
+
+func (e *JournalEntry) BeforeCreate(tx *gorm.DB) (err error) {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return
+}
@@ -101,6 +101,7 @@ type Market struct {
 	ConditionID           string      `gorm:"primaryKey;column:condition_id" json:"condition_id"`
 	GammaMarketID         string      `gorm:"column:gamma_market_id" json:"gamma_market_id"`
 	QuestionID            string      `gorm:"column:question_id" json:"question_id"`
+	EventID               string      `gorm:"column:event_id;index" json:"event_id"` // Gamma event the market belongs to
 	Slug                  string      `gorm:"column:slug;index" json:"slug"`
 	Title                 string      `gorm:"column:title" json:"title"`
 	Description           string      `gorm:"column:description" json:"description"`
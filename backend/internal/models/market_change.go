@@ -0,0 +1,39 @@
+/**
+ * @description
+ * Market change log model.
+ * Maps to the 'market_changes' table populated by the Gamma sync diff.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - github.com/google/uuid
+ */
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MarketChange records a single field edit Polymarket made to a market
+type MarketChange struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	MarketID   string    `gorm:"size:66;not null;index" json:"market_id"`
+	Field      string    `gorm:"size:64;not null" json:"field"`
+	OldValue   string    `gorm:"column:old_value" json:"old_value"`
+	NewValue   string    `gorm:"column:new_value" json:"new_value"`
+	DetectedAt time.Time `gorm:"column:detected_at;not null" json:"detected_at"`
+}
+
+func (MarketChange) TableName() string {
+	return "market_changes"
+}
+
+func (c *MarketChange) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,50 @@
+/**
+ * @description
+ * Market history models.
+ * Maps to the 'market_trades' and 'book_snapshots' tables recorded by the RTDS worker for backtesting.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ *
+ * @notes
+ * - Bids / Asks are JSON arrays of {"price","size"} strings, as received from the CLOB.
+ * - Book snapshots are sampled per token, not stored for every book event.
+ */
+
+package models
+
+import (
+	"time"
+)
+
+// MarketTrade is a public trade print for an outcome token
+type MarketTrade struct {
+	ID         uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	MarketID   string    `gorm:"column:market_id;size:66;not null;index:idx_market_trades_market_time" json:"market_id"`
+	TokenID    string    `gorm:"column:token_id;size:255;not null" json:"token_id"`
+	Price      float64   `gorm:"column:price;type:decimal;not null" json:"price"`
+	Size       float64   `gorm:"column:size;type:decimal;not null" json:"size"`
+	Side       string    `gorm:"column:side;size:4" json:"side"` // Taker side
+	FeeRateBps int       `gorm:"column:fee_rate_bps;not null;default:0" json:"fee_rate_bps"`
+	TradedAt   time.Time `gorm:"column:traded_at;not null;index:idx_market_trades_market_time" json:"traded_at"`
+}
+
+// TableName overrides the table name used by MarketTrade to `market_trades`
+func (MarketTrade) TableName() string {
+	return "market_trades"
+}
+
+// BookSnapshot is an order book snapshot for an outcome token
+type BookSnapshot struct {
+	ID         uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	MarketID   string    `gorm:"column:market_id;size:66;not null;index:idx_book_snapshots_market_time" json:"market_id"`
+	TokenID    string    `gorm:"column:token_id;size:255;not null" json:"token_id"`
+	Bids       string    `gorm:"column:bids;type:jsonb;not null" json:"bids"`
+	Asks       string    `gorm:"column:asks;type:jsonb;not null" json:"asks"`
+	CapturedAt time.Time `gorm:"column:captured_at;not null;index:idx_book_snapshots_market_time" json:"captured_at"`
+}
+
+// TableName overrides the table name used by BookSnapshot to `book_snapshots`
+func (BookSnapshot) TableName() string {
+	return "book_snapshots"
+}
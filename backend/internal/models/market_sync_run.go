@@ -0,0 +1,60 @@
+/**
+ * @description
+ * Market sync run model.
+ * Maps to the 'market_sync_runs' table written by the incremental Gamma sync engine.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - github.com/google/uuid
+ */
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MarketSyncStatus represents the outcome of a sync run
+type MarketSyncStatus string
+
+const (
+	MarketSyncStatusRunning   MarketSyncStatus = "RUNNING"
+	MarketSyncStatusSucceeded MarketSyncStatus = "SUCCEEDED"
+	MarketSyncStatusPartial   MarketSyncStatus = "PARTIAL"
+	MarketSyncStatusFailed    MarketSyncStatus = "FAILED"
+)
+
+// MarketSyncRun records the timing and delta counts of a single Gamma sync pass
+type MarketSyncRun struct {
+	ID               uuid.UUID        `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	Trigger          string           `gorm:"size:32;not null" json:"trigger"`
+	Mode             string           `gorm:"size:16;not null" json:"mode"`
+	Status           MarketSyncStatus `gorm:"size:16;not null" json:"status"`
+	DryRun           bool             `gorm:"column:dry_run" json:"dry_run"`
+	Pages            int              `gorm:"column:pages" json:"pages"`
+	EventsSeen       int              `gorm:"column:events_seen" json:"events_seen"`
+	MarketsSeen      int              `gorm:"column:markets_seen" json:"markets_seen"`
+	MarketsCreated   int              `gorm:"column:markets_created" json:"markets_created"`
+	MarketsUpdated   int              `gorm:"column:markets_updated" json:"markets_updated"`
+	MarketsUnchanged int              `gorm:"column:markets_unchanged" json:"markets_unchanged"`
+	MarketsArchived  int              `gorm:"column:markets_archived" json:"markets_archived"`
+	ErrorCount       int              `gorm:"column:error_count" json:"error_count"`
+	Errors           StringArray      `gorm:"type:text[]" json:"errors"`
+	StartedAt        time.Time        `gorm:"column:started_at;not null" json:"started_at"`
+	FinishedAt       *time.Time       `gorm:"column:finished_at" json:"finished_at"`
+	DurationMs       int64            `gorm:"column:duration_ms" json:"duration_ms"`
+}
+
+func (MarketSyncRun) TableName() string {
+	return "market_sync_runs"
+}
+
+func (r *MarketSyncRun) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return
+}
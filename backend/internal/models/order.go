@@ -55,8 +55,11 @@ type Order struct {
 	Side           OrderSide   `gorm:"column:side;type:varchar(4)" json:"side"`
 	Outcome        string      `gorm:"column:outcome;type:varchar(64)" json:"outcome"` // Outcome label (e.g., "YES", "NO", "CANDIDATE A")
 	OutcomeTokenID string      `gorm:"column:outcome_token_id;type:varchar(255)" json:"outcome_token_id"`
+	MakerAddress   string      `gorm:"column:maker_address;type:varchar(42)" json:"maker_address"` // Vault (or EOA) that signed as maker
 	Price          float64     `gorm:"column:price;type:decimal" json:"price"`
 	Size           float64     `gorm:"column:size;type:decimal" json:"size"`
+	SizeMatched    float64     `gorm:"column:size_matched;type:decimal;default:0" json:"size_matched"`
+	FeeRateBps     int         `gorm:"column:fee_rate_bps;default:0" json:"fee_rate_bps"`
 	OrderType      string      `gorm:"column:order_type;type:varchar(10)" json:"order_type"` // LIMIT, MARKET, FOK, FAK, GTC, GTD
 	Status         OrderStatus `gorm:"column:status;type:varchar(20);default:'PENDING';index:idx_orders_status" json:"status"`
 	StatusDetail   string      `gorm:"column:status_detail;type:varchar(32)" json:"status_detail"`
@@ -77,6 +80,18 @@ func (Order) TableName() string {
 	return "orders"
 }
 
+// FilledSize returns the shares actually matched. Orders synced without size_matched
+// fall back to their full size once FILLED.
+func (o *Order) FilledSize() float64 {
+	if o.SizeMatched > 0 {
+		return o.SizeMatched
+	}
+	if o.Status == OrderStatusFilled {
+		return o.Size
+	}
+	return 0
+}
+
 // BeforeCreate ensures UUID is generated if not present
 func (o *Order) BeforeCreate(tx *gorm.DB) (err error) {
 	if o.ID == uuid.Nil {
@@ -0,0 +1,126 @@
+/**
+ * @description
+ * Order group models.
+ * Maps to the 'order_groups' and 'order_group_legs' tables backing OCO and bracket orders.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - github.com/google/uuid
+ *
+ * @notes
+ * - A leg points at either a CLOB order (by clob_order_id) or a server-held conditional order.
+ * - Leg status is derived from the underlying order during reconciliation; it is a cache, not the source of truth.
+ * - A leg that ended after matching part of its size is FILLED with filled_size below size.
+ */
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OrderGroupKind defines how the legs of a group relate
+type OrderGroupKind string
+
+const (
+	OrderGroupOCO     OrderGroupKind = "OCO"     // first leg to fill cancels the rest
+	OrderGroupBracket OrderGroupKind = "BRACKET" // exits wait for the entry to fill, then behave as OCO
+)
+
+// OrderGroupStatus defines the group state machine
+type OrderGroupStatus string
+
+const (
+	OrderGroupPendingEntry OrderGroupStatus = "PENDING_ENTRY"
+	OrderGroupActive       OrderGroupStatus = "ACTIVE"
+	OrderGroupCompleted    OrderGroupStatus = "COMPLETED"
+	OrderGroupCanceled     OrderGroupStatus = "CANCELED"
+	OrderGroupFailed       OrderGroupStatus = "FAILED"
+)
+
+// OrderGroupLegRole identifies a leg's purpose
+type OrderGroupLegRole string
+
+const (
+	OrderGroupLegEntry      OrderGroupLegRole = "ENTRY"
+	OrderGroupLegTakeProfit OrderGroupLegRole = "TAKE_PROFIT"
+	OrderGroupLegStopLoss   OrderGroupLegRole = "STOP_LOSS"
+)
+
+// OrderGroupLegStatus is the normalized state of a leg's underlying order
+type OrderGroupLegStatus string
+
+const (
+	OrderGroupLegWaiting  OrderGroupLegStatus = "WAITING" // held until the entry fills
+	OrderGroupLegWorking  OrderGroupLegStatus = "WORKING"
+	OrderGroupLegPartial  OrderGroupLegStatus = "PARTIAL" // matched in part and still working
+	OrderGroupLegFilled   OrderGroupLegStatus = "FILLED"
+	OrderGroupLegCanceled OrderGroupLegStatus = "CANCELED"
+	OrderGroupLegFailed   OrderGroupLegStatus = "FAILED"
+)
+
+// OrderGroup links orders whose lifecycles depend on each other
+type OrderGroup struct {
+	ID                   uuid.UUID        `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID               uuid.UUID        `gorm:"type:uuid;not null;index" json:"user_id"`
+	Kind                 OrderGroupKind   `gorm:"column:kind;size:16;not null" json:"kind"`
+	Status               OrderGroupStatus `gorm:"column:status;size:16;not null" json:"status"`
+	MarketID             string           `gorm:"column:market_id;size:66" json:"market_id"`
+	EncryptedCredentials string           `gorm:"column:encrypted_credentials;not null" json:"-"`
+	FiredLegID           *uuid.UUID       `gorm:"column:fired_leg_id;type:uuid" json:"fired_leg_id,omitempty"`
+	StatusReason         string           `gorm:"column:status_reason" json:"status_reason,omitempty"`
+	CompletedAt          *time.Time       `gorm:"column:completed_at" json:"completed_at,omitempty"`
+	CreatedAt            time.Time        `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt            time.Time        `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Legs []OrderGroupLeg `gorm:"foreignKey:GroupID" json:"legs"`
+}
+
+func (OrderGroup) TableName() string {
+	return "order_groups"
+}
+
+func (g *OrderGroup) BeforeCreate(tx *gorm.DB) (err error) {
+	if g.ID == uuid.Nil {
+		g.ID = uuid.New()
+	}
+	return
+}
+
+// IsOpen reports whether the group still needs reconciliation.
+func (g *OrderGroup) IsOpen() bool {
+	return g.Status == OrderGroupPendingEntry || g.Status == OrderGroupActive
+}
+
+// OrderGroupLeg is a single order within a group
+type OrderGroupLeg struct {
+	ID                 uuid.UUID           `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	GroupID            uuid.UUID           `gorm:"type:uuid;not null;index" json:"group_id"`
+	Role               OrderGroupLegRole   `gorm:"column:role;size:16;not null" json:"role"`
+	CLOBOrderID        string              `gorm:"column:clob_order_id" json:"clob_order_id,omitempty"`
+	ConditionalOrderID *uuid.UUID          `gorm:"column:conditional_order_id;type:uuid" json:"conditional_order_id,omitempty"`
+	Status             OrderGroupLegStatus `gorm:"column:status;size:16;not null" json:"status"`
+	Size               float64             `gorm:"column:size;type:decimal;default:0" json:"size"`
+	FilledSize         float64             `gorm:"column:filled_size;type:decimal;default:0" json:"filled_size"`
+	CreatedAt          time.Time           `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt          time.Time           `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (OrderGroupLeg) TableName() string {
+	return "order_group_legs"
+}
+
+func (l *OrderGroupLeg) BeforeCreate(tx *gorm.DB) (err error) {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	return
+}
+
+// IsTerminal reports whether the leg can no longer change.
+func (l *OrderGroupLeg) IsTerminal() bool {
+	return l.Status == OrderGroupLegFilled || l.Status == OrderGroupLegCanceled || l.Status == OrderGroupLegFailed
+}
@@ -0,0 +1,174 @@
+/**
+ * @description
+ * Paper trading models.
+ * Maps to the 'paper_accounts', 'paper_orders', 'paper_fills' and 'paper_positions' tables backing
+ * simulated trading against the live order books.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - github.com/google/uuid
+ *
+ * @notes
+ * - CashBalance is free cash; ReservedCash backs resting BUY orders at their limit price.
+ * - ReservedSize is the part of a position committed to resting SELL orders.
+ * - QueueAhead is the resting size estimated to be ahead of the order at its price level.
+ */
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PaperOrderStatus defines the lifecycle of a simulated order
+type PaperOrderStatus string
+
+const (
+	PaperOrderOpen     PaperOrderStatus = "OPEN" // resting; may be partially filled
+	PaperOrderFilled   PaperOrderStatus = "FILLED"
+	PaperOrderCanceled PaperOrderStatus = "CANCELED" // user cancel or unfilled FOK / FAK remainder
+	PaperOrderExpired  PaperOrderStatus = "EXPIRED"
+)
+
+// PaperLiquidity records whether a simulated fill took or made liquidity
+type PaperLiquidity string
+
+const (
+	PaperLiquidityTaker PaperLiquidity = "TAKER"
+	PaperLiquidityMaker PaperLiquidity = "MAKER"
+)
+
+// PaperAccount is a user's virtual USDC account
+type PaperAccount struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID          uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex" json:"user_id"`
+	StartingBalance float64    `gorm:"column:starting_balance;type:decimal;not null" json:"starting_balance"`
+	CashBalance     float64    `gorm:"column:cash_balance;type:decimal;not null" json:"cash_balance"`
+	ReservedCash    float64    `gorm:"column:reserved_cash;type:decimal;not null;default:0" json:"reserved_cash"`
+	RealizedPnL     float64    `gorm:"column:realized_pnl;type:decimal;not null;default:0" json:"realized_pnl"`
+	ResetAt         *time.Time `gorm:"column:reset_at" json:"reset_at,omitempty"`
+	CreatedAt       time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName overrides the table name used by PaperAccount to `paper_accounts`
+func (PaperAccount) TableName() string {
+	return "paper_accounts"
+}
+
+// BeforeCreate ensures UUID is generated if not present
+func (a *PaperAccount) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return
+}
+
+// PaperOrder is a simulated order
+type PaperOrder struct {
+	ID             uuid.UUID        `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID         uuid.UUID        `gorm:"type:uuid;not null;index" json:"user_id"`
+	MarketID       string           `gorm:"column:market_id;size:66;not null" json:"market_id"`
+	TokenID        string           `gorm:"column:token_id;size:255;not null" json:"token_id"`
+	Outcome        string           `gorm:"column:outcome;size:64" json:"outcome"`
+	Side           OrderSide        `gorm:"column:side;size:4;not null" json:"side"`
+	OrderType      string           `gorm:"column:order_type;size:10;not null" json:"order_type"` // GTC, GTD, FOK, FAK
+	Price          float64          `gorm:"column:price;type:decimal;not null" json:"price"`      // Limit (worst acceptable) price
+	Size           float64          `gorm:"column:size;type:decimal;not null" json:"size"`
+	FilledSize     float64          `gorm:"column:filled_size;type:decimal;not null;default:0" json:"filled_size"`
+	FilledNotional float64          `gorm:"column:filled_notional;type:decimal;not null;default:0" json:"filled_notional"`
+	QueueAhead     float64          `gorm:"column:queue_ahead;type:decimal;not null;default:0" json:"queue_ahead"`
+	Status         PaperOrderStatus `gorm:"column:status;size:16;not null" json:"status"`
+	StatusReason   string           `gorm:"column:status_reason" json:"status_reason,omitempty"`
+	ExpiresAt      *time.Time       `gorm:"column:expires_at" json:"expires_at,omitempty"`
+	CompletedAt    *time.Time       `gorm:"column:completed_at" json:"completed_at,omitempty"`
+	CreatedAt      time.Time        `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time        `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName overrides the table name used by PaperOrder to `paper_orders`
+func (PaperOrder) TableName() string {
+	return "paper_orders"
+}
+
+// BeforeCreate ensures UUID is generated if not present
+func (o *PaperOrder) BeforeCreate(tx *gorm.DB) (err error) {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	return
+}
+
+// Remaining returns the unfilled size
+func (o *PaperOrder) Remaining() float64 {
+	if rem := o.Size - o.FilledSize; rem > 0 {
+		return rem
+	}
+	return 0
+}
+
+// AvgFillPrice returns the volume-weighted fill price, or 0 when unfilled
+func (o *PaperOrder) AvgFillPrice() float64 {
+	if o.FilledSize <= 0 {
+		return 0
+	}
+	return o.FilledNotional / o.FilledSize
+}
+
+// PaperFill is a single simulated execution
+type PaperFill struct {
+	ID        uuid.UUID      `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	OrderID   uuid.UUID      `gorm:"type:uuid;not null;index" json:"order_id"`
+	UserID    uuid.UUID      `gorm:"type:uuid;not null;index" json:"user_id"`
+	MarketID  string         `gorm:"column:market_id;size:66;not null" json:"market_id"`
+	TokenID   string         `gorm:"column:token_id;size:255;not null" json:"token_id"`
+	Side      OrderSide      `gorm:"column:side;size:4;not null" json:"side"`
+	Price     float64        `gorm:"column:price;type:decimal;not null" json:"price"`
+	Size      float64        `gorm:"column:size;type:decimal;not null" json:"size"`
+	Liquidity PaperLiquidity `gorm:"column:liquidity;size:5;not null" json:"liquidity"`
+	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName overrides the table name used by PaperFill to `paper_fills`
+func (PaperFill) TableName() string {
+	return "paper_fills"
+}
+
+// BeforeCreate ensures UUID is generated if not present
+func (f *PaperFill) BeforeCreate(tx *gorm.DB) (err error) {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return
+}
+
+// PaperPosition is a virtual outcome token holding
+type PaperPosition struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID       uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_paper_positions_user_token" json:"user_id"`
+	MarketID     string    `gorm:"column:market_id;size:66;not null" json:"market_id"`
+	TokenID      string    `gorm:"column:token_id;size:255;not null;uniqueIndex:idx_paper_positions_user_token" json:"token_id"`
+	Outcome      string    `gorm:"column:outcome;size:64" json:"outcome"`
+	Size         float64   `gorm:"column:size;type:decimal;not null;default:0" json:"size"`
+	ReservedSize float64   `gorm:"column:reserved_size;type:decimal;not null;default:0" json:"reserved_size"`
+	AvgPrice     float64   `gorm:"column:avg_price;type:decimal;not null;default:0" json:"avg_price"`
+	RealizedPnL  float64   `gorm:"column:realized_pnl;type:decimal;not null;default:0" json:"realized_pnl"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName overrides the table name used by PaperPosition to `paper_positions`
+func (PaperPosition) TableName() string {
+	return "paper_positions"
+}
+
+// BeforeCreate ensures UUID is generated if not present
+func (p *PaperPosition) BeforeCreate(tx *gorm.DB) (err error) {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return
+}
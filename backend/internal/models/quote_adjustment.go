@@ -0,0 +1,46 @@
+/**
+ * @description
+ * Quote adjustment model.
+ * Maps to the 'quote_adjustments' table: one row per applied market-maker ladder diff.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - github.com/google/uuid
+ */
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// QuoteAdjustment records a computed ladder and the order changes needed to reach it
+type QuoteAdjustment struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	MarketID  string    `gorm:"column:market_id;not null" json:"market_id"`
+	TokenID   string    `gorm:"column:token_id;not null" json:"token_id"`
+	Params    string    `gorm:"column:params;type:jsonb;not null" json:"params"`
+	Midpoint  float64   `gorm:"column:midpoint;type:decimal" json:"midpoint"`
+	Inventory float64   `gorm:"column:inventory;type:decimal" json:"inventory"`
+	Skew      float64   `gorm:"column:skew;type:decimal" json:"skew"`
+	Desired   string    `gorm:"column:desired;type:jsonb" json:"desired"`
+	Cancels   string    `gorm:"column:cancels;type:jsonb" json:"cancels"`
+	Places    string    `gorm:"column:places;type:jsonb" json:"places"`
+	Kept      int       `gorm:"column:kept" json:"kept"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (QuoteAdjustment) TableName() string {
+	return "quote_adjustments"
+}
+
+func (q *QuoteAdjustment) BeforeCreate(tx *gorm.DB) (err error) {
+	if q.ID == uuid.Nil {
+		q.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,71 @@
+/**
+ * @description
+ * Relayer transaction model.
+ * Maps to the 'relayer_transactions' table: gasless transactions relayed for a user and their confirmation state.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - github.com/google/uuid
+ */
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RelayerTransactionType is what a relayed transaction does for the user
+type RelayerTransactionType string
+
+const (
+	RelayerTransactionDeploy   RelayerTransactionType = "DEPLOY"
+	RelayerTransactionApprove  RelayerTransactionType = "APPROVE"
+	RelayerTransactionSplit    RelayerTransactionType = "SPLIT"
+	RelayerTransactionMerge    RelayerTransactionType = "MERGE"
+	RelayerTransactionRedeem   RelayerTransactionType = "REDEEM"
+	RelayerTransactionWithdraw RelayerTransactionType = "WITHDRAW"
+)
+
+// RelayerTransactionStatus summarises the relayer state
+type RelayerTransactionStatus string
+
+const (
+	RelayerTransactionPending   RelayerTransactionStatus = "PENDING"
+	RelayerTransactionConfirmed RelayerTransactionStatus = "CONFIRMED"
+	RelayerTransactionFailed    RelayerTransactionStatus = "FAILED"
+)
+
+// RelayerTransaction is a transaction submitted through the Polymarket relayer
+type RelayerTransaction struct {
+	ID              uuid.UUID                `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID          uuid.UUID                `gorm:"type:uuid;not null;index" json:"user_id"`
+	Type            RelayerTransactionType   `gorm:"column:type;size:16;not null" json:"type"`
+	RelayerType     string                   `gorm:"column:relayer_type;size:16;not null" json:"relayer_type"`
+	PayloadHash     string                   `gorm:"column:payload_hash;not null" json:"payload_hash"`
+	RelayerTxID     *string                  `gorm:"column:relayer_tx_id" json:"relayer_tx_id"`
+	TransactionHash string                   `gorm:"column:transaction_hash" json:"transaction_hash"`
+	FromAddress     string                   `gorm:"column:from_address;not null" json:"from_address"`
+	ProxyAddress    string                   `gorm:"column:proxy_address" json:"proxy_address"`
+	Status          RelayerTransactionStatus `gorm:"column:status;size:16;not null;default:'PENDING'" json:"status"`
+	State           string                   `gorm:"column:state" json:"state"`
+	Error           string                   `gorm:"column:error" json:"error,omitempty"`
+	PollAttempts    int                      `gorm:"column:poll_attempts;not null;default:0" json:"-"`
+	LastPolledAt    *time.Time               `gorm:"column:last_polled_at" json:"last_polled_at"`
+	ConfirmedAt     *time.Time               `gorm:"column:confirmed_at" json:"confirmed_at"`
+	CreatedAt       time.Time                `json:"created_at"`
+	UpdatedAt       time.Time                `json:"updated_at"`
+}
+
+func (RelayerTransaction) TableName() string {
+	return "relayer_transactions"
+}
+
+func (r *RelayerTransaction) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return
+}
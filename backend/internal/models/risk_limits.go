@@ -0,0 +1,56 @@
+/**
+ * @description
+ * Risk limit model.
+ * Maps to the 'risk_limits' table holding per-user pre-trade limits.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - github.com/google/uuid
+ *
+ * @notes
+ * - A nil limit means "no limit". Amounts are in USDC.
+ * - Locked limits are managed by an admin (users.role) and cannot be edited by the user. managed_by records
+ *   the admin who last set them.
+ */
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RiskLimits are the pre-trade limits applied to a user's orders
+type RiskLimits struct {
+	ID                uuid.UUID   `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID            uuid.UUID   `gorm:"type:uuid;not null;uniqueIndex" json:"user_id"`
+	MaxOrderNotional  *float64    `gorm:"column:max_order_notional;type:decimal" json:"max_order_notional"`
+	MaxMarketExposure *float64    `gorm:"column:max_market_exposure;type:decimal" json:"max_market_exposure"`
+	MaxEventExposure  *float64    `gorm:"column:max_event_exposure;type:decimal" json:"max_event_exposure"`
+	DailyLossLimit    *float64    `gorm:"column:daily_loss_limit;type:decimal" json:"daily_loss_limit"`
+	BlockedCategories StringArray `gorm:"column:blocked_categories;type:text[]" json:"blocked_categories"`
+	Locked            bool        `gorm:"column:locked;not null;default:false" json:"locked"`
+	LockedReason      string      `gorm:"column:locked_reason" json:"locked_reason,omitempty"`
+	ManagedBy         *uuid.UUID  `gorm:"column:managed_by;type:uuid" json:"managed_by,omitempty"`
+	CreatedAt         time.Time   `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time   `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (RiskLimits) TableName() string {
+	return "risk_limits"
+}
+
+func (r *RiskLimits) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return
+}
+
+// HasLimits reports whether any limit is configured.
+func (r *RiskLimits) HasLimits() bool {
+	return r.MaxOrderNotional != nil || r.MaxMarketExposure != nil || r.MaxEventExposure != nil ||
+		r.DailyLossLimit != nil || len(r.BlockedCategories) > 0
+}
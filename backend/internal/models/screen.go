@@ -0,0 +1,85 @@
+/**
+ * @description
+ * Saved screener model.
+ * Maps to the 'saved_screens' table.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - github.com/google/uuid
+ */
+
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SavedScreen stores a user's screener expression and alert state
+type SavedScreen struct {
+	ID             uuid.UUID   `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID         uuid.UUID   `gorm:"type:uuid;not null;index" json:"user_id"`
+	Name           string      `gorm:"size:128;not null" json:"name"`
+	Expression     string      `gorm:"not null" json:"expression"`
+	Sort           string      `gorm:"size:32" json:"sort"`
+	AlertsEnabled  bool        `gorm:"column:alerts_enabled;default:false" json:"alerts_enabled"`
+	SeenMarkets    SeenMarkets `gorm:"column:seen_markets;type:jsonb" json:"-"`
+	LastMatchCount int         `gorm:"column:last_match_count" json:"last_match_count"`
+	LastCheckedAt  *time.Time  `gorm:"column:last_checked_at" json:"last_checked_at"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (SavedScreen) TableName() string {
+	return "saved_screens"
+}
+
+func (s *SavedScreen) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return
+}
+
+// SeenMarkets maps the condition IDs a screen has matched to when they last matched.
+// Stored as a JSONB object.
+type SeenMarkets map[string]time.Time
+
+// Scan implements the sql.Scanner interface
+func (m *SeenMarkets) Scan(src interface{}) error {
+	var raw []byte
+	switch v := src.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return errors.New("type assertion failed for SeenMarkets")
+	}
+	seen := SeenMarkets{}
+	if err := json.Unmarshal(raw, &seen); err != nil {
+		return err
+	}
+	*m = seen
+	return nil
+}
+
+// Value implements the driver.Valuer interface
+func (m SeenMarkets) Value() (driver.Value, error) {
+	if len(m) == 0 {
+		return "{}", nil
+	}
+	raw, err := json.Marshal(map[string]time.Time(m))
+	if err != nil {
+		return nil, err
+	}
+	return string(raw), nil
+}
@@ -68,9 +68,18 @@ func (b *MarketBookmark) BeforeCreate(tx *gorm.DB) (err error) {
 type NotificationType string
 
 const (
-	NotificationTypeTradeAlert NotificationType = "TRADE_ALERT"
-	NotificationTypeFollowed   NotificationType = "FOLLOWED"
-	NotificationTypeSystem     NotificationType = "SYSTEM"
+	NotificationTypeTradeAlert       NotificationType = "TRADE_ALERT"
+	NotificationTypeFollowed         NotificationType = "FOLLOWED"
+	NotificationTypeSystem           NotificationType = "SYSTEM"
+	NotificationTypeMarketChange     NotificationType = "MARKET_CHANGE"
+	NotificationTypeScreenAlert      NotificationType = "SCREEN_ALERT"
+	NotificationTypeConditionalOrder NotificationType = "CONDITIONAL_ORDER"
+	NotificationTypeOrderGroup       NotificationType = "ORDER_GROUP"
+	NotificationTypeExecutionAlgo    NotificationType = "EXECUTION_ALGO"
+	NotificationTypeRiskBreach       NotificationType = "RISK_BREACH"
+	NotificationTypeRedeemable       NotificationType = "REDEEMABLE"
+	NotificationTypeRewardsDrift     NotificationType = "REWARDS_DRIFT"
+	NotificationTypeDeposit          NotificationType = "DEPOSIT"
 )
 
 // Notification stores user notifications for trade alerts
@@ -24,6 +24,14 @@ const (
 	WalletTypeSafe  WalletType = "SAFE"
 )
 
+// UserRole grants access to admin operations
+type UserRole string
+
+const (
+	UserRoleUser  UserRole = "USER"
+	UserRoleAdmin UserRole = "ADMIN" // may set and lock any user's risk limits
+)
+
 // User represents a registered user in the system
 type User struct {
 	ID           uuid.UUID   `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
@@ -32,6 +40,7 @@ type User struct {
 	EOAAddress   string       `gorm:"column:eoa_address" json:"eoa_address"` // Optional - can be set when wallet is connected
 	VaultAddress string       `gorm:"column:vault_address" json:"vault_address"` // Proxy or Gnosis Safe
 	WalletType   *WalletType  `gorm:"column:wallet_type" json:"wallet_type"` // Pointer to allow NULL
+	Role         UserRole     `gorm:"column:role;size:16;not null;default:'USER'" json:"role"` // Granted in the database, never by the API
 
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
@@ -42,6 +51,12 @@ func (User) TableName() string {
 	return "users"
 }
 
+// CanManageRiskLimits reports whether the user may set managed limits for other accounts. There is no desk
+// membership to scope a lead to their own accounts, so only admins may.
+func (u *User) CanManageRiskLimits() bool {
+	return u.Role == UserRoleAdmin
+}
+
 // BeforeCreate ensures UUID is generated if not present (though DB usually handles this)
 func (u *User) BeforeCreate(tx *gorm.DB) (err error) {
 	if u.ID == uuid.Nil {
@@ -0,0 +1,54 @@
+/**
+ * @description
+ * User wallet model.
+ * Maps to the 'user_wallets' table: every signer wallet linked to a user account.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - github.com/google/uuid
+ *
+ * @notes
+ * - The primary wallet is mirrored onto the users row, which existing single-wallet code keeps reading.
+ */
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UserWallet is a signer wallet (EOA) linked to a user, with its Polymarket vault
+type UserWallet struct {
+	ID           uuid.UUID   `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID       uuid.UUID   `gorm:"type:uuid;not null;index" json:"user_id"`
+	EOAAddress   string      `gorm:"column:eoa_address;not null" json:"eoa_address"`
+	VaultAddress string      `gorm:"column:vault_address" json:"vault_address"`
+	WalletType   *WalletType `gorm:"column:wallet_type" json:"wallet_type"`
+	Label        string      `gorm:"column:label;size:64" json:"label"`
+	IsPrimary    bool        `gorm:"column:is_primary;not null;default:false" json:"is_primary"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (UserWallet) TableName() string {
+	return "user_wallets"
+}
+
+func (w *UserWallet) BeforeCreate(tx *gorm.DB) (err error) {
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	return
+}
+
+// ApplyTo returns a copy of user acting through this wallet, for services that read the user's addresses.
+func (w *UserWallet) ApplyTo(user *User) *User {
+	view := *user
+	view.EOAAddress = w.EOAAddress
+	view.VaultAddress = w.VaultAddress
+	view.WalletType = w.WalletType
+	return &view
+}
@@ -0,0 +1,73 @@
+/**
+ * @description
+ * Wallet transfer and chain cursor models.
+ * Maps to the 'wallet_transfers' table (detected vault inflows) and 'chain_cursors' (scanner progress).
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - github.com/google/uuid
+ */
+
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TransferDirection is the direction of a vault transfer
+type TransferDirection string
+
+const (
+	TransferDirectionIn TransferDirection = "IN"
+)
+
+// TransferKind separates user deposits from collateral paid out by Polymarket's own contracts
+type TransferKind string
+
+const (
+	TransferKindDeposit    TransferKind = "DEPOSIT"
+	TransferKindSettlement TransferKind = "SETTLEMENT" // sell fills, merges and redemptions
+)
+
+// WalletTransfer is an ERC20 transfer into a user's vault
+type WalletTransfer struct {
+	ID           uuid.UUID         `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UserID       uuid.UUID         `gorm:"type:uuid;not null;index" json:"user_id"`
+	VaultAddress string            `gorm:"column:vault_address;not null" json:"vault_address"`
+	Direction    TransferDirection `gorm:"column:direction;size:8;not null" json:"direction"`
+	Kind         TransferKind      `gorm:"column:kind;size:16;not null;default:'DEPOSIT'" json:"kind"`
+	Token        string            `gorm:"column:token;size:16;not null" json:"token"`
+	TokenAddress string            `gorm:"column:token_address;not null" json:"token_address"`
+	FromAddress  string            `gorm:"column:from_address;not null" json:"from_address"`
+	Amount       string            `gorm:"column:amount;type:numeric(78,0);not null" json:"amount"` // Base units
+	AmountUSDC   float64           `gorm:"column:amount_usdc;type:decimal;not null" json:"amount_usdc"`
+	TxHash       string            `gorm:"column:tx_hash;not null" json:"tx_hash"`
+	LogIndex     uint              `gorm:"column:log_index;not null" json:"log_index"`
+	BlockNumber  uint64            `gorm:"column:block_number;not null" json:"block_number"`
+	CreatedAt    time.Time         `json:"created_at"`
+}
+
+func (WalletTransfer) TableName() string {
+	return "wallet_transfers"
+}
+
+func (w *WalletTransfer) BeforeCreate(tx *gorm.DB) (err error) {
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	return
+}
+
+// ChainCursor is the last block an on-chain scanner fully processed
+type ChainCursor struct {
+	Name        string    `gorm:"column:name;primaryKey" json:"name"`
+	BlockNumber uint64    `gorm:"column:block_number;not null" json:"block_number"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (ChainCursor) TableName() string {
+	return "chain_cursors"
+}
@@ -36,6 +36,7 @@ const (
 	lastTradeEndpoint     = "/last-trade-price"
 	midpointEndpoint      = "/midpoint"
 	spreadsEndpoint       = "/spreads"
+	feeRateEndpoint       = "/fee-rate"
 )
 
 type Client struct {
@@ -88,6 +89,24 @@ func (c *Client) CancelOrders(ctx context.Context, req *CancelOrdersRequest, use
 	return &resp, nil
 }
 
+// CancelAll cancels every open order owned by the credentials' API key
+func (c *Client) CancelAll(ctx context.Context, userCreds *APIKeyCredentials) (*CancelResponse, error) {
+	var resp CancelResponse
+	if err := c.sendRequestDecode(ctx, http.MethodDelete, "/cancel-all", nil, &resp, userCreds); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// CancelMarketOrders cancels the open orders in a market, optionally narrowed to one outcome token
+func (c *Client) CancelMarketOrders(ctx context.Context, req *CancelMarketOrdersRequest, userCreds *APIKeyCredentials) (*CancelResponse, error) {
+	var resp CancelResponse
+	if err := c.sendRequestDecode(ctx, http.MethodDelete, "/cancel-market-orders", req, &resp, userCreds); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // GetBook fetches the current order book for a token (asset) from the CLOB API.
 func (c *Client) GetBook(ctx context.Context, tokenID string) (*BookResponse, error) {
 	if strings.TrimSpace(tokenID) == "" {
@@ -236,6 +255,35 @@ func (c *Client) GetSpread(ctx context.Context, tokenID string) (float64, error)
 	return parseSpreadResponse(raw, tokenID)
 }
 
+// GetFeeRateBps fetches the base taker fee rate for a token from the CLOB API.
+// GET /fee-rate?token_id={tokenId}
+func (c *Client) GetFeeRateBps(ctx context.Context, tokenID string) (int, error) {
+	tokenID = strings.TrimSpace(tokenID)
+	if tokenID == "" {
+		return 0, fmt.Errorf("tokenID is required")
+	}
+
+	values := url.Values{}
+	values.Set("token_id", tokenID)
+	path := fmt.Sprintf("%s?%s", feeRateEndpoint, values.Encode())
+
+	var resp struct {
+		BaseFee json.Number `json:"base_fee"`
+	}
+	if err := c.sendRequestDecode(ctx, http.MethodGet, path, nil, &resp, nil); err != nil {
+		return 0, err
+	}
+	if resp.BaseFee == "" {
+		return 0, nil
+	}
+
+	rate, err := resp.BaseFee.Int64()
+	if err != nil {
+		return 0, fmt.Errorf("unexpected fee-rate response: %w", err)
+	}
+	return int(rate), nil
+}
+
 func parseLastTradePriceResponse(raw json.RawMessage) (float64, string, error) {
 	var num float64
 	if err := json.Unmarshal(raw, &num); err == nil && num > 0 {
@@ -0,0 +1,94 @@
+package clob
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bankai-project/backend/internal/polymarket/relayer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+const (
+	orderDomainName    = "Polymarket CTF Exchange"
+	orderDomainVersion = "1"
+	orderPrimaryType   = "Order"
+)
+
+var orderTypes = apitypes.Types{
+	"EIP712Domain": {
+		{Name: "name", Type: "string"},
+		{Name: "version", Type: "string"},
+		{Name: "chainId", Type: "uint256"},
+		{Name: "verifyingContract", Type: "address"},
+	},
+	orderPrimaryType: {
+		{Name: "salt", Type: "uint256"},
+		{Name: "maker", Type: "address"},
+		{Name: "signer", Type: "address"},
+		{Name: "taker", Type: "address"},
+		{Name: "tokenId", Type: "uint256"},
+		{Name: "makerAmount", Type: "uint256"},
+		{Name: "takerAmount", Type: "uint256"},
+		{Name: "expiration", Type: "uint256"},
+		{Name: "nonce", Type: "uint256"},
+		{Name: "feeRateBps", Type: "uint256"},
+		{Name: "side", Type: "uint8"},
+		{Name: "signatureType", Type: "uint8"},
+	},
+}
+
+// Hash returns the EIP-712 hash of the signed order, which the CLOB uses as the order ID.
+// negRisk selects the Neg Risk CTF Exchange as the verifying contract.
+func (o *Order) Hash(negRisk bool) (string, error) {
+	var side string
+	switch OrderSide(strings.ToUpper(strings.TrimSpace(string(o.Side)))) {
+	case BUY, "0":
+		side = "0"
+	case SELL, "1":
+		side = "1"
+	default:
+		return "", fmt.Errorf("order.side %q is invalid", o.Side)
+	}
+	for field, addr := range map[string]string{"maker": o.Maker, "signer": o.Signer, "taker": o.Taker} {
+		if !common.IsHexAddress(addr) {
+			return "", fmt.Errorf("order.%s is not a valid address", field)
+		}
+	}
+
+	exchange := relayer.CTFExchangeAddress
+	if negRisk {
+		exchange = relayer.NegRiskCTFExchangeAddress
+	}
+	data := apitypes.TypedData{
+		Types:       orderTypes,
+		PrimaryType: orderPrimaryType,
+		Domain: apitypes.TypedDataDomain{
+			Name:              orderDomainName,
+			Version:           orderDomainVersion,
+			ChainId:           math.NewHexOrDecimal256(relayer.PolygonChainID),
+			VerifyingContract: exchange,
+		},
+		Message: apitypes.TypedDataMessage{
+			"salt":          o.Salt.String(),
+			"maker":         o.Maker,
+			"signer":        o.Signer,
+			"taker":         o.Taker,
+			"tokenId":       o.TokenID,
+			"makerAmount":   o.MakerAmount,
+			"takerAmount":   o.TakerAmount,
+			"expiration":    o.Expiration,
+			"nonce":         o.Nonce,
+			"feeRateBps":    o.FeeRateBps,
+			"side":          side,
+			"signatureType": fmt.Sprintf("%d", o.SignatureType),
+		},
+	}
+	hash, _, err := apitypes.TypedDataAndHash(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash order: %w", err)
+	}
+	return hexutil.Encode(hash), nil
+}
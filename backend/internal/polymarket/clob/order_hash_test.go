@@ -0,0 +1,90 @@
+package clob
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/bankai-project/backend/internal/polymarket/relayer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestOrderHash(t *testing.T) {
+	order := Order{
+		Salt:          SaltNumber{value: "479249096354"},
+		Maker:         "0x000000000000000000000000000000000000dEaD",
+		Signer:        "0x000000000000000000000000000000000000bEEF",
+		Taker:         "0x0000000000000000000000000000000000000000",
+		TokenID:       "71321045679252212594626385532706912750332728571942532289631379312455583992563",
+		MakerAmount:   "50000000",
+		TakerAmount:   "21000000",
+		Expiration:    "0",
+		Nonce:         "0",
+		FeeRateBps:    "0",
+		Side:          SELL,
+		SignatureType: SignatureTypePolyGnosisSafe,
+	}
+
+	// Recompute the EIP-712 digest by hand from the CTF Exchange type strings.
+	word := func(s string) []byte {
+		n, _ := new(big.Int).SetString(s, 10)
+		return common.LeftPadBytes(n.Bytes(), 32)
+	}
+	address := func(a string) []byte { return common.LeftPadBytes(common.HexToAddress(a).Bytes(), 32) }
+	expected := func(exchange string) string {
+		domainType := crypto.Keccak256([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+		domain := crypto.Keccak256(domainType,
+			crypto.Keccak256([]byte("Polymarket CTF Exchange")), crypto.Keccak256([]byte("1")),
+			word("137"), address(exchange))
+		orderType := crypto.Keccak256([]byte("Order(uint256 salt,address maker,address signer,address taker,uint256 tokenId,uint256 makerAmount,uint256 takerAmount,uint256 expiration,uint256 nonce,uint256 feeRateBps,uint8 side,uint8 signatureType)"))
+		structHash := crypto.Keccak256(orderType,
+			word(order.Salt.String()), address(order.Maker), address(order.Signer), address(order.Taker),
+			word(order.TokenID), word(order.MakerAmount), word(order.TakerAmount),
+			word(order.Expiration), word(order.Nonce), word(order.FeeRateBps),
+			word("1"), word("2"))
+		return hexutil.Encode(crypto.Keccak256([]byte{0x19, 0x01}, domain, structHash))
+	}
+
+	for _, tc := range []struct {
+		negRisk  bool
+		exchange string
+	}{
+		{false, relayer.CTFExchangeAddress},
+		{true, relayer.NegRiskCTFExchangeAddress},
+	} {
+		hash, err := order.Hash(tc.negRisk)
+		if err != nil {
+			t.Fatalf("negRisk=%v: Hash: %v", tc.negRisk, err)
+		}
+		if want := expected(tc.exchange); hash != want {
+			t.Errorf("negRisk=%v: hash = %s, want %s", tc.negRisk, hash, want)
+		}
+	}
+
+	numeric := order
+	numeric.Side = "1"
+	if a, _ := order.Hash(false); a != mustHash(t, &numeric) {
+		t.Error("numeric side should hash like SELL")
+	}
+
+	bad := order
+	bad.Maker = "0x1234"
+	if _, err := bad.Hash(false); err == nil {
+		t.Error("expected error for invalid maker")
+	}
+	bad = order
+	bad.Side = "HOLD"
+	if _, err := bad.Hash(false); err == nil {
+		t.Error("expected error for invalid side")
+	}
+}
+
+func mustHash(t *testing.T, o *Order) string {
+	t.Helper()
+	hash, err := o.Hash(false)
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	return hash
+}
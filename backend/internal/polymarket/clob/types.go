@@ -36,6 +36,13 @@ const (
 	OrderTypeFAK OrderType = "FAK" // Fill-And-Kill
 )
 
+// Signature types (per Polymarket clob-client)
+const (
+	SignatureTypeEOA            = 0 // Raw EOA signature, maker is the signer (also accepted for vault makers)
+	SignatureTypePolyProxy      = 1 // Maker is the signer's Polymarket proxy wallet (Magic / email)
+	SignatureTypePolyGnosisSafe = 2 // Maker is the signer's Gnosis Safe (browser wallets)
+)
+
 var (
 	validOrderTypes = map[OrderType]struct{}{
 		OrderTypeGTC: {},
@@ -47,14 +54,10 @@ var (
 		BUY:  {},
 		SELL: {},
 	}
-	// Signature types (per Polymarket clob-client):
-	// 0 = raw EOA signature (works for most wallets, including Safe owners),
-	// 1 = Magic/Proxy,
-	// 2 = Browser wallets (Metamask/Coinbase) / Safe.
 	validSignatureTypes = map[int]struct{}{
-		0: {},
-		1: {},
-		2: {},
+		SignatureTypeEOA:            {},
+		SignatureTypePolyProxy:      {},
+		SignatureTypePolyGnosisSafe: {},
 	}
 )
 
@@ -204,6 +207,9 @@ type PostOrderResponse struct {
 	OrderIDs    []string `json:"orderIds,omitempty"`
 	OrderHashes []string `json:"orderHashes"` // Hashes of settlement transactions if matched
 	Status      string   `json:"status,omitempty"`
+	// Amounts matched immediately, in whole units: for BUY making=USDC and taking=shares, reversed for SELL.
+	MakingAmount string `json:"makingAmount,omitempty"`
+	TakingAmount string `json:"takingAmount,omitempty"`
 }
 
 // BookResponse represents the simplified order book snapshot returned by the CLOB API.
@@ -249,6 +255,12 @@ type CancelOrdersRequest struct {
 	OrderIDs []string `json:"orderIds"`
 }
 
+// CancelMarketOrdersRequest represents the payload for DELETE /cancel-market-orders
+type CancelMarketOrdersRequest struct {
+	Market  string `json:"market,omitempty"`   // Condition ID
+	AssetID string `json:"asset_id,omitempty"` // Outcome token ID
+}
+
 // CancelResponse represents the response for cancellation endpoints
 type CancelResponse struct {
 	Canceled    []string          `json:"canceled"`
@@ -83,6 +83,9 @@ func (c *Client) GetPositions(ctx context.Context, address string, params *Posit
 		if params.Market != "" {
 			q.Set("market", params.Market)
 		}
+		if params.Redeemable {
+			q.Set("redeemable", "true")
+		}
 	}
 	u.RawQuery = q.Encode()
 
@@ -290,6 +293,9 @@ func (c *Client) GetTrades(ctx context.Context, address string, params *TradesPa
 		if params.After != "" {
 			q.Set("after", params.After)
 		}
+		if params.TakerOnly != nil {
+			q.Set("takerOnly", strconv.FormatBool(*params.TakerOnly))
+		}
 	}
 	u.RawQuery = q.Encode()
 
@@ -37,6 +37,10 @@ type Position struct {
 	Title            string  `json:"title"`
 	ProxyWallet      string  `json:"proxyWallet"`
 	Owner            string  `json:"owner"`
+	OutcomeIndex     int     `json:"outcomeIndex"`
+	Redeemable       bool    `json:"redeemable"`
+	Mergeable        bool    `json:"mergeable"`
+	NegativeRisk     bool    `json:"negativeRisk"`
 }
 
 // ClosedPosition represents a user's closed/resolved position
@@ -115,6 +119,8 @@ type Trade struct {
 	DisplayUsernamePublic bool   `json:"displayUsernamePublic,omitempty"`
 	Timestamp     int64     `json:"timestamp"`
 	TxHash        string    `json:"transactionHash"`
+	Asset         string    `json:"asset,omitempty"`       // Token ID as returned by /trades
+	ProxyWallet   string    `json:"proxyWallet,omitempty"` // Wallet the trade was queried for
 }
 
 // TradedCount represents the total markets traded response from /traded
@@ -178,6 +184,7 @@ type PositionsParams struct {
 	SortBy        string `json:"sortBy,omitempty"`        // SIZE, CASHPNL, PERCENTPNL
 	SortDirection string `json:"sortDirection,omitempty"` // ASC, DESC
 	Market        string `json:"market,omitempty"`        // Filter by conditionId
+	Redeemable    bool   `json:"redeemable,omitempty"`    // Only positions in resolved markets that can be redeemed
 }
 
 // TradesParams query parameters for /trades endpoint
@@ -186,6 +193,7 @@ type TradesParams struct {
 	Offset int    `json:"offset,omitempty"`
 	Before string `json:"before,omitempty"` // ISO timestamp
 	After  string `json:"after,omitempty"`  // ISO timestamp
+	TakerOnly *bool `json:"takerOnly,omitempty"` // The API defaults to taker fills only
 }
 
 // parseFloatSafe converts interface to float64 safely
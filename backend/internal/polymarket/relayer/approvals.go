@@ -0,0 +1,87 @@
+/**
+ * @description
+ * Token approvals a vault needs before it can trade on Polymarket.
+ * The exchanges pull USDC.e collateral via ERC20 allowances and move outcome shares via the CTF's ERC1155
+ * setApprovalForAll; the neg risk adapter and the CTF itself need the same for split / merge / convert.
+ *
+ * @dependencies
+ * - github.com/ethereum/go-ethereum/common
+ *
+ * @notes
+ * - Collateral approvals are granted for the max uint256 amount, matching Polymarket's own onboarding.
+ */
+
+package relayer
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+const (
+	CTFExchangeAddress        = "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E"
+	NegRiskCTFExchangeAddress = "0xC5d563A36AE78145C45a50134d48A1215220f80a"
+)
+
+// ApprovalKind distinguishes ERC20 allowances from ERC1155 operator approvals
+type ApprovalKind string
+
+const (
+	ApprovalKindERC20   ApprovalKind = "ERC20"
+	ApprovalKindERC1155 ApprovalKind = "ERC1155"
+)
+
+// TokenApproval is one approval a vault must grant to trade
+type TokenApproval struct {
+	Kind    ApprovalKind `json:"kind"`
+	Token   string       `json:"token"`   // USDC.e for ERC20, the CTF for ERC1155
+	Spender string       `json:"spender"` // Spender / operator
+	Label   string       `json:"label"`
+}
+
+// RequiredTradingApprovals lists every approval needed to trade standard and neg risk markets.
+func RequiredTradingApprovals() []TokenApproval {
+	spenders := []struct {
+		address string
+		label   string
+	}{
+		{CTFExchangeAddress, "CTF Exchange"},
+		{NegRiskCTFExchangeAddress, "Neg Risk CTF Exchange"},
+		{NegRiskAdapterAddress, "Neg Risk Adapter"},
+	}
+
+	approvals := []TokenApproval{
+		{Kind: ApprovalKindERC20, Token: CollateralTokenAddress, Spender: ConditionalTokensAddress, Label: "USDC.e -> Conditional Tokens"},
+	}
+	for _, s := range spenders {
+		approvals = append(approvals, TokenApproval{
+			Kind:    ApprovalKindERC20,
+			Token:   CollateralTokenAddress,
+			Spender: s.address,
+			Label:   "USDC.e -> " + s.label,
+		})
+	}
+	for _, s := range spenders {
+		approvals = append(approvals, TokenApproval{
+			Kind:    ApprovalKindERC1155,
+			Token:   ConditionalTokensAddress,
+			Spender: s.address,
+			Label:   "Conditional Tokens -> " + s.label,
+		})
+	}
+	return approvals
+}
+
+// BuildApprovalTransaction encodes the Safe call that grants approval.
+func BuildApprovalTransaction(approval TokenApproval) (SafeTransaction, error) {
+	switch approval.Kind {
+	case ApprovalKindERC20:
+		return BuildERC20ApproveTransaction(approval.Token, approval.Spender, new(big.Int).Set(math.MaxBig256))
+	case ApprovalKindERC1155:
+		return BuildSetApprovalForAllTransaction(approval.Token, approval.Spender, true)
+	default:
+		return SafeTransaction{}, fmt.Errorf("unknown approval kind: %s", approval.Kind)
+	}
+}
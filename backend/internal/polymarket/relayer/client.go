@@ -58,12 +58,46 @@ func NewClient(cfg *config.Config) *Client {
 
 // RelayerResponse is the response from /submit
 type RelayerResponse struct {
+	TransactionID   string `json:"transactionID"`
 	TransactionHash string `json:"transactionHash"`
 	TaskID          string `json:"taskId"`
 	State           string `json:"state"`                  // PENDING, MINED, etc.
 	ProxyAddress    string `json:"proxyAddress,omitempty"` // Safe address after deployment (may not be in initial response)
 }
 
+// ID returns the relayer's identifier for the submitted transaction.
+func (r *RelayerResponse) ID() string {
+	if r.TransactionID != "" {
+		return r.TransactionID
+	}
+	return r.TaskID
+}
+
+// Relayer transaction states reported by GET /transaction
+const (
+	TransactionStateNew       = "STATE_NEW"
+	TransactionStateExecuted  = "STATE_EXECUTED"
+	TransactionStateMined     = "STATE_MINED"
+	TransactionStateConfirmed = "STATE_CONFIRMED"
+	TransactionStateFailed    = "STATE_FAILED"
+	TransactionStateInvalid   = "STATE_INVALID"
+)
+
+// RelayerTransaction is a relayed transaction as reported by GET /transaction
+type RelayerTransaction struct {
+	TransactionID   string `json:"transactionID"`
+	TransactionHash string `json:"transactionHash"`
+	From            string `json:"from"`
+	To              string `json:"to"`
+	ProxyAddress    string `json:"proxyAddress"`
+	Nonce           string `json:"nonce"`
+	State           string `json:"state"`
+	Type            string `json:"type"`
+	Metadata        string `json:"metadata"`
+	CreatedAt       string `json:"createdAt"`
+	UpdatedAt       string `json:"updatedAt"`
+}
+
 // RelayerError represents an error response from the relayer
 type RelayerError struct {
 	Message string `json:"message"`
@@ -116,6 +150,152 @@ func (c *Client) GetDeployed(ctx context.Context, safeAddress string) (bool, err
 	return result.Deployed, nil
 }
 
+// SubmitSafeTransaction submits a signed SAFE TransactionRequest to the relayer.
+func (c *Client) SubmitSafeTransaction(ctx context.Context, request *TransactionRequest) (*RelayerResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("transaction request cannot be nil")
+	}
+	if request.Type != TransactionTypeSafe {
+		return nil, fmt.Errorf("expected a %s transaction, got %s", TransactionTypeSafe, request.Type)
+	}
+	return c.submitTransaction(ctx, request)
+}
+
+type nonceResponse struct {
+	Nonce json.Number `json:"nonce"`
+}
+
+// GetNonce returns the relayer's next nonce for signer's wallet of the given type.
+func (c *Client) GetNonce(ctx context.Context, signer string, txType TransactionType) (string, error) {
+	if signer == "" {
+		return "", fmt.Errorf("signer address cannot be empty")
+	}
+
+	u := fmt.Sprintf("%s/nonce?address=%s&type=%s", c.BaseURL, signer, txType)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if err := c.setHeaders(req, nil); err != nil {
+		return "", fmt.Errorf("failed to sign relayer request: %w", err)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("relayer request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("relayer returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result nonceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	if result.Nonce == "" {
+		return "", fmt.Errorf("relayer returned an empty nonce")
+	}
+
+	return result.Nonce.String(), nil
+}
+
+// SubmitProxyTransaction submits a signed PROXY TransactionRequest to the relayer.
+func (c *Client) SubmitProxyTransaction(ctx context.Context, request *TransactionRequest) (*RelayerResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("transaction request cannot be nil")
+	}
+	if request.Type != TransactionTypeProxy {
+		return nil, fmt.Errorf("expected a %s transaction, got %s", TransactionTypeProxy, request.Type)
+	}
+	return c.submitTransaction(ctx, request)
+}
+
+// RelayPayload is the relay address and nonce a PROXY transaction must be signed against
+type RelayPayload struct {
+	Address string      `json:"address"`
+	Nonce   json.Number `json:"nonce"`
+}
+
+// GetRelayPayload returns the relay node and nonce for signer's wallet of the given type.
+func (c *Client) GetRelayPayload(ctx context.Context, signer string, txType TransactionType) (*RelayPayload, error) {
+	if signer == "" {
+		return nil, fmt.Errorf("signer address cannot be empty")
+	}
+
+	u := fmt.Sprintf("%s/relay-payload?address=%s&type=%s", c.BaseURL, signer, txType)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if err := c.setHeaders(req, nil); err != nil {
+		return nil, fmt.Errorf("failed to sign relayer request: %w", err)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("relayer request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("relayer returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result RelayPayload
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if result.Address == "" || result.Nonce == "" {
+		return nil, fmt.Errorf("relayer returned an incomplete relay payload")
+	}
+
+	return &result, nil
+}
+
+// GetTransaction fetches the current state of a relayed transaction by its relayer ID.
+func (c *Client) GetTransaction(ctx context.Context, transactionID string) (*RelayerTransaction, error) {
+	if transactionID == "" {
+		return nil, fmt.Errorf("transaction id cannot be empty")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/transaction?id=%s", c.BaseURL, transactionID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if err := c.setHeaders(req, nil); err != nil {
+		return nil, fmt.Errorf("failed to sign relayer request: %w", err)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("relayer request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("relayer returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	// The relayer answers with a list, even for a single id.
+	var result []RelayerTransaction
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("relayer transaction %s not found", transactionID)
+	}
+
+	return &result[0], nil
+}
+
 // submitTransaction sends a transaction to the relayer
 func (c *Client) submitTransaction(ctx context.Context, payload interface{}) (*RelayerResponse, error) {
 	data, err := json.Marshal(payload)
@@ -2,6 +2,7 @@ package relayer
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -29,10 +30,23 @@ const (
 	TransactionTypeSafe       TransactionType = "SAFE"
 )
 
+// SignatureParams carries SAFE-CREATE payment fields or SAFE gas fields, depending on the request type.
 type SignatureParams struct {
-	PaymentToken    string `json:"paymentToken"`
-	Payment         string `json:"payment"`
-	PaymentReceiver string `json:"paymentReceiver"`
+	PaymentToken    string `json:"paymentToken,omitempty"`
+	Payment         string `json:"payment,omitempty"`
+	PaymentReceiver string `json:"paymentReceiver,omitempty"`
+
+	GasPrice       string `json:"gasPrice,omitempty"`
+	Operation      string `json:"operation,omitempty"`
+	SafeTxnGas     string `json:"safeTxnGas,omitempty"`
+	BaseGas        string `json:"baseGas,omitempty"`
+	GasToken       string `json:"gasToken,omitempty"`
+	RefundReceiver string `json:"refundReceiver,omitempty"`
+
+	GasLimit   string `json:"gasLimit,omitempty"`
+	RelayerFee string `json:"relayerFee,omitempty"`
+	RelayHub   string `json:"relayHub,omitempty"`
+	Relay      string `json:"relay,omitempty"`
 }
 
 type TransactionRequest struct {
@@ -41,11 +55,21 @@ type TransactionRequest struct {
 	To              string          `json:"to"`
 	ProxyWallet     string          `json:"proxyWallet,omitempty"`
 	Data            string          `json:"data"`
+	Nonce           string          `json:"nonce,omitempty"`
 	Signature       string          `json:"signature"`
 	SignatureParams SignatureParams `json:"signatureParams"`
 	Metadata        string          `json:"metadata,omitempty"`
 }
 
+// PayloadHash is the keccak256 of the request's JSON encoding, used to identify a submission locally.
+func (r *TransactionRequest) PayloadHash() (string, error) {
+	raw, err := json.Marshal(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode transaction request: %w", err)
+	}
+	return crypto.Keccak256Hash(raw).Hex(), nil
+}
+
 type TypedDataField struct {
 	Name string `json:"name"`
 	Type string `json:"type"`
@@ -0,0 +1,255 @@
+/**
+ * @description
+ * Calldata encoders for the Gnosis Conditional Tokens Framework (CTF) and Polymarket's Neg Risk Adapter.
+ * Used to build Safe transactions that split collateral into outcome sets, merge sets back into collateral
+ * and redeem winning positions after a market resolves.
+ *
+ * @dependencies
+ * - github.com/ethereum/go-ethereum/accounts/abi
+ * - github.com/ethereum/go-ethereum/common
+ *
+ * @notes
+ * - Standard markets redeem on the CTF with index sets [1, 2] (both outcomes); losing shares simply burn.
+ * - Neg risk markets redeem through the adapter, which takes the per-outcome share amounts instead.
+ * - Split / merge always use the binary partition [1, 2]; neg risk markets route through the adapter.
+ * - Amounts are in base units (6 decimals) for both USDC.e and outcome shares.
+ * - Collateral is USDC.e, the token Polymarket's CTF positions are backed by.
+ */
+
+package relayer
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	ConditionalTokensAddress = "0x4D97DCd97eC945f40cF65F87097ACe5EA0476045"
+	NegRiskAdapterAddress    = "0xd91E80cF2E7be2e162c6513ceD06f1dD0dA35296"
+	CollateralTokenAddress   = "0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174" // USDC.e
+)
+
+const ctfRedeemABI = `[{"inputs":[{"internalType":"contract IERC20","name":"collateralToken","type":"address"},{"internalType":"bytes32","name":"parentCollectionId","type":"bytes32"},{"internalType":"bytes32","name":"conditionId","type":"bytes32"},{"internalType":"uint256[]","name":"indexSets","type":"uint256[]"}],"name":"redeemPositions","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
+
+const ctfSplitMergeABI = `[{"inputs":[{"internalType":"contract IERC20","name":"collateralToken","type":"address"},{"internalType":"bytes32","name":"parentCollectionId","type":"bytes32"},{"internalType":"bytes32","name":"conditionId","type":"bytes32"},{"internalType":"uint256[]","name":"partition","type":"uint256[]"},{"internalType":"uint256","name":"amount","type":"uint256"}],"name":"splitPosition","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"contract IERC20","name":"collateralToken","type":"address"},{"internalType":"bytes32","name":"parentCollectionId","type":"bytes32"},{"internalType":"bytes32","name":"conditionId","type":"bytes32"},{"internalType":"uint256[]","name":"partition","type":"uint256[]"},{"internalType":"uint256","name":"amount","type":"uint256"}],"name":"mergePositions","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
+
+const negRiskSplitMergeABI = `[{"inputs":[{"internalType":"bytes32","name":"_conditionId","type":"bytes32"},{"internalType":"uint256","name":"_amount","type":"uint256"}],"name":"splitPosition","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"bytes32","name":"_conditionId","type":"bytes32"},{"internalType":"uint256","name":"_amount","type":"uint256"}],"name":"mergePositions","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
+
+const erc20ApproveABI = `[{"inputs":[{"internalType":"address","name":"spender","type":"address"},{"internalType":"uint256","name":"amount","type":"uint256"}],"name":"approve","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"}]`
+
+const erc1155ApprovalForAllABI = `[{"inputs":[{"internalType":"address","name":"operator","type":"address"},{"internalType":"bool","name":"approved","type":"bool"}],"name":"setApprovalForAll","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
+
+const negRiskRedeemABI = `[{"inputs":[{"internalType":"bytes32","name":"_conditionId","type":"bytes32"},{"internalType":"uint256[]","name":"_amounts","type":"uint256[]"}],"name":"redeemPositions","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
+
+// BuildRedeemPositionsTransaction redeems both outcomes of a binary condition on the CTF.
+func BuildRedeemPositionsTransaction(conditionID string) (SafeTransaction, error) {
+	condition, err := parseConditionID(conditionID)
+	if err != nil {
+		return SafeTransaction{}, err
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(ctfRedeemABI))
+	if err != nil {
+		return SafeTransaction{}, fmt.Errorf("failed to parse CTF ABI: %w", err)
+	}
+	data, err := parsed.Pack("redeemPositions",
+		common.HexToAddress(CollateralTokenAddress),
+		[32]byte{},
+		condition,
+		[]*big.Int{big.NewInt(1), big.NewInt(2)},
+	)
+	if err != nil {
+		return SafeTransaction{}, fmt.Errorf("failed to encode redeemPositions: %w", err)
+	}
+
+	return SafeTransaction{
+		To:        ConditionalTokensAddress,
+		Operation: OperationCall,
+		Data:      "0x" + hex.EncodeToString(data),
+		Value:     "0",
+	}, nil
+}
+
+// BuildNegRiskRedeemTransaction redeems a neg risk condition through the adapter.
+// amounts are the [YES, NO] share balances in base units (6 decimals).
+func BuildNegRiskRedeemTransaction(conditionID string, amounts [2]*big.Int) (SafeTransaction, error) {
+	condition, err := parseConditionID(conditionID)
+	if err != nil {
+		return SafeTransaction{}, err
+	}
+	values := make([]*big.Int, 2)
+	for i, amount := range amounts {
+		if amount == nil {
+			amount = big.NewInt(0)
+		}
+		if amount.Sign() < 0 {
+			return SafeTransaction{}, fmt.Errorf("redeem amounts cannot be negative")
+		}
+		values[i] = amount
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(negRiskRedeemABI))
+	if err != nil {
+		return SafeTransaction{}, fmt.Errorf("failed to parse Neg Risk Adapter ABI: %w", err)
+	}
+	data, err := parsed.Pack("redeemPositions", condition, values)
+	if err != nil {
+		return SafeTransaction{}, fmt.Errorf("failed to encode redeemPositions: %w", err)
+	}
+
+	return SafeTransaction{
+		To:        NegRiskAdapterAddress,
+		Operation: OperationCall,
+		Data:      "0x" + hex.EncodeToString(data),
+		Value:     "0",
+	}, nil
+}
+
+// BuildSplitPositionTransactions splits amount of USDC.e into YES + NO sets.
+// The collateral approval for the spender is included so the batch is self-contained. It grants the same
+// max uint256 allowance as RequiredTradingApprovals, so a split never leaves the vault with a spent allowance.
+func BuildSplitPositionTransactions(conditionID string, amount *big.Int, negRisk bool) ([]SafeTransaction, error) {
+	spender := ConditionalTokensAddress
+	if negRisk {
+		spender = NegRiskAdapterAddress
+	}
+	approve, err := BuildApprovalTransaction(TokenApproval{
+		Kind:    ApprovalKindERC20,
+		Token:   CollateralTokenAddress,
+		Spender: spender,
+	})
+	if err != nil {
+		return nil, err
+	}
+	split, err := buildSplitMerge("splitPosition", conditionID, amount, negRisk)
+	if err != nil {
+		return nil, err
+	}
+	return []SafeTransaction{approve, split}, nil
+}
+
+// BuildMergePositionsTransactions merges amount of YES + NO sets back into USDC.e.
+// Neg risk merges go through the adapter, which must be approved to move the vault's CTF tokens.
+func BuildMergePositionsTransactions(conditionID string, amount *big.Int, negRisk bool) ([]SafeTransaction, error) {
+	merge, err := buildSplitMerge("mergePositions", conditionID, amount, negRisk)
+	if err != nil {
+		return nil, err
+	}
+	if !negRisk {
+		return []SafeTransaction{merge}, nil
+	}
+	approve, err := BuildSetApprovalForAllTransaction(ConditionalTokensAddress, NegRiskAdapterAddress, true)
+	if err != nil {
+		return nil, err
+	}
+	return []SafeTransaction{approve, merge}, nil
+}
+
+func buildSplitMerge(method, conditionID string, amount *big.Int, negRisk bool) (SafeTransaction, error) {
+	condition, err := parseConditionID(conditionID)
+	if err != nil {
+		return SafeTransaction{}, err
+	}
+	if amount == nil || amount.Sign() <= 0 {
+		return SafeTransaction{}, fmt.Errorf("amount must be positive")
+	}
+
+	var (
+		to   string
+		data []byte
+	)
+	if negRisk {
+		parsed, err := abi.JSON(strings.NewReader(negRiskSplitMergeABI))
+		if err != nil {
+			return SafeTransaction{}, fmt.Errorf("failed to parse Neg Risk Adapter ABI: %w", err)
+		}
+		to = NegRiskAdapterAddress
+		data, err = parsed.Pack(method, condition, amount)
+		if err != nil {
+			return SafeTransaction{}, fmt.Errorf("failed to encode %s: %w", method, err)
+		}
+	} else {
+		parsed, err := abi.JSON(strings.NewReader(ctfSplitMergeABI))
+		if err != nil {
+			return SafeTransaction{}, fmt.Errorf("failed to parse CTF ABI: %w", err)
+		}
+		to = ConditionalTokensAddress
+		data, err = parsed.Pack(method,
+			common.HexToAddress(CollateralTokenAddress),
+			[32]byte{},
+			condition,
+			[]*big.Int{big.NewInt(1), big.NewInt(2)},
+			amount,
+		)
+		if err != nil {
+			return SafeTransaction{}, fmt.Errorf("failed to encode %s: %w", method, err)
+		}
+	}
+
+	return SafeTransaction{
+		To:        to,
+		Operation: OperationCall,
+		Data:      "0x" + hex.EncodeToString(data),
+		Value:     "0",
+	}, nil
+}
+
+// BuildERC20ApproveTransaction approves spender to pull amount of token from the Safe.
+func BuildERC20ApproveTransaction(token, spender string, amount *big.Int) (SafeTransaction, error) {
+	if !common.IsHexAddress(token) || !common.IsHexAddress(spender) {
+		return SafeTransaction{}, fmt.Errorf("invalid token or spender address")
+	}
+	if amount == nil || amount.Sign() < 0 {
+		return SafeTransaction{}, fmt.Errorf("approval amount cannot be negative")
+	}
+	parsed, err := abi.JSON(strings.NewReader(erc20ApproveABI))
+	if err != nil {
+		return SafeTransaction{}, fmt.Errorf("failed to parse ERC20 ABI: %w", err)
+	}
+	data, err := parsed.Pack("approve", common.HexToAddress(spender), amount)
+	if err != nil {
+		return SafeTransaction{}, fmt.Errorf("failed to encode approve: %w", err)
+	}
+	return SafeTransaction{
+		To:        common.HexToAddress(token).Hex(),
+		Operation: OperationCall,
+		Data:      "0x" + hex.EncodeToString(data),
+		Value:     "0",
+	}, nil
+}
+
+// BuildSetApprovalForAllTransaction lets operator move all of the Safe's ERC1155 tokens on contract.
+func BuildSetApprovalForAllTransaction(contract, operator string, approved bool) (SafeTransaction, error) {
+	if !common.IsHexAddress(contract) || !common.IsHexAddress(operator) {
+		return SafeTransaction{}, fmt.Errorf("invalid contract or operator address")
+	}
+	parsed, err := abi.JSON(strings.NewReader(erc1155ApprovalForAllABI))
+	if err != nil {
+		return SafeTransaction{}, fmt.Errorf("failed to parse ERC1155 ABI: %w", err)
+	}
+	data, err := parsed.Pack("setApprovalForAll", common.HexToAddress(operator), approved)
+	if err != nil {
+		return SafeTransaction{}, fmt.Errorf("failed to encode setApprovalForAll: %w", err)
+	}
+	return SafeTransaction{
+		To:        common.HexToAddress(contract).Hex(),
+		Operation: OperationCall,
+		Data:      "0x" + hex.EncodeToString(data),
+		Value:     "0",
+	}, nil
+}
+
+func parseConditionID(conditionID string) ([32]byte, error) {
+	var out [32]byte
+	raw, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(conditionID), "0x"))
+	if err != nil || len(raw) != 32 {
+		return out, fmt.Errorf("invalid condition id: %s", conditionID)
+	}
+	copy(out[:], raw)
+	return out, nil
+}
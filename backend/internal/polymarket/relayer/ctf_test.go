@@ -0,0 +1,184 @@
+package relayer
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+const testConditionID = "0x5f65177b394277fd294cd75650044e32ba009a95022d88a0c1d565897d72f8f1"
+
+// unpackCall checks the selector of a Safe call and returns its decoded arguments.
+func unpackCall(t *testing.T, abiJSON, method string, tx SafeTransaction) []interface{} {
+	t.Helper()
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		t.Fatalf("parse ABI: %v", err)
+	}
+	m := parsed.Methods[method]
+	data := common.FromHex(tx.Data)
+	if len(data) < 4 || string(data[:4]) != string(m.ID) {
+		t.Fatalf("%s: selector = %x, want %x", method, data[:min(len(data), 4)], m.ID)
+	}
+	args, err := m.Inputs.Unpack(data[4:])
+	if err != nil {
+		t.Fatalf("%s: unpack: %v", method, err)
+	}
+	if tx.Operation != OperationCall || tx.Value != "0" {
+		t.Errorf("%s: operation %d value %s, want plain call", method, tx.Operation, tx.Value)
+	}
+	return args
+}
+
+func binaryPartition(t *testing.T, v interface{}) {
+	t.Helper()
+	sets := v.([]*big.Int)
+	if len(sets) != 2 || sets[0].Int64() != 1 || sets[1].Int64() != 2 {
+		t.Errorf("index sets = %v, want [1 2]", sets)
+	}
+}
+
+func TestBuildRedeemTransactions(t *testing.T) {
+	condition := common.HexToHash(testConditionID)
+
+	tx, err := BuildRedeemPositionsTransaction(testConditionID)
+	if err != nil {
+		t.Fatalf("BuildRedeemPositionsTransaction: %v", err)
+	}
+	if tx.To != ConditionalTokensAddress {
+		t.Errorf("redeem to = %s, want CTF", tx.To)
+	}
+	args := unpackCall(t, ctfRedeemABI, "redeemPositions", tx)
+	if args[0].(common.Address) != common.HexToAddress(CollateralTokenAddress) {
+		t.Errorf("collateral = %v", args[0])
+	}
+	if args[1].([32]byte) != [32]byte{} || args[2].([32]byte) != condition {
+		t.Errorf("parent / condition = %x / %x", args[1], args[2])
+	}
+	binaryPartition(t, args[3])
+
+	amounts := [2]*big.Int{big.NewInt(2_500_000), nil}
+	tx, err = BuildNegRiskRedeemTransaction(testConditionID, amounts)
+	if err != nil {
+		t.Fatalf("BuildNegRiskRedeemTransaction: %v", err)
+	}
+	if tx.To != NegRiskAdapterAddress {
+		t.Errorf("neg risk redeem to = %s, want adapter", tx.To)
+	}
+	args = unpackCall(t, negRiskRedeemABI, "redeemPositions", tx)
+	if args[0].([32]byte) != condition {
+		t.Errorf("condition = %x", args[0])
+	}
+	values := args[1].([]*big.Int)
+	if len(values) != 2 || values[0].Int64() != 2_500_000 || values[1].Sign() != 0 {
+		t.Errorf("amounts = %v, want [2500000 0]", values)
+	}
+
+	if _, err := BuildNegRiskRedeemTransaction(testConditionID, [2]*big.Int{big.NewInt(-1), nil}); err == nil {
+		t.Error("expected error for negative redeem amount")
+	}
+	for _, bad := range []string{"", "0x1234", "not-hex"} {
+		if _, err := BuildRedeemPositionsTransaction(bad); err == nil {
+			t.Errorf("BuildRedeemPositionsTransaction(%q) expected error", bad)
+		}
+	}
+}
+
+func TestBuildSplitMergeTransactions(t *testing.T) {
+	condition := common.HexToHash(testConditionID)
+	amount := big.NewInt(10_000_000)
+
+	cases := []struct {
+		name     string
+		split    bool
+		negRisk  bool
+		contract string
+		approval string // expected approval call target, empty when none
+	}{
+		{"split standard", true, false, ConditionalTokensAddress, CollateralTokenAddress},
+		{"split neg risk", true, true, NegRiskAdapterAddress, CollateralTokenAddress},
+		{"merge standard", false, false, ConditionalTokensAddress, ""},
+		{"merge neg risk", false, true, NegRiskAdapterAddress, common.HexToAddress(ConditionalTokensAddress).Hex()},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				txs    []SafeTransaction
+				err    error
+				method = "mergePositions"
+			)
+			if tc.split {
+				method = "splitPosition"
+				txs, err = BuildSplitPositionTransactions(testConditionID, amount, tc.negRisk)
+			} else {
+				txs, err = BuildMergePositionsTransactions(testConditionID, amount, tc.negRisk)
+			}
+			if err != nil {
+				t.Fatalf("build: %v", err)
+			}
+
+			want := 1
+			if tc.approval != "" {
+				want = 2
+			}
+			if len(txs) != want {
+				t.Fatalf("got %d transactions, want %d", len(txs), want)
+			}
+
+			if tc.approval != "" {
+				approve := txs[0]
+				if !strings.EqualFold(approve.To, tc.approval) {
+					t.Errorf("approval to = %s, want %s", approve.To, tc.approval)
+				}
+				if tc.split {
+					args := unpackCall(t, erc20ApproveABI, "approve", approve)
+					if args[0].(common.Address) != common.HexToAddress(tc.contract) {
+						t.Errorf("spender = %v, want %s", args[0], tc.contract)
+					}
+					// Matches RequiredTradingApprovals so a split never leaves a spent allowance behind.
+					if args[1].(*big.Int).Cmp(math.MaxBig256) != 0 {
+						t.Errorf("allowance = %v, want max uint256", args[1])
+					}
+				} else {
+					args := unpackCall(t, erc1155ApprovalForAllABI, "setApprovalForAll", approve)
+					if args[0].(common.Address) != common.HexToAddress(NegRiskAdapterAddress) || !args[1].(bool) {
+						t.Errorf("setApprovalForAll args = %v", args)
+					}
+				}
+			}
+
+			call := txs[len(txs)-1]
+			if call.To != tc.contract {
+				t.Errorf("call to = %s, want %s", call.To, tc.contract)
+			}
+			if tc.negRisk {
+				args := unpackCall(t, negRiskSplitMergeABI, method, call)
+				if args[0].([32]byte) != condition || args[1].(*big.Int).Cmp(amount) != 0 {
+					t.Errorf("args = %v", args)
+				}
+				return
+			}
+			args := unpackCall(t, ctfSplitMergeABI, method, call)
+			if args[0].(common.Address) != common.HexToAddress(CollateralTokenAddress) || args[2].([32]byte) != condition {
+				t.Errorf("collateral / condition = %v / %x", args[0], args[2])
+			}
+			binaryPartition(t, args[3])
+			if args[4].(*big.Int).Cmp(amount) != 0 {
+				t.Errorf("amount = %v, want %v", args[4], amount)
+			}
+		})
+	}
+
+	for _, bad := range []*big.Int{nil, big.NewInt(0), big.NewInt(-5)} {
+		if _, err := BuildSplitPositionTransactions(testConditionID, bad, false); err == nil {
+			t.Errorf("split amount %v expected error", bad)
+		}
+		if _, err := BuildMergePositionsTransactions(testConditionID, bad, true); err == nil {
+			t.Errorf("merge amount %v expected error", bad)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+/**
+ * @description
+ * Polymarket proxy wallet (PROXY type) support.
+ * Proxy wallets are the vaults Polymarket gives Magic / email users: a minimal proxy per EOA created by the
+ * proxy factory with CREATE2 on its first relayed call. This file derives the proxy address, encodes the
+ * factory's proxy(calls) batch and builds the PROXY relay request and the hash the owner signs.
+ *
+ * @dependencies
+ * - github.com/ethereum/go-ethereum/accounts/abi
+ * - github.com/ethereum/go-ethereum/crypto
+ *
+ * @notes
+ * - salt = keccak256(abi.encodePacked(owner)); unlike Safes the owner is packed to 20 bytes, not padded.
+ * - There is no separate deployment call: the factory deploys the proxy on its first proxy(calls) transaction,
+ *   so "deploying" means relaying a first batch (we use the trading approvals).
+ * - The owner personal_signs the relay hub struct hash; the relayer pays gas, so fee and gas price are zero.
+ */
+
+package relayer
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	ProxyFactoryAddress   = "0xaB45c5A4B0c941a2F231C04C3f49182e1A254052"
+	ProxyInitCodeHash     = "0xd21df8dc65880a8606f09fe0ce3df9b8869287ab0b058be05aa9e8af6330a00b"
+	RelayHubAddress       = "0xD216153c06E857cD7f72665E0aF1d7D82172F494"
+	DefaultProxyGasLimit  = "10000000"
+	proxyRelayHashPrefix  = "rlx:"
+	proxyCallTypeCodeCall = 1
+)
+
+// TransactionTypeProxy is the relayer transaction type for proxy wallet calls
+const TransactionTypeProxy TransactionType = "PROXY"
+
+const proxyFactoryABI = `[{"inputs":[{"components":[{"internalType":"enum ProxyWalletLib.CallType","name":"typeCode","type":"uint8"},{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"value","type":"uint256"},{"internalType":"bytes","name":"data","type":"bytes"}],"internalType":"struct ProxyWalletLib.ProxyCall[]","name":"calls","type":"tuple[]"}],"name":"proxy","outputs":[{"internalType":"bytes[]","name":"returnValues","type":"bytes[]"}],"stateMutability":"payable","type":"function"}]`
+
+// proxyCall mirrors ProxyWalletLib.ProxyCall for ABI packing
+type proxyCall struct {
+	TypeCode uint8
+	To       common.Address
+	Value    *big.Int
+	Data     []byte
+}
+
+// ProxyTransaction is a factory proxy(calls) transaction ready to be signed
+type ProxyTransaction struct {
+	To       string `json:"to"` // Proxy factory
+	Data     string `json:"data"`
+	Nonce    string `json:"nonce"`
+	GasLimit string `json:"gasLimit"`
+	Relay    string `json:"relay"`
+	RelayHub string `json:"relayHub"`
+}
+
+// DeriveProxyAddress returns the CREATE2 address of owner's Polymarket proxy wallet.
+func DeriveProxyAddress(owner string) (string, error) {
+	if !common.IsHexAddress(owner) {
+		return "", fmt.Errorf("invalid owner address: %s", owner)
+	}
+
+	salt := crypto.Keccak256(common.HexToAddress(owner).Bytes())
+	initCodeHash, err := hex.DecodeString(strings.TrimPrefix(ProxyInitCodeHash, "0x"))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode init code hash: %w", err)
+	}
+
+	var buf []byte
+	buf = append(buf, 0xff)
+	buf = append(buf, common.HexToAddress(ProxyFactoryAddress).Bytes()...)
+	buf = append(buf, salt...)
+	buf = append(buf, initCodeHash...)
+
+	return common.BytesToAddress(crypto.Keccak256(buf)[12:]).Hex(), nil
+}
+
+// EncodeProxyCalls packs txs into the factory's proxy(calls) calldata. Only plain calls are supported.
+func EncodeProxyCalls(txs []SafeTransaction) (string, error) {
+	if len(txs) == 0 {
+		return "", fmt.Errorf("at least one call is required")
+	}
+	calls := make([]proxyCall, 0, len(txs))
+	for _, tx := range txs {
+		if tx.Operation != OperationCall {
+			return "", fmt.Errorf("proxy wallets only support plain calls")
+		}
+		if !common.IsHexAddress(tx.To) {
+			return "", fmt.Errorf("invalid call target: %s", tx.To)
+		}
+		data, err := hexutil.Decode(normalizeHexData(tx.Data))
+		if err != nil {
+			return "", fmt.Errorf("invalid call data for %s: %w", tx.To, err)
+		}
+		value, ok := new(big.Int).SetString(defaultString(tx.Value, "0"), 10)
+		if !ok {
+			return "", fmt.Errorf("invalid call value: %s", tx.Value)
+		}
+		calls = append(calls, proxyCall{
+			TypeCode: proxyCallTypeCodeCall,
+			To:       common.HexToAddress(tx.To),
+			Value:    value,
+			Data:     data,
+		})
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(proxyFactoryABI))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse proxy factory ABI: %w", err)
+	}
+	packed, err := parsed.Pack("proxy", calls)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode proxy calls: %w", err)
+	}
+	return hexutil.Encode(packed), nil
+}
+
+// BuildProxyStructHash returns the relay hub hash the owner personal_signs for a proxy transaction.
+// keccak256("rlx:" ++ from ++ to ++ data ++ relayerFee ++ gasPrice ++ gasLimit ++ nonce ++ relayHub ++ relay)
+func BuildProxyStructHash(from string, tx ProxyTransaction) (string, error) {
+	for _, addr := range []string{from, tx.To, tx.RelayHub, tx.Relay} {
+		if !common.IsHexAddress(addr) {
+			return "", fmt.Errorf("invalid address: %s", addr)
+		}
+	}
+	data, err := hexutil.Decode(normalizeHexData(tx.Data))
+	if err != nil {
+		return "", fmt.Errorf("invalid proxy data: %w", err)
+	}
+	gasLimit, ok := new(big.Int).SetString(defaultString(tx.GasLimit, DefaultProxyGasLimit), 10)
+	if !ok {
+		return "", fmt.Errorf("invalid gas limit: %s", tx.GasLimit)
+	}
+	nonce, ok := new(big.Int).SetString(defaultString(tx.Nonce, "0"), 10)
+	if !ok {
+		return "", fmt.Errorf("invalid nonce: %s", tx.Nonce)
+	}
+
+	var buf []byte
+	buf = append(buf, []byte(proxyRelayHashPrefix)...)
+	buf = append(buf, common.HexToAddress(from).Bytes()...)
+	buf = append(buf, common.HexToAddress(tx.To).Bytes()...)
+	buf = append(buf, data...)
+	buf = append(buf, common.LeftPadBytes(big.NewInt(0).Bytes(), 32)...) // relayer fee
+	buf = append(buf, common.LeftPadBytes(big.NewInt(0).Bytes(), 32)...) // gas price
+	buf = append(buf, common.LeftPadBytes(gasLimit.Bytes(), 32)...)
+	buf = append(buf, common.LeftPadBytes(nonce.Bytes(), 32)...)
+	buf = append(buf, common.HexToAddress(tx.RelayHub).Bytes()...)
+	buf = append(buf, common.HexToAddress(tx.Relay).Bytes()...)
+
+	return crypto.Keccak256Hash(buf).Hex(), nil
+}
+
+// BuildProxyTransactionRequest builds the PROXY relayer payload for a signed proxy transaction.
+func BuildProxyTransactionRequest(signer string, tx ProxyTransaction, signature, metadata string) (*TransactionRequest, error) {
+	if !common.IsHexAddress(signer) {
+		return nil, fmt.Errorf("invalid signer address: %s", signer)
+	}
+	sig, err := hexutil.Decode(signature)
+	if err != nil || len(sig) != 65 {
+		return nil, fmt.Errorf("invalid signature")
+	}
+	proxyWallet, err := DeriveProxyAddress(signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransactionRequest{
+		Type:        TransactionTypeProxy,
+		From:        common.HexToAddress(signer).Hex(),
+		To:          common.HexToAddress(tx.To).Hex(),
+		ProxyWallet: proxyWallet,
+		Data:        normalizeHexData(tx.Data),
+		Nonce:       tx.Nonce,
+		Signature:   hexutil.Encode(sig),
+		SignatureParams: SignatureParams{
+			GasPrice:   "0",
+			GasLimit:   defaultString(tx.GasLimit, DefaultProxyGasLimit),
+			RelayerFee: "0",
+			RelayHub:   common.HexToAddress(tx.RelayHub).Hex(),
+			Relay:      common.HexToAddress(tx.Relay).Hex(),
+		},
+		Metadata: metadata,
+	}, nil
+}
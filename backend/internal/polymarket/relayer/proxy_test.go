@@ -0,0 +1,169 @@
+package relayer
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestDeriveProxyAddress(t *testing.T) {
+	// Regression vectors for the well-known Hardhat test accounts. They were computed from ProxyFactoryAddress and
+	// ProxyInitCodeHash, so they pin the CREATE2 derivation but do not prove the constants match Polymarket's
+	// deployed factory; that needs an EOA -> proxyWallet pair observed on Polygon (e.g. a Gamma profile).
+	cases := []struct {
+		owner string
+		want  string
+	}{
+		{"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", "0x365f0CA36Ae1f641E02fE3B7743673da42A13A70"},
+		{"0x70997970C51812dc3A010C7d01b50e0d17dc79C8", "0xd9d24e482c11F586cd9A1a53dC3eEc6dE3883862"},
+		{"0x70997970c51812dc3a010c7d01b50e0d17dc79c8", "0xd9d24e482c11F586cd9A1a53dC3eEc6dE3883862"},
+	}
+	for _, tc := range cases {
+		got, err := DeriveProxyAddress(tc.owner)
+		if err != nil {
+			t.Fatalf("DeriveProxyAddress(%s): %v", tc.owner, err)
+		}
+		if got != tc.want {
+			t.Errorf("DeriveProxyAddress(%s) = %s, want %s", tc.owner, got, tc.want)
+		}
+
+		// Cross-check against go-ethereum's CREATE2 implementation.
+		salt := crypto.Keccak256Hash(common.HexToAddress(tc.owner).Bytes())
+		ref := crypto.CreateAddress2(common.HexToAddress(ProxyFactoryAddress), salt, common.FromHex(ProxyInitCodeHash))
+		if got != ref.Hex() {
+			t.Errorf("DeriveProxyAddress(%s) = %s, CreateAddress2 = %s", tc.owner, got, ref.Hex())
+		}
+
+		safe, err := DeriveSafeAddress(tc.owner)
+		if err != nil {
+			t.Fatalf("DeriveSafeAddress(%s): %v", tc.owner, err)
+		}
+		if strings.EqualFold(safe, got) {
+			t.Errorf("proxy and Safe addresses for %s must differ", tc.owner)
+		}
+	}
+
+	for _, bad := range []string{"", "0x1234", "not-an-address"} {
+		if _, err := DeriveProxyAddress(bad); err == nil {
+			t.Errorf("DeriveProxyAddress(%q) expected error", bad)
+		}
+	}
+}
+
+func TestEncodeProxyCalls(t *testing.T) {
+	approval := RequiredTradingApprovals()[0]
+	call, err := BuildApprovalTransaction(approval)
+	if err != nil {
+		t.Fatalf("BuildApprovalTransaction: %v", err)
+	}
+
+	encoded, err := EncodeProxyCalls([]SafeTransaction{call})
+	if err != nil {
+		t.Fatalf("EncodeProxyCalls: %v", err)
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(proxyFactoryABI))
+	if err != nil {
+		t.Fatalf("parse ABI: %v", err)
+	}
+	data := common.FromHex(encoded)
+	method := parsed.Methods["proxy"]
+	if string(data[:4]) != string(method.ID) {
+		t.Fatalf("selector = %x, want %x", data[:4], method.ID)
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		t.Fatalf("unpack: %v", err)
+	}
+	calls := args[0].([]struct {
+		TypeCode uint8          `json:"typeCode"`
+		To       common.Address `json:"to"`
+		Value    *big.Int       `json:"value"`
+		Data     []byte         `json:"data"`
+	})
+	if len(calls) != 1 {
+		t.Fatalf("got %d calls, want 1", len(calls))
+	}
+	if calls[0].TypeCode != proxyCallTypeCodeCall || calls[0].To != common.HexToAddress(call.To) || calls[0].Value.Sign() != 0 {
+		t.Errorf("unexpected call %+v", calls[0])
+	}
+	if hexutil.Encode(calls[0].Data) != strings.ToLower(call.Data) {
+		t.Errorf("call data = %s, want %s", hexutil.Encode(calls[0].Data), call.Data)
+	}
+
+	if _, err := EncodeProxyCalls(nil); err == nil {
+		t.Error("expected error for empty batch")
+	}
+	if _, err := EncodeProxyCalls([]SafeTransaction{{To: call.To, Data: call.Data, Operation: OperationDelegateCall}}); err == nil {
+		t.Error("expected error for delegate call")
+	}
+}
+
+func TestProxyStructHashSignRoundTrip(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	signer := crypto.PubkeyToAddress(key.PublicKey).Hex()
+	relay := "0x0000000000000000000000000000000000000001"
+
+	tx := ProxyTransaction{
+		To:       ProxyFactoryAddress,
+		Data:     "0xdeadbeef",
+		Nonce:    "7",
+		GasLimit: DefaultProxyGasLimit,
+		Relay:    relay,
+		RelayHub: RelayHubAddress,
+	}
+	hash, err := BuildProxyStructHash(signer, tx)
+	if err != nil {
+		t.Fatalf("BuildProxyStructHash: %v", err)
+	}
+
+	var want []byte
+	want = append(want, "rlx:"...)
+	want = append(want, common.HexToAddress(signer).Bytes()...)
+	want = append(want, common.HexToAddress(ProxyFactoryAddress).Bytes()...)
+	want = append(want, 0xde, 0xad, 0xbe, 0xef)
+	want = append(want, make([]byte, 64)...) // relayer fee, gas price
+	want = append(want, common.LeftPadBytes(big.NewInt(10000000).Bytes(), 32)...)
+	want = append(want, common.LeftPadBytes(big.NewInt(7).Bytes(), 32)...)
+	want = append(want, common.HexToAddress(RelayHubAddress).Bytes()...)
+	want = append(want, common.HexToAddress(relay).Bytes()...)
+	if hash != crypto.Keccak256Hash(want).Hex() {
+		t.Fatalf("struct hash = %s, want %s", hash, crypto.Keccak256Hash(want).Hex())
+	}
+
+	sig, err := crypto.Sign(accounts.TextHash(common.FromHex(hash)), key)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	sig[64] += 27
+	signature := hexutil.Encode(sig)
+
+	recovered, err := RecoverSafeTxSigner(hash, signature)
+	if err != nil {
+		t.Fatalf("RecoverSafeTxSigner: %v", err)
+	}
+	if !strings.EqualFold(recovered, signer) {
+		t.Fatalf("recovered %s, want %s", recovered, signer)
+	}
+
+	req, err := BuildProxyTransactionRequest(signer, tx, signature, "deploy")
+	if err != nil {
+		t.Fatalf("BuildProxyTransactionRequest: %v", err)
+	}
+	proxy, _ := DeriveProxyAddress(signer)
+	if req.Type != TransactionTypeProxy || req.ProxyWallet != proxy || req.To != common.HexToAddress(ProxyFactoryAddress).Hex() {
+		t.Errorf("unexpected request %+v", req)
+	}
+	if req.SignatureParams.GasLimit != DefaultProxyGasLimit || req.SignatureParams.RelayHub != common.HexToAddress(RelayHubAddress).Hex() {
+		t.Errorf("unexpected signature params %+v", req.SignatureParams)
+	}
+}
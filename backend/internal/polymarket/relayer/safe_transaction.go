@@ -0,0 +1,285 @@
+/**
+ * @description
+ * Safe transaction builder for relayer-submitted (gasless) vault transactions.
+ * Builds SafeTx EIP-712 typed data, batches calls through MultiSend and packs the
+ * owner's signature into the SAFE TransactionRequest the relayer expects.
+ *
+ * @dependencies
+ * - github.com/ethereum/go-ethereum/common
+ * - github.com/ethereum/go-ethereum/crypto
+ * - github.com/ethereum/go-ethereum/signer/core/apitypes
+ *
+ * @notes
+ * - The owner signs the SafeTx hash with personal_sign (eth_sign), so v is shifted by +4 as Safe requires.
+ * - Gas fields are always zero: the relayer pays gas and is not refunded.
+ */
+
+package relayer
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+const safeTxPrimaryType = "SafeTx"
+
+// OperationType is the Safe call type
+type OperationType uint8
+
+const (
+	OperationCall         OperationType = 0
+	OperationDelegateCall OperationType = 1
+)
+
+// SafeTransaction is a single call executed by a Safe
+type SafeTransaction struct {
+	To        string        `json:"to"`
+	Operation OperationType `json:"operation"`
+	Data      string        `json:"data"`
+	Value     string        `json:"value"`
+}
+
+// SafeTxTypedData is the EIP-712 payload the Safe owner signs
+type SafeTxTypedData struct {
+	Domain      map[string]interface{}      `json:"domain"`
+	Types       map[string][]TypedDataField `json:"types"`
+	PrimaryType string                      `json:"primaryType"`
+	Message     map[string]interface{}      `json:"message"`
+}
+
+const multiSendABI = `[{"inputs":[{"internalType":"bytes","name":"transactions","type":"bytes"}],"name":"multiSend","outputs":[],"stateMutability":"payable","type":"function"}]`
+
+// AggregateSafeTransactions returns txs[0] unchanged, or a MultiSend delegatecall batching all of them.
+func AggregateSafeTransactions(txs []SafeTransaction) (SafeTransaction, error) {
+	if len(txs) == 0 {
+		return SafeTransaction{}, fmt.Errorf("at least one transaction is required")
+	}
+	if len(txs) == 1 {
+		return txs[0], nil
+	}
+
+	// multiSend packs each tx as: uint8 operation | address to | uint256 value | uint256 dataLength | bytes data
+	var packed []byte
+	for i, tx := range txs {
+		if !common.IsHexAddress(tx.To) {
+			return SafeTransaction{}, fmt.Errorf("transaction %d: invalid to address %s", i, tx.To)
+		}
+		data, err := hexutil.Decode(normalizeHexData(tx.Data))
+		if err != nil {
+			return SafeTransaction{}, fmt.Errorf("transaction %d: invalid data: %w", i, err)
+		}
+		value, ok := new(big.Int).SetString(defaultString(tx.Value, "0"), 10)
+		if !ok {
+			return SafeTransaction{}, fmt.Errorf("transaction %d: invalid value %s", i, tx.Value)
+		}
+
+		packed = append(packed, byte(tx.Operation))
+		packed = append(packed, common.HexToAddress(tx.To).Bytes()...)
+		packed = append(packed, common.LeftPadBytes(value.Bytes(), 32)...)
+		packed = append(packed, common.LeftPadBytes(big.NewInt(int64(len(data))).Bytes(), 32)...)
+		packed = append(packed, data...)
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(multiSendABI))
+	if err != nil {
+		return SafeTransaction{}, fmt.Errorf("failed to parse MultiSend ABI: %w", err)
+	}
+	calldata, err := parsed.Pack("multiSend", packed)
+	if err != nil {
+		return SafeTransaction{}, fmt.Errorf("failed to encode multiSend: %w", err)
+	}
+
+	return SafeTransaction{
+		To:        SafeMultisendAddress,
+		Operation: OperationDelegateCall,
+		Data:      "0x" + hex.EncodeToString(calldata),
+		Value:     "0",
+	}, nil
+}
+
+// BuildSafeTxTypedData returns the SafeTx typed data for safe at nonce, plus the hash the owner signs.
+func BuildSafeTxTypedData(safe string, tx SafeTransaction, nonce string) (*SafeTxTypedData, string, error) {
+	if !common.IsHexAddress(safe) {
+		return nil, "", fmt.Errorf("invalid safe address: %s", safe)
+	}
+	if !common.IsHexAddress(tx.To) {
+		return nil, "", fmt.Errorf("invalid to address: %s", tx.To)
+	}
+	if _, err := strconv.ParseUint(nonce, 10, 64); err != nil {
+		return nil, "", fmt.Errorf("invalid safe nonce %q", nonce)
+	}
+
+	typed := &SafeTxTypedData{
+		Domain: map[string]interface{}{
+			"chainId":           PolygonChainID,
+			"verifyingContract": common.HexToAddress(safe).Hex(),
+		},
+		Types: map[string][]TypedDataField{
+			"EIP712Domain": {
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			safeTxPrimaryType: {
+				{Name: "to", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "data", Type: "bytes"},
+				{Name: "operation", Type: "uint8"},
+				{Name: "safeTxGas", Type: "uint256"},
+				{Name: "baseGas", Type: "uint256"},
+				{Name: "gasPrice", Type: "uint256"},
+				{Name: "gasToken", Type: "address"},
+				{Name: "refundReceiver", Type: "address"},
+				{Name: "nonce", Type: "uint256"},
+			},
+		},
+		PrimaryType: safeTxPrimaryType,
+		Message: map[string]interface{}{
+			"to":             common.HexToAddress(tx.To).Hex(),
+			"value":          defaultString(tx.Value, "0"),
+			"data":           normalizeHexData(tx.Data),
+			"operation":      strconv.Itoa(int(tx.Operation)),
+			"safeTxGas":      "0",
+			"baseGas":        "0",
+			"gasPrice":       "0",
+			"gasToken":       ZeroAddress,
+			"refundReceiver": ZeroAddress,
+			"nonce":          nonce,
+		},
+	}
+
+	hash, err := typed.hash()
+	if err != nil {
+		return nil, "", err
+	}
+	return typed, hash, nil
+}
+
+func (t *SafeTxTypedData) hash() (string, error) {
+	types := apitypes.Types{}
+	for name, fields := range t.Types {
+		for _, f := range fields {
+			types[name] = append(types[name], apitypes.Type{Name: f.Name, Type: f.Type})
+		}
+	}
+	verifying, _ := t.Domain["verifyingContract"].(string)
+	data := apitypes.TypedData{
+		Types:       types,
+		PrimaryType: t.PrimaryType,
+		Domain: apitypes.TypedDataDomain{
+			ChainId:           math.NewHexOrDecimal256(PolygonChainID),
+			VerifyingContract: verifying,
+		},
+		Message: t.Message,
+	}
+	hash, _, err := apitypes.TypedDataAndHash(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash SafeTx typed data: %w", err)
+	}
+	return hexutil.Encode(hash), nil
+}
+
+// RecoverSafeTxSigner recovers the owner that personal_signed safeTxHash.
+func RecoverSafeTxSigner(safeTxHash, signature string) (string, error) {
+	hash, err := hexutil.Decode(safeTxHash)
+	if err != nil || len(hash) != 32 {
+		return "", fmt.Errorf("invalid safe tx hash")
+	}
+	sig, err := hexutil.Decode(signature)
+	if err != nil || len(sig) != 65 {
+		return "", fmt.Errorf("invalid signature")
+	}
+	sig = append([]byte(nil), sig...)
+	switch sig[64] {
+	case 27, 28:
+		sig[64] -= 27
+	case 31, 32:
+		sig[64] -= 31
+	}
+
+	digest := crypto.Keccak256(append([]byte("\x19Ethereum Signed Message:\n32"), hash...))
+	pub, err := crypto.SigToPub(digest, sig)
+	if err != nil {
+		return "", fmt.Errorf("failed to recover signer: %w", err)
+	}
+	return crypto.PubkeyToAddress(*pub).Hex(), nil
+}
+
+// PackSafeSignature converts a personal_sign signature into the eth_sign form Safe verifies (v + 4).
+func PackSafeSignature(signature string) (string, error) {
+	sig, err := hexutil.Decode(signature)
+	if err != nil || len(sig) != 65 {
+		return "", fmt.Errorf("invalid signature")
+	}
+	sig = append([]byte(nil), sig...)
+	switch sig[64] {
+	case 0, 1:
+		sig[64] += 31
+	case 27, 28:
+		sig[64] += 4
+	case 31, 32:
+	default:
+		return "", fmt.Errorf("invalid signature v value %d", sig[64])
+	}
+	return hexutil.Encode(sig), nil
+}
+
+// BuildSafeTransactionRequest builds the SAFE relayer payload for a signed Safe transaction.
+func BuildSafeTransactionRequest(signer, safe string, tx SafeTransaction, nonce, signature, metadata string) (*TransactionRequest, error) {
+	if !common.IsHexAddress(signer) {
+		return nil, fmt.Errorf("invalid signer address: %s", signer)
+	}
+	if !common.IsHexAddress(safe) {
+		return nil, fmt.Errorf("invalid safe address: %s", safe)
+	}
+	packed, err := PackSafeSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransactionRequest{
+		Type:        TransactionTypeSafe,
+		From:        common.HexToAddress(signer).Hex(),
+		To:          common.HexToAddress(tx.To).Hex(),
+		ProxyWallet: common.HexToAddress(safe).Hex(),
+		Data:        normalizeHexData(tx.Data),
+		Nonce:       nonce,
+		Signature:   packed,
+		SignatureParams: SignatureParams{
+			GasPrice:       "0",
+			Operation:      strconv.Itoa(int(tx.Operation)),
+			SafeTxnGas:     "0",
+			BaseGas:        "0",
+			GasToken:       ZeroAddress,
+			RefundReceiver: ZeroAddress,
+		},
+		Metadata: metadata,
+	}, nil
+}
+
+func normalizeHexData(data string) string {
+	data = strings.TrimSpace(data)
+	if data == "" {
+		return "0x"
+	}
+	if !strings.HasPrefix(data, "0x") {
+		return "0x" + data
+	}
+	return data
+}
+
+func defaultString(v, fallback string) string {
+	if strings.TrimSpace(v) == "" {
+		return fallback
+	}
+	return v
+}
@@ -0,0 +1,183 @@
+package relayer
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestAggregateSafeTransactions(t *testing.T) {
+	first := SafeTransaction{To: CollateralTokenAddress, Operation: OperationCall, Data: "0xdeadbeef", Value: "0"}
+	second := SafeTransaction{To: ConditionalTokensAddress, Operation: OperationCall, Data: "", Value: "5"}
+
+	single, err := AggregateSafeTransactions([]SafeTransaction{first})
+	if err != nil {
+		t.Fatalf("AggregateSafeTransactions: %v", err)
+	}
+	if single != first {
+		t.Errorf("single transaction = %+v, want it unchanged", single)
+	}
+
+	batch, err := AggregateSafeTransactions([]SafeTransaction{first, second})
+	if err != nil {
+		t.Fatalf("AggregateSafeTransactions: %v", err)
+	}
+	if batch.To != SafeMultisendAddress || batch.Operation != OperationDelegateCall || batch.Value != "0" {
+		t.Fatalf("unexpected batch %+v", batch)
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(multiSendABI))
+	if err != nil {
+		t.Fatalf("parse ABI: %v", err)
+	}
+	method := parsed.Methods["multiSend"]
+	data := common.FromHex(batch.Data)
+	if string(data[:4]) != string(method.ID) {
+		t.Fatalf("selector = %x, want %x", data[:4], method.ID)
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		t.Fatalf("unpack: %v", err)
+	}
+
+	word := func(n int64) []byte { return common.LeftPadBytes(big.NewInt(n).Bytes(), 32) }
+	var want []byte
+	want = append(want, 0)
+	want = append(want, common.HexToAddress(CollateralTokenAddress).Bytes()...)
+	want = append(want, word(0)...)
+	want = append(want, word(4)...)
+	want = append(want, 0xde, 0xad, 0xbe, 0xef)
+	want = append(want, 0)
+	want = append(want, common.HexToAddress(ConditionalTokensAddress).Bytes()...)
+	want = append(want, word(5)...)
+	want = append(want, word(0)...)
+	if got := args[0].([]byte); !bytes.Equal(got, want) {
+		t.Errorf("packed transactions = %x\nwant %x", got, want)
+	}
+
+	bad := []struct {
+		name string
+		txs  []SafeTransaction
+	}{
+		{"empty", nil},
+		{"bad address", []SafeTransaction{first, {To: "0x1234"}}},
+		{"bad data", []SafeTransaction{first, {To: CollateralTokenAddress, Data: "0xzz"}}},
+		{"bad value", []SafeTransaction{first, {To: CollateralTokenAddress, Value: "-1x"}}},
+	}
+	for _, tc := range bad {
+		if _, err := AggregateSafeTransactions(tc.txs); err == nil {
+			t.Errorf("%s: expected error", tc.name)
+		}
+	}
+}
+
+func TestSafeTxHashSignRoundTrip(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	signer := crypto.PubkeyToAddress(key.PublicKey).Hex()
+	safe := "0x000000000000000000000000000000000000dEaD"
+	tx := SafeTransaction{To: SafeMultisendAddress, Operation: OperationDelegateCall, Data: "0xdeadbeef", Value: "0"}
+
+	_, hash, err := BuildSafeTxTypedData(safe, tx, "3")
+	if err != nil {
+		t.Fatalf("BuildSafeTxTypedData: %v", err)
+	}
+
+	// Recompute the EIP-712 digest by hand from the Safe 1.3.0 type strings.
+	word := func(n int64) []byte { return common.LeftPadBytes(big.NewInt(n).Bytes(), 32) }
+	address := func(a string) []byte { return common.LeftPadBytes(common.HexToAddress(a).Bytes(), 32) }
+	domainType := crypto.Keccak256([]byte("EIP712Domain(uint256 chainId,address verifyingContract)"))
+	domain := crypto.Keccak256(domainType, word(PolygonChainID), address(safe))
+	safeTxType := crypto.Keccak256([]byte("SafeTx(address to,uint256 value,bytes data,uint8 operation,uint256 safeTxGas,uint256 baseGas,uint256 gasPrice,address gasToken,address refundReceiver,uint256 nonce)"))
+	structHash := crypto.Keccak256(
+		safeTxType,
+		address(tx.To),
+		word(0),
+		crypto.Keccak256([]byte{0xde, 0xad, 0xbe, 0xef}),
+		word(int64(OperationDelegateCall)),
+		word(0), word(0), word(0),
+		address(ZeroAddress), address(ZeroAddress),
+		word(3),
+	)
+	want := hexutil.Encode(crypto.Keccak256([]byte{0x19, 0x01}, domain, structHash))
+	if hash != want {
+		t.Fatalf("safe tx hash = %s, want %s", hash, want)
+	}
+
+	sig, err := crypto.Sign(accounts.TextHash(common.FromHex(hash)), key)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	sig[64] += 27
+	signature := hexutil.Encode(sig)
+
+	recovered, err := RecoverSafeTxSigner(hash, signature)
+	if err != nil {
+		t.Fatalf("RecoverSafeTxSigner: %v", err)
+	}
+	if !strings.EqualFold(recovered, signer) {
+		t.Fatalf("recovered %s, want %s", recovered, signer)
+	}
+
+	req, err := BuildSafeTransactionRequest(signer, safe, tx, "3", signature, "batch")
+	if err != nil {
+		t.Fatalf("BuildSafeTransactionRequest: %v", err)
+	}
+	packed := common.FromHex(req.Signature)
+	if packed[64] != sig[64]+4 {
+		t.Errorf("packed v = %d, want %d", packed[64], sig[64]+4)
+	}
+	if req.Type != TransactionTypeSafe || req.ProxyWallet != common.HexToAddress(safe).Hex() || req.SignatureParams.Operation != "1" {
+		t.Errorf("unexpected request %+v", req)
+	}
+
+	if _, _, err := BuildSafeTxTypedData(safe, tx, "not-a-nonce"); err == nil {
+		t.Error("expected error for invalid nonce")
+	}
+	if _, err := RecoverSafeTxSigner(hash, "0x1234"); err == nil {
+		t.Error("expected error for short signature")
+	}
+}
+
+func TestPackSafeSignature(t *testing.T) {
+	base := make([]byte, 65)
+	cases := []struct {
+		v     byte
+		want  byte
+		valid bool
+	}{
+		{0, 31, true},
+		{1, 32, true},
+		{27, 31, true},
+		{28, 32, true},
+		{31, 31, true},
+		{32, 32, true},
+		{29, 0, false},
+	}
+	for _, tc := range cases {
+		sig := append([]byte(nil), base...)
+		sig[64] = tc.v
+		got, err := PackSafeSignature(hexutil.Encode(sig))
+		if !tc.valid {
+			if err == nil {
+				t.Errorf("v=%d: expected error", tc.v)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("v=%d: %v", tc.v, err)
+		}
+		if v := common.FromHex(got)[64]; v != tc.want {
+			t.Errorf("v=%d: packed v = %d, want %d", tc.v, v, tc.want)
+		}
+	}
+}
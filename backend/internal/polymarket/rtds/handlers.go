@@ -8,7 +8,8 @@
  * - Handles the "Sept 2025" Price Change schema (breaking change support).
  * - Processes Orderbook Snapshots (`book`).
  * - Processes Trades (`last_trade_price`).
- * - Updates Redis with latest prices/velocity metrics.
+ * - Updates Redis with latest prices and bucketed activity metrics.
+ * - Hands trades and book snapshots to the HistoryRecorder when one is attached.
  *
  * @dependencies
  * - encoding/json
@@ -96,14 +97,17 @@ type LastTradeMessage struct {
 
 // MessageHandler processes incoming WS messages
 type MessageHandler struct {
-	DB    *gorm.DB
-	Redis *redis.Client
+	DB       *gorm.DB
+	Redis    *redis.Client
+	Activity *services.MarketActivityTracker
+	Recorder *HistoryRecorder // Optional; records trade / book history for backtests
 }
 
 func NewMessageHandler(db *gorm.DB, r *redis.Client) *MessageHandler {
 	return &MessageHandler{
-		DB:    db,
-		Redis: r,
+		DB:       db,
+		Redis:    r,
+		Activity: services.NewMarketActivityTracker(r),
 	}
 }
 
@@ -178,18 +182,9 @@ func (h *MessageHandler) HandleMessage(ctx context.Context, msg []byte) error {
 
 // handlePriceChange updates the "High Velocity" metrics and caches current prices
 func (h *MessageHandler) handlePriceChange(ctx context.Context, m *PriceChangeMessage) error {
-	// For velocity, we might want to count updates per minute per market.
-	// Use Redis HyperLogLog or simple counters.
-
-	// 1. Update Velocity Counter (Expires in 1 hour)
-	// Key: velocity:{market_id}:{minute_bucket}
-	// This allows us to calculate acceleration later
-
-	// For simplicity in MVP, just increment a score in a Sorted Set "market:velocity"
-	// Score = number of updates (proxy for activity)
-	err := h.Redis.ZIncrBy(ctx, "market:velocity", 1, m.Market).Err()
-	if err != nil {
-		log.Printf("Redis error updating velocity: %v", err)
+	// 1. Count the update in the minute-bucketed activity metrics (feeds trending + lanes)
+	if err := h.Activity.RecordPriceUpdate(ctx, m.Market); err != nil {
+		log.Printf("Redis error updating activity metrics: %v", err)
 	}
 
 	// 2. Cache latest prices for immediate frontend retrieval
@@ -215,7 +210,7 @@ func (h *MessageHandler) handlePriceChange(ctx context.Context, m *PriceChangeMe
 		// For now, we skip DB insert here to prioritize ingestion speed.
 	}
 
-	if _, err = pipe.Exec(ctx); err != nil {
+	if _, err := pipe.Exec(ctx); err != nil {
 		return err
 	}
 
@@ -225,6 +220,10 @@ func (h *MessageHandler) handlePriceChange(ctx context.Context, m *PriceChangeMe
 
 // handleBook processes the initial snapshot
 func (h *MessageHandler) handleBook(ctx context.Context, m *BookMessage) error {
+	if h.Recorder != nil {
+		h.Recorder.RecordBook(m)
+	}
+
 	// Store the full book snapshot in Redis if needed for the UI "Depth" view
 	// Key: book:{market_id}:{asset_id}
 	key := fmt.Sprintf("book:%s:%s", m.Market, m.AssetID)
@@ -246,12 +245,16 @@ func (h *MessageHandler) handleLastTrade(ctx context.Context, m *LastTradeMessag
 	size, _ := strconv.ParseFloat(m.Size, 64)
 	volume := price * size
 
-	pipe := h.Redis.Pipeline()
+	if h.Recorder != nil {
+		h.Recorder.RecordTrade(m)
+	}
 
-	// 2. Update Redis Volume Stats
-	// Increment 24h volume for the market
-	// Key: market:{id}:volume
-	pipe.IncrByFloat(ctx, fmt.Sprintf("market:%s:volume", m.Market), volume)
+	// 2. Count the trade and its notional in the rolling activity windows
+	if err := h.Activity.RecordTrade(ctx, m.Market, volume); err != nil {
+		log.Printf("Redis error updating trade activity: %v", err)
+	}
+
+	pipe := h.Redis.Pipeline()
 
 	// 3. Cache latest last trade price for UI fallback when spread > $0.10
 	key := fmt.Sprintf("price:%s:%s", m.Market, m.AssetID)
@@ -272,11 +275,15 @@ type priceUpdatePayload struct {
 	ConditionID        string   `json:"condition_id"`
 	AssetID            string   `json:"asset_id"`
 	Price              *float64 `json:"price,omitempty"`
+	Size               *float64 `json:"size,omitempty"` // Resting size at Price after the change
+	Side               string   `json:"side,omitempty"` // Book side of the changed level (BUY = bids)
 	BestBid            *float64 `json:"best_bid,omitempty"`
 	BestAsk            *float64 `json:"best_ask,omitempty"`
 	Timestamp          *string  `json:"timestamp,omitempty"`
 	LastTradePrice     *float64 `json:"last_trade_price,omitempty"`
 	LastTradeTimestamp *string  `json:"last_trade_timestamp,omitempty"`
+	LastTradeSize      *float64 `json:"last_trade_size,omitempty"`
+	LastTradeSide      string   `json:"last_trade_side,omitempty"` // Taker side
 }
 
 func (h *MessageHandler) publishPriceUpdates(ctx context.Context, m *PriceChangeMessage) {
@@ -284,6 +291,7 @@ func (h *MessageHandler) publishPriceUpdates(ctx context.Context, m *PriceChange
 
 	for _, change := range m.PriceChanges {
 		price := parseFloat(change.Price)
+		size := parseFloat(change.Size)
 		bestBid := parseFloat(change.BestBid)
 		bestAsk := parseFloat(change.BestAsk)
 		ts := timestamp
@@ -292,6 +300,8 @@ func (h *MessageHandler) publishPriceUpdates(ctx context.Context, m *PriceChange
 			ConditionID: m.Market,
 			AssetID:     change.AssetID,
 			Price:       &price,
+			Size:        &size,
+			Side:        change.Side,
 			BestBid:     &bestBid,
 			BestAsk:     &bestAsk,
 			Timestamp:   &ts,
@@ -310,12 +320,15 @@ func (h *MessageHandler) publishPriceUpdates(ctx context.Context, m *PriceChange
 
 func (h *MessageHandler) publishLastTradeUpdate(ctx context.Context, m *LastTradeMessage) {
 	price := parseFloat(m.Price)
+	size := parseFloat(m.Size)
 	ts := m.Timestamp
 	payload := priceUpdatePayload{
 		ConditionID:        m.Market,
 		AssetID:            m.AssetID,
 		LastTradePrice:     &price,
 		LastTradeTimestamp: &ts,
+		LastTradeSize:      &size,
+		LastTradeSide:      m.Side,
 	}
 
 	data, err := json.Marshal(payload)
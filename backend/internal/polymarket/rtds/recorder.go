@@ -0,0 +1,191 @@
+/**
+ * @description
+ * History recorder for RTDS market data.
+ * Buffers trade prints and sampled order book snapshots off the ingestion path and writes them to
+ * Postgres in batches, building the history the backtesting engine replays.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - backend/internal/models
+ *
+ * @notes
+ * - Record* never blocks: when the buffer is full the event is dropped and counted.
+ * - Book snapshots are kept at most once per token per bookSampleInterval.
+ */
+
+package rtds
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bankai-project/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+const (
+	recorderBufferSize    = 10000
+	recorderFlushInterval = 5 * time.Second
+	recorderBatchSize     = 500
+	bookSampleInterval    = time.Minute
+)
+
+// HistoryRecorder persists trades and book snapshots in batches
+type HistoryRecorder struct {
+	db     *gorm.DB
+	trades chan models.MarketTrade
+	books  chan models.BookSnapshot
+
+	mu       sync.Mutex
+	lastBook map[string]time.Time // token_id -> last sampled snapshot
+	dropped  int
+}
+
+// NewHistoryRecorder creates a new HistoryRecorder
+func NewHistoryRecorder(db *gorm.DB) *HistoryRecorder {
+	return &HistoryRecorder{
+		db:       db,
+		trades:   make(chan models.MarketTrade, recorderBufferSize),
+		books:    make(chan models.BookSnapshot, recorderBufferSize),
+		lastBook: make(map[string]time.Time),
+	}
+}
+
+// RecordTrade queues a last_trade_price event.
+func (r *HistoryRecorder) RecordTrade(m *LastTradeMessage) {
+	price := parseFloat(m.Price)
+	size := parseFloat(m.Size)
+	if price <= 0 || size <= 0 {
+		return
+	}
+	fee, _ := strconv.Atoi(m.FeeRateBps)
+
+	trade := models.MarketTrade{
+		MarketID:   m.Market,
+		TokenID:    m.AssetID,
+		Price:      price,
+		Size:       size,
+		Side:       m.Side,
+		FeeRateBps: fee,
+		TradedAt:   parseMillis(m.Timestamp),
+	}
+	select {
+	case r.trades <- trade:
+	default:
+		r.drop()
+	}
+}
+
+// RecordBook queues a book snapshot if the token has not been sampled recently.
+func (r *HistoryRecorder) RecordBook(m *BookMessage) {
+	capturedAt := parseMillis(m.Timestamp)
+
+	r.mu.Lock()
+	if last, ok := r.lastBook[m.AssetID]; ok && capturedAt.Sub(last) < bookSampleInterval {
+		r.mu.Unlock()
+		return
+	}
+	r.lastBook[m.AssetID] = capturedAt
+	r.mu.Unlock()
+
+	bids, err := json.Marshal(nonNilLevels(m.Bids))
+	if err != nil {
+		return
+	}
+	asks, err := json.Marshal(nonNilLevels(m.Asks))
+	if err != nil {
+		return
+	}
+
+	snapshot := models.BookSnapshot{
+		MarketID:   m.Market,
+		TokenID:    m.AssetID,
+		Bids:       string(bids),
+		Asks:       string(asks),
+		CapturedAt: capturedAt,
+	}
+	select {
+	case r.books <- snapshot:
+	default:
+		r.drop()
+	}
+}
+
+func (r *HistoryRecorder) drop() {
+	r.mu.Lock()
+	r.dropped++
+	r.mu.Unlock()
+}
+
+// Run flushes buffered history until ctx is cancelled, then flushes what is left.
+func (r *HistoryRecorder) Run(ctx context.Context) {
+	ticker := time.NewTicker(recorderFlushInterval)
+	defer ticker.Stop()
+
+	var trades []models.MarketTrade
+	var books []models.BookSnapshot
+	flush := func(flushCtx context.Context) {
+		if len(trades) > 0 {
+			if err := r.db.WithContext(flushCtx).CreateInBatches(trades, recorderBatchSize).Error; err != nil {
+				log.Printf("HistoryRecorder: failed to write %d trades: %v", len(trades), err)
+			}
+			trades = trades[:0]
+		}
+		if len(books) > 0 {
+			if err := r.db.WithContext(flushCtx).CreateInBatches(books, recorderBatchSize).Error; err != nil {
+				log.Printf("HistoryRecorder: failed to write %d book snapshots: %v", len(books), err)
+			}
+			books = books[:0]
+		}
+
+		r.mu.Lock()
+		dropped := r.dropped
+		r.dropped = 0
+		r.mu.Unlock()
+		if dropped > 0 {
+			log.Printf("HistoryRecorder: dropped %d events (buffer full)", dropped)
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			flush(shutdownCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			flush(ctx)
+		case trade := <-r.trades:
+			trades = append(trades, trade)
+			if len(trades) >= recorderBatchSize {
+				flush(ctx)
+			}
+		case book := <-r.books:
+			books = append(books, book)
+			if len(books) >= recorderBatchSize {
+				flush(ctx)
+			}
+		}
+	}
+}
+
+// parseMillis parses an RTDS millisecond timestamp, defaulting to now.
+func parseMillis(raw string) time.Time {
+	ms, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || ms <= 0 {
+		return time.Now().UTC()
+	}
+	return time.UnixMilli(ms).UTC()
+}
+
+func nonNilLevels(levels []OrderSummary) []OrderSummary {
+	if levels == nil {
+		return []OrderSummary{}
+	}
+	return levels
+}
@@ -0,0 +1,211 @@
+/**
+ * @description
+ * Field and function registry for the market screener.
+ * Maps expression identifiers onto models.Market columns and the live Redis price
+ * snapshot attached by the market service.
+ *
+ * @dependencies
+ * - backend/internal/models
+ *
+ * @notes
+ * - Fields marked Live read YesPrice/YesBestBid/YesBestAsk, so callers must attach realtime prices first.
+ * - `price` follows the terminal's display rule: midpoint unless the spread is wider than $0.10, then last trade.
+ */
+
+package screener
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bankai-project/backend/internal/models"
+)
+
+// FieldType describes the value type of a screener field.
+type FieldType string
+
+const (
+	FieldNumber FieldType = "number"
+	FieldString FieldType = "string"
+	FieldBool   FieldType = "bool"
+)
+
+// FieldInfo documents a field or function for clients building expressions.
+type FieldInfo struct {
+	Name        string    `json:"name"`
+	Type        FieldType `json:"type"`
+	Live        bool      `json:"live"`
+	Description string    `json:"description"`
+}
+
+type fieldDef struct {
+	info    FieldInfo
+	number  func(*models.Market) float64
+	text    func(*models.Market) string
+	boolean func(*models.Market) bool
+}
+
+type funcDef struct {
+	info  FieldInfo
+	arg   tokenKind
+	match func(m *models.Market, arg token, now time.Time) bool
+}
+
+const displaySpreadLimit = 0.10
+
+var fields = map[string]fieldDef{}
+
+var functions = map[string]funcDef{}
+
+func numberField(name, description string, live bool, fn func(*models.Market) float64) {
+	fields[name] = fieldDef{info: FieldInfo{Name: name, Type: FieldNumber, Live: live, Description: description}, number: fn}
+}
+
+func stringField(name, description string, fn func(*models.Market) string) {
+	fields[name] = fieldDef{info: FieldInfo{Name: name, Type: FieldString, Description: description}, text: fn}
+}
+
+func boolField(name, description string, fn func(*models.Market) bool) {
+	fields[name] = fieldDef{info: FieldInfo{Name: name, Type: FieldBool, Description: description}, boolean: fn}
+}
+
+func init() {
+	numberField("volume_24h", "24h traded volume (USDC)", false, func(m *models.Market) float64 { return m.Volume24h })
+	numberField("volume_1w", "7d traded volume (USDC)", false, func(m *models.Market) float64 { return m.Volume1Week })
+	numberField("volume_1m", "30d traded volume (USDC)", false, func(m *models.Market) float64 { return m.Volume1Month })
+	numberField("volume", "Lifetime traded volume (USDC)", false, func(m *models.Market) float64 { return m.VolumeAllTime })
+	numberField("liquidity", "Order book liquidity (USDC)", false, func(m *models.Market) float64 { return m.Liquidity })
+	numberField("competitive", "Gamma competitiveness score (0-1)", false, func(m *models.Market) float64 { return m.Competitive })
+	numberField("one_hour_change", "YES price change over 1h", false, func(m *models.Market) float64 { return m.OneHourPriceChange })
+	numberField("one_day_change", "YES price change over 24h", false, func(m *models.Market) float64 { return m.OneDayPriceChange })
+	numberField("one_week_change", "YES price change over 7d", false, func(m *models.Market) float64 { return m.OneWeekPriceChange })
+	numberField("rewards_min_size", "Minimum order size for liquidity rewards", false, func(m *models.Market) float64 { return m.RewardsMinSize })
+	numberField("rewards_max_spread", "Maximum spread (cents) for liquidity rewards", false, func(m *models.Market) float64 { return m.RewardsMaxSpread })
+	numberField("min_size", "Minimum order size", false, func(m *models.Market) float64 { return m.OrderMinSize })
+	numberField("tick_size", "Minimum price increment", false, func(m *models.Market) float64 { return m.OrderPriceMinTickSize })
+	numberField("price", "Live YES display price (midpoint, or last trade when spread > 0.10)", true, livePrice)
+	numberField("no_price", "Live NO display price", true, liveNoPrice)
+	numberField("bid", "Live YES best bid", true, func(m *models.Market) float64 {
+		if m.YesBestBid > 0 {
+			return m.YesBestBid
+		}
+		return m.BestBid
+	})
+	numberField("ask", "Live YES best ask", true, func(m *models.Market) float64 {
+		if m.YesBestAsk > 0 {
+			return m.YesBestAsk
+		}
+		return m.BestAsk
+	})
+	numberField("spread", "Live YES bid/ask spread", true, func(m *models.Market) float64 {
+		if m.YesBestBid > 0 && m.YesBestAsk > 0 && m.YesBestBid <= m.YesBestAsk {
+			return m.YesBestAsk - m.YesBestBid
+		}
+		return m.Spread
+	})
+	numberField("last_trade", "Live YES last trade price", true, func(m *models.Market) float64 {
+		if m.YesPrice > 0 {
+			return m.YesPrice
+		}
+		return m.LastTradePrice
+	})
+
+	stringField("category", "Market category", func(m *models.Market) string { return m.Category })
+	stringField("slug", "Market slug", func(m *models.Market) string { return m.Slug })
+	stringField("title", "Market question", func(m *models.Market) string { return m.Title })
+
+	boolField("neg_risk", "Part of a negative-risk event", func(m *models.Market) bool { return m.NegRisk })
+	boolField("fees_enabled", "Taker fees enabled", func(m *models.Market) bool { return m.FeesEnabled })
+	boolField("holding_rewards", "Holding rewards enabled", func(m *models.Market) bool { return m.HoldingRewardsEnabled })
+	boolField("accepting_orders", "Order book accepting orders", func(m *models.Market) bool { return m.AcceptingOrders })
+	boolField("featured", "Featured by Polymarket", func(m *models.Market) bool { return m.Featured })
+	boolField("new", "Flagged as new by Polymarket", func(m *models.Market) bool { return m.IsNew })
+
+	functions["ends_within"] = funcDef{
+		info: FieldInfo{Name: "ends_within(duration)", Type: FieldBool, Description: "End date falls between now and now + duration"},
+		arg:  tokenDuration,
+		match: func(m *models.Market, arg token, now time.Time) bool {
+			return m.EndDate != nil && m.EndDate.After(now) && !m.EndDate.After(now.Add(arg.duration))
+		},
+	}
+	functions["ends_after"] = funcDef{
+		info: FieldInfo{Name: "ends_after(duration)", Type: FieldBool, Description: "End date is later than now + duration"},
+		arg:  tokenDuration,
+		match: func(m *models.Market, arg token, now time.Time) bool {
+			return m.EndDate != nil && m.EndDate.After(now.Add(arg.duration))
+		},
+	}
+	functions["starts_within"] = funcDef{
+		info: FieldInfo{Name: "starts_within(duration)", Type: FieldBool, Description: "Event start time falls between now and now + duration"},
+		arg:  tokenDuration,
+		match: func(m *models.Market, arg token, now time.Time) bool {
+			return m.EventStartTime != nil && m.EventStartTime.After(now) && !m.EventStartTime.After(now.Add(arg.duration))
+		},
+	}
+	functions["created_within"] = funcDef{
+		info: FieldInfo{Name: "created_within(duration)", Type: FieldBool, Description: "Market was created in the last duration"},
+		arg:  tokenDuration,
+		match: func(m *models.Market, arg token, now time.Time) bool {
+			created := m.MarketCreatedAt
+			if created == nil {
+				created = m.StartDate
+			}
+			return created != nil && !created.Before(now.Add(-arg.duration))
+		},
+	}
+	functions["has_tag"] = funcDef{
+		info: FieldInfo{Name: "has_tag(\"slug\")", Type: FieldBool, Description: "Market carries the tag slug"},
+		arg:  tokenString,
+		match: func(m *models.Market, arg token, now time.Time) bool {
+			for _, tag := range m.Tags {
+				if strings.EqualFold(tag, arg.text) {
+					return true
+				}
+			}
+			return false
+		},
+	}
+	functions["title_contains"] = funcDef{
+		info: FieldInfo{Name: "title_contains(\"text\")", Type: FieldBool, Description: "Case-insensitive substring match on the question"},
+		arg:  tokenString,
+		match: func(m *models.Market, arg token, now time.Time) bool {
+			return strings.Contains(strings.ToLower(m.Title), strings.ToLower(arg.text))
+		},
+	}
+}
+
+// Fields lists the supported fields and functions, sorted by name.
+func Fields() []FieldInfo {
+	result := make([]FieldInfo, 0, len(fields)+len(functions))
+	for _, def := range fields {
+		result = append(result, def.info)
+	}
+	for _, def := range functions {
+		result = append(result, def.info)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+func livePrice(m *models.Market) float64 {
+	return displayPrice(m.YesBestBid, m.YesBestAsk, m.YesPrice, m.LastTradePrice)
+}
+
+func liveNoPrice(m *models.Market) float64 {
+	fallback := 0.0
+	if yes := livePrice(m); yes > 0 {
+		fallback = 1 - yes
+	}
+	return displayPrice(m.NoBestBid, m.NoBestAsk, m.NoPrice, fallback)
+}
+
+func displayPrice(bid, ask, last, fallback float64) float64 {
+	if bid > 0 && ask > 0 && bid <= ask && ask-bid <= displaySpreadLimit {
+		return (bid + ask) / 2
+	}
+	if last > 0 {
+		return last
+	}
+	return fallback
+}
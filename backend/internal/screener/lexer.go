@@ -0,0 +1,156 @@
+/**
+ * @description
+ * Tokenizer for the market screener expression language.
+ * Turns input such as `volume_24h > 50000 AND ends_within(7d)` into a flat token stream.
+ *
+ * @notes
+ * - Keywords (AND, OR, NOT, BETWEEN, IN, TRUE, FALSE) are lexed as identifiers and matched case-insensitively by the parser.
+ * - Duration literals are a number immediately followed by a unit: m (minutes), h, d or w.
+ */
+
+package screener
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenDuration
+	tokenString
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	kind     tokenKind
+	text     string
+	pos      int
+	number   float64
+	duration time.Duration
+}
+
+var durationUnits = map[byte]time.Duration{
+	'm': time.Minute,
+	'h': time.Hour,
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+}
+
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(input) {
+		ch := input[i]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r':
+			i++
+		case ch == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: i})
+			i++
+		case ch == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: i})
+			i++
+		case ch == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: i})
+			i++
+		case ch == '>' || ch == '<' || ch == '=' || ch == '!':
+			start := i
+			i++
+			if i < len(input) && input[i] == '=' {
+				i++
+			}
+			op := input[start:i]
+			if op == "!" {
+				return nil, &Error{Pos: start, Msg: "unexpected '!', use != or NOT"}
+			}
+			if op == "==" {
+				op = "="
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: op, pos: start})
+		case ch == '"' || ch == '\'':
+			start := i
+			quote := ch
+			i++
+			var sb strings.Builder
+			closed := false
+			for i < len(input) {
+				if input[i] == '\\' && i+1 < len(input) {
+					sb.WriteByte(input[i+1])
+					i += 2
+					continue
+				}
+				if input[i] == quote {
+					closed = true
+					i++
+					break
+				}
+				sb.WriteByte(input[i])
+				i++
+			}
+			if !closed {
+				return nil, &Error{Pos: start, Msg: "unterminated string literal"}
+			}
+			tokens = append(tokens, token{kind: tokenString, text: sb.String(), pos: start})
+		case isDigit(ch) || (ch == '.' && i+1 < len(input) && isDigit(input[i+1])) || (ch == '-' && i+1 < len(input) && (isDigit(input[i+1]) || input[i+1] == '.')):
+			start := i
+			i++
+			for i < len(input) && (isDigit(input[i]) || input[i] == '.') {
+				i++
+			}
+			text := input[start:i]
+			value, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, &Error{Pos: start, Msg: fmt.Sprintf("invalid number %q", text)}
+			}
+			if i < len(input) {
+				if unit, ok := durationUnits[input[i]]; ok && (i+1 == len(input) || !isIdentChar(input[i+1])) {
+					i++
+					tokens = append(tokens, token{
+						kind:     tokenDuration,
+						text:     input[start:i],
+						pos:      start,
+						duration: time.Duration(value * float64(unit)),
+					})
+					continue
+				}
+				if isIdentChar(input[i]) {
+					return nil, &Error{Pos: start, Msg: fmt.Sprintf("invalid literal %q, durations use m, h, d or w", input[start:i+1])}
+				}
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: text, pos: start, number: value})
+		case isIdentStart(ch):
+			start := i
+			for i < len(input) && isIdentChar(input[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: input[start:i], pos: start})
+		default:
+			return nil, &Error{Pos: i, Msg: fmt.Sprintf("unexpected character %q", ch)}
+		}
+	}
+	tokens = append(tokens, token{kind: tokenEOF, pos: len(input)})
+	return tokens, nil
+}
+
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+func isIdentStart(ch byte) bool {
+	return ch == '_' || unicode.IsLetter(rune(ch))
+}
+
+func isIdentChar(ch byte) bool {
+	return isIdentStart(ch) || isDigit(ch)
+}
@@ -0,0 +1,467 @@
+/**
+ * @description
+ * Parser and evaluator for the market screener expression language.
+ *
+ * Grammar (keywords are case-insensitive):
+ *   expr       := and_expr ( OR and_expr )*
+ *   and_expr   := unary ( AND unary )*
+ *   unary      := NOT unary | primary
+ *   primary    := '(' expr ')' | function | comparison | bool_field
+ *   function   := name '(' duration | string ')'
+ *   comparison := number_field op number
+ *               | number_field [NOT] BETWEEN number AND number
+ *               | string_field ( '=' | '!=' ) string
+ *               | string_field [NOT] IN '(' string ( ',' string )* ')'
+ *               | bool_field ( '=' | '!=' ) ( TRUE | FALSE )
+ *   op         := '>' | '>=' | '<' | '<=' | '=' | '!='
+ *
+ * Example: volume_24h > 50000 AND spread < 0.02 AND ends_within(7d) AND price between 0.1 and 0.9
+ *
+ * @notes
+ * - Everything is validated at parse time; evaluation never fails.
+ * - String comparisons are case-insensitive.
+ */
+
+package screener
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bankai-project/backend/internal/models"
+)
+
+// MaxExpressionLength bounds user input so saved screens stay cheap to evaluate.
+const MaxExpressionLength = 2000
+
+// Error reports a parse or validation failure at a byte offset in the expression.
+type Error struct {
+	Pos int    `json:"position"`
+	Msg string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("position %d: %s", e.Pos, e.Msg)
+}
+
+// Expression is a validated screener expression ready for evaluation.
+type Expression struct {
+	source string
+	root   node
+	live   bool
+}
+
+// Parse validates the input and compiles it into an Expression.
+func Parse(input string) (*Expression, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, &Error{Pos: 0, Msg: "expression is empty"}
+	}
+	if len(input) > MaxExpressionLength {
+		return nil, &Error{Pos: MaxExpressionLength, Msg: fmt.Sprintf("expression exceeds %d characters", MaxExpressionLength)}
+	}
+
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, &Error{Pos: tok.pos, Msg: fmt.Sprintf("unexpected %q", tok.text)}
+	}
+
+	return &Expression{source: input, root: root, live: p.live}, nil
+}
+
+// String returns the normalised source text.
+func (e *Expression) String() string {
+	return e.source
+}
+
+// UsesLivePrices reports whether evaluation needs realtime prices attached to the markets.
+func (e *Expression) UsesLivePrices() bool {
+	return e.live
+}
+
+// Match evaluates the expression against a market at the given time.
+func (e *Expression) Match(m *models.Market, now time.Time) bool {
+	return e.root.eval(m, now)
+}
+
+type node interface {
+	eval(m *models.Market, now time.Time) bool
+}
+
+type andNode struct{ left, right node }
+
+func (n andNode) eval(m *models.Market, now time.Time) bool {
+	return n.left.eval(m, now) && n.right.eval(m, now)
+}
+
+type orNode struct{ left, right node }
+
+func (n orNode) eval(m *models.Market, now time.Time) bool {
+	return n.left.eval(m, now) || n.right.eval(m, now)
+}
+
+type notNode struct{ inner node }
+
+func (n notNode) eval(m *models.Market, now time.Time) bool {
+	return !n.inner.eval(m, now)
+}
+
+type numberCompareNode struct {
+	field func(*models.Market) float64
+	op    string
+	value float64
+}
+
+func (n numberCompareNode) eval(m *models.Market, now time.Time) bool {
+	v := n.field(m)
+	switch n.op {
+	case ">":
+		return v > n.value
+	case ">=":
+		return v >= n.value
+	case "<":
+		return v < n.value
+	case "<=":
+		return v <= n.value
+	case "=":
+		return v == n.value
+	default:
+		return v != n.value
+	}
+}
+
+type betweenNode struct {
+	field  func(*models.Market) float64
+	lo, hi float64
+}
+
+func (n betweenNode) eval(m *models.Market, now time.Time) bool {
+	v := n.field(m)
+	return v >= n.lo && v <= n.hi
+}
+
+type stringInNode struct {
+	field  func(*models.Market) string
+	values []string
+}
+
+func (n stringInNode) eval(m *models.Market, now time.Time) bool {
+	v := n.field(m)
+	for _, candidate := range n.values {
+		if strings.EqualFold(v, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+type boolNode struct {
+	field func(*models.Market) bool
+	want  bool
+}
+
+func (n boolNode) eval(m *models.Market, now time.Time) bool {
+	return n.field(m) == n.want
+}
+
+type callNode struct {
+	fn  funcDef
+	arg token
+}
+
+func (n callNode) eval(m *models.Market, now time.Time) bool {
+	return n.fn.match(m, n.arg, now)
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	live   bool
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) isKeyword(word string) bool {
+	tok := p.peek()
+	return tok.kind == tokenIdent && strings.EqualFold(tok.text, word)
+}
+
+func (p *parser) expectKeyword(word string) error {
+	if !p.isKeyword(word) {
+		tok := p.peek()
+		return &Error{Pos: tok.pos, Msg: fmt.Sprintf("expected %s, found %s", word, describe(tok))}
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) expect(kind tokenKind, what string) (token, error) {
+	tok := p.peek()
+	if tok.kind != kind {
+		return tok, &Error{Pos: tok.pos, Msg: fmt.Sprintf("expected %s, found %s", what, describe(tok))}
+	}
+	return p.next(), nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("and") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.isKeyword("not") {
+		p.next()
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{inner: inner}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.peek()
+	switch tok.kind {
+	case tokenLParen:
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenRParen, "')'"); err != nil {
+			return nil, err
+		}
+		return inner, nil
+	case tokenIdent:
+		p.next()
+		name := strings.ToLower(tok.text)
+		if p.peek().kind == tokenLParen {
+			return p.parseCall(tok, name)
+		}
+		def, ok := fields[name]
+		if !ok {
+			return nil, &Error{Pos: tok.pos, Msg: fmt.Sprintf("unknown field %q", tok.text)}
+		}
+		if def.info.Live {
+			p.live = true
+		}
+		switch def.info.Type {
+		case FieldNumber:
+			return p.parseNumberComparison(tok, def)
+		case FieldString:
+			return p.parseStringComparison(tok, def)
+		default:
+			return p.parseBoolField(def)
+		}
+	default:
+		return nil, &Error{Pos: tok.pos, Msg: fmt.Sprintf("expected a field, function or '(', found %s", describe(tok))}
+	}
+}
+
+func (p *parser) parseCall(nameTok token, name string) (node, error) {
+	fn, ok := functions[name]
+	if !ok {
+		return nil, &Error{Pos: nameTok.pos, Msg: fmt.Sprintf("unknown function %q", nameTok.text)}
+	}
+	p.next() // (
+
+	what := "a duration such as 7d"
+	if fn.arg == tokenString {
+		what = "a quoted string"
+	}
+	arg, err := p.expect(fn.arg, what)
+	if err != nil {
+		return nil, err
+	}
+	if fn.arg == tokenDuration && arg.duration <= 0 {
+		return nil, &Error{Pos: arg.pos, Msg: "duration must be positive"}
+	}
+	if _, err := p.expect(tokenRParen, "')'"); err != nil {
+		return nil, err
+	}
+	return callNode{fn: fn, arg: arg}, nil
+}
+
+func (p *parser) parseNumberComparison(fieldTok token, def fieldDef) (node, error) {
+	negate := false
+	if p.isKeyword("not") {
+		p.next()
+		negate = true
+		if !p.isKeyword("between") {
+			tok := p.peek()
+			return nil, &Error{Pos: tok.pos, Msg: fmt.Sprintf("expected BETWEEN after NOT, found %s", describe(tok))}
+		}
+	}
+
+	if p.isKeyword("between") {
+		p.next()
+		lo, err := p.expect(tokenNumber, "a number")
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectKeyword("and"); err != nil {
+			return nil, err
+		}
+		hi, err := p.expect(tokenNumber, "a number")
+		if err != nil {
+			return nil, err
+		}
+		if lo.number > hi.number {
+			return nil, &Error{Pos: lo.pos, Msg: fmt.Sprintf("BETWEEN lower bound %s exceeds upper bound %s", lo.text, hi.text)}
+		}
+		var n node = betweenNode{field: def.number, lo: lo.number, hi: hi.number}
+		if negate {
+			n = notNode{inner: n}
+		}
+		return n, nil
+	}
+
+	op, err := p.expect(tokenOperator, fmt.Sprintf("a comparison after %q", fieldTok.text))
+	if err != nil {
+		return nil, err
+	}
+	value, err := p.expect(tokenNumber, "a number")
+	if err != nil {
+		return nil, err
+	}
+	return numberCompareNode{field: def.number, op: op.text, value: value.number}, nil
+}
+
+func (p *parser) parseStringComparison(fieldTok token, def fieldDef) (node, error) {
+	negate := false
+	if p.isKeyword("not") {
+		p.next()
+		negate = true
+		if !p.isKeyword("in") {
+			tok := p.peek()
+			return nil, &Error{Pos: tok.pos, Msg: fmt.Sprintf("expected IN after NOT, found %s", describe(tok))}
+		}
+	}
+
+	if p.isKeyword("in") {
+		p.next()
+		if _, err := p.expect(tokenLParen, "'('"); err != nil {
+			return nil, err
+		}
+		var values []string
+		for {
+			value, err := p.expect(tokenString, "a quoted string")
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value.text)
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.next()
+		}
+		if _, err := p.expect(tokenRParen, "')'"); err != nil {
+			return nil, err
+		}
+		var n node = stringInNode{field: def.text, values: values}
+		if negate {
+			n = notNode{inner: n}
+		}
+		return n, nil
+	}
+
+	op, err := p.expect(tokenOperator, fmt.Sprintf("= or != after %q", fieldTok.text))
+	if err != nil {
+		return nil, err
+	}
+	if op.text != "=" && op.text != "!=" {
+		return nil, &Error{Pos: op.pos, Msg: fmt.Sprintf("operator %s is not supported for text field %q", op.text, fieldTok.text)}
+	}
+	value, err := p.expect(tokenString, "a quoted string")
+	if err != nil {
+		return nil, err
+	}
+	var n node = stringInNode{field: def.text, values: []string{value.text}}
+	if op.text == "!=" {
+		n = notNode{inner: n}
+	}
+	return n, nil
+}
+
+func (p *parser) parseBoolField(def fieldDef) (node, error) {
+	tok := p.peek()
+	if tok.kind != tokenOperator {
+		return boolNode{field: def.boolean, want: true}, nil
+	}
+	if tok.text != "=" && tok.text != "!=" {
+		return nil, &Error{Pos: tok.pos, Msg: fmt.Sprintf("operator %s is not supported for boolean fields", tok.text)}
+	}
+	p.next()
+
+	var want bool
+	switch {
+	case p.isKeyword("true"):
+		want = true
+	case p.isKeyword("false"):
+		want = false
+	default:
+		next := p.peek()
+		return nil, &Error{Pos: next.pos, Msg: fmt.Sprintf("expected true or false, found %s", describe(next))}
+	}
+	p.next()
+
+	if tok.text == "!=" {
+		want = !want
+	}
+	return boolNode{field: def.boolean, want: want}, nil
+}
+
+func describe(tok token) string {
+	if tok.kind == tokenEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q", tok.text)
+}
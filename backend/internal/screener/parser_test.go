@@ -0,0 +1,92 @@
+package screener
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bankai-project/backend/internal/models"
+)
+
+func TestParseAndMatch(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	endSoon := now.Add(3 * 24 * time.Hour)
+	endLate := now.Add(30 * 24 * time.Hour)
+
+	market := models.Market{
+		Title:      "Will BTC close above $100k?",
+		Category:   "crypto",
+		Tags:       models.StringArray{"bitcoin", "crypto"},
+		Volume24h:  75000,
+		Liquidity:  20000,
+		YesBestBid: 0.41,
+		YesBestAsk: 0.43,
+		EndDate:    &endSoon,
+		NegRisk:    true,
+	}
+
+	cases := []struct {
+		expr      string
+		want      bool
+		wantsLive bool
+	}{
+		{"volume_24h > 50000 AND spread < 0.05 AND ends_within(7d) AND price between 0.1 and 0.9", true, true},
+		{"volume_24h > 100000", false, false},
+		{"volume_24h > 100000 OR has_tag('bitcoin')", true, false},
+		{"NOT neg_risk", false, false},
+		{"neg_risk = true and category in ('politics', 'Crypto')", true, false},
+		{"category != \"crypto\"", false, false},
+		{"price not between 0.5 and 1", true, true},
+		{"(liquidity >= 20000 or volume_24h < 10) and title_contains(\"btc\")", true, false},
+		{"ends_after(7d)", false, false},
+	}
+
+	for _, tc := range cases {
+		expr, err := Parse(tc.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", tc.expr, err)
+		}
+		if got := expr.Match(&market, now); got != tc.want {
+			t.Errorf("Match(%q) = %v, want %v", tc.expr, got, tc.want)
+		}
+		if expr.UsesLivePrices() != tc.wantsLive {
+			t.Errorf("UsesLivePrices(%q) = %v, want %v", tc.expr, expr.UsesLivePrices(), tc.wantsLive)
+		}
+	}
+
+	market.EndDate = &endLate
+	expr, _ := Parse("ends_within(7d)")
+	if expr.Match(&market, now) {
+		t.Errorf("ends_within(7d) matched a market ending in 30 days")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []struct {
+		expr string
+		pos  int
+	}{
+		{"", 0},
+		{"volume_24 > 5", 0},
+		{"volume_24h >", 12},
+		{"volume_24h > 5 AND", 18},
+		{"category > 'x'", 9},
+		{"ends_within(7)", 12},
+		{"ends_within(7y)", 12},
+		{"price between 0.9 and 0.1", 14},
+		{"(volume_24h > 5", 15},
+		{"liquidity > 5 spread < 1", 14},
+		{"title_contains('oops)", 15},
+	}
+
+	for _, tc := range cases {
+		_, err := Parse(tc.expr)
+		var parseErr *Error
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("Parse(%q) error = %v, want *Error", tc.expr, err)
+		}
+		if parseErr.Pos != tc.pos {
+			t.Errorf("Parse(%q) error position = %d (%s), want %d", tc.expr, parseErr.Pos, parseErr.Msg, tc.pos)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+/**
+ * @description
+ * Symmetric encryption for secrets stored in Postgres.
+ * Wraps AES-256-GCM with a versioned, base64 text encoding so ciphertexts fit in TEXT columns.
+ *
+ * @dependencies
+ * - crypto/aes, crypto/cipher
+ *
+ * @notes
+ * - Callers pass associated data (typically the owning row ID) so a ciphertext cannot be replayed onto another row.
+ * - The key comes from BANKAI_ENCRYPTION_KEY; rotating it makes existing ciphertexts unreadable.
+ */
+
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const boxVersionPrefix = "v1:"
+
+var (
+	ErrNoKey             = errors.New("encryption key is not configured")
+	ErrInvalidCiphertext = errors.New("invalid ciphertext")
+)
+
+// Box encrypts and decrypts small payloads with a single AES-256-GCM key
+type Box struct {
+	aead cipher.AEAD
+}
+
+// NewBox creates a new Box from a 32-byte key encoded as hex or base64.
+func NewBox(encodedKey string) (*Box, error) {
+	encodedKey = strings.TrimSpace(encodedKey)
+	if encodedKey == "" {
+		return nil, ErrNoKey
+	}
+
+	key, err := decodeKey(encodedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init gcm: %w", err)
+	}
+
+	return &Box{aead: aead}, nil
+}
+
+// Seal encrypts plaintext bound to the associated data.
+func (b *Box) Seal(plaintext, associated []byte) (string, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := b.aead.Seal(nonce, nonce, plaintext, associated)
+	return boxVersionPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts a value produced by Seal with the same associated data.
+func (b *Box) Open(ciphertext string, associated []byte) ([]byte, error) {
+	if !strings.HasPrefix(ciphertext, boxVersionPrefix) {
+		return nil, ErrInvalidCiphertext
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(ciphertext, boxVersionPrefix))
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+	if len(raw) < b.aead.NonceSize() {
+		return nil, ErrInvalidCiphertext
+	}
+
+	nonce, sealed := raw[:b.aead.NonceSize()], raw[b.aead.NonceSize():]
+	plaintext, err := b.aead.Open(nil, nonce, sealed, associated)
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+	return plaintext, nil
+}
+
+func decodeKey(encoded string) ([]byte, error) {
+	if key, err := hex.DecodeString(strings.TrimPrefix(encoded, "0x")); err == nil && len(key) == 32 {
+		return key, nil
+	}
+	if key, err := base64.StdEncoding.DecodeString(encoded); err == nil && len(key) == 32 {
+		return key, nil
+	}
+	if key, err := base64.RawURLEncoding.DecodeString(encoded); err == nil && len(key) == 32 {
+		return key, nil
+	}
+	return nil, errors.New("encryption key must be 32 bytes encoded as hex or base64")
+}
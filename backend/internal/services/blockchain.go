@@ -1,12 +1,23 @@
 /**
  * @description
  * Blockchain Service for interacting with Polygon network.
- * Handles balance checks for USDC and other ERC20 tokens.
+ * Handles balance checks for USDC and other ERC20 tokens, conditional token (ERC1155) balances, and reads
+ * the ERC20 allowances and ERC1155 approvals a vault needs before it can trade.
  *
  * @dependencies
  * - github.com/ethereum/go-ethereum
  * - backend/internal/config
  * - backend/internal/logger
+ * - backend/internal/polymarket/relayer
+ *
+ * @notes
+ * - Trading readiness is read live (no cache) so the UI sees approvals as soon as they are mined.
+ * - An ERC20 allowance only counts as granted above readinessMinAllowance, so a partly spent or exact-amount
+ *   approval granted outside the app does not make a vault ready to trade.
+ * - Conditional token balances share the USDC balance cache (keyed ctf:{vault}:{tokenId}), including its
+ *   stale fallback and per-key attempt cooldown. balanceOfBatch is chunked to ctfBalanceBatchSize IDs.
+ * - The cache is per process: the worker's deposit watcher publishes vault addresses on WalletBalanceChannel
+ *   and each API instance drops its cached balance via WatchBalanceInvalidations.
  */
 
 package services
@@ -20,10 +31,14 @@ import (
 	"time"
 
 	"github.com/bankai-project/backend/internal/config"
+	"github.com/bankai-project/backend/internal/polymarket/relayer"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/redis/go-redis/v9"
 )
 
 const (
@@ -39,11 +54,32 @@ const (
 	balanceCacheTTL        = 30 * time.Second
 	balanceStaleFallback   = 5 * time.Minute
 	balanceAttemptCooldown = 15 * time.Second
+
+	readinessCallTimeout = 10 * time.Second
+	logFilterTimeout     = 30 * time.Second
+
+	// WalletBalanceChannel carries vault addresses whose cached balances are stale
+	WalletBalanceChannel = "wallet:balance_invalidated"
+	ctfBalanceBatchSize  = 100
 )
 
+// erc20TransferTopic is keccak256("Transfer(address,address,uint256)")
+var erc20TransferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// readinessMinAllowance is 1B USDC.e in base units
+var readinessMinAllowance = new(big.Int).Exp(big.NewInt(10), big.NewInt(15), nil)
+
 // ERC20 ABI for balanceOf function
 const erc20BalanceOfABI = `[{"constant":true,"inputs":[{"name":"_owner","type":"address"}],"name":"balanceOf","outputs":[{"name":"balance","type":"uint256"}],"type":"function"}]`
 
+// ERC20 allowance and ERC1155 isApprovedForAll ABIs
+const erc20AllowanceABI = `[{"constant":true,"inputs":[{"name":"_owner","type":"address"},{"name":"_spender","type":"address"}],"name":"allowance","outputs":[{"name":"","type":"uint256"}],"type":"function"}]`
+
+const erc1155IsApprovedForAllABI = `[{"inputs":[{"name":"account","type":"address"},{"name":"operator","type":"address"}],"name":"isApprovedForAll","outputs":[{"name":"","type":"bool"}],"stateMutability":"view","type":"function"}]`
+
+// ERC1155 balanceOfBatch ABI
+const erc1155BalanceOfBatchABI = `[{"inputs":[{"name":"accounts","type":"address[]"},{"name":"ids","type":"uint256[]"}],"name":"balanceOfBatch","outputs":[{"name":"","type":"uint256[]"}],"stateMutability":"view","type":"function"}]`
+
 type BlockchainService struct {
 	client       *ethclient.Client
 	usdcAddress  common.Address
@@ -222,6 +258,74 @@ func (s *BlockchainService) markBalanceError(key string) {
 	s.balanceCache[key] = entry
 }
 
+// InvalidateBalance drops the cached USDC balance for an address so the next read hits the chain.
+func (s *BlockchainService) InvalidateBalance(address string) {
+	addr := common.HexToAddress(address)
+	if addr == (common.Address{}) {
+		return
+	}
+
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	delete(s.balanceCache, strings.ToLower(addr.Hex()))
+}
+
+// WatchBalanceInvalidations drops cached balances for addresses published on WalletBalanceChannel until ctx ends.
+func (s *BlockchainService) WatchBalanceInvalidations(ctx context.Context, rdb *redis.Client) {
+	sub := rdb.Subscribe(ctx, WalletBalanceChannel)
+	defer sub.Close()
+
+	ch := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			s.InvalidateBalance(msg.Payload)
+		}
+	}
+}
+
+// LatestBlockNumber returns the current chain head.
+func (s *BlockchainService) LatestBlockNumber(ctx context.Context) (uint64, error) {
+	ctx, cancel := context.WithTimeout(ctx, readinessCallTimeout)
+	defer cancel()
+
+	block, err := s.client.BlockNumber(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch block number: %w", err)
+	}
+	return block, nil
+}
+
+// FilterTransferLogs returns ERC20 Transfer logs of tokens sent to any of recipients in [fromBlock, toBlock].
+func (s *BlockchainService) FilterTransferLogs(ctx context.Context, tokens, recipients []common.Address, fromBlock, toBlock uint64) ([]types.Log, error) {
+	if len(tokens) == 0 || len(recipients) == 0 {
+		return nil, nil
+	}
+	toTopics := make([]common.Hash, len(recipients))
+	for i, r := range recipients {
+		toTopics[i] = common.BytesToHash(r.Bytes())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, logFilterTimeout)
+	defer cancel()
+
+	logs, err := s.client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+		Addresses: tokens,
+		Topics:    [][]common.Hash{{erc20TransferTopic}, nil, toTopics},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter transfer logs %d-%d: %w", fromBlock, toBlock, err)
+	}
+	return logs, nil
+}
+
 // FormatUSDCBalance formats a USDC balance (6 decimals) to a human-readable string
 func (s *BlockchainService) FormatUSDCBalance(balance *big.Int) string {
 	if balance == nil {
@@ -247,6 +351,224 @@ func (s *BlockchainService) FormatUSDCBalance(balance *big.Int) string {
 	return fmt.Sprintf("%s.%s", quotient.String(), remainderStr)
 }
 
+// GetConditionalTokenBalances returns the vault's CTF balance (6-decimal base units) for each token ID.
+// Cached balances are served while fresh; the rest are read with balanceOfBatch.
+func (s *BlockchainService) GetConditionalTokenBalances(ctx context.Context, vault string, tokenIDs []string) (map[string]*big.Int, error) {
+	if !common.IsHexAddress(vault) {
+		return nil, fmt.Errorf("invalid address: %s", vault)
+	}
+	owner := common.HexToAddress(vault)
+	ownerKey := strings.ToLower(owner.Hex())
+
+	balances := make(map[string]*big.Int, len(tokenIDs))
+	pending := make([]string, 0, len(tokenIDs))
+	ids := make([]*big.Int, 0, len(tokenIDs))
+	for _, tokenID := range tokenIDs {
+		tokenID = strings.TrimSpace(tokenID)
+		if _, done := balances[tokenID]; done || tokenID == "" {
+			continue
+		}
+		id, ok := new(big.Int).SetString(tokenID, 10)
+		if !ok || id.Sign() < 0 {
+			return nil, fmt.Errorf("invalid token id: %s", tokenID)
+		}
+
+		key := ctfBalanceCacheKey(ownerKey, tokenID)
+		if cached := s.getCachedBalance(key, false); cached != nil {
+			balances[tokenID] = cached
+			continue
+		}
+		if s.shouldBackoffBalance(key) {
+			if cached := s.getCachedBalance(key, true); cached != nil {
+				balances[tokenID] = cached
+				continue
+			}
+		}
+		balances[tokenID] = nil
+		pending = append(pending, tokenID)
+		ids = append(ids, id)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readinessCallTimeout)
+	defer cancel()
+
+	ctfAddress := common.HexToAddress(relayer.ConditionalTokensAddress)
+	for start := 0; start < len(pending); start += ctfBalanceBatchSize {
+		end := start + ctfBalanceBatchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		accounts := make([]common.Address, end-start)
+		for i := range accounts {
+			accounts[i] = owner
+		}
+		for _, tokenID := range pending[start:end] {
+			s.markBalanceAttempt(ctfBalanceCacheKey(ownerKey, tokenID))
+		}
+
+		results, err := s.call(ctx, ctfAddress, erc1155BalanceOfBatchABI, "balanceOfBatch", accounts, ids[start:end])
+		var values []*big.Int
+		if err == nil {
+			var ok bool
+			values, ok = results[0].([]*big.Int)
+			if !ok || len(values) != end-start {
+				err = fmt.Errorf("failed to decode balanceOfBatch result")
+			}
+		}
+		if err != nil {
+			for _, tokenID := range pending[start:end] {
+				key := ctfBalanceCacheKey(ownerKey, tokenID)
+				s.markBalanceError(key)
+				cached := s.getCachedBalance(key, true)
+				if cached == nil {
+					return nil, err
+				}
+				balances[tokenID] = cached
+			}
+			continue
+		}
+
+		for i, tokenID := range pending[start:end] {
+			s.setCachedBalance(ctfBalanceCacheKey(ownerKey, tokenID), values[i])
+			balances[tokenID] = new(big.Int).Set(values[i])
+		}
+	}
+	return balances, nil
+}
+
+func ctfBalanceCacheKey(owner, tokenID string) string {
+	return fmt.Sprintf("ctf:%s:%s", owner, tokenID)
+}
+
+// ApprovalStatus is the on-chain state of one required trading approval
+type ApprovalStatus struct {
+	relayer.TokenApproval
+	Approved  bool   `json:"approved"`
+	Allowance string `json:"allowance,omitempty"` // ERC20 only, base units
+}
+
+// TradingReadiness summarises whether a vault can place its first order
+type TradingReadiness struct {
+	VaultAddress               string                  `json:"vaultAddress"`
+	Deployed                   bool                    `json:"deployed"`
+	CollateralBalance          string                  `json:"collateralBalance"` // USDC.e base units
+	CollateralBalanceFormatted string                  `json:"collateralBalanceFormatted"`
+	Approvals                  []ApprovalStatus        `json:"approvals"`
+	Missing                    []relayer.TokenApproval `json:"missing"`
+	Ready                      bool                    `json:"ready"`
+	RelaySupported             bool                    `json:"relaySupported"` // Approvals, split, merge and redeem can be relayed
+	RelayUnsupportedReason     string                  `json:"relayUnsupportedReason,omitempty"`
+}
+
+// GetTradingReadiness reads deployment, USDC.e collateral balance and every required approval for a vault.
+func (s *BlockchainService) GetTradingReadiness(ctx context.Context, vault string) (*TradingReadiness, error) {
+	if !common.IsHexAddress(vault) {
+		return nil, fmt.Errorf("invalid address: %s", vault)
+	}
+	owner := common.HexToAddress(vault)
+
+	ctx, cancel := context.WithTimeout(ctx, readinessCallTimeout)
+	defer cancel()
+
+	code, err := s.client.CodeAt(ctx, owner, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vault code: %w", err)
+	}
+
+	readiness := &TradingReadiness{
+		VaultAddress: owner.Hex(),
+		Deployed:     len(code) > 0,
+		Approvals:    []ApprovalStatus{},
+		Missing:      []relayer.TokenApproval{},
+	}
+
+	balance, err := s.callUint256(ctx, common.HexToAddress(relayer.CollateralTokenAddress), erc20BalanceOfABI, "balanceOf", owner)
+	if err != nil {
+		return nil, err
+	}
+	readiness.CollateralBalance = balance.String()
+	readiness.CollateralBalanceFormatted = s.FormatUSDCBalance(balance)
+
+	for _, approval := range relayer.RequiredTradingApprovals() {
+		status, err := s.approvalStatus(ctx, owner, approval)
+		if err != nil {
+			return nil, err
+		}
+		readiness.Approvals = append(readiness.Approvals, status)
+		if !status.Approved {
+			readiness.Missing = append(readiness.Missing, approval)
+		}
+	}
+
+	readiness.Ready = readiness.Deployed && len(readiness.Missing) == 0 && balance.Sign() > 0
+	return readiness, nil
+}
+
+func (s *BlockchainService) approvalStatus(ctx context.Context, owner common.Address, approval relayer.TokenApproval) (ApprovalStatus, error) {
+	status := ApprovalStatus{TokenApproval: approval}
+	token := common.HexToAddress(approval.Token)
+	spender := common.HexToAddress(approval.Spender)
+
+	switch approval.Kind {
+	case relayer.ApprovalKindERC20:
+		allowance, err := s.callUint256(ctx, token, erc20AllowanceABI, "allowance", owner, spender)
+		if err != nil {
+			return status, err
+		}
+		status.Allowance = allowance.String()
+		status.Approved = allowance.Cmp(readinessMinAllowance) >= 0
+	case relayer.ApprovalKindERC1155:
+		results, err := s.call(ctx, token, erc1155IsApprovedForAllABI, "isApprovedForAll", owner, spender)
+		if err != nil {
+			return status, err
+		}
+		approved, ok := results[0].(bool)
+		if !ok {
+			return status, fmt.Errorf("failed to decode isApprovedForAll result")
+		}
+		status.Approved = approved
+	default:
+		return status, fmt.Errorf("unknown approval kind: %s", approval.Kind)
+	}
+	return status, nil
+}
+
+func (s *BlockchainService) callUint256(ctx context.Context, contract common.Address, abiJSON, method string, args ...interface{}) (*big.Int, error) {
+	results, err := s.call(ctx, contract, abiJSON, method, args...)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := results[0].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("failed to decode %s result as *big.Int", method)
+	}
+	return value, nil
+}
+
+// call packs a read-only contract call, executes it at the latest block and unpacks the outputs.
+func (s *BlockchainService) call(ctx context.Context, contract common.Address, abiJSON, method string, args ...interface{}) ([]interface{}, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s ABI: %w", method, err)
+	}
+	data, err := parsedABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s call: %w", method, err)
+	}
+	result, err := s.client.CallContract(ctx, ethereum.CallMsg{To: &contract, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s on %s: %w", method, contract.Hex(), err)
+	}
+	results, err := parsedABI.Unpack(method, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack %s result: %w", method, err)
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("no results returned from %s call", method)
+	}
+	return results, nil
+}
+
 // Close closes the Ethereum client connection
 func (s *BlockchainService) Close() {
 	if s.client != nil {
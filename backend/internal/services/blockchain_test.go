@@ -0,0 +1,319 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bankai-project/backend/internal/polymarket/relayer"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// fakeChain answers the eth_getCode and eth_call requests BlockchainService makes.
+type fakeChain struct {
+	code       []byte
+	collateral *big.Int
+	allowances map[common.Address]*big.Int // by spender
+	approved   map[common.Address]bool     // isApprovedForAll by operator
+
+	ctf         map[string]*big.Int // conditional token balances by token ID
+	ctfOwner    common.Address
+	batches     []int // size of each balanceOfBatch call
+	failBatch   bool
+	shortResult bool
+}
+
+type fakeCallArgs struct {
+	To    *common.Address `json:"to"`
+	Input hexutil.Bytes   `json:"input"`
+}
+
+// fakeEthAPI is registered as the "eth" namespace of an in-process RPC server.
+type fakeEthAPI struct {
+	chain *fakeChain
+	abis  []abi.ABI
+}
+
+func (api *fakeEthAPI) GetCode(addr common.Address, block string) hexutil.Bytes {
+	return api.chain.code
+}
+
+func (api *fakeEthAPI) Call(args fakeCallArgs, block string) (hexutil.Bytes, error) {
+	if len(args.Input) < 4 {
+		return nil, fmt.Errorf("missing call data")
+	}
+	for _, parsed := range api.abis {
+		method, err := parsed.MethodById(args.Input[:4])
+		if err != nil {
+			continue
+		}
+		in, err := method.Inputs.Unpack(args.Input[4:])
+		if err != nil {
+			return nil, err
+		}
+		out, err := api.chain.answer(method.Name, in)
+		if err != nil {
+			return nil, err
+		}
+		return method.Outputs.Pack(out)
+	}
+	return nil, fmt.Errorf("unknown selector %x", args.Input[:4])
+}
+
+func (c *fakeChain) answer(method string, in []interface{}) (interface{}, error) {
+	switch method {
+	case "balanceOf":
+		return valueOrZero(c.collateral), nil
+	case "allowance":
+		return valueOrZero(c.allowances[in[1].(common.Address)]), nil
+	case "isApprovedForAll":
+		return c.approved[in[1].(common.Address)], nil
+	case "balanceOfBatch":
+		accounts, ids := in[0].([]common.Address), in[1].([]*big.Int)
+		c.batches = append(c.batches, len(ids))
+		if c.failBatch {
+			return nil, fmt.Errorf("execution reverted")
+		}
+		values := make([]*big.Int, 0, len(ids))
+		for i, id := range ids {
+			if accounts[i] != c.ctfOwner {
+				return nil, fmt.Errorf("balanceOfBatch account %s, want %s", accounts[i].Hex(), c.ctfOwner.Hex())
+			}
+			values = append(values, valueOrZero(c.ctf[id.String()]))
+		}
+		if c.shortResult {
+			values = values[:len(values)-1]
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("unsupported method %s", method)
+}
+
+func valueOrZero(v *big.Int) *big.Int {
+	if v == nil {
+		return new(big.Int)
+	}
+	return v
+}
+
+// newFakeChainService returns a BlockchainService whose RPC client is served by chain.
+func newFakeChainService(t *testing.T, chain *fakeChain) *BlockchainService {
+	t.Helper()
+	api := &fakeEthAPI{chain: chain}
+	for _, raw := range []string{erc20BalanceOfABI, erc20AllowanceABI, erc1155IsApprovedForAllABI, erc1155BalanceOfBatchABI} {
+		parsed, err := abi.JSON(strings.NewReader(raw))
+		if err != nil {
+			t.Fatalf("parse ABI: %v", err)
+		}
+		api.abis = append(api.abis, parsed)
+	}
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", api); err != nil {
+		t.Fatalf("register fake eth API: %v", err)
+	}
+	client := ethclient.NewClient(rpc.DialInProc(server))
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+	return &BlockchainService{client: client, balanceCache: make(map[string]cachedBalance)}
+}
+
+func TestTradingReadinessAllowanceThreshold(t *testing.T) {
+	const vault = "0x000000000000000000000000000000000000dEaD"
+	maxUint := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	below := new(big.Int).Sub(readinessMinAllowance, big.NewInt(1))
+	exactAmount := big.NewInt(250_000_000) // a 250 USDC.e approval granted outside the app
+
+	cases := []struct {
+		name      string
+		allowance *big.Int
+		approved  bool
+	}{
+		{"unlimited", maxUint, true},
+		{"at threshold", readinessMinAllowance, true},
+		{"just below threshold", below, false},
+		{"exact amount approval", exactAmount, false},
+		{"none", nil, false},
+	}
+	for _, tc := range cases {
+		chain := &fakeChain{
+			code:       []byte{0x60, 0x80},
+			collateral: big.NewInt(5_000_000),
+			allowances: map[common.Address]*big.Int{},
+			approved:   map[common.Address]bool{},
+		}
+		approvals := relayer.RequiredTradingApprovals()
+		for _, a := range approvals {
+			if a.Kind == relayer.ApprovalKindERC20 {
+				chain.allowances[common.HexToAddress(a.Spender)] = maxUint
+			} else {
+				chain.approved[common.HexToAddress(a.Spender)] = true
+			}
+		}
+		// Only the Conditional Tokens allowance varies.
+		chain.allowances[common.HexToAddress(relayer.ConditionalTokensAddress)] = tc.allowance
+
+		readiness, err := newFakeChainService(t, chain).GetTradingReadiness(context.Background(), vault)
+		if err != nil {
+			t.Fatalf("%s: GetTradingReadiness: %v", tc.name, err)
+		}
+		if len(readiness.Approvals) != len(approvals) {
+			t.Fatalf("%s: got %d approvals, want %d", tc.name, len(readiness.Approvals), len(approvals))
+		}
+		status := readiness.Approvals[0]
+		if status.Spender != relayer.ConditionalTokensAddress || status.Approved != tc.approved {
+			t.Errorf("%s: %s approved = %v, want %v", tc.name, status.Label, status.Approved, tc.approved)
+		}
+		if want := valueOrZero(tc.allowance).String(); status.Allowance != want {
+			t.Errorf("%s: allowance = %s, want %s", tc.name, status.Allowance, want)
+		}
+		wantMissing := 0
+		if !tc.approved {
+			wantMissing = 1
+		}
+		if len(readiness.Missing) != wantMissing || readiness.Ready != tc.approved {
+			t.Errorf("%s: missing = %v, ready = %v", tc.name, readiness.Missing, readiness.Ready)
+		}
+	}
+}
+
+func TestTradingReadinessNeedsDeploymentAndCollateral(t *testing.T) {
+	const vault = "0x000000000000000000000000000000000000dEaD"
+	full := func() *fakeChain {
+		chain := &fakeChain{
+			code:       []byte{0x60, 0x80},
+			collateral: big.NewInt(1),
+			allowances: map[common.Address]*big.Int{},
+			approved:   map[common.Address]bool{},
+		}
+		for _, a := range relayer.RequiredTradingApprovals() {
+			chain.allowances[common.HexToAddress(a.Spender)] = readinessMinAllowance
+			chain.approved[common.HexToAddress(a.Spender)] = true
+		}
+		return chain
+	}
+
+	cases := []struct {
+		name  string
+		edit  func(*fakeChain)
+		ready bool
+	}{
+		{"ready", func(*fakeChain) {}, true},
+		{"not deployed", func(c *fakeChain) { c.code = nil }, false},
+		{"no collateral", func(c *fakeChain) { c.collateral = nil }, false},
+		{"operator not approved", func(c *fakeChain) {
+			c.approved[common.HexToAddress(relayer.NegRiskAdapterAddress)] = false
+		}, false},
+	}
+	for _, tc := range cases {
+		chain := full()
+		tc.edit(chain)
+		readiness, err := newFakeChainService(t, chain).GetTradingReadiness(context.Background(), vault)
+		if err != nil {
+			t.Fatalf("%s: GetTradingReadiness: %v", tc.name, err)
+		}
+		if readiness.Ready != tc.ready {
+			t.Errorf("%s: ready = %v, want %v (%+v)", tc.name, readiness.Ready, tc.ready, readiness)
+		}
+	}
+
+	if _, err := newFakeChainService(t, full()).GetTradingReadiness(context.Background(), "0x1234"); err == nil {
+		t.Error("expected an error for an invalid vault address")
+	}
+}
+
+func TestConditionalTokenBalancesBatchMapping(t *testing.T) {
+	const vault = "0x000000000000000000000000000000000000dEaD"
+	chain := &fakeChain{
+		ctfOwner: common.HexToAddress(vault),
+		ctf:      map[string]*big.Int{},
+	}
+	var tokenIDs []string
+	for i := 1; i <= 2*ctfBalanceBatchSize+50; i++ {
+		id := fmt.Sprintf("%d", 1000+i)
+		chain.ctf[id] = big.NewInt(int64(i) * 1_000_000)
+		tokenIDs = append(tokenIDs, id)
+	}
+	// Duplicates and blanks are skipped; unknown tokens read as zero.
+	request := append([]string{" 1001 ", "1001", "", "999"}, tokenIDs[1:]...)
+
+	svc := newFakeChainService(t, chain)
+	balances, err := svc.GetConditionalTokenBalances(context.Background(), vault, request)
+	if err != nil {
+		t.Fatalf("GetConditionalTokenBalances: %v", err)
+	}
+	if want := []int{ctfBalanceBatchSize, ctfBalanceBatchSize, 51}; fmt.Sprint(chain.batches) != fmt.Sprint(want) {
+		t.Errorf("batches = %v, want %v", chain.batches, want)
+	}
+	if len(balances) != len(tokenIDs)+1 {
+		t.Errorf("got %d balances, want %d", len(balances), len(tokenIDs)+1)
+	}
+	for _, id := range tokenIDs {
+		if got := balances[id]; got == nil || got.Cmp(chain.ctf[id]) != 0 {
+			t.Errorf("balance[%s] = %v, want %v", id, got, chain.ctf[id])
+		}
+	}
+	if got := balances["999"]; got == nil || got.Sign() != 0 {
+		t.Errorf("balance[999] = %v, want 0", got)
+	}
+
+	// Fresh balances come from the cache, and callers cannot mutate it.
+	balances["1001"].SetInt64(-1)
+	chain.batches = nil
+	cached, err := svc.GetConditionalTokenBalances(context.Background(), vault, []string{"1001", "1250"})
+	if err != nil {
+		t.Fatalf("cached read: %v", err)
+	}
+	if len(chain.batches) != 0 {
+		t.Errorf("cached read made %d balanceOfBatch calls", len(chain.batches))
+	}
+	if cached["1001"].Cmp(chain.ctf["1001"]) != 0 || cached["1250"].Cmp(chain.ctf["1250"]) != 0 {
+		t.Errorf("cached balances = %v", cached)
+	}
+
+	if _, err := svc.GetConditionalTokenBalances(context.Background(), vault, []string{"12x"}); err == nil {
+		t.Error("expected an error for a non-numeric token id")
+	}
+}
+
+func TestConditionalTokenBalancesBatchFailure(t *testing.T) {
+	const vault = "0x000000000000000000000000000000000000dEaD"
+	owner := strings.ToLower(common.HexToAddress(vault).Hex())
+
+	for _, chain := range []*fakeChain{
+		{ctfOwner: common.HexToAddress(vault), failBatch: true},
+		{ctfOwner: common.HexToAddress(vault), shortResult: true, ctf: map[string]*big.Int{"1": big.NewInt(5)}},
+	} {
+		svc := newFakeChainService(t, chain)
+		if _, err := svc.GetConditionalTokenBalances(context.Background(), vault, []string{"1", "2"}); err == nil {
+			t.Errorf("fail=%v short=%v: expected an error without cached balances", chain.failBatch, chain.shortResult)
+		}
+
+		// A stale cached balance is served when the batch read fails.
+		svc.setCachedBalance(ctfBalanceCacheKey(owner, "1"), big.NewInt(7))
+		svc.setCachedBalance(ctfBalanceCacheKey(owner, "2"), big.NewInt(8))
+		for _, id := range []string{"1", "2"} {
+			key := ctfBalanceCacheKey(owner, id)
+			entry := svc.balanceCache[key]
+			entry.expiresAt = time.Now().Add(-time.Second)
+			entry.lastAttempt = time.Time{}
+			svc.balanceCache[key] = entry
+		}
+		balances, err := svc.GetConditionalTokenBalances(context.Background(), vault, []string{"1", "2"})
+		if err != nil {
+			t.Fatalf("fail=%v short=%v: stale fallback: %v", chain.failBatch, chain.shortResult, err)
+		}
+		if balances["1"].Int64() != 7 || balances["2"].Int64() != 8 {
+			t.Errorf("fail=%v short=%v: balances = %v, want stale 7 and 8", chain.failBatch, chain.shortResult, balances)
+		}
+	}
+}
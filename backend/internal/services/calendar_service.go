@@ -0,0 +1,463 @@
+/**
+ * @description
+ * Market Calendar Service.
+ * Builds a time-oriented view of markets (end dates, event start times, trading open times)
+ * and renders per-user iCalendar (.ics) feeds of bookmarked and held markets.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - backend/internal/models
+ * - backend/internal/services (MarketService, ProfileService)
+ *
+ * @notes
+ * - The calendar reads the raw Gamma snapshot so markets that have not opened for trading yet are included.
+ * - Feed tokens are bearer credentials embedded in the URL; rotating a token invalidates old subscriptions.
+ * - iCalendar output follows RFC 5545 (CRLF line endings, 75-octet line folding, TEXT escaping).
+ */
+
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	CalendarEventEnd          = "END"
+	CalendarEventStart        = "EVENT_START"
+	CalendarEventTradingOpens = "TRADING_OPENS"
+
+	maxCalendarRange       = 93 * 24 * time.Hour
+	maxCalendarEvents      = 2000
+	calendarFeedLookback   = 7 * 24 * time.Hour
+	calendarFeedPositions  = 500
+	calendarEventDuration  = 15 * time.Minute
+	calendarDescriptionMax = 600
+)
+
+var (
+	ErrCalendarFeedNotFound = errors.New("calendar feed not found")
+	ErrInvalidCalendarRange = errors.New("invalid calendar range")
+)
+
+// CalendarService serves the market calendar and iCalendar feeds
+type CalendarService struct {
+	db       *gorm.DB
+	markets  *MarketService
+	profiles *ProfileService
+}
+
+// NewCalendarService creates a new CalendarService
+func NewCalendarService(db *gorm.DB, markets *MarketService, profiles *ProfileService) *CalendarService {
+	return &CalendarService{
+		db:       db,
+		markets:  markets,
+		profiles: profiles,
+	}
+}
+
+// CalendarParams filters the calendar view
+type CalendarParams struct {
+	From     time.Time
+	To       time.Time
+	Category string
+	Tag      string
+}
+
+// CalendarEvent is a single dated milestone for a market
+type CalendarEvent struct {
+	Type        string    `json:"type"`
+	At          time.Time `json:"at"`
+	ConditionID string    `json:"condition_id"`
+	Slug        string    `json:"slug"`
+	Title       string    `json:"title"`
+	Category    string    `json:"category"`
+	ImageURL    string    `json:"image_url"`
+	Volume24h   float64   `json:"volume_24h"`
+}
+
+// CalendarDay groups events by UTC calendar date
+type CalendarDay struct {
+	Date   string          `json:"date"`
+	Events []CalendarEvent `json:"events"`
+}
+
+// MarketCalendar is the response for the calendar view
+type MarketCalendar struct {
+	From      time.Time     `json:"from"`
+	To        time.Time     `json:"to"`
+	Total     int           `json:"total"`
+	Truncated bool          `json:"truncated"`
+	Days      []CalendarDay `json:"days"`
+}
+
+// GetCalendar returns market milestones between From and To grouped by day.
+func (s *CalendarService) GetCalendar(ctx context.Context, params CalendarParams) (*MarketCalendar, error) {
+	if !params.To.After(params.From) {
+		return nil, fmt.Errorf("%w: to must be after from", ErrInvalidCalendarRange)
+	}
+	if params.To.Sub(params.From) > maxCalendarRange {
+		return nil, fmt.Errorf("%w: range cannot exceed %d days", ErrInvalidCalendarRange, int(maxCalendarRange.Hours()/24))
+	}
+
+	markets, err := s.loadCalendarMarkets(ctx, params.From, params.To)
+	if err != nil {
+		return nil, err
+	}
+	markets = filterMarketsForParams(markets, params.Category, params.Tag)
+
+	var events []CalendarEvent
+	for _, market := range markets {
+		events = append(events, marketCalendarEvents(market, params.From, params.To)...)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].At.Equal(events[j].At) {
+			return events[i].Volume24h > events[j].Volume24h
+		}
+		return events[i].At.Before(events[j].At)
+	})
+
+	calendar := &MarketCalendar{
+		From:  params.From,
+		To:    params.To,
+		Total: len(events),
+		Days:  []CalendarDay{},
+	}
+	if len(events) > maxCalendarEvents {
+		events = events[:maxCalendarEvents]
+		calendar.Truncated = true
+	}
+
+	for _, event := range events {
+		date := event.At.UTC().Format("2006-01-02")
+		if n := len(calendar.Days); n == 0 || calendar.Days[n-1].Date != date {
+			calendar.Days = append(calendar.Days, CalendarDay{Date: date})
+		}
+		day := &calendar.Days[len(calendar.Days)-1]
+		day.Events = append(day.Events, event)
+	}
+
+	return calendar, nil
+}
+
+// loadCalendarMarkets prefers the raw Gamma snapshot and falls back to Postgres when the cache is cold.
+func (s *CalendarService) loadCalendarMarkets(ctx context.Context, from, to time.Time) ([]models.Market, error) {
+	if snapshot, err := s.markets.loadMarketSnapshotFromCache(ctx); err == nil {
+		result := make([]models.Market, 0, len(snapshot))
+		for _, market := range snapshot {
+			if market.Closed || market.Archived {
+				continue
+			}
+			result = append(result, market)
+		}
+		return result, nil
+	}
+
+	var markets []models.Market
+	if err := s.db.WithContext(ctx).
+		Where("closed = ? AND archived = ?", false, false).
+		Where("(end_date BETWEEN ? AND ?) OR (event_start_time BETWEEN ? AND ?) OR (accepting_orders_at BETWEEN ? AND ?)",
+			from, to, from, to, from, to).
+		Find(&markets).Error; err != nil {
+		return nil, fmt.Errorf("failed to query calendar markets: %w", err)
+	}
+	return markets, nil
+}
+
+func marketCalendarEvents(market models.Market, from, to time.Time) []CalendarEvent {
+	var events []CalendarEvent
+	add := func(kind string, at *time.Time) {
+		if at == nil || at.Before(from) || at.After(to) {
+			return
+		}
+		events = append(events, CalendarEvent{
+			Type:        kind,
+			At:          at.UTC(),
+			ConditionID: market.ConditionID,
+			Slug:        market.Slug,
+			Title:       market.Title,
+			Category:    market.Category,
+			ImageURL:    market.ImageURL,
+			Volume24h:   market.Volume24h,
+		})
+	}
+
+	add(CalendarEventEnd, market.EndDate)
+	add(CalendarEventStart, market.EventStartTime)
+	if !market.AcceptingOrders {
+		add(CalendarEventTradingOpens, market.AcceptingOrdersAt)
+	}
+	return events
+}
+
+// GetOrCreateFeed returns the user's feed token, creating one on first use.
+func (s *CalendarService) GetOrCreateFeed(ctx context.Context, userID uuid.UUID) (*models.CalendarFeed, error) {
+	var feed models.CalendarFeed
+	err := s.db.WithContext(ctx).Where("user_id = ?", userID).First(&feed).Error
+	if err == nil {
+		return &feed, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	token, err := newCalendarToken()
+	if err != nil {
+		return nil, err
+	}
+	feed = models.CalendarFeed{UserID: userID, Token: token}
+	if err := s.db.WithContext(ctx).Create(&feed).Error; err != nil {
+		return nil, fmt.Errorf("failed to create calendar feed: %w", err)
+	}
+	return &feed, nil
+}
+
+// RotateFeed replaces the user's feed token, invalidating existing subscriptions.
+func (s *CalendarService) RotateFeed(ctx context.Context, userID uuid.UUID) (*models.CalendarFeed, error) {
+	feed, err := s.GetOrCreateFeed(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := newCalendarToken()
+	if err != nil {
+		return nil, err
+	}
+	feed.Token = token
+	if err := s.db.WithContext(ctx).Save(feed).Error; err != nil {
+		return nil, fmt.Errorf("failed to rotate calendar feed: %w", err)
+	}
+	return feed, nil
+}
+
+// RenderFeed renders the iCalendar document for the feed token.
+func (s *CalendarService) RenderFeed(ctx context.Context, token string) (string, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", ErrCalendarFeedNotFound
+	}
+
+	var feed models.CalendarFeed
+	if err := s.db.WithContext(ctx).Where("token = ?", token).First(&feed).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", ErrCalendarFeedNotFound
+		}
+		return "", err
+	}
+
+	var user models.User
+	if err := s.db.WithContext(ctx).Where("id = ?", feed.UserID).First(&user).Error; err != nil {
+		return "", fmt.Errorf("failed to load feed owner: %w", err)
+	}
+
+	marketIDs, held := s.feedMarketIDs(ctx, user)
+	markets, err := s.loadMarketsByID(ctx, marketIDs)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC()
+	_ = s.db.WithContext(ctx).Model(&feed).Update("last_accessed_at", now).Error
+
+	return renderICS(markets, held, now), nil
+}
+
+// feedMarketIDs collects bookmarked markets plus markets with open positions or resting orders.
+func (s *CalendarService) feedMarketIDs(ctx context.Context, user models.User) ([]string, map[string]bool) {
+	seen := make(map[string]bool)
+	held := make(map[string]bool)
+	var ids []string
+	add := func(id string, isHeld bool) {
+		if id == "" {
+			return
+		}
+		if isHeld {
+			held[id] = true
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	var bookmarked []string
+	if err := s.db.WithContext(ctx).Model(&models.MarketBookmark{}).
+		Where("user_id = ?", user.ID).Pluck("market_id", &bookmarked).Error; err != nil {
+		logger.Error("CalendarService: Failed to load bookmarks: %v", err)
+	}
+	for _, id := range bookmarked {
+		add(id, false)
+	}
+
+	var ordered []string
+	if err := s.db.WithContext(ctx).Model(&models.Order{}).
+		Where("user_id = ? AND status IN ?", user.ID, []models.OrderStatus{models.OrderStatusOpen, models.OrderStatusFilled}).
+		Distinct("market_id").Pluck("market_id", &ordered).Error; err != nil {
+		logger.Error("CalendarService: Failed to load order markets: %v", err)
+	}
+	for _, id := range ordered {
+		add(id, true)
+	}
+
+	if s.profiles != nil && user.VaultAddress != "" {
+		positions, err := s.profiles.GetOpenPositions(ctx, user.VaultAddress, calendarFeedPositions, 0)
+		if err != nil {
+			logger.Error("CalendarService: Failed to load positions for %s: %v", user.VaultAddress, err)
+		}
+		for _, position := range positions {
+			if position.Size > 0 {
+				add(position.ConditionID, true)
+			}
+		}
+	}
+
+	return ids, held
+}
+
+func (s *CalendarService) loadMarketsByID(ctx context.Context, ids []string) ([]models.Market, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	wanted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+
+	var result []models.Market
+	if snapshot, err := s.markets.loadMarketSnapshotFromCache(ctx); err == nil {
+		for _, market := range snapshot {
+			if wanted[market.ConditionID] {
+				result = append(result, market)
+				delete(wanted, market.ConditionID)
+			}
+		}
+	}
+
+	if len(wanted) > 0 {
+		missing := make([]string, 0, len(wanted))
+		for id := range wanted {
+			missing = append(missing, id)
+		}
+		var stored []models.Market
+		if err := s.db.WithContext(ctx).Where("condition_id IN ?", missing).Find(&stored).Error; err != nil {
+			return nil, fmt.Errorf("failed to load feed markets: %w", err)
+		}
+		result = append(result, stored...)
+	}
+
+	return result, nil
+}
+
+func renderICS(markets []models.Market, held map[string]bool, now time.Time) string {
+	var b strings.Builder
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//Bankai//Market Calendar//EN")
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+	writeICSLine(&b, "METHOD:PUBLISH")
+	writeICSLine(&b, "X-WR-CALNAME:Bankai Markets")
+	writeICSLine(&b, "REFRESH-INTERVAL;VALUE=DURATION:PT1H")
+
+	sort.SliceStable(markets, func(i, j int) bool {
+		return markets[i].ConditionID < markets[j].ConditionID
+	})
+
+	from := now.Add(-calendarFeedLookback)
+	to := now.Add(365 * 24 * time.Hour)
+	stamp := formatICSTime(now)
+	for _, market := range markets {
+		for _, event := range marketCalendarEvents(market, from, to) {
+			label := "Ends"
+			switch event.Type {
+			case CalendarEventStart:
+				label = "Starts"
+			case CalendarEventTradingOpens:
+				label = "Trading opens"
+			}
+
+			description := fmt.Sprintf("Market: %s\nCondition: %s", market.Title, market.ConditionID)
+			if held[market.ConditionID] {
+				description += "\nYou hold a position or have orders in this market."
+			}
+			if event.Type == CalendarEventEnd && market.ResolutionRules != "" {
+				rules := market.ResolutionRules
+				if len(rules) > calendarDescriptionMax {
+					rules = rules[:calendarDescriptionMax] + "..."
+				}
+				description += "\n\nRules: " + rules
+			}
+
+			writeICSLine(&b, "BEGIN:VEVENT")
+			writeICSLine(&b, fmt.Sprintf("UID:%s-%s@bankai", market.ConditionID, strings.ToLower(event.Type)))
+			writeICSLine(&b, "DTSTAMP:"+stamp)
+			writeICSLine(&b, "DTSTART:"+formatICSTime(event.At))
+			writeICSLine(&b, "DTEND:"+formatICSTime(event.At.Add(calendarEventDuration)))
+			writeICSLine(&b, "SUMMARY:"+escapeICSText(fmt.Sprintf("%s: %s", label, market.Title)))
+			writeICSLine(&b, "DESCRIPTION:"+escapeICSText(description))
+			if market.Category != "" {
+				writeICSLine(&b, "CATEGORIES:"+escapeICSText(market.Category))
+			}
+			writeICSLine(&b, "TRANSP:TRANSPARENT")
+			writeICSLine(&b, "END:VEVENT")
+		}
+	}
+
+	writeICSLine(&b, "END:VCALENDAR")
+	return b.String()
+}
+
+// writeICSLine writes a content line folded at 75 octets (74 after the leading space of a continuation line)
+// without splitting UTF-8 sequences.
+func writeICSLine(b *strings.Builder, line string) {
+	// RFC 5545 3.1: lines are at most 75 octets; continuation lines start with a space, leaving 74 for content.
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && (line[cut]&0xC0) == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+func escapeICSText(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", "",
+	)
+	return replacer.Replace(value)
+}
+
+func formatICSTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+func newCalendarToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate calendar token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
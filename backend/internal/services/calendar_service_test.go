@@ -0,0 +1,56 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestWriteICSLine(t *testing.T) {
+	cases := []struct {
+		name      string
+		line      string
+		wantLines []int // octets per physical line, including the leading space of continuations
+	}{
+		{"short line unchanged", "SUMMARY:hello", []int{13}},
+		{"exactly 75 octets", strings.Repeat("a", 75), []int{75}},
+		{"76 octets folds once", strings.Repeat("a", 76), []int{75, 2}},
+		{"continuations carry 74 octets", strings.Repeat("a", 75+74+10), []int{75, 75, 11}},
+		{"multi-byte rune not split", strings.Repeat("a", 74) + "é" + "b", []int{74, 4}},
+	}
+
+	for _, tc := range cases {
+		var b strings.Builder
+		writeICSLine(&b, tc.line)
+		out := b.String()
+		if !strings.HasSuffix(out, "\r\n") {
+			t.Errorf("%s: output %q does not end with CRLF", tc.name, out)
+			continue
+		}
+
+		physical := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+		if len(physical) != len(tc.wantLines) {
+			t.Errorf("%s: %d lines, want %d (%q)", tc.name, len(physical), len(tc.wantLines), out)
+			continue
+		}
+		var unfolded strings.Builder
+		for i, p := range physical {
+			if len(p) != tc.wantLines[i] {
+				t.Errorf("%s: line %d has %d octets, want %d", tc.name, i, len(p), tc.wantLines[i])
+			}
+			if !utf8.ValidString(p) {
+				t.Errorf("%s: line %d splits a UTF-8 sequence: %q", tc.name, i, p)
+			}
+			if i > 0 {
+				if !strings.HasPrefix(p, " ") {
+					t.Errorf("%s: continuation line %d does not start with a space", tc.name, i)
+				}
+				p = p[1:]
+			}
+			unfolded.WriteString(p)
+		}
+		if unfolded.String() != tc.line {
+			t.Errorf("%s: unfolded = %q, want %q", tc.name, unfolded.String(), tc.line)
+		}
+	}
+}
@@ -0,0 +1,696 @@
+/**
+ * @description
+ * Conditional Order Service.
+ * Holds pre-signed FOK/FAK sell orders (stop-loss / take-profit) encrypted at rest and submits them
+ * to the CLOB when the live RTDS price crosses the user's trigger.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - github.com/redis/go-redis/v9
+ * - backend/internal/polymarket/clob
+ * - backend/internal/secrets
+ * - backend/internal/services (TradeService)
+ *
+ * @notes
+ * - The API process only creates/cancels; evaluation runs in the worker via Watch.
+ * - Orders are claimed with a conditional UPDATE (ARMED -> TRIGGERED) so only one worker ever submits.
+ * - Claims left in TRIGGERED by a crashed worker are failed rather than resubmitted, since the CLOB may have
+ *   already accepted them.
+ * - Orders, group exits included, pass the pre-trade risk check when they are created, so a blocked stop is
+ *   rejected up front. They are not re-checked when they fire: every conditional order is a position-reducing
+ *   SELL, and a per-order notional limit must not stop an exit at the moment it has to close the position.
+ */
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/polymarket/clob"
+	"github.com/bankai-project/backend/internal/secrets"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+const (
+	ConditionalOrdersChannel = "conditional_orders:updated"
+
+	maxArmedConditionalOrders  = 50
+	conditionalReloadInterval  = 30 * time.Second
+	conditionalClaimStaleAfter = 5 * time.Minute
+	conditionalSubmitTimeout   = 20 * time.Second
+	conditionalMinLifetime     = time.Minute
+)
+
+var (
+	ErrConditionalOrdersDisabled = errors.New("conditional orders are not enabled on this server")
+	ErrConditionalOrderNotFound  = errors.New("conditional order not found")
+	ErrConditionalOrderNotArmed  = errors.New("conditional order is no longer armed")
+	ErrConditionalOrderLimit     = fmt.Errorf("a maximum of %d armed conditional orders is allowed", maxArmedConditionalOrders)
+	ErrInvalidConditionalOrder   = errors.New("invalid conditional order")
+
+	// openConditionalStatuses are the statuses that can still be cancelled.
+	openConditionalStatuses = []models.ConditionalOrderStatus{models.ConditionalOrderPending, models.ConditionalOrderArmed}
+)
+
+// ConditionalOrderService stores conditional orders and fires them from live prices
+type ConditionalOrderService struct {
+	db     *gorm.DB
+	redis  *redis.Client
+	trades *TradeService
+	box    *secrets.Box
+
+	mu     sync.Mutex
+	armed  map[string][]models.ConditionalOrder // token_id -> armed orders
+	quotes map[string]*tokenQuote
+}
+
+// tokenQuote caches the latest RTDS values per token, since bid/ask and last trade arrive in separate messages.
+type tokenQuote struct {
+	BestBid   float64
+	BestAsk   float64
+	LastTrade float64
+}
+
+// NewConditionalOrderService creates a new ConditionalOrderService. box may be nil, which disables the feature.
+func NewConditionalOrderService(db *gorm.DB, redis *redis.Client, trades *TradeService, box *secrets.Box) *ConditionalOrderService {
+	return &ConditionalOrderService{
+		db:     db,
+		redis:  redis,
+		trades: trades,
+		box:    box,
+		armed:  make(map[string][]models.ConditionalOrder),
+		quotes: make(map[string]*tokenQuote),
+	}
+}
+
+// Enabled reports whether an encryption key is configured.
+func (s *ConditionalOrderService) Enabled() bool {
+	return s.box != nil
+}
+
+// ConditionalOrderInput is the request to arm a conditional order
+type ConditionalOrderInput struct {
+	Kind          models.ConditionalOrderKind `json:"kind"`
+	TriggerSource models.TriggerSource        `json:"triggerSource"`
+	TriggerPrice  float64                     `json:"triggerPrice"`
+	Order         clob.PostOrderRequest       `json:"order"`
+	Credentials   clob.APIKeyCredentials      `json:"credentials"`
+}
+
+// conditionalPayload is the plaintext sealed into encrypted_payload.
+type conditionalPayload struct {
+	Request     clob.PostOrderRequest  `json:"request"`
+	Credentials clob.APIKeyCredentials `json:"credentials"`
+}
+
+// conditionalPriceUpdate mirrors the RTDS payload published on PriceUpdateChannel.
+type conditionalPriceUpdate struct {
+	ConditionID    string   `json:"condition_id"`
+	AssetID        string   `json:"asset_id"`
+	BestBid        *float64 `json:"best_bid,omitempty"`
+	BestAsk        *float64 `json:"best_ask,omitempty"`
+	LastTradePrice *float64 `json:"last_trade_price,omitempty"`
+}
+
+// ConditionalOrderAlertData is the payload stored on CONDITIONAL_ORDER notifications
+type ConditionalOrderAlertData struct {
+	ConditionalOrderID string  `json:"conditional_order_id"`
+	MarketID           string  `json:"market_id"`
+	Kind               string  `json:"kind"`
+	Status             string  `json:"status"`
+	TriggerPrice       float64 `json:"trigger_price"`
+	ObservedPrice      float64 `json:"observed_price"`
+	CLOBOrderID        string  `json:"clob_order_id,omitempty"`
+	Error              string  `json:"error,omitempty"`
+}
+
+// Create validates, encrypts and arms a conditional order for the user.
+func (s *ConditionalOrderService) Create(ctx context.Context, user *models.User, input ConditionalOrderInput) (*models.ConditionalOrder, error) {
+	if s.box == nil {
+		return nil, ErrConditionalOrdersDisabled
+	}
+	if user == nil {
+		return nil, errors.New("user context is required")
+	}
+
+	var armed int64
+	if err := s.db.WithContext(ctx).Model(&models.ConditionalOrder{}).
+		Where("user_id = ? AND status IN ?", user.ID, openConditionalStatuses).
+		Count(&armed).Error; err != nil {
+		return nil, fmt.Errorf("failed to count conditional orders: %w", err)
+	}
+	if armed >= maxArmedConditionalOrders {
+		return nil, ErrConditionalOrderLimit
+	}
+
+	order, err := s.prepare(ctx, user, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.WithContext(ctx).Create(order).Error; err != nil {
+		return nil, fmt.Errorf("failed to store conditional order: %w", err)
+	}
+
+	s.publishChange(ctx)
+	return order, nil
+}
+
+// prepare validates the input and returns an unsaved, sealed conditional order.
+func (s *ConditionalOrderService) prepare(ctx context.Context, user *models.User, input ConditionalOrderInput) (*models.ConditionalOrder, error) {
+	if s.box == nil {
+		return nil, ErrConditionalOrdersDisabled
+	}
+
+	order, err := s.validateInput(ctx, user, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := json.Marshal(conditionalPayload{Request: input.Order, Credentials: input.Credentials})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode conditional order: %w", err)
+	}
+	sealed, err := s.box.Seal(plaintext, []byte(order.ID.String()))
+	if err != nil {
+		return nil, err
+	}
+	order.EncryptedPayload = sealed
+	return order, nil
+}
+
+func (s *ConditionalOrderService) validateInput(ctx context.Context, user *models.User, input *ConditionalOrderInput) (*models.ConditionalOrder, error) {
+	invalid := func(format string, args ...interface{}) error {
+		return fmt.Errorf("%w: %s", ErrInvalidConditionalOrder, fmt.Sprintf(format, args...))
+	}
+
+	kind := models.ConditionalOrderKind(strings.ToUpper(strings.TrimSpace(string(input.Kind))))
+	if kind != models.ConditionalOrderStopLoss && kind != models.ConditionalOrderTakeProfit {
+		return nil, invalid("kind must be STOP_LOSS or TAKE_PROFIT")
+	}
+
+	source := models.TriggerSource(strings.ToUpper(strings.TrimSpace(string(input.TriggerSource))))
+	if source == "" {
+		source = models.TriggerSourceBid
+	}
+	switch source {
+	case models.TriggerSourceBid, models.TriggerSourceMid, models.TriggerSourceLastTrade:
+	default:
+		return nil, invalid("triggerSource must be BID, MID or LAST_TRADE")
+	}
+
+	if input.TriggerPrice <= 0 || input.TriggerPrice >= 1 {
+		return nil, invalid("triggerPrice must be between 0 and 1")
+	}
+
+	if err := input.Order.Validate(); err != nil {
+		return nil, invalid("%v", err)
+	}
+	if input.Order.OrderType != clob.OrderTypeFOK && input.Order.OrderType != clob.OrderTypeFAK {
+		return nil, invalid("orderType must be FOK or FAK")
+	}
+	if normalizeClobSide(input.Order.Order.Side) != clob.SELL {
+		return nil, invalid("only SELL orders can be held as conditional orders")
+	}
+
+	creds := input.Credentials
+	if strings.TrimSpace(creds.Key) == "" || strings.TrimSpace(creds.Secret) == "" || strings.TrimSpace(creds.Passphrase) == "" {
+		return nil, invalid("credentials key, secret and passphrase are required")
+	}
+	if input.Order.Owner != creds.Key {
+		return nil, invalid("order owner must match the credentials key")
+	}
+
+	owner, err := userForMaker(ctx, s.db, user, input.Order.Order.Maker)
+	if err != nil {
+		return nil, err
+	}
+	if owner == nil {
+		return nil, invalid("order maker does not belong to this user")
+	}
+
+	if err := s.trades.validateOrderAmounts(ctx, &input.Order.Order); err != nil {
+		return nil, invalid("%v", err)
+	}
+
+	var expiresAt *time.Time
+	if exp, err := strconv.ParseInt(strings.TrimSpace(input.Order.Order.Expiration), 10, 64); err != nil {
+		return nil, invalid("order expiration must be a unix timestamp")
+	} else if exp > 0 {
+		t := time.Unix(exp, 0).UTC()
+		if time.Until(t) < conditionalMinLifetime {
+			return nil, invalid("order expires too soon to be held")
+		}
+		expiresAt = &t
+	}
+
+	var market models.Market
+	if err := s.db.WithContext(ctx).
+		Where("token_id_yes = ? OR token_id_no = ?", input.Order.Order.TokenID, input.Order.Order.TokenID).
+		First(&market).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, invalid("unknown token %s", input.Order.Order.TokenID)
+		}
+		return nil, err
+	}
+
+	if s.trades.Risk != nil {
+		if err := s.trades.Risk.CheckSignedOrder(ctx, owner, &input.Order.Order); err != nil {
+			if errors.Is(err, ErrRiskCheckFailed) || errors.Is(err, ErrInvalidRiskCheck) {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidConditionalOrder, err)
+			}
+			return nil, err
+		}
+	}
+
+	makerAmt, _ := strconv.ParseFloat(input.Order.Order.MakerAmount, 64)
+	takerAmt, _ := strconv.ParseFloat(input.Order.Order.TakerAmount, 64)
+
+	return &models.ConditionalOrder{
+		ID:            uuid.New(),
+		UserID:        user.ID,
+		MarketID:      market.ConditionID,
+		TokenID:       input.Order.Order.TokenID,
+		Outcome:       deriveOutcomeLabel(&market, input.Order.Order.TokenID, clob.SELL),
+		Kind:          kind,
+		TriggerSource: source,
+		TriggerPrice:  input.TriggerPrice,
+		OrderType:     string(input.Order.OrderType),
+		LimitPrice:    takerAmt / makerAmt,
+		Size:          makerAmt / 1e6,
+		Status:        models.ConditionalOrderArmed,
+		ExpiresAt:     expiresAt,
+	}, nil
+}
+
+// List returns the user's conditional orders, newest first, optionally filtered by status.
+func (s *ConditionalOrderService) List(ctx context.Context, userID uuid.UUID, status string, limit, offset int) ([]models.ConditionalOrder, int64, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	query := s.db.WithContext(ctx).Model(&models.ConditionalOrder{}).Where("user_id = ?", userID)
+	if status = strings.ToUpper(strings.TrimSpace(status)); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count conditional orders: %w", err)
+	}
+
+	var orders []models.ConditionalOrder
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&orders).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch conditional orders: %w", err)
+	}
+	return orders, total, nil
+}
+
+// Cancel disarms a conditional order. Orders already claimed by the worker cannot be cancelled.
+func (s *ConditionalOrderService) Cancel(ctx context.Context, userID, orderID uuid.UUID) (*models.ConditionalOrder, error) {
+	now := time.Now().UTC()
+	result := s.db.WithContext(ctx).Model(&models.ConditionalOrder{}).
+		Where("id = ? AND user_id = ? AND status IN ?", orderID, userID, openConditionalStatuses).
+		Updates(map[string]interface{}{
+			"status":       models.ConditionalOrderCanceled,
+			"completed_at": now,
+		})
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to cancel conditional order: %w", result.Error)
+	}
+
+	var order models.ConditionalOrder
+	if err := s.db.WithContext(ctx).Where("id = ? AND user_id = ?", orderID, userID).First(&order).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrConditionalOrderNotFound
+		}
+		return nil, err
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrConditionalOrderNotArmed
+	}
+
+	s.publishChange(ctx)
+	s.reconcileGroup(ctx, &order)
+	return &order, nil
+}
+
+// armPending moves group-held legs from PENDING to ARMED once their entry has filled.
+func (s *ConditionalOrderService) armPending(ctx context.Context, tx *gorm.DB, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return tx.WithContext(ctx).Model(&models.ConditionalOrder{}).
+		Where("id IN ? AND status = ?", ids, models.ConditionalOrderPending).
+		Update("status", models.ConditionalOrderArmed).Error
+}
+
+// cancelOpen cancels any of the given orders that are still pending or armed.
+func (s *ConditionalOrderService) cancelOpen(ctx context.Context, tx *gorm.DB, ids []uuid.UUID, reason string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return tx.WithContext(ctx).Model(&models.ConditionalOrder{}).
+		Where("id IN ? AND status IN ?", ids, openConditionalStatuses).
+		Updates(map[string]interface{}{
+			"status":       models.ConditionalOrderCanceled,
+			"error_msg":    reason,
+			"completed_at": time.Now().UTC(),
+		}).Error
+}
+
+// reconcileGroup lets the owning order group react to a leg reaching a new state.
+func (s *ConditionalOrderService) reconcileGroup(ctx context.Context, order *models.ConditionalOrder) {
+	if order.GroupID == nil || s.trades.Groups == nil {
+		return
+	}
+	if err := s.trades.Groups.ReconcileGroup(ctx, *order.GroupID); err != nil {
+		logger.Error("ConditionalOrderService: Failed to reconcile group %s: %v", *order.GroupID, err)
+	}
+}
+
+func (s *ConditionalOrderService) publishChange(ctx context.Context) {
+	if err := s.redis.Publish(ctx, ConditionalOrdersChannel, "reload").Err(); err != nil {
+		logger.Error("ConditionalOrderService: Failed to publish change: %v", err)
+	}
+}
+
+// Watch evaluates armed orders against live prices until ctx is cancelled. Run from the worker only.
+func (s *ConditionalOrderService) Watch(ctx context.Context) {
+	if s.box == nil {
+		logger.Info("ConditionalOrderService: BANKAI_ENCRYPTION_KEY not set, conditional orders disabled")
+		return
+	}
+
+	sub := s.redis.Subscribe(ctx, PriceUpdateChannel, ConditionalOrdersChannel)
+	defer sub.Close()
+
+	s.maintain(ctx)
+
+	ticker := time.NewTicker(conditionalReloadInterval)
+	defer ticker.Stop()
+
+	messages := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.maintain(ctx)
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			if msg.Channel == ConditionalOrdersChannel {
+				s.reload(ctx)
+				continue
+			}
+			s.handlePriceUpdate(ctx, msg.Payload)
+		}
+	}
+}
+
+// maintain expires stale orders, fails abandoned claims and reloads the armed set.
+func (s *ConditionalOrderService) maintain(ctx context.Context) {
+	now := time.Now().UTC()
+
+	if err := s.db.WithContext(ctx).Model(&models.ConditionalOrder{}).
+		Where("status IN ? AND expires_at IS NOT NULL AND expires_at <= ?", openConditionalStatuses, now).
+		Updates(map[string]interface{}{
+			"status":       models.ConditionalOrderExpired,
+			"completed_at": now,
+		}).Error; err != nil {
+		logger.Error("ConditionalOrderService: Failed to expire orders: %v", err)
+	}
+
+	var abandoned []models.ConditionalOrder
+	if err := s.db.WithContext(ctx).
+		Where("status = ? AND triggered_at < ?", models.ConditionalOrderTriggered, now.Add(-conditionalClaimStaleAfter)).
+		Find(&abandoned).Error; err != nil {
+		logger.Error("ConditionalOrderService: Failed to load abandoned claims: %v", err)
+	}
+	for i := range abandoned {
+		order := &abandoned[i]
+		s.complete(ctx, order, models.ConditionalOrderFailed, nil, "", "submission was interrupted; check your open orders before re-arming")
+	}
+
+	s.reload(ctx)
+}
+
+func (s *ConditionalOrderService) reload(ctx context.Context) {
+	var orders []models.ConditionalOrder
+	if err := s.db.WithContext(ctx).
+		Select("id, user_id, market_id, token_id, outcome, kind, trigger_source, trigger_price, order_type, limit_price, size, status, expires_at").
+		Where("status = ?", models.ConditionalOrderArmed).
+		Find(&orders).Error; err != nil {
+		logger.Error("ConditionalOrderService: Failed to load armed orders: %v", err)
+		return
+	}
+
+	armed := make(map[string][]models.ConditionalOrder)
+	for _, order := range orders {
+		armed[order.TokenID] = append(armed[order.TokenID], order)
+	}
+
+	s.mu.Lock()
+	s.armed = armed
+	s.mu.Unlock()
+}
+
+func (s *ConditionalOrderService) handlePriceUpdate(ctx context.Context, payload string) {
+	var update conditionalPriceUpdate
+	if err := json.Unmarshal([]byte(payload), &update); err != nil || update.AssetID == "" {
+		return
+	}
+
+	s.mu.Lock()
+	quote := s.quotes[update.AssetID]
+	if quote == nil {
+		quote = &tokenQuote{}
+		s.quotes[update.AssetID] = quote
+	}
+	if update.BestBid != nil && *update.BestBid > 0 {
+		quote.BestBid = *update.BestBid
+	}
+	if update.BestAsk != nil && *update.BestAsk > 0 {
+		quote.BestAsk = *update.BestAsk
+	}
+	if update.LastTradePrice != nil && *update.LastTradePrice > 0 {
+		quote.LastTrade = *update.LastTradePrice
+	}
+
+	candidates := s.armed[update.AssetID]
+	if len(candidates) == 0 {
+		s.mu.Unlock()
+		return
+	}
+
+	var remaining []models.ConditionalOrder
+	type firing struct {
+		order models.ConditionalOrder
+		price float64
+	}
+	var fired []firing
+	for _, order := range candidates {
+		price := quote.priceFor(order.TriggerSource)
+		if order.Crossed(price) {
+			fired = append(fired, firing{order: order, price: price})
+			continue
+		}
+		remaining = append(remaining, order)
+	}
+	s.armed[update.AssetID] = remaining
+	s.mu.Unlock()
+
+	for _, f := range fired {
+		go s.fire(ctx, f.order, f.price)
+	}
+}
+
+func (q *tokenQuote) priceFor(source models.TriggerSource) float64 {
+	switch source {
+	case models.TriggerSourceMid:
+		if q.BestBid > 0 && q.BestAsk > 0 && q.BestAsk >= q.BestBid {
+			return (q.BestBid + q.BestAsk) / 2
+		}
+		return 0
+	case models.TriggerSourceLastTrade:
+		return q.LastTrade
+	default:
+		return q.BestBid
+	}
+}
+
+// fire claims the order, submits the signed payload and records the outcome.
+func (s *ConditionalOrderService) fire(ctx context.Context, armed models.ConditionalOrder, observed float64) {
+	now := time.Now().UTC()
+	claim := s.db.WithContext(ctx).Model(&models.ConditionalOrder{}).
+		Where("id = ? AND status = ?", armed.ID, models.ConditionalOrderArmed).
+		Updates(map[string]interface{}{
+			"status":         models.ConditionalOrderTriggered,
+			"triggered_at":   now,
+			"observed_price": observed,
+		})
+	if claim.Error != nil {
+		logger.Error("ConditionalOrderService: Failed to claim %s: %v", armed.ID, claim.Error)
+		return
+	}
+	if claim.RowsAffected == 0 {
+		return // cancelled, expired or claimed elsewhere
+	}
+
+	var order models.ConditionalOrder
+	if err := s.db.WithContext(ctx).Where("id = ?", armed.ID).First(&order).Error; err != nil {
+		logger.Error("ConditionalOrderService: Failed to load claimed order %s: %v", armed.ID, err)
+		return
+	}
+
+	logger.Info("ConditionalOrderService: %s %s triggered at %.4f (trigger %.4f, %s)",
+		order.Kind, order.ID, observed, order.TriggerPrice, order.TriggerSource)
+
+	if order.ExpiresAt != nil && !order.ExpiresAt.After(now) {
+		s.complete(ctx, &order, models.ConditionalOrderExpired, nil, "", "signed order expired before the trigger fired")
+		return
+	}
+
+	plaintext, err := s.box.Open(order.EncryptedPayload, []byte(order.ID.String()))
+	if err != nil {
+		s.complete(ctx, &order, models.ConditionalOrderFailed, nil, "", "stored order could not be decrypted")
+		return
+	}
+	var payload conditionalPayload
+	if err := json.Unmarshal(plaintext, &payload); err != nil {
+		s.complete(ctx, &order, models.ConditionalOrderFailed, nil, "", "stored order is corrupt")
+		return
+	}
+
+	var user models.User
+	if err := s.db.WithContext(ctx).Where("id = ?", order.UserID).First(&user).Error; err != nil {
+		s.complete(ctx, &order, models.ConditionalOrderFailed, nil, "", "order owner not found")
+		return
+	}
+
+	if order.GroupID != nil && s.trades.Groups != nil {
+		claimed, err := s.trades.Groups.claimExit(ctx, *order.GroupID, order.ID)
+		if err != nil || !claimed {
+			s.complete(ctx, &order, models.ConditionalOrderCanceled, nil, "", "another leg in the order group already fired")
+			return
+		}
+	}
+
+	submitCtx, cancel := context.WithTimeout(ctx, conditionalSubmitTimeout)
+	defer cancel()
+
+	creds := payload.Credentials
+	resp, postErr := s.trades.Clob.PostOrder(submitCtx, &payload.Request, &creds)
+	if postErr != nil {
+		resp = &clob.PostOrderResponse{Success: false, ErrorMsg: postErr.Error()}
+	}
+
+	persisted, err := s.trades.persistOrder(ctx, &user, &payload.Request, resp)
+	if err != nil {
+		logger.Error("ConditionalOrderService: Failed to persist order for %s: %v", order.ID, err)
+	}
+
+	if !resp.Success {
+		msg := resp.ErrorMsg
+		if msg == "" {
+			msg = "order rejected by the CLOB"
+		}
+		s.complete(ctx, &order, models.ConditionalOrderFailed, persisted, "", msg)
+		return
+	}
+
+	clobID := resp.OrderID
+	if clobID == "" && len(resp.OrderIDs) == 1 {
+		clobID = resp.OrderIDs[0]
+	}
+	s.complete(ctx, &order, models.ConditionalOrderSubmitted, persisted, clobID, "")
+}
+
+// complete records a terminal status and notifies the owner.
+func (s *ConditionalOrderService) complete(ctx context.Context, order *models.ConditionalOrder, status models.ConditionalOrderStatus, persisted *models.Order, clobID, errMsg string) {
+	now := time.Now().UTC()
+	updates := map[string]interface{}{
+		"status":        status,
+		"completed_at":  now,
+		"error_msg":     errMsg,
+		"clob_order_id": clobID,
+	}
+	if persisted != nil {
+		updates["order_id"] = persisted.ID
+	}
+	if err := s.db.WithContext(ctx).Model(order).Updates(updates).Error; err != nil {
+		logger.Error("ConditionalOrderService: Failed to record %s for %s: %v", status, order.ID, err)
+	}
+
+	if err := s.notify(ctx, order, status, clobID, errMsg); err != nil {
+		logger.Error("ConditionalOrderService: Failed to notify user for %s: %v", order.ID, err)
+	}
+
+	s.reconcileGroup(ctx, order)
+}
+
+func (s *ConditionalOrderService) notify(ctx context.Context, order *models.ConditionalOrder, status models.ConditionalOrderStatus, clobID, errMsg string) error {
+	label := "Stop-loss"
+	if order.Kind == models.ConditionalOrderTakeProfit {
+		label = "Take-profit"
+	}
+
+	var observed float64
+	if order.ObservedPrice != nil {
+		observed = *order.ObservedPrice
+	}
+
+	title := fmt.Sprintf("%s order submitted", label)
+	message := fmt.Sprintf("%s triggered at %.3f: sell %.2f %s shares at %.3f or better was submitted.",
+		label, observed, order.Size, order.Outcome, order.LimitPrice)
+	switch status {
+	case models.ConditionalOrderFailed:
+		title = fmt.Sprintf("%s order failed", label)
+		message = fmt.Sprintf("%s for %s could not be executed: %s", label, order.Outcome, errMsg)
+	case models.ConditionalOrderExpired:
+		title = fmt.Sprintf("%s order expired", label)
+		message = fmt.Sprintf("%s for %s was not submitted: %s", label, order.Outcome, errMsg)
+	case models.ConditionalOrderCanceled:
+		title = fmt.Sprintf("%s order canceled", label)
+		message = fmt.Sprintf("%s for %s was not submitted: %s", label, order.Outcome, errMsg)
+	}
+
+	data, err := json.Marshal(ConditionalOrderAlertData{
+		ConditionalOrderID: order.ID.String(),
+		MarketID:           order.MarketID,
+		Kind:               string(order.Kind),
+		Status:             string(status),
+		TriggerPrice:       order.TriggerPrice,
+		ObservedPrice:      observed,
+		CLOBOrderID:        clobID,
+		Error:              errMsg,
+	})
+	if err != nil {
+		return err
+	}
+
+	notification := models.Notification{
+		ID:        uuid.New(),
+		UserID:    order.UserID,
+		Type:      models.NotificationTypeConditionalOrder,
+		Title:     title,
+		Message:   message,
+		Data:      string(data),
+		Read:      false,
+		CreatedAt: time.Now(),
+	}
+	return s.db.WithContext(ctx).Create(&notification).Error
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/polymarket/clob"
+)
+
+func TestConditionalOrderCrossed(t *testing.T) {
+	cases := []struct {
+		name  string
+		kind  models.ConditionalOrderKind
+		price float64
+		want  bool
+	}{
+		{"stop loss above trigger", models.ConditionalOrderStopLoss, 0.41, false},
+		{"stop loss at trigger", models.ConditionalOrderStopLoss, 0.40, true},
+		{"stop loss below trigger", models.ConditionalOrderStopLoss, 0.35, true},
+		{"take profit below trigger", models.ConditionalOrderTakeProfit, 0.39, false},
+		{"take profit at trigger", models.ConditionalOrderTakeProfit, 0.40, true},
+		{"take profit above trigger", models.ConditionalOrderTakeProfit, 0.55, true},
+		{"stop loss without price", models.ConditionalOrderStopLoss, 0, false},
+		{"stop loss negative price", models.ConditionalOrderStopLoss, -0.1, false},
+		{"take profit without price", models.ConditionalOrderTakeProfit, 0, false},
+	}
+	for _, tc := range cases {
+		for _, source := range []models.TriggerSource{models.TriggerSourceBid, models.TriggerSourceMid, models.TriggerSourceLastTrade} {
+			order := models.ConditionalOrder{Kind: tc.kind, TriggerSource: source, TriggerPrice: 0.40}
+			if got := order.Crossed(tc.price); got != tc.want {
+				t.Errorf("%s (%s): crossed = %v, want %v", tc.name, source, got, tc.want)
+			}
+		}
+	}
+}
+
+func TestTokenQuotePriceFor(t *testing.T) {
+	cases := []struct {
+		name   string
+		quote  tokenQuote
+		source models.TriggerSource
+		want   float64
+	}{
+		{"bid", tokenQuote{BestBid: 0.40, BestAsk: 0.44, LastTrade: 0.42}, models.TriggerSourceBid, 0.40},
+		{"default is bid", tokenQuote{BestBid: 0.40, BestAsk: 0.44}, "", 0.40},
+		{"mid", tokenQuote{BestBid: 0.40, BestAsk: 0.44}, models.TriggerSourceMid, 0.42},
+		{"mid without bid", tokenQuote{BestAsk: 0.44}, models.TriggerSourceMid, 0},
+		{"mid without ask", tokenQuote{BestBid: 0.40}, models.TriggerSourceMid, 0},
+		{"mid crossed book", tokenQuote{BestBid: 0.45, BestAsk: 0.44}, models.TriggerSourceMid, 0},
+		{"last trade", tokenQuote{BestBid: 0.40, LastTrade: 0.37}, models.TriggerSourceLastTrade, 0.37},
+		{"no last trade", tokenQuote{BestBid: 0.40}, models.TriggerSourceLastTrade, 0},
+	}
+	for _, tc := range cases {
+		if got := tc.quote.priceFor(tc.source); got < tc.want-1e-9 || got > tc.want+1e-9 {
+			t.Errorf("%s: price = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestConditionalOrderValidateInputRejects(t *testing.T) {
+	valid := func() ConditionalOrderInput {
+		var order clob.Order
+		if err := json.Unmarshal([]byte(`{
+			"salt": 12345,
+			"maker": "0x000000000000000000000000000000000000dEaD",
+			"signer": "0x000000000000000000000000000000000000bEEF",
+			"taker": "0x0000000000000000000000000000000000000000",
+			"tokenId": "1234",
+			"makerAmount": "100000000",
+			"takerAmount": "40000000",
+			"expiration": "0",
+			"nonce": "0",
+			"feeRateBps": "0",
+			"side": "SELL",
+			"signatureType": 0,
+			"signature": "0x01"
+		}`), &order); err != nil {
+			t.Fatalf("decode order: %v", err)
+		}
+		return ConditionalOrderInput{
+			Kind:          models.ConditionalOrderStopLoss,
+			TriggerSource: models.TriggerSourceBid,
+			TriggerPrice:  0.40,
+			Order:         clob.PostOrderRequest{Order: order, Owner: "key", OrderType: clob.OrderTypeFOK},
+			Credentials:   clob.APIKeyCredentials{Key: "key", Secret: "secret", Passphrase: "pass"},
+		}
+	}
+
+	cases := []struct {
+		name   string
+		want   string
+		mutate func(*ConditionalOrderInput)
+	}{
+		{"unknown kind", "kind must be", func(in *ConditionalOrderInput) { in.Kind = "TRAILING" }},
+		{"unknown trigger source", "triggerSource must be", func(in *ConditionalOrderInput) { in.TriggerSource = "ASK" }},
+		{"zero trigger price", "triggerPrice must be", func(in *ConditionalOrderInput) { in.TriggerPrice = 0 }},
+		{"trigger price of one", "triggerPrice must be", func(in *ConditionalOrderInput) { in.TriggerPrice = 1 }},
+		{"negative trigger price", "triggerPrice must be", func(in *ConditionalOrderInput) { in.TriggerPrice = -0.2 }},
+		{"GTC order", "orderType must be FOK or FAK", func(in *ConditionalOrderInput) { in.Order.OrderType = clob.OrderTypeGTC }},
+		{"GTD order", "orderType must be FOK or FAK", func(in *ConditionalOrderInput) { in.Order.OrderType = clob.OrderTypeGTD }},
+		{"BUY order", "only SELL orders", func(in *ConditionalOrderInput) { in.Order.Order.Side = clob.BUY }},
+		{"BUY order by code", "only SELL orders", func(in *ConditionalOrderInput) { in.Order.Order.Side = "0" }},
+		{"unsigned order", "order.signature is required", func(in *ConditionalOrderInput) { in.Order.Order.Signature = "" }},
+		{"missing credentials", "credentials key, secret and passphrase", func(in *ConditionalOrderInput) { in.Credentials.Secret = "" }},
+		{"owner mismatch", "order owner must match", func(in *ConditionalOrderInput) { in.Order.Owner = "other" }},
+	}
+
+	// Every rejection here happens before the service touches the database.
+	svc := &ConditionalOrderService{}
+	for _, tc := range cases {
+		input := valid()
+		tc.mutate(&input)
+		_, err := svc.validateInput(context.Background(), &models.User{}, &input)
+		if !errors.Is(err, ErrInvalidConditionalOrder) || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: err = %v, want %v (%s)", tc.name, err, ErrInvalidConditionalOrder, tc.want)
+		}
+	}
+}
@@ -0,0 +1,621 @@
+/**
+ * @description
+ * CTF Service.
+ * Builds gasless Safe transactions against the Conditional Tokens contracts for a user's vault: split USDC.e into
+ * YES + NO sets, merge sets back, redeem winnings in resolved markets and grant the token approvals trading
+ * needs. Every action follows the same flow:
+ * prepare (calldata + SafeTx typed data), user signs the hash, submit through the Polymarket relayer.
+ *
+ * @dependencies
+ * - backend/internal/polymarket/relayer
+ * - backend/internal/polymarket/data_api
+ * - github.com/redis/go-redis/v9
+ *
+ * @notes
+ * - Only Safe vaults are supported; proxy wallets use a different relay transaction type and are rejected
+ *   with ErrCTFUnsupportedWalletType so clients can tell them apart from users without a vault.
+ * - Prepared plans live in Redis for ctfPlanTTL. Submit checks the signature first, then deletes the plan as
+ *   its claim, so a signature is used once and a bad signature does not burn the plan.
+ * - Neg risk markets route through the adapter; others call the CTF directly.
+ * - Submitted relayer transaction IDs are bound to the user in Redis so status lookups stay private.
+ * - When Transactions is set, every submission (accepted or rejected) is also recorded in relayer_transactions.
+ * - The sweep cannot redeem on its own (the owner must sign); it notifies users about new redeemable markets.
+ * - Approval plans only accept entries from relayer.RequiredTradingApprovals, so a client cannot approve
+ *   arbitrary spenders through this endpoint.
+ */
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/polymarket/data_api"
+	"github.com/bankai-project/backend/internal/polymarket/relayer"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+const (
+	ctfPlanTTL             = 10 * time.Minute
+	ctfTransactionOwnerTTL = 30 * 24 * time.Hour
+	redeemPositionsLimit   = 500
+	redeemNotifyDedupTTL   = 30 * 24 * time.Hour
+	redeemSweepBatchSize   = 200
+	redeemMaxConditionsTx  = 20
+	ctfShareDecimalsFactor = 1e6
+)
+
+// CTF plan kinds
+const (
+	CTFPlanSplit   = "SPLIT"
+	CTFPlanMerge   = "MERGE"
+	CTFPlanRedeem  = "REDEEM"
+	CTFPlanApprove = "APPROVE"
+)
+
+var (
+	ErrInvalidCTFRequest        = errors.New("invalid CTF request")
+	ErrNothingToRedeem          = errors.New("no redeemable positions")
+	ErrCTFPlanNotFound          = errors.New("CTF plan not found or expired")
+	ErrCTFUnsupportedVault      = errors.New("CTF transactions require a deployed Safe vault")
+	ErrCTFUnsupportedWalletType = errors.New("wallet type is not supported for CTF transactions")
+	ErrCTFTransactionNotFound   = errors.New("CTF transaction not found")
+)
+
+// CTFService builds and relays Conditional Tokens transactions for user vaults
+type CTFService struct {
+	db      *gorm.DB
+	redis   *redis.Client
+	relayer *relayer.Client
+	dataAPI *data_api.Client
+
+	Transactions *RelayerTransactionService // Optional history of relayed transactions
+}
+
+// NewCTFService creates a new CTFService
+func NewCTFService(db *gorm.DB, rdb *redis.Client, relayerClient *relayer.Client, dataAPIClient *data_api.Client) *CTFService {
+	return &CTFService{
+		db:      db,
+		redis:   rdb,
+		relayer: relayerClient,
+		dataAPI: dataAPIClient,
+	}
+}
+
+// RedeemablePosition is one outcome balance in a resolved market
+type RedeemablePosition struct {
+	TokenID      string  `json:"tokenId"`
+	Outcome      string  `json:"outcome"`
+	OutcomeIndex int     `json:"outcomeIndex"`
+	Size         float64 `json:"size"`
+	Payout       float64 `json:"payout"` // USDC received when redeemed
+}
+
+// RedeemableCondition groups a vault's balances in one resolved condition
+type RedeemableCondition struct {
+	ConditionID string               `json:"conditionId"`
+	Title       string               `json:"title"`
+	Slug        string               `json:"slug"`
+	NegRisk     bool                 `json:"negRisk"`
+	Payout      float64              `json:"payout"`
+	Positions   []RedeemablePosition `json:"positions"`
+}
+
+// CTFPlan is a prepared, unsigned Safe transaction
+type CTFPlan struct {
+	ID          string                   `json:"id"`
+	Kind        string                   `json:"kind"`
+	Safe        string                   `json:"safe"`
+	Signer      string                   `json:"signer"`
+	ConditionID string                   `json:"conditionId,omitempty"` // Split / merge
+	NegRisk     bool                     `json:"negRisk,omitempty"`     // Split / merge
+	Amount      float64                  `json:"amount,omitempty"`      // Split: USDC.e in, merge: full sets in
+	Conditions  []RedeemableCondition    `json:"conditions,omitempty"`  // Redeem
+	Payout      float64                  `json:"payout,omitempty"`      // Redeem
+	Approvals   []relayer.TokenApproval  `json:"approvals,omitempty"`   // Approve
+	Transaction relayer.SafeTransaction  `json:"transaction"`
+	Nonce       string                   `json:"nonce"`
+	TypedData   *relayer.SafeTxTypedData `json:"typedData"`
+	SafeTxHash  string                   `json:"safeTxHash"` // personal_sign this hash
+	ExpiresAt   time.Time                `json:"expiresAt"`
+}
+
+// CTFSubmission is the relayer's answer to a submitted plan
+type CTFSubmission struct {
+	TransactionID   string `json:"transactionId"`
+	TransactionHash string `json:"transactionHash,omitempty"`
+	State           string `json:"state"`
+	Kind            string `json:"kind"`
+}
+
+// storedCTFPlan is the Redis copy of a plan, bound to its user.
+type storedCTFPlan struct {
+	UserID      uuid.UUID               `json:"user_id"`
+	Kind        string                  `json:"kind"`
+	Safe        string                  `json:"safe"`
+	Signer      string                  `json:"signer"`
+	Transaction relayer.SafeTransaction `json:"transaction"`
+	Nonce       string                  `json:"nonce"`
+	SafeTxHash  string                  `json:"safe_tx_hash"`
+}
+
+// ctfTransactionOwner binds a relayer transaction ID to the user who submitted it.
+type ctfTransactionOwner struct {
+	UserID uuid.UUID `json:"user_id"`
+	Kind   string    `json:"kind"`
+}
+
+// ListRedeemable returns the vault's redeemable balances grouped by condition.
+func (s *CTFService) ListRedeemable(ctx context.Context, vault string) ([]RedeemableCondition, error) {
+	vault = strings.TrimSpace(vault)
+	if vault == "" {
+		return nil, fmt.Errorf("%w: vault address is required", ErrInvalidCTFRequest)
+	}
+
+	positions, err := s.dataAPI.GetPositions(ctx, vault, &data_api.PositionsParams{
+		Limit:      redeemPositionsLimit,
+		Redeemable: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load positions: %w", err)
+	}
+
+	byCondition := make(map[string]*RedeemableCondition)
+	order := make([]string, 0)
+	for _, p := range positions {
+		if !p.Redeemable || p.Size <= 0 || p.ConditionID == "" {
+			continue
+		}
+		cond, ok := byCondition[p.ConditionID]
+		if !ok {
+			cond = &RedeemableCondition{
+				ConditionID: p.ConditionID,
+				Title:       p.Title,
+				Slug:        p.Slug,
+				NegRisk:     p.NegativeRisk,
+			}
+			byCondition[p.ConditionID] = cond
+			order = append(order, p.ConditionID)
+		}
+		tokenID := p.TokenID
+		if tokenID == "" {
+			tokenID = p.Asset
+		}
+		cond.Positions = append(cond.Positions, RedeemablePosition{
+			TokenID:      tokenID,
+			Outcome:      p.Outcome,
+			OutcomeIndex: p.OutcomeIndex,
+			Size:         p.Size,
+			Payout:       p.CurrentValue,
+		})
+		cond.Payout += p.CurrentValue
+	}
+
+	// Prefer our own market record for the neg risk flag when we have it.
+	if len(order) > 0 {
+		var markets []models.Market
+		if err := s.db.WithContext(ctx).Select("condition_id", "neg_risk").
+			Where("condition_id IN ?", order).Find(&markets).Error; err != nil {
+			logger.Error("CTFService: Failed to load markets for neg risk flags: %v", err)
+		}
+		for _, m := range markets {
+			if cond, ok := byCondition[m.ConditionID]; ok {
+				cond.NegRisk = m.NegRisk
+			}
+		}
+	}
+
+	result := make([]RedeemableCondition, 0, len(order))
+	for _, id := range order {
+		result = append(result, *byCondition[id])
+	}
+	return result, nil
+}
+
+// PrepareRedeem builds a redemption for the given conditions (all redeemable ones when empty).
+func (s *CTFService) PrepareRedeem(ctx context.Context, user *models.User, conditionIDs []string) (*CTFPlan, error) {
+	if err := requireSafeVault(user); err != nil {
+		return nil, err
+	}
+
+	available, err := s.ListRedeemable(ctx, user.VaultAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool)
+	for _, id := range conditionIDs {
+		if id = strings.ToLower(strings.TrimSpace(id)); id != "" {
+			wanted[id] = true
+		}
+	}
+
+	selected := make([]RedeemableCondition, 0, len(available))
+	for _, cond := range available {
+		if len(wanted) == 0 || wanted[strings.ToLower(cond.ConditionID)] {
+			selected = append(selected, cond)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, ErrNothingToRedeem
+	}
+	if len(selected) > redeemMaxConditionsTx {
+		selected = selected[:redeemMaxConditionsTx]
+	}
+
+	txs := make([]relayer.SafeTransaction, 0, len(selected))
+	plan := &CTFPlan{Kind: CTFPlanRedeem, Conditions: selected}
+	for _, cond := range selected {
+		tx, err := buildRedeemTransaction(cond)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCTFRequest, err)
+		}
+		txs = append(txs, tx)
+		plan.Payout += cond.Payout
+	}
+
+	if err := s.preparePlan(ctx, user, plan, txs); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// PrepareSplit builds a split of amount USDC.e into YES + NO sets of a condition.
+func (s *CTFService) PrepareSplit(ctx context.Context, user *models.User, conditionID string, amount float64) (*CTFPlan, error) {
+	return s.prepareSplitMerge(ctx, user, CTFPlanSplit, conditionID, amount)
+}
+
+// PrepareMerge builds a merge of amount YES + NO sets of a condition back into USDC.e.
+func (s *CTFService) PrepareMerge(ctx context.Context, user *models.User, conditionID string, amount float64) (*CTFPlan, error) {
+	return s.prepareSplitMerge(ctx, user, CTFPlanMerge, conditionID, amount)
+}
+
+func (s *CTFService) prepareSplitMerge(ctx context.Context, user *models.User, kind, conditionID string, amount float64) (*CTFPlan, error) {
+	if err := requireSafeVault(user); err != nil {
+		return nil, err
+	}
+	conditionID = strings.TrimSpace(conditionID)
+	if conditionID == "" {
+		return nil, fmt.Errorf("%w: conditionId is required", ErrInvalidCTFRequest)
+	}
+	units := ctfUnits(amount)
+	if units.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: amount must be at least 0.000001", ErrInvalidCTFRequest)
+	}
+
+	var market models.Market
+	if err := s.db.WithContext(ctx).Where("condition_id = ?", conditionID).First(&market).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: unknown market %s", ErrInvalidCTFRequest, conditionID)
+		}
+		return nil, fmt.Errorf("failed to load market: %w", err)
+	}
+	if kind == CTFPlanSplit && (market.Closed || market.Archived) {
+		return nil, fmt.Errorf("%w: cannot split positions in a closed market", ErrInvalidCTFRequest)
+	}
+
+	var (
+		txs []relayer.SafeTransaction
+		err error
+	)
+	if kind == CTFPlanSplit {
+		txs, err = relayer.BuildSplitPositionTransactions(market.ConditionID, units, market.NegRisk)
+	} else {
+		txs, err = relayer.BuildMergePositionsTransactions(market.ConditionID, units, market.NegRisk)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCTFRequest, err)
+	}
+
+	plan := &CTFPlan{
+		Kind:        kind,
+		ConditionID: market.ConditionID,
+		NegRisk:     market.NegRisk,
+		Amount:      amount,
+	}
+	if err := s.preparePlan(ctx, user, plan, txs); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// PrepareApprovals builds one batched Safe transaction granting the given trading approvals.
+func (s *CTFService) PrepareApprovals(ctx context.Context, user *models.User, approvals []relayer.TokenApproval) (*CTFPlan, error) {
+	if err := requireSafeVault(user); err != nil {
+		return nil, err
+	}
+
+	allowed := make(map[string]bool)
+	for _, a := range relayer.RequiredTradingApprovals() {
+		allowed[approvalKey(a)] = true
+	}
+
+	seen := make(map[string]bool)
+	selected := make([]relayer.TokenApproval, 0, len(approvals))
+	txs := make([]relayer.SafeTransaction, 0, len(approvals))
+	for _, a := range approvals {
+		key := approvalKey(a)
+		if !allowed[key] {
+			return nil, fmt.Errorf("%w: %s approval of %s for %s is not a trading approval", ErrInvalidCTFRequest, a.Kind, a.Token, a.Spender)
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		tx, err := relayer.BuildApprovalTransaction(a)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCTFRequest, err)
+		}
+		selected = append(selected, a)
+		txs = append(txs, tx)
+	}
+	if len(txs) == 0 {
+		return nil, fmt.Errorf("%w: no approvals to grant", ErrInvalidCTFRequest)
+	}
+
+	plan := &CTFPlan{Kind: CTFPlanApprove, Approvals: selected}
+	if err := s.preparePlan(ctx, user, plan, txs); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// preparePlan batches txs, fetches the Safe nonce, fills plan's signing fields and stores it for submit.
+func (s *CTFService) preparePlan(ctx context.Context, user *models.User, plan *CTFPlan, txs []relayer.SafeTransaction) error {
+	tx, err := relayer.AggregateSafeTransactions(txs)
+	if err != nil {
+		return err
+	}
+	nonce, err := s.relayer.GetNonce(ctx, user.EOAAddress, relayer.TransactionTypeSafe)
+	if err != nil {
+		return fmt.Errorf("failed to fetch safe nonce: %w", err)
+	}
+	typed, hash, err := relayer.BuildSafeTxTypedData(user.VaultAddress, tx, nonce)
+	if err != nil {
+		return err
+	}
+
+	plan.ID = uuid.NewString()
+	plan.Safe = user.VaultAddress
+	plan.Signer = user.EOAAddress
+	plan.Transaction = tx
+	plan.Nonce = nonce
+	plan.TypedData = typed
+	plan.SafeTxHash = hash
+	plan.ExpiresAt = time.Now().Add(ctfPlanTTL).UTC()
+
+	stored, err := json.Marshal(storedCTFPlan{
+		UserID:      user.ID,
+		Kind:        plan.Kind,
+		Safe:        plan.Safe,
+		Signer:      plan.Signer,
+		Transaction: tx,
+		Nonce:       nonce,
+		SafeTxHash:  hash,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode CTF plan: %w", err)
+	}
+	if err := s.redis.Set(ctx, ctfPlanKey(plan.ID), stored, ctfPlanTTL).Err(); err != nil {
+		return fmt.Errorf("failed to store CTF plan: %w", err)
+	}
+	return nil
+}
+
+// SubmitPlan relays a prepared plan signed by the vault owner.
+func (s *CTFService) SubmitPlan(ctx context.Context, user *models.User, planID, signature string) (*CTFSubmission, error) {
+	key := ctfPlanKey(planID)
+	raw, err := s.redis.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrCTFPlanNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load CTF plan: %w", err)
+	}
+
+	var plan storedCTFPlan
+	if err := json.Unmarshal(raw, &plan); err != nil {
+		return nil, fmt.Errorf("failed to decode CTF plan: %w", err)
+	}
+	if plan.UserID != user.ID {
+		return nil, ErrCTFPlanNotFound
+	}
+
+	signer, err := relayer.RecoverSafeTxSigner(plan.SafeTxHash, signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCTFRequest, err)
+	}
+	if !strings.EqualFold(signer, plan.Signer) {
+		return nil, fmt.Errorf("%w: signature is from %s, expected %s", ErrInvalidCTFRequest, signer, plan.Signer)
+	}
+
+	// Deleting the plan is the claim: only the request that removes it relays, so a signature is used once.
+	claimed, err := s.redis.Del(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim CTF plan: %w", err)
+	}
+	if claimed == 0 {
+		return nil, ErrCTFPlanNotFound
+	}
+
+	req, err := relayer.BuildSafeTransactionRequest(plan.Signer, plan.Safe, plan.Transaction, plan.Nonce, signature, strings.ToLower(plan.Kind))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCTFRequest, err)
+	}
+	resp, err := s.relayer.SubmitSafeTransaction(ctx, req)
+	if s.Transactions != nil {
+		if _, recErr := s.Transactions.Record(ctx, user.ID, models.RelayerTransactionType(plan.Kind), req, plan.SafeTxHash, resp, err); recErr != nil {
+			logger.Error("CTFService: %v", recErr)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	submission := &CTFSubmission{
+		TransactionID:   resp.ID(),
+		TransactionHash: resp.TransactionHash,
+		State:           resp.State,
+		Kind:            plan.Kind,
+	}
+	if submission.TransactionID != "" {
+		owner, _ := json.Marshal(ctfTransactionOwner{UserID: user.ID, Kind: plan.Kind})
+		if err := s.redis.Set(ctx, ctfTransactionKey(submission.TransactionID), owner, ctfTransactionOwnerTTL).Err(); err != nil {
+			logger.Error("CTFService: Failed to record transaction owner for %s: %v", submission.TransactionID, err)
+		}
+	}
+
+	logger.Info("CTFService: Relayed %s for user %s (relayer tx %s)", plan.Kind, user.ID, submission.TransactionID)
+	return submission, nil
+}
+
+// GetTransactionStatus returns the relayer state of a transaction the user submitted.
+func (s *CTFService) GetTransactionStatus(ctx context.Context, user *models.User, transactionID string) (*relayer.RelayerTransaction, error) {
+	raw, err := s.redis.Get(ctx, ctfTransactionKey(transactionID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrCTFTransactionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load transaction owner: %w", err)
+	}
+	var owner ctfTransactionOwner
+	if err := json.Unmarshal(raw, &owner); err != nil || owner.UserID != user.ID {
+		return nil, ErrCTFTransactionNotFound
+	}
+
+	return s.relayer.GetTransaction(ctx, transactionID)
+}
+
+// SweepRedeemable notifies Safe vault owners about markets that became redeemable. Returns notifications sent.
+func (s *CTFService) SweepRedeemable(ctx context.Context) (int, error) {
+	sent := 0
+	var users []models.User
+	err := s.db.WithContext(ctx).
+		Where("wallet_type = ? AND vault_address IS NOT NULL AND vault_address <> ''", models.WalletTypeSafe).
+		FindInBatches(&users, redeemSweepBatchSize, func(tx *gorm.DB, batch int) error {
+			for i := range users {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				n, err := s.notifyRedeemable(ctx, &users[i])
+				if err != nil {
+					logger.Error("CTFService: Redeem sweep failed for user %s: %v", users[i].ID, err)
+					continue
+				}
+				sent += n
+			}
+			return nil
+		}).Error
+	return sent, err
+}
+
+func (s *CTFService) notifyRedeemable(ctx context.Context, user *models.User) (int, error) {
+	conditions, err := s.ListRedeemable(ctx, user.VaultAddress)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, cond := range conditions {
+		if cond.Payout <= 0 {
+			continue
+		}
+		key := fmt.Sprintf("ctf:redeemable_notified:%s:%s", user.ID, cond.ConditionID)
+		ok, err := s.redis.SetNX(ctx, key, "1", redeemNotifyDedupTTL).Result()
+		if err != nil {
+			return sent, err
+		}
+		if !ok {
+			continue
+		}
+
+		data, err := json.Marshal(cond)
+		if err != nil {
+			return sent, err
+		}
+		notification := models.Notification{
+			ID:        uuid.New(),
+			UserID:    user.ID,
+			Type:      models.NotificationTypeRedeemable,
+			Title:     "Winnings ready to redeem",
+			Message:   fmt.Sprintf("%s resolved. Redeem %.2f USDC to your vault.", cond.Title, cond.Payout),
+			Data:      string(data),
+			Read:      false,
+			CreatedAt: time.Now(),
+		}
+		if err := s.db.WithContext(ctx).Create(&notification).Error; err != nil {
+			return sent, err
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// buildRedeemTransaction picks the CTF or neg risk adapter call for a condition.
+func buildRedeemTransaction(cond RedeemableCondition) (relayer.SafeTransaction, error) {
+	if !cond.NegRisk {
+		return relayer.BuildRedeemPositionsTransaction(cond.ConditionID)
+	}
+
+	var amounts [2]*big.Int
+	for _, p := range cond.Positions {
+		if p.OutcomeIndex < 0 || p.OutcomeIndex > 1 {
+			return relayer.SafeTransaction{}, fmt.Errorf("unexpected outcome index %d for neg risk condition %s", p.OutcomeIndex, cond.ConditionID)
+		}
+		units := ctfUnits(p.Size)
+		if amounts[p.OutcomeIndex] != nil {
+			units.Add(units, amounts[p.OutcomeIndex])
+		}
+		amounts[p.OutcomeIndex] = units
+	}
+	return relayer.BuildNegRiskRedeemTransaction(cond.ConditionID, amounts)
+}
+
+// CheckWallet reports whether the user's wallet can sign CTF plans; nil means it can.
+func (s *CTFService) CheckWallet(user *models.User) error {
+	return requireSafeVault(user)
+}
+
+func requireSafeVault(user *models.User) error {
+	if user.WalletType != nil && *user.WalletType != models.WalletTypeSafe {
+		return fmt.Errorf("%w: %s wallets cannot sign Safe transactions; split, merge, redeem and approvals need a Safe vault",
+			ErrCTFUnsupportedWalletType, *user.WalletType)
+	}
+	if user.WalletType == nil || user.VaultAddress == "" {
+		return ErrCTFUnsupportedVault
+	}
+	if user.EOAAddress == "" {
+		return fmt.Errorf("%w: no connected signer wallet", ErrInvalidCTFRequest)
+	}
+	return nil
+}
+
+// ctfUnits converts a USDC.e / share amount to 6-decimal base units, rounding down.
+func ctfUnits(amount float64) *big.Int {
+	if amount <= 0 || math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return big.NewInt(0)
+	}
+	return new(big.Int).SetUint64(uint64(math.Floor(amount * ctfShareDecimalsFactor)))
+}
+
+func approvalKey(a relayer.TokenApproval) string {
+	return strings.ToLower(fmt.Sprintf("%s:%s:%s", a.Kind, a.Token, a.Spender))
+}
+
+func ctfPlanKey(id string) string {
+	return fmt.Sprintf("ctf:plan:%s", id)
+}
+
+func ctfTransactionKey(id string) string {
+	return fmt.Sprintf("ctf:tx_owner:%s", id)
+}
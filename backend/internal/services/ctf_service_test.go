@@ -0,0 +1,38 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bankai-project/backend/internal/models"
+)
+
+func TestRequireSafeVault(t *testing.T) {
+	safe, proxy := models.WalletTypeSafe, models.WalletTypeProxy
+	const vault = "0x000000000000000000000000000000000000dEaD"
+	const eoa = "0x000000000000000000000000000000000000bEEF"
+
+	cases := []struct {
+		name string
+		user models.User
+		want error
+	}{
+		{"safe vault", models.User{WalletType: &safe, VaultAddress: vault, EOAAddress: eoa}, nil},
+		{"proxy wallet", models.User{WalletType: &proxy, VaultAddress: vault, EOAAddress: eoa}, ErrCTFUnsupportedWalletType},
+		{"no wallet type", models.User{VaultAddress: vault, EOAAddress: eoa}, ErrCTFUnsupportedVault},
+		{"no vault", models.User{WalletType: &safe, EOAAddress: eoa}, ErrCTFUnsupportedVault},
+		{"no signer", models.User{WalletType: &safe, VaultAddress: vault}, ErrInvalidCTFRequest},
+	}
+	for _, tc := range cases {
+		err := requireSafeVault(&tc.user)
+		if tc.want == nil {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tc.name, err)
+			}
+			continue
+		}
+		if !errors.Is(err, tc.want) {
+			t.Errorf("%s: err = %v, want %v", tc.name, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+/**
+ * @description
+ * Deposit Watcher Service.
+ * Scans USDC and USDC.e Transfer logs into known vault addresses, records each one in wallet_transfers,
+ * invalidates the vault's cached balance and notifies the owner of deposits.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - github.com/redis/go-redis/v9
+ * - github.com/ethereum/go-ethereum
+ * - backend/internal/services (BlockchainService)
+ *
+ * @notes
+ * - Progress is persisted in chain_cursors under depositCursorName; a fresh install starts depositInitialLookback
+ *   blocks behind the safe head instead of replaying history.
+ * - Only blocks at least depositConfirmations deep are scanned, so Polygon reorgs do not record phantom deposits.
+ * - Ranges are capped at depositMaxBlockRange blocks and recipients at depositRecipientChunk per query to stay
+ *   within public RPC limits.
+ * - (tx_hash, log_index) is unique, so a rescan after a crash never double-notifies.
+ * - Collateral sent by the exchanges, the CTF or the neg risk adapter is a sell fill, merge or redemption
+ *   payout, not a deposit: it is stored as SETTLEMENT and does not notify.
+ */
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/polymarket/relayer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	depositCursorName       = "usdc_deposits"
+	depositConfirmations    = 64
+	depositInitialLookback  = 2000
+	depositMaxBlockRange    = 2000
+	depositRecipientChunk   = 200
+	depositTokenDecimalsPow = 1e6
+)
+
+// depositTokens are the ERC20s watched for vault deposits, keyed by contract address
+var depositTokens = map[common.Address]string{
+	common.HexToAddress(USDCAddressPolygon):             "USDC",
+	common.HexToAddress(relayer.CollateralTokenAddress): "USDC.e",
+}
+
+// settlementSenders are Polymarket contracts whose USDC.e transfers into a vault settle trades or positions
+var settlementSenders = map[common.Address]bool{
+	common.HexToAddress(relayer.CTFExchangeAddress):        true,
+	common.HexToAddress(relayer.NegRiskCTFExchangeAddress): true,
+	common.HexToAddress(relayer.ConditionalTokensAddress):  true,
+	common.HexToAddress(relayer.NegRiskAdapterAddress):     true,
+}
+
+// transferKind classifies an inflow by its sender.
+func transferKind(from common.Address) models.TransferKind {
+	if settlementSenders[from] {
+		return models.TransferKindSettlement
+	}
+	return models.TransferKindDeposit
+}
+
+// DepositWatcherService detects USDC deposits into user vaults
+type DepositWatcherService struct {
+	db         *gorm.DB
+	redis      *redis.Client
+	blockchain *BlockchainService
+}
+
+// NewDepositWatcherService creates a new DepositWatcherService
+func NewDepositWatcherService(db *gorm.DB, rdb *redis.Client, blockchain *BlockchainService) *DepositWatcherService {
+	return &DepositWatcherService{
+		db:         db,
+		redis:      rdb,
+		blockchain: blockchain,
+	}
+}
+
+// Scan processes every confirmed block since the cursor. Returns the number of new deposits recorded.
+func (s *DepositWatcherService) Scan(ctx context.Context) (int, error) {
+	head, err := s.blockchain.LatestBlockNumber(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if head <= depositConfirmations {
+		return 0, nil
+	}
+	safe := head - depositConfirmations
+
+	cursor, err := s.loadCursor(ctx, safe)
+	if err != nil {
+		return 0, err
+	}
+	if cursor >= safe {
+		return 0, nil
+	}
+
+	vaults, err := s.vaultOwners(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(vaults) == 0 {
+		return 0, s.saveCursor(ctx, safe)
+	}
+	recipients := make([]common.Address, 0, len(vaults))
+	for addr := range vaults {
+		recipients = append(recipients, addr)
+	}
+	tokens := make([]common.Address, 0, len(depositTokens))
+	for addr := range depositTokens {
+		tokens = append(tokens, addr)
+	}
+
+	recorded := 0
+	for from := cursor + 1; from <= safe; from += depositMaxBlockRange {
+		to := from + depositMaxBlockRange - 1
+		if to > safe {
+			to = safe
+		}
+
+		for start := 0; start < len(recipients); start += depositRecipientChunk {
+			end := start + depositRecipientChunk
+			if end > len(recipients) {
+				end = len(recipients)
+			}
+			logs, err := s.blockchain.FilterTransferLogs(ctx, tokens, recipients[start:end], from, to)
+			if err != nil {
+				return recorded, err
+			}
+			for _, log := range logs {
+				created, err := s.recordDeposit(ctx, log, vaults)
+				if err != nil {
+					return recorded, err
+				}
+				if created {
+					recorded++
+				}
+			}
+		}
+
+		if err := s.saveCursor(ctx, to); err != nil {
+			return recorded, err
+		}
+	}
+	return recorded, nil
+}
+
+// recordDeposit stores a Transfer log and, when it is new, invalidates the balance cache. Only deposits notify
+// the owner and count towards the returned flag.
+func (s *DepositWatcherService) recordDeposit(ctx context.Context, log types.Log, vaults map[common.Address]uuid.UUID) (bool, error) {
+	if log.Removed || len(log.Topics) != 3 || len(log.Data) != 32 {
+		return false, nil
+	}
+	token, ok := depositTokens[log.Address]
+	if !ok {
+		return false, nil
+	}
+	fromAddr := common.BytesToAddress(log.Topics[1].Bytes())
+	toAddr := common.BytesToAddress(log.Topics[2].Bytes())
+	userID, ok := vaults[toAddr]
+	if !ok || fromAddr == toAddr {
+		return false, nil
+	}
+	amount := new(big.Int).SetBytes(log.Data)
+	if amount.Sign() == 0 {
+		return false, nil
+	}
+	amountUSDC, _ := new(big.Float).Quo(new(big.Float).SetInt(amount), big.NewFloat(depositTokenDecimalsPow)).Float64()
+
+	transfer := &models.WalletTransfer{
+		UserID:       userID,
+		VaultAddress: toAddr.Hex(),
+		Direction:    models.TransferDirectionIn,
+		Kind:         transferKind(fromAddr),
+		Token:        token,
+		TokenAddress: log.Address.Hex(),
+		FromAddress:  fromAddr.Hex(),
+		Amount:       amount.String(),
+		AmountUSDC:   amountUSDC,
+		TxHash:       log.TxHash.Hex(),
+		LogIndex:     log.Index,
+		BlockNumber:  log.BlockNumber,
+	}
+	result := s.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "tx_hash"}, {Name: "log_index"}}, DoNothing: true}).
+		Create(transfer)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to record deposit %s:%d: %w", transfer.TxHash, transfer.LogIndex, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	s.blockchain.InvalidateBalance(transfer.VaultAddress)
+	if err := s.redis.Publish(ctx, WalletBalanceChannel, transfer.VaultAddress).Err(); err != nil {
+		logger.Error("DepositWatcherService: Failed to publish balance invalidation: %v", err)
+	}
+	if transfer.Kind != models.TransferKindDeposit {
+		return false, nil
+	}
+	if err := s.notify(ctx, transfer); err != nil {
+		logger.Error("DepositWatcherService: Failed to notify user %s of deposit %s: %v", userID, transfer.TxHash, err)
+	}
+
+	logger.Info("DepositWatcherService: %.2f %s deposited to %s (tx %s)", amountUSDC, token, transfer.VaultAddress, transfer.TxHash)
+	return true, nil
+}
+
+func (s *DepositWatcherService) notify(ctx context.Context, transfer *models.WalletTransfer) error {
+	data, err := json.Marshal(transfer)
+	if err != nil {
+		return err
+	}
+	notification := models.Notification{
+		ID:        uuid.New(),
+		UserID:    transfer.UserID,
+		Type:      models.NotificationTypeDeposit,
+		Title:     "Deposit received",
+		Message:   fmt.Sprintf("%.2f %s arrived in your vault.", transfer.AmountUSDC, transfer.Token),
+		Data:      string(data),
+		Read:      false,
+		CreatedAt: time.Now(),
+	}
+	return s.db.WithContext(ctx).Create(&notification).Error
+}
+
+// vaultOwners maps every known vault address (primary and linked wallets) to its user. Linked wallets win over
+// user rows, primary links first, then the earliest; the first owner found for an address keeps it.
+func (s *DepositWatcherService) vaultOwners(ctx context.Context) (map[common.Address]uuid.UUID, error) {
+	var linked []models.UserWallet
+	if err := s.db.WithContext(ctx).
+		Select("user_id", "vault_address").
+		Where("vault_address IS NOT NULL AND vault_address <> ''").
+		Order("is_primary DESC, created_at ASC, id ASC").
+		Find(&linked).Error; err != nil {
+		return nil, fmt.Errorf("failed to load linked vault addresses: %w", err)
+	}
+	var users []models.User
+	if err := s.db.WithContext(ctx).
+		Select("id", "vault_address").
+		Where("vault_address IS NOT NULL AND vault_address <> ''").
+		Order("created_at ASC, id ASC").
+		Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("failed to load vault addresses: %w", err)
+	}
+
+	vaults := make(map[common.Address]uuid.UUID, len(users)+len(linked))
+	claim := func(addr string, userID uuid.UUID) {
+		if !common.IsHexAddress(strings.TrimSpace(addr)) {
+			return
+		}
+		key := common.HexToAddress(addr)
+		if _, ok := vaults[key]; !ok {
+			vaults[key] = userID
+		}
+	}
+	for _, w := range linked {
+		claim(w.VaultAddress, w.UserID)
+	}
+	for _, u := range users {
+		claim(u.VaultAddress, u.ID)
+	}
+	return vaults, nil
+}
+
+// loadCursor returns the last processed block, seeding it behind safe on first run.
+func (s *DepositWatcherService) loadCursor(ctx context.Context, safe uint64) (uint64, error) {
+	var cursor models.ChainCursor
+	err := s.db.WithContext(ctx).Where("name = ?", depositCursorName).Limit(1).Find(&cursor).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to load deposit cursor: %w", err)
+	}
+	if cursor.Name != "" {
+		return cursor.BlockNumber, nil
+	}
+	if safe <= depositInitialLookback {
+		return 0, nil
+	}
+	return safe - depositInitialLookback, nil
+}
+
+func (s *DepositWatcherService) saveCursor(ctx context.Context, block uint64) error {
+	cursor := models.ChainCursor{Name: depositCursorName, BlockNumber: block, UpdatedAt: time.Now()}
+	if err := s.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"block_number", "updated_at"}),
+		}).
+		Create(&cursor).Error; err != nil {
+		return fmt.Errorf("failed to save deposit cursor: %w", err)
+	}
+	return nil
+}
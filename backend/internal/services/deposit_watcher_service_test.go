@@ -0,0 +1,31 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/polymarket/relayer"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestTransferKind(t *testing.T) {
+	cases := []struct {
+		name string
+		from string
+		want models.TransferKind
+	}{
+		{"CTF exchange sell fill", relayer.CTFExchangeAddress, models.TransferKindSettlement},
+		{"neg risk exchange sell fill", relayer.NegRiskCTFExchangeAddress, models.TransferKindSettlement},
+		{"CTF merge or redemption", relayer.ConditionalTokensAddress, models.TransferKindSettlement},
+		{"neg risk adapter payout", relayer.NegRiskAdapterAddress, models.TransferKindSettlement},
+		{"lowercase sender address", strings.ToLower(relayer.CTFExchangeAddress), models.TransferKindSettlement},
+		{"user wallet", "0x70997970C51812dc3A010C7d01b50e0d17dc79C8", models.TransferKindDeposit},
+		{"collateral token contract", relayer.CollateralTokenAddress, models.TransferKindDeposit},
+	}
+	for _, tc := range cases {
+		if got := transferKind(common.HexToAddress(tc.from)); got != tc.want {
+			t.Errorf("%s: transferKind = %s, want %s", tc.name, got, tc.want)
+		}
+	}
+}
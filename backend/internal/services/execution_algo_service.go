@@ -0,0 +1,957 @@
+/**
+ * @description
+ * Execution Algo Service.
+ * Schedules large orders as a series of pre-signed FOK/FAK child orders: TWAP releases one child per interval,
+ * iceberg releases one whenever the visible book within the limit covers the next child. Both pause while the
+ * top of book sits beyond the parent's limit price.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - backend/internal/polymarket/clob
+ * - backend/internal/secrets
+ * - backend/internal/services (TradeService, MarketService)
+ *
+ * @notes
+ * - The server cannot sign, so children are signed by the client up front or appended later; when the queue
+ *   runs dry the algo waits in WAITING_ORDERS and exposes the suggested size of the next child.
+ * - Children are marketable (FOK/FAK) so each release settles immediately and fills are read from the
+ *   CLOB's making/taking amounts.
+ * - The worker claims due algos by pushing next_release_at forward with a conditional UPDATE.
+ * - Each child passes the pre-trade risk check before it is posted; a blocked child counts as a failure.
+ * - A child left SUBMITTING by a crashed worker is recovered after algoChildStaleAfter: its order hash is
+ *   looked up in orders and the recorded outcome is folded in, otherwise the child fails like any rejection.
+ */
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/polymarket/clob"
+	"github.com/bankai-project/backend/internal/secrets"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	maxActiveExecutionAlgos  = 10
+	maxExecutionAlgoChildren = 200
+	minAlgoIntervalSeconds   = 5
+	maxAlgoIntervalSeconds   = 24 * 60 * 60
+	algoPollInterval         = 3 * time.Second
+	algoClaimLease           = 30 * time.Second
+	algoChildStaleAfter      = 5 * time.Minute
+	algoMaxFailures          = 5
+	algoSizeEpsilon          = 1e-6
+)
+
+var (
+	ErrExecutionAlgosDisabled = errors.New("execution algos are not enabled on this server")
+	ErrExecutionAlgoNotFound  = errors.New("execution algo not found")
+	ErrExecutionAlgoClosed    = errors.New("execution algo is no longer active")
+	ErrExecutionAlgoLimit     = fmt.Errorf("a maximum of %d active execution algos is allowed", maxActiveExecutionAlgos)
+	ErrInvalidExecutionAlgo   = errors.New("invalid execution algo")
+
+	activeAlgoStatuses = []models.ExecutionAlgoStatus{models.ExecutionAlgoRunning, models.ExecutionAlgoPaused, models.ExecutionAlgoWaitingOrders}
+)
+
+// ExecutionAlgoService stores execution algos and releases their child orders
+type ExecutionAlgoService struct {
+	db      *gorm.DB
+	trades  *TradeService
+	markets *MarketService
+	box     *secrets.Box
+}
+
+// NewExecutionAlgoService creates a new ExecutionAlgoService. box may be nil, which disables the feature.
+func NewExecutionAlgoService(db *gorm.DB, trades *TradeService, markets *MarketService, box *secrets.Box) *ExecutionAlgoService {
+	return &ExecutionAlgoService{
+		db:      db,
+		trades:  trades,
+		markets: markets,
+		box:     box,
+	}
+}
+
+// ExecutionAlgoInput is the request to start an execution algo
+type ExecutionAlgoInput struct {
+	Kind            models.ExecutionAlgoKind `json:"kind"`
+	TotalSize       float64                  `json:"totalSize"`
+	LimitPrice      float64                  `json:"limitPrice"`
+	IntervalSeconds int                      `json:"intervalSeconds"`
+	EndsAt          *time.Time               `json:"endsAt,omitempty"`
+	Children        []clob.PostOrderRequest  `json:"children"`
+	Credentials     clob.APIKeyCredentials   `json:"credentials"`
+}
+
+// ExecutionAlgoDetail is an algo with its children and derived progress
+type ExecutionAlgoDetail struct {
+	models.ExecutionAlgo
+	RemainingSize    float64                     `json:"remaining_size"`
+	QueuedSize       float64                     `json:"queued_size"`
+	AverageFillPrice float64                     `json:"average_fill_price"`
+	Progress         float64                     `json:"progress"` // filled / total, 0..1
+	NextChildSize    float64                     `json:"next_child_size,omitempty"`
+	Children         []models.ExecutionAlgoChild `json:"children"`
+}
+
+// ExecutionAlgoAlertData is the payload stored on EXECUTION_ALGO notifications
+type ExecutionAlgoAlertData struct {
+	ExecutionAlgoID  string  `json:"execution_algo_id"`
+	MarketID         string  `json:"market_id"`
+	Kind             string  `json:"kind"`
+	Status           string  `json:"status"`
+	FilledSize       float64 `json:"filled_size"`
+	TotalSize        float64 `json:"total_size"`
+	AverageFillPrice float64 `json:"average_fill_price"`
+	Reason           string  `json:"reason,omitempty"`
+}
+
+// Create validates the parent and its children and starts the algo.
+func (s *ExecutionAlgoService) Create(ctx context.Context, user *models.User, input ExecutionAlgoInput) (*ExecutionAlgoDetail, error) {
+	if s.box == nil {
+		return nil, ErrExecutionAlgosDisabled
+	}
+	if user == nil {
+		return nil, errors.New("user context is required")
+	}
+	invalid := func(format string, args ...interface{}) error {
+		return fmt.Errorf("%w: %s", ErrInvalidExecutionAlgo, fmt.Sprintf(format, args...))
+	}
+
+	kind := models.ExecutionAlgoKind(strings.ToUpper(strings.TrimSpace(string(input.Kind))))
+	if kind != models.ExecutionAlgoTWAP && kind != models.ExecutionAlgoIceberg {
+		return nil, invalid("kind must be TWAP or ICEBERG")
+	}
+	if input.TotalSize <= 0 {
+		return nil, invalid("totalSize must be greater than zero")
+	}
+	if input.LimitPrice < 0 || input.LimitPrice >= 1 {
+		return nil, invalid("limitPrice must be between 0 and 1")
+	}
+	if input.IntervalSeconds == 0 && kind == models.ExecutionAlgoIceberg {
+		input.IntervalSeconds = minAlgoIntervalSeconds
+	}
+	if input.IntervalSeconds < minAlgoIntervalSeconds || input.IntervalSeconds > maxAlgoIntervalSeconds {
+		return nil, invalid("intervalSeconds must be between %d and %d", minAlgoIntervalSeconds, maxAlgoIntervalSeconds)
+	}
+	if input.EndsAt != nil && !input.EndsAt.After(time.Now()) {
+		return nil, invalid("endsAt must be in the future")
+	}
+	if len(input.Children) == 0 {
+		return nil, invalid("at least one signed child order is required")
+	}
+
+	creds := input.Credentials
+	if strings.TrimSpace(creds.Key) == "" || strings.TrimSpace(creds.Secret) == "" || strings.TrimSpace(creds.Passphrase) == "" {
+		return nil, invalid("credentials key, secret and passphrase are required")
+	}
+
+	var active int64
+	if err := s.db.WithContext(ctx).Model(&models.ExecutionAlgo{}).
+		Where("user_id = ? AND status IN ?", user.ID, activeAlgoStatuses).
+		Count(&active).Error; err != nil {
+		return nil, fmt.Errorf("failed to count execution algos: %w", err)
+	}
+	if active >= maxActiveExecutionAlgos {
+		return nil, ErrExecutionAlgoLimit
+	}
+
+	first := &input.Children[0]
+	if err := first.Validate(); err != nil {
+		return nil, invalid("child 1: %v", err)
+	}
+	var market models.Market
+	if err := s.db.WithContext(ctx).
+		Where("token_id_yes = ? OR token_id_no = ?", first.Order.TokenID, first.Order.TokenID).
+		First(&market).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, invalid("unknown token %s", first.Order.TokenID)
+		}
+		return nil, err
+	}
+
+	side := normalizeClobSide(first.Order.Side)
+	now := time.Now().UTC()
+	algo := &models.ExecutionAlgo{
+		ID:              uuid.New(),
+		UserID:          user.ID,
+		Kind:            kind,
+		Status:          models.ExecutionAlgoRunning,
+		MarketID:        market.ConditionID,
+		TokenID:         first.Order.TokenID,
+		Outcome:         deriveOutcomeLabel(&market, first.Order.TokenID, side),
+		Side:            models.OrderSide(side),
+		TotalSize:       input.TotalSize,
+		LimitPrice:      input.LimitPrice,
+		IntervalSeconds: input.IntervalSeconds,
+		NextReleaseAt:   &now,
+		EndsAt:          input.EndsAt,
+	}
+
+	children, err := s.prepareChildren(ctx, user, algo, input.Children, creds, 0, input.TotalSize)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := json.Marshal(creds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode credentials: %w", err)
+	}
+	if algo.EncryptedCredentials, err = s.box.Seal(plaintext, []byte(algo.ID.String())); err != nil {
+		return nil, err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(algo).Error; err != nil {
+			return err
+		}
+		return tx.Create(&children).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store execution algo: %w", err)
+	}
+
+	return s.Get(ctx, user.ID, algo.ID)
+}
+
+// AddChildren appends signed child orders, e.g. after the algo asked for more in WAITING_ORDERS.
+func (s *ExecutionAlgoService) AddChildren(ctx context.Context, user *models.User, algoID uuid.UUID, children []clob.PostOrderRequest) (*ExecutionAlgoDetail, error) {
+	if s.box == nil {
+		return nil, ErrExecutionAlgosDisabled
+	}
+	if len(children) == 0 {
+		return nil, fmt.Errorf("%w: at least one signed child order is required", ErrInvalidExecutionAlgo)
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var algo models.ExecutionAlgo
+		if err := lockedAlgo(tx, user.ID, algoID, &algo); err != nil {
+			return err
+		}
+		if !algo.IsActive() {
+			return ErrExecutionAlgoClosed
+		}
+
+		var queued struct {
+			Count int
+			Size  float64
+			Seq   int
+		}
+		if err := tx.Model(&models.ExecutionAlgoChild{}).
+			Select("COUNT(*) FILTER (WHERE status = ?) AS count, COALESCE(SUM(size) FILTER (WHERE status = ?), 0) AS size, COALESCE(MAX(seq), 0) AS seq",
+				models.ExecutionAlgoChildQueued, models.ExecutionAlgoChildQueued).
+			Where("algo_id = ?", algo.ID).
+			Scan(&queued).Error; err != nil {
+			return err
+		}
+		if queued.Count+len(children) > maxExecutionAlgoChildren {
+			return fmt.Errorf("%w: at most %d queued children are allowed", ErrInvalidExecutionAlgo, maxExecutionAlgoChildren)
+		}
+
+		creds, err := s.openCredentials(&algo)
+		if err != nil {
+			return err
+		}
+		prepared, err := s.prepareChildren(ctx, user, &algo, children, *creds, queued.Seq, algo.RemainingSize()-queued.Size)
+		if err != nil {
+			return err
+		}
+		if err := tx.Create(&prepared).Error; err != nil {
+			return err
+		}
+
+		if algo.Status == models.ExecutionAlgoWaitingOrders {
+			now := time.Now().UTC()
+			return tx.Model(&algo).Updates(map[string]interface{}{
+				"status":          models.ExecutionAlgoRunning,
+				"status_reason":   "",
+				"next_release_at": now,
+			}).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, user.ID, algoID)
+}
+
+// prepareChildren validates signed children against the parent and seals them for storage.
+func (s *ExecutionAlgoService) prepareChildren(ctx context.Context, user *models.User, algo *models.ExecutionAlgo, reqs []clob.PostOrderRequest, creds clob.APIKeyCredentials, lastSeq int, capacity float64) ([]models.ExecutionAlgoChild, error) {
+	invalid := func(i int, format string, args ...interface{}) error {
+		return fmt.Errorf("%w: child %d: %s", ErrInvalidExecutionAlgo, i+1, fmt.Sprintf(format, args...))
+	}
+
+	children := make([]models.ExecutionAlgoChild, 0, len(reqs))
+	var total float64
+	for i := range reqs {
+		req := reqs[i]
+		if err := req.Validate(); err != nil {
+			return nil, invalid(i, "%v", err)
+		}
+		if req.OrderType != clob.OrderTypeFOK && req.OrderType != clob.OrderTypeFAK {
+			return nil, invalid(i, "orderType must be FOK or FAK")
+		}
+		if req.Order.TokenID != algo.TokenID {
+			return nil, invalid(i, "all children must trade the same token")
+		}
+		if models.OrderSide(normalizeClobSide(req.Order.Side)) != algo.Side {
+			return nil, invalid(i, "all children must have the same side")
+		}
+		if req.Owner != creds.Key {
+			return nil, invalid(i, "order owner must match the credentials key")
+		}
+		owner, err := userForMaker(ctx, s.db, user, req.Order.Maker)
+		if err != nil {
+			return nil, err
+		}
+		if owner == nil {
+			return nil, invalid(i, "order maker does not belong to this user")
+		}
+		if err := s.trades.validateOrderAmounts(ctx, &req.Order); err != nil {
+			return nil, invalid(i, "%v", err)
+		}
+
+		price, size, err := signedOrderTerms(&req.Order)
+		if err != nil {
+			return nil, invalid(i, "%v", err)
+		}
+		if algo.LimitPrice > 0 && !withinLimit(algo.Side, price, algo.LimitPrice) {
+			return nil, invalid(i, "price %.4f is beyond the algo limit %.4f", price, algo.LimitPrice)
+		}
+		total += size
+
+		child := models.ExecutionAlgoChild{
+			ID:         uuid.New(),
+			AlgoID:     algo.ID,
+			Seq:        lastSeq + i + 1,
+			Size:       size,
+			LimitPrice: price,
+			Status:     models.ExecutionAlgoChildQueued,
+		}
+		plaintext, err := json.Marshal(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode child order: %w", err)
+		}
+		if child.EncryptedPayload, err = s.box.Seal(plaintext, []byte(child.ID.String())); err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+	}
+
+	if total > capacity+algoSizeEpsilon {
+		return nil, fmt.Errorf("%w: children total %.4f shares but only %.4f remain unallocated", ErrInvalidExecutionAlgo, total, math.Max(capacity, 0))
+	}
+	return children, nil
+}
+
+// signedOrderTerms returns the limit price and share size encoded in a signed order's atomic amounts.
+func signedOrderTerms(order *clob.Order) (float64, float64, error) {
+	makerAmt, err := strconv.ParseFloat(order.MakerAmount, 64)
+	if err != nil || makerAmt <= 0 {
+		return 0, 0, fmt.Errorf("invalid makerAmount")
+	}
+	takerAmt, err := strconv.ParseFloat(order.TakerAmount, 64)
+	if err != nil || takerAmt <= 0 {
+		return 0, 0, fmt.Errorf("invalid takerAmount")
+	}
+	if normalizeClobSide(order.Side) == clob.BUY {
+		return makerAmt / takerAmt, takerAmt / 1e6, nil
+	}
+	return takerAmt / makerAmt, makerAmt / 1e6, nil
+}
+
+// withinLimit reports whether price is no worse than limit for the given side.
+func withinLimit(side models.OrderSide, price, limit float64) bool {
+	if side == models.OrderSideBuy {
+		return price <= limit+1e-9
+	}
+	return price >= limit-1e-9
+}
+
+func lockedAlgo(tx *gorm.DB, userID, algoID uuid.UUID, algo *models.ExecutionAlgo) error {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND user_id = ?", algoID, userID).
+		First(algo).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrExecutionAlgoNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// List returns the user's execution algos, newest first.
+func (s *ExecutionAlgoService) List(ctx context.Context, userID uuid.UUID, status string, limit, offset int) ([]models.ExecutionAlgo, int64, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	query := s.db.WithContext(ctx).Model(&models.ExecutionAlgo{}).Where("user_id = ?", userID)
+	if status = strings.ToUpper(strings.TrimSpace(status)); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count execution algos: %w", err)
+	}
+
+	var algos []models.ExecutionAlgo
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&algos).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch execution algos: %w", err)
+	}
+	return algos, total, nil
+}
+
+// Get returns an algo with its children and progress.
+func (s *ExecutionAlgoService) Get(ctx context.Context, userID, algoID uuid.UUID) (*ExecutionAlgoDetail, error) {
+	var algo models.ExecutionAlgo
+	if err := s.db.WithContext(ctx).Where("id = ? AND user_id = ?", algoID, userID).First(&algo).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrExecutionAlgoNotFound
+		}
+		return nil, err
+	}
+
+	var children []models.ExecutionAlgoChild
+	if err := s.db.WithContext(ctx).Where("algo_id = ?", algo.ID).Order("seq").Find(&children).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch algo children: %w", err)
+	}
+
+	detail := &ExecutionAlgoDetail{
+		ExecutionAlgo:    algo,
+		RemainingSize:    algo.RemainingSize(),
+		AverageFillPrice: algo.AverageFillPrice(),
+		Children:         children,
+	}
+	if algo.TotalSize > 0 {
+		detail.Progress = math.Min(algo.FilledSize/algo.TotalSize, 1)
+	}
+
+	var lastSize float64
+	for _, child := range children {
+		if child.Status == models.ExecutionAlgoChildQueued {
+			detail.QueuedSize += child.Size
+		}
+		lastSize = child.Size
+	}
+	if algo.Status == models.ExecutionAlgoWaitingOrders {
+		detail.NextChildSize = math.Min(lastSize, detail.RemainingSize)
+		if detail.NextChildSize <= 0 {
+			detail.NextChildSize = detail.RemainingSize
+		}
+	}
+	return detail, nil
+}
+
+// Cancel stops an active algo and drops its queued children. Submitted or in-flight children are unaffected.
+func (s *ExecutionAlgoService) Cancel(ctx context.Context, userID, algoID uuid.UUID) (*ExecutionAlgoDetail, error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var algo models.ExecutionAlgo
+		if err := lockedAlgo(tx, userID, algoID, &algo); err != nil {
+			return err
+		}
+		if !algo.IsActive() {
+			return ErrExecutionAlgoClosed
+		}
+		return s.finish(tx, &algo, models.ExecutionAlgoCanceled, "canceled by user")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, userID, algoID)
+}
+
+// finish moves an algo to a terminal status and cancels its queued children.
+func (s *ExecutionAlgoService) finish(tx *gorm.DB, algo *models.ExecutionAlgo, status models.ExecutionAlgoStatus, reason string) error {
+	now := time.Now().UTC()
+	if err := tx.Model(&models.ExecutionAlgoChild{}).
+		Where("algo_id = ? AND status = ?", algo.ID, models.ExecutionAlgoChildQueued).
+		Update("status", models.ExecutionAlgoChildCanceled).Error; err != nil {
+		return fmt.Errorf("failed to cancel queued children: %w", err)
+	}
+
+	algo.Status = status
+	algo.StatusReason = reason
+	algo.CompletedAt = &now
+	algo.NextReleaseAt = nil
+	return tx.Model(algo).Updates(map[string]interface{}{
+		"status":          status,
+		"status_reason":   reason,
+		"completed_at":    now,
+		"next_release_at": nil,
+	}).Error
+}
+
+// Run releases due child orders until ctx is cancelled. Run from the worker only.
+func (s *ExecutionAlgoService) Run(ctx context.Context) {
+	if s.box == nil {
+		logger.Info("ExecutionAlgoService: BANKAI_ENCRYPTION_KEY not set, execution algos disabled")
+		return
+	}
+
+	ticker := time.NewTicker(algoPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.recoverStale(ctx)
+			s.releaseDue(ctx)
+		}
+	}
+}
+
+// recoverStale settles children whose worker stopped between claiming and recording them.
+func (s *ExecutionAlgoService) recoverStale(ctx context.Context) {
+	cutoff := time.Now().UTC().Add(-algoChildStaleAfter)
+	var stale []models.ExecutionAlgoChild
+	if err := s.db.WithContext(ctx).
+		Where("status = ? AND updated_at < ?", models.ExecutionAlgoChildSubmitting, cutoff).
+		Find(&stale).Error; err != nil {
+		logger.Error("ExecutionAlgoService: Failed to load stale children: %v", err)
+		return
+	}
+
+	for i := range stale {
+		child := &stale[i]
+		// Re-lease the claim so only one worker recovers the child.
+		claim := s.db.WithContext(ctx).Model(&models.ExecutionAlgoChild{}).
+			Where("id = ? AND status = ? AND updated_at < ?", child.ID, models.ExecutionAlgoChildSubmitting, cutoff).
+			Update("updated_at", time.Now().UTC())
+		if claim.Error != nil || claim.RowsAffected == 0 {
+			continue
+		}
+		if err := s.recoverChild(ctx, child); err != nil {
+			logger.Error("ExecutionAlgoService: Failed to recover child %s: %v", child.ID, err)
+		}
+	}
+}
+
+func (s *ExecutionAlgoService) recoverChild(ctx context.Context, child *models.ExecutionAlgoChild) error {
+	var algo models.ExecutionAlgo
+	if err := s.db.WithContext(ctx).Where("id = ?", child.AlgoID).First(&algo).Error; err != nil {
+		return err
+	}
+
+	const interrupted = "submission was interrupted; check your orders before adding more children"
+	plaintext, err := s.box.Open(child.EncryptedPayload, []byte(child.ID.String()))
+	if err != nil {
+		return s.recordChild(ctx, &algo, child, nil, nil, interrupted)
+	}
+	var req clob.PostOrderRequest
+	if err := json.Unmarshal(plaintext, &req); err != nil {
+		return s.recordChild(ctx, &algo, child, nil, nil, interrupted)
+	}
+
+	var market models.Market
+	if err := s.db.WithContext(ctx).Select("neg_risk").Where("condition_id = ?", algo.MarketID).First(&market).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	hash, err := req.Order.Hash(market.NegRisk)
+	if err != nil {
+		return s.recordChild(ctx, &algo, child, nil, nil, interrupted)
+	}
+
+	// The CLOB order ID is the order hash, so this finds rows from persistOrder as well as client syncs.
+	var order models.Order
+	err = s.db.WithContext(ctx).
+		Where("user_id = ? AND (LOWER(clob_order_id) = ? OR ? = ANY(order_hashes))", algo.UserID, hash, hash).
+		Order("created_at DESC").
+		First(&order).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Info("ExecutionAlgoService: No order found for stale child %s (%s)", child.ID, hash)
+		return s.recordChild(ctx, &algo, child, nil, nil, interrupted)
+	}
+	if err != nil {
+		return err
+	}
+
+	resp, errMsg := recoveredChildResponse(&order)
+	return s.recordChild(ctx, &algo, child, resp, &order, errMsg)
+}
+
+// recoveredChildResponse rebuilds the CLOB response of a child from the order row it was stored as.
+func recoveredChildResponse(order *models.Order) (*clob.PostOrderResponse, string) {
+	if order.Status == models.OrderStatusFailed && order.FilledSize() <= 0 {
+		if order.ErrorMessage != "" {
+			return nil, order.ErrorMessage
+		}
+		return nil, "order rejected by the CLOB"
+	}
+
+	resp := &clob.PostOrderResponse{Success: true, OrderID: order.CLOBOrderID}
+	filled := order.FilledSize()
+	if filled <= 0 {
+		resp.Status = "unmatched"
+		return resp, ""
+	}
+	shares := strconv.FormatFloat(filled, 'f', -1, 64)
+	notional := strconv.FormatFloat(filled*order.Price, 'f', -1, 64)
+	resp.Status = "matched"
+	if order.Side == models.OrderSideBuy {
+		resp.MakingAmount, resp.TakingAmount = notional, shares
+	} else {
+		resp.MakingAmount, resp.TakingAmount = shares, notional
+	}
+	return resp, ""
+}
+
+func (s *ExecutionAlgoService) releaseDue(ctx context.Context) {
+	now := time.Now().UTC()
+	var due []uuid.UUID
+	if err := s.db.WithContext(ctx).Model(&models.ExecutionAlgo{}).
+		Where("status IN ? AND next_release_at <= ?", []models.ExecutionAlgoStatus{models.ExecutionAlgoRunning, models.ExecutionAlgoPaused}, now).
+		Order("next_release_at").
+		Pluck("id", &due).Error; err != nil {
+		logger.Error("ExecutionAlgoService: Failed to load due algos: %v", err)
+		return
+	}
+
+	for _, id := range due {
+		// Claim by leasing next_release_at so a second worker skips this algo.
+		claim := s.db.WithContext(ctx).Model(&models.ExecutionAlgo{}).
+			Where("id = ? AND next_release_at <= ?", id, now).
+			Update("next_release_at", now.Add(algoClaimLease))
+		if claim.Error != nil || claim.RowsAffected == 0 {
+			continue
+		}
+		if err := s.step(ctx, id); err != nil {
+			logger.Error("ExecutionAlgoService: Step failed for %s: %v", id, err)
+			s.reschedule(ctx, id, time.Now().UTC().Add(algoPollInterval), nil)
+		}
+	}
+}
+
+// step advances one algo: expire, complete, pause/resume, or release the next child.
+func (s *ExecutionAlgoService) step(ctx context.Context, algoID uuid.UUID) error {
+	var algo models.ExecutionAlgo
+	if err := s.db.WithContext(ctx).Where("id = ?", algoID).First(&algo).Error; err != nil {
+		return err
+	}
+	if !algo.IsActive() {
+		return nil
+	}
+	now := time.Now().UTC()
+
+	if algo.RemainingSize() <= algoSizeEpsilon {
+		return s.close(ctx, &algo, models.ExecutionAlgoCompleted, "parent order filled")
+	}
+	if algo.EndsAt != nil && !algo.EndsAt.After(now) {
+		return s.close(ctx, &algo, models.ExecutionAlgoExpired, "end time reached before the parent filled")
+	}
+
+	var child models.ExecutionAlgoChild
+	if err := s.db.WithContext(ctx).
+		Where("algo_id = ? AND status = ?", algo.ID, models.ExecutionAlgoChildQueued).
+		Order("seq").
+		First(&child).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return s.db.WithContext(ctx).Model(&algo).Where("status IN ?", activeAlgoStatuses).Updates(map[string]interface{}{
+				"status":          models.ExecutionAlgoWaitingOrders,
+				"status_reason":   "no signed child orders left; sign more to continue",
+				"next_release_at": nil,
+			}).Error
+		}
+		return err
+	}
+
+	estimate, err := s.markets.GetDepthEstimate(ctx, algo.MarketID, algo.TokenID, string(algo.Side), child.Size)
+	if err != nil {
+		s.reschedule(ctx, algo.ID, now.Add(algoPollInterval), &algoState{models.ExecutionAlgoRunning, fmt.Sprintf("order book unavailable: %v", err)})
+		return nil
+	}
+
+	// The effective limit is the tighter of the child's signed price and the parent's limit.
+	limit := child.LimitPrice
+	if algo.LimitPrice > 0 && withinLimit(algo.Side, algo.LimitPrice, limit) {
+		limit = algo.LimitPrice
+	}
+	best := estimate.Levels[0].Price
+	if !withinLimit(algo.Side, best, limit) {
+		s.reschedule(ctx, algo.ID, now.Add(algoPollInterval), &algoState{
+			models.ExecutionAlgoPaused,
+			fmt.Sprintf("best price %.4f is beyond limit %.4f", best, limit),
+		})
+		return nil
+	}
+
+	if algo.Kind == models.ExecutionAlgoIceberg {
+		var visible float64
+		for _, lvl := range estimate.Levels {
+			if withinLimit(algo.Side, lvl.Price, limit) {
+				visible += lvl.Used
+			}
+		}
+		if visible+algoSizeEpsilon < child.Size {
+			s.reschedule(ctx, algo.ID, now.Add(algoPollInterval), &algoState{
+				models.ExecutionAlgoRunning,
+				fmt.Sprintf("waiting for visible size: %.2f of %.2f within limit", visible, child.Size),
+			})
+			return nil
+		}
+	}
+
+	return s.release(ctx, &algo, &child)
+}
+
+// algoState is a non-terminal status and reason to record while rescheduling.
+type algoState struct {
+	status models.ExecutionAlgoStatus
+	reason string
+}
+
+func (s *ExecutionAlgoService) reschedule(ctx context.Context, algoID uuid.UUID, next time.Time, state *algoState) {
+	updates := map[string]interface{}{"next_release_at": next}
+	if state != nil {
+		updates["status"] = state.status
+		updates["status_reason"] = state.reason
+	}
+	if err := s.db.WithContext(ctx).Model(&models.ExecutionAlgo{}).
+		Where("id = ? AND status IN ?", algoID, activeAlgoStatuses).
+		Updates(updates).Error; err != nil {
+		logger.Error("ExecutionAlgoService: Failed to reschedule %s: %v", algoID, err)
+	}
+}
+
+// release submits one child and folds its fill into the parent.
+func (s *ExecutionAlgoService) release(ctx context.Context, algo *models.ExecutionAlgo, child *models.ExecutionAlgoChild) error {
+	creds, err := s.openCredentials(algo)
+	if err != nil {
+		return s.close(ctx, algo, models.ExecutionAlgoFailed, "stored credentials could not be decrypted")
+	}
+	var user models.User
+	if err := s.db.WithContext(ctx).Where("id = ?", algo.UserID).First(&user).Error; err != nil {
+		return s.close(ctx, algo, models.ExecutionAlgoFailed, "order owner not found")
+	}
+
+	// Claim the child before posting: Cancel only drops QUEUED children, so whichever side moves it first wins.
+	claim := s.db.WithContext(ctx).Model(child).
+		Where("status = ?", models.ExecutionAlgoChildQueued).
+		Update("status", models.ExecutionAlgoChildSubmitting)
+	if claim.Error != nil {
+		return fmt.Errorf("failed to claim child %s: %w", child.ID, claim.Error)
+	}
+	if claim.RowsAffected == 0 {
+		return nil
+	}
+
+	plaintext, err := s.box.Open(child.EncryptedPayload, []byte(child.ID.String()))
+	if err != nil {
+		return s.recordChild(ctx, algo, child, nil, nil, "stored child order could not be decrypted")
+	}
+	var req clob.PostOrderRequest
+	if err := json.Unmarshal(plaintext, &req); err != nil {
+		return s.recordChild(ctx, algo, child, nil, nil, "stored child order is corrupt")
+	}
+	if s.trades.Risk != nil {
+		if err := s.trades.Risk.CheckSignedOrder(ctx, &user, &req.Order); err != nil {
+			logger.Info("ExecutionAlgoService: Risk check blocked child %s: %v", child.ID, err)
+			return s.recordChild(ctx, algo, child, nil, nil, riskFailureMessage(err))
+		}
+	}
+
+	submitCtx, cancel := context.WithTimeout(ctx, conditionalSubmitTimeout)
+	defer cancel()
+
+	resp, postErr := s.trades.Clob.PostOrder(submitCtx, &req, creds)
+	if postErr != nil {
+		resp = &clob.PostOrderResponse{Success: false, ErrorMsg: postErr.Error()}
+	}
+
+	persisted, err := s.trades.persistOrder(ctx, &user, &req, resp)
+	if err != nil {
+		logger.Error("ExecutionAlgoService: Failed to persist child %s: %v", child.ID, err)
+	}
+
+	errMsg := ""
+	if !resp.Success {
+		errMsg = resp.ErrorMsg
+		if errMsg == "" {
+			errMsg = "order rejected by the CLOB"
+		}
+	}
+	return s.recordChild(ctx, algo, child, resp, persisted, errMsg)
+}
+
+// recordChild stores a child's outcome, updates the parent's fills and schedules the next release.
+func (s *ExecutionAlgoService) recordChild(ctx context.Context, algo *models.ExecutionAlgo, child *models.ExecutionAlgoChild, resp *clob.PostOrderResponse, persisted *models.Order, errMsg string) error {
+	now := time.Now().UTC()
+
+	var filled, notional float64
+	status := models.ExecutionAlgoChildFailed
+	if errMsg == "" && resp != nil {
+		filled, notional = childFill(algo.Side, child, resp)
+		switch {
+		case filled+algoSizeEpsilon >= child.Size:
+			status = models.ExecutionAlgoChildFilled
+		case filled > 0:
+			status = models.ExecutionAlgoChildPartial
+		default:
+			status = models.ExecutionAlgoChildUnfilled
+		}
+	}
+
+	childUpdates := map[string]interface{}{
+		"status":       status,
+		"filled_size":  filled,
+		"error_msg":    errMsg,
+		"submitted_at": now,
+	}
+	if filled > 0 {
+		childUpdates["avg_price"] = notional / filled
+	}
+	if persisted != nil {
+		childUpdates["order_id"] = persisted.ID
+		childUpdates["clob_order_id"] = persisted.CLOBOrderID
+	}
+
+	failures := 0
+	if status == models.ExecutionAlgoChildFailed {
+		failures = algo.ConsecutiveFailures + 1
+	}
+
+	next := now.Add(time.Duration(algo.IntervalSeconds) * time.Second)
+	if algo.Kind == models.ExecutionAlgoIceberg && status == models.ExecutionAlgoChildFilled {
+		next = now.Add(algoPollInterval) // refill as soon as the book allows
+	}
+	reason := ""
+	if errMsg != "" {
+		reason = fmt.Sprintf("child %d failed: %s", child.Seq, errMsg)
+	}
+
+	// Fills are always folded in, even if the algo was canceled while the child was in flight; status and
+	// scheduling only change while the algo is still active, so a cancel is never resurrected.
+	active := false
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(child).Where("status = ?", models.ExecutionAlgoChildSubmitting).Updates(childUpdates).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.ExecutionAlgo{}).Where("id = ?", algo.ID).Updates(map[string]interface{}{
+			"filled_size":        gorm.Expr("filled_size + ?", filled),
+			"filled_notional":    gorm.Expr("filled_notional + ?", notional),
+			"children_submitted": gorm.Expr("children_submitted + 1"),
+		}).Error; err != nil {
+			return err
+		}
+		res := tx.Model(&models.ExecutionAlgo{}).
+			Where("id = ? AND status IN ?", algo.ID, activeAlgoStatuses).
+			Updates(map[string]interface{}{
+				"status":               models.ExecutionAlgoRunning,
+				"status_reason":        reason,
+				"consecutive_failures": failures,
+				"next_release_at":      next,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		active = res.RowsAffected > 0
+		return tx.Where("id = ?", algo.ID).First(algo).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record child %s: %w", child.ID, err)
+	}
+
+	logger.Info("ExecutionAlgoService: %s %s child %d %s (%.2f filled, parent %.2f/%.2f)",
+		algo.Kind, algo.ID, child.Seq, status, filled, algo.FilledSize, algo.TotalSize)
+
+	if !active {
+		return nil
+	}
+	switch {
+	case algo.RemainingSize() <= algoSizeEpsilon:
+		return s.close(ctx, algo, models.ExecutionAlgoCompleted, "parent order filled")
+	case failures >= algoMaxFailures:
+		return s.close(ctx, algo, models.ExecutionAlgoFailed, fmt.Sprintf("%d consecutive child orders failed: %s", failures, errMsg))
+	}
+	return nil
+}
+
+// childFill returns the shares filled and USDC notional from a CLOB response. Without reported amounts,
+// a matched order is assumed filled at its limit price.
+func childFill(side models.OrderSide, child *models.ExecutionAlgoChild, resp *clob.PostOrderResponse) (float64, float64) {
+	making, _ := strconv.ParseFloat(resp.MakingAmount, 64)
+	taking, _ := strconv.ParseFloat(resp.TakingAmount, 64)
+	if making > 0 && taking > 0 {
+		if side == models.OrderSideBuy {
+			return taking, making
+		}
+		return making, taking
+	}
+
+	if mapClobStatus(resp, clob.OrderTypeFOK) == models.OrderStatusFilled && strings.ToLower(resp.Status) != "unmatched" {
+		return child.Size, child.Size * child.LimitPrice
+	}
+	return 0, 0
+}
+
+// close moves an algo to a terminal status and notifies the owner.
+func (s *ExecutionAlgoService) close(ctx context.Context, algo *models.ExecutionAlgo, status models.ExecutionAlgoStatus, reason string) error {
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return s.finish(tx, algo, status, reason)
+	}); err != nil {
+		return err
+	}
+	if err := s.notify(ctx, algo); err != nil {
+		logger.Error("ExecutionAlgoService: Failed to notify user for %s: %v", algo.ID, err)
+	}
+	return nil
+}
+
+func (s *ExecutionAlgoService) openCredentials(algo *models.ExecutionAlgo) (*clob.APIKeyCredentials, error) {
+	plaintext, err := s.box.Open(algo.EncryptedCredentials, []byte(algo.ID.String()))
+	if err != nil {
+		return nil, err
+	}
+	var creds clob.APIKeyCredentials
+	if err := json.Unmarshal(plaintext, &creds); err != nil {
+		return nil, err
+	}
+	return &creds, nil
+}
+
+func (s *ExecutionAlgoService) notify(ctx context.Context, algo *models.ExecutionAlgo) error {
+	title := fmt.Sprintf("%s order %s", algo.Kind, strings.ToLower(string(algo.Status)))
+	message := fmt.Sprintf("%s %s %s: filled %.2f of %.2f shares at an average of %.4f (%s).",
+		algo.Kind, algo.Side, algo.Outcome, algo.FilledSize, algo.TotalSize, algo.AverageFillPrice(), algo.StatusReason)
+
+	data, err := json.Marshal(ExecutionAlgoAlertData{
+		ExecutionAlgoID:  algo.ID.String(),
+		MarketID:         algo.MarketID,
+		Kind:             string(algo.Kind),
+		Status:           string(algo.Status),
+		FilledSize:       algo.FilledSize,
+		TotalSize:        algo.TotalSize,
+		AverageFillPrice: algo.AverageFillPrice(),
+		Reason:           algo.StatusReason,
+	})
+	if err != nil {
+		return err
+	}
+
+	notification := models.Notification{
+		ID:        uuid.New(),
+		UserID:    algo.UserID,
+		Type:      models.NotificationTypeExecutionAlgo,
+		Title:     title,
+		Message:   message,
+		Data:      string(data),
+		Read:      false,
+		CreatedAt: time.Now(),
+	}
+	return s.db.WithContext(ctx).Create(&notification).Error
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/polymarket/clob"
+)
+
+func TestWithinLimit(t *testing.T) {
+	cases := []struct {
+		side  models.OrderSide
+		price float64
+		limit float64
+		want  bool
+	}{
+		{models.OrderSideBuy, 0.40, 0.45, true},
+		{models.OrderSideBuy, 0.45, 0.45, true},
+		{models.OrderSideBuy, 0.45 + 1e-12, 0.45, true},
+		{models.OrderSideBuy, 0.46, 0.45, false},
+		{models.OrderSideSell, 0.50, 0.45, true},
+		{models.OrderSideSell, 0.45, 0.45, true},
+		{models.OrderSideSell, 0.44, 0.45, false},
+	}
+	for _, tc := range cases {
+		if got := withinLimit(tc.side, tc.price, tc.limit); got != tc.want {
+			t.Errorf("withinLimit(%s, %.4f, %.4f) = %v, want %v", tc.side, tc.price, tc.limit, got, tc.want)
+		}
+	}
+}
+
+func TestChildFill(t *testing.T) {
+	child := &models.ExecutionAlgoChild{Size: 100, LimitPrice: 0.42}
+
+	cases := []struct {
+		name         string
+		side         models.OrderSide
+		resp         clob.PostOrderResponse
+		wantFilled   float64
+		wantNotional float64
+	}{
+		{"buy with reported amounts", models.OrderSideBuy, clob.PostOrderResponse{Success: true, Status: "matched", MakingAmount: "20.5", TakingAmount: "50"}, 50, 20.5},
+		{"sell with reported amounts", models.OrderSideSell, clob.PostOrderResponse{Success: true, Status: "matched", MakingAmount: "50", TakingAmount: "21"}, 50, 21},
+		{"matched without amounts fills at limit", models.OrderSideBuy, clob.PostOrderResponse{Success: true, Status: "matched"}, 100, 42},
+		{"success without status is a FOK fill", models.OrderSideSell, clob.PostOrderResponse{Success: true}, 100, 42},
+		{"unmatched", models.OrderSideBuy, clob.PostOrderResponse{Success: true, Status: "unmatched"}, 0, 0},
+		{"resting", models.OrderSideBuy, clob.PostOrderResponse{Success: true, Status: "live"}, 0, 0},
+		{"rejected", models.OrderSideBuy, clob.PostOrderResponse{Success: false, ErrorMsg: "not enough balance"}, 0, 0},
+		{"one amount missing", models.OrderSideBuy, clob.PostOrderResponse{Success: true, Status: "live", MakingAmount: "20"}, 0, 0},
+	}
+	for _, tc := range cases {
+		resp := tc.resp
+		filled, notional := childFill(tc.side, child, &resp)
+		if math.Abs(filled-tc.wantFilled) > 1e-9 || math.Abs(notional-tc.wantNotional) > 1e-9 {
+			t.Errorf("%s: childFill = (%.4f, %.4f), want (%.4f, %.4f)", tc.name, filled, notional, tc.wantFilled, tc.wantNotional)
+		}
+	}
+}
+
+func TestSignedOrderTerms(t *testing.T) {
+	cases := []struct {
+		order     clob.Order
+		wantPrice float64
+		wantSize  float64
+		wantErr   bool
+	}{
+		{clob.Order{Side: clob.BUY, MakerAmount: "42000000", TakerAmount: "100000000"}, 0.42, 100, false},
+		{clob.Order{Side: clob.SELL, MakerAmount: "100000000", TakerAmount: "58000000"}, 0.58, 100, false},
+		{clob.Order{Side: clob.BUY, MakerAmount: "0", TakerAmount: "100000000"}, 0, 0, true},
+		{clob.Order{Side: clob.SELL, MakerAmount: "100000000", TakerAmount: "abc"}, 0, 0, true},
+	}
+	for _, tc := range cases {
+		order := tc.order
+		price, size, err := signedOrderTerms(&order)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("signedOrderTerms(%+v) error = %v, wantErr %v", tc.order, err, tc.wantErr)
+			continue
+		}
+		if math.Abs(price-tc.wantPrice) > 1e-9 || math.Abs(size-tc.wantSize) > 1e-9 {
+			t.Errorf("signedOrderTerms(%+v) = (%.4f, %.4f), want (%.4f, %.4f)", tc.order, price, size, tc.wantPrice, tc.wantSize)
+		}
+	}
+}
+
+func TestRecoveredChildResponse(t *testing.T) {
+	child := &models.ExecutionAlgoChild{Size: 100, LimitPrice: 0.42}
+
+	cases := []struct {
+		name         string
+		order        models.Order
+		wantErr      string
+		wantFilled   float64
+		wantNotional float64
+	}{
+		{"buy filled", models.Order{Side: models.OrderSideBuy, Status: models.OrderStatusFilled, Price: 0.4, Size: 100, SizeMatched: 100}, "", 100, 40},
+		{"sell partly filled", models.Order{Side: models.OrderSideSell, Status: models.OrderStatusCanceled, Price: 0.5, Size: 100, SizeMatched: 30}, "", 30, 15},
+		{"filled without size matched", models.Order{Side: models.OrderSideBuy, Status: models.OrderStatusFilled, Price: 0.4, Size: 60}, "", 60, 24},
+		{"killed unfilled", models.Order{Side: models.OrderSideBuy, Status: models.OrderStatusCanceled, Price: 0.4, Size: 100}, "", 0, 0},
+		{"rejected", models.Order{Side: models.OrderSideBuy, Status: models.OrderStatusFailed, ErrorMessage: "not enough balance"}, "not enough balance", 0, 0},
+		{"rejected without message", models.Order{Side: models.OrderSideSell, Status: models.OrderStatusFailed}, "order rejected by the CLOB", 0, 0},
+	}
+	for _, tc := range cases {
+		resp, errMsg := recoveredChildResponse(&tc.order)
+		if errMsg != tc.wantErr {
+			t.Errorf("%s: errMsg = %q, want %q", tc.name, errMsg, tc.wantErr)
+			continue
+		}
+		if errMsg != "" {
+			continue
+		}
+		filled, notional := childFill(tc.order.Side, child, resp)
+		if math.Abs(filled-tc.wantFilled) > 1e-9 || math.Abs(notional-tc.wantNotional) > 1e-9 {
+			t.Errorf("%s: fill = (%.4f, %.4f), want (%.4f, %.4f)", tc.name, filled, notional, tc.wantFilled, tc.wantNotional)
+		}
+	}
+}
@@ -0,0 +1,491 @@
+/**
+ * @description
+ * Trade Journal Service.
+ * Stores per-user journal entries attached to an order, a market or a position (one outcome token) and
+ * shows the realized outcome next to each entry once its market resolves.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - backend/internal/models
+ *
+ * @notes
+ * - ORDER entries copy market, token, side and price from the order; POSITION entries need a market and token.
+ * - Tags are normalised to lowercase [a-z0-9_-] so they round-trip through TEXT[] without quoting.
+ * - Outcomes are derived on read from the stored market (closed with 0 / 1 outcome prices), never persisted.
+ * - status=resolved / open filters on the market's closed flag, so a closed but unsettled market counts as resolved.
+ */
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	maxJournalNotesLength = 10000
+	maxJournalTags        = 10
+	maxJournalTagLength   = 32
+)
+
+var (
+	ErrJournalEntryNotFound = errors.New("journal entry not found")
+	ErrInvalidJournalEntry  = errors.New("invalid journal entry")
+
+	journalTagInvalidChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+)
+
+// JournalService manages trade journal entries
+type JournalService struct {
+	db *gorm.DB
+}
+
+// NewJournalService creates a new JournalService
+func NewJournalService(db *gorm.DB) *JournalService {
+	return &JournalService{db: db}
+}
+
+// JournalEntryInput carries the fields of a new entry. OrderID, MarketID and TokenID choose the subject.
+type JournalEntryInput struct {
+	OrderID          *uuid.UUID `json:"order_id"`
+	MarketID         string     `json:"market_id"`
+	TokenID          string     `json:"token_id"`
+	Side             string     `json:"side"`
+	EntryPrice       *float64   `json:"entry_price"`
+	Notes            string     `json:"notes"`
+	Tags             []string   `json:"tags"`
+	Confidence       *int       `json:"confidence"`
+	PlannedExitPrice *float64   `json:"planned_exit_price"`
+	PlannedExitAt    *time.Time `json:"planned_exit_at"`
+	PostMortem       string     `json:"post_mortem"`
+}
+
+// JournalEntryUpdate replaces the editable fields of an entry
+type JournalEntryUpdate struct {
+	EntryPrice       *float64   `json:"entry_price"`
+	Notes            string     `json:"notes"`
+	Tags             []string   `json:"tags"`
+	Confidence       *int       `json:"confidence"`
+	PlannedExitPrice *float64   `json:"planned_exit_price"`
+	PlannedExitAt    *time.Time `json:"planned_exit_at"`
+	PostMortem       string     `json:"post_mortem"`
+}
+
+// JournalFilter narrows a journal listing
+type JournalFilter struct {
+	Tags     []string
+	MarketID string
+	Subject  models.JournalSubject
+	Status   string // "", "open" or "resolved"
+	Query    string // Case-insensitive search in notes and post-mortem
+	From     *time.Time
+	To       *time.Time
+	Limit    int
+	Offset   int
+}
+
+// JournalOutcome is how the entry's market settled
+type JournalOutcome struct {
+	Resolved       bool       `json:"resolved"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+	WinningOutcome string     `json:"winning_outcome,omitempty"`
+	Payout         *float64   `json:"payout,omitempty"`       // Per share of the entry's token
+	PriceMove      *float64   `json:"price_move,omitempty"`   // Payout - entry price
+	Correct        *bool      `json:"correct,omitempty"`      // Buying a winner or selling a loser
+	RealizedPnL    *float64   `json:"realized_pnl,omitempty"` // ORDER entries with a filled size, fees excluded
+}
+
+// JournalEntryView is an entry with its market context and outcome
+type JournalEntryView struct {
+	models.JournalEntry
+	MarketTitle string         `json:"market_title"`
+	MarketSlug  string         `json:"market_slug"`
+	Outcome     JournalOutcome `json:"realized_outcome"`
+}
+
+// ListEntries returns a page of the user's entries, newest first, with outcomes.
+func (s *JournalService) ListEntries(ctx context.Context, userID uuid.UUID, filter JournalFilter) ([]JournalEntryView, int64, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 50
+	}
+	if filter.Limit > 200 {
+		filter.Limit = 200
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	query, err := journalFilterQuery(s.db.WithContext(ctx).Model(&models.JournalEntry{}).Where("user_id = ?", userID), filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count journal entries: %w", err)
+	}
+
+	var entries []models.JournalEntry
+	if err := query.Order("created_at DESC").Limit(filter.Limit).Offset(filter.Offset).Find(&entries).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to load journal entries: %w", err)
+	}
+
+	views, err := s.buildViews(ctx, entries)
+	if err != nil {
+		return nil, 0, err
+	}
+	return views, total, nil
+}
+
+// GetEntry returns one of the user's entries with its outcome.
+func (s *JournalService) GetEntry(ctx context.Context, userID, entryID uuid.UUID) (*JournalEntryView, error) {
+	entry, err := s.loadEntry(ctx, userID, entryID)
+	if err != nil {
+		return nil, err
+	}
+	views, err := s.buildViews(ctx, []models.JournalEntry{*entry})
+	if err != nil {
+		return nil, err
+	}
+	return &views[0], nil
+}
+
+// CreateEntry validates and stores a new entry.
+func (s *JournalService) CreateEntry(ctx context.Context, userID uuid.UUID, input JournalEntryInput) (*JournalEntryView, error) {
+	entry := &models.JournalEntry{UserID: userID}
+
+	switch {
+	case input.OrderID != nil:
+		var order models.Order
+		if err := s.db.WithContext(ctx).
+			Where("id = ? AND user_id = ?", *input.OrderID, userID).
+			First(&order).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("%w: order not found", ErrInvalidJournalEntry)
+			}
+			return nil, fmt.Errorf("failed to load order: %w", err)
+		}
+		if order.MarketID == "" {
+			return nil, fmt.Errorf("%w: order has no market", ErrInvalidJournalEntry)
+		}
+		orderID := order.ID
+		price := order.Price
+		entry.Subject = models.JournalSubjectOrder
+		entry.OrderID = &orderID
+		entry.MarketID = order.MarketID
+		entry.TokenID = order.OutcomeTokenID
+		entry.Outcome = order.Outcome
+		entry.Side = string(order.Side)
+		entry.EntryPrice = &price
+	case strings.TrimSpace(input.MarketID) != "":
+		market, err := s.loadMarket(ctx, strings.TrimSpace(input.MarketID))
+		if err != nil {
+			return nil, err
+		}
+		entry.Subject = models.JournalSubjectMarket
+		entry.MarketID = market.ConditionID
+		if tokenID := strings.TrimSpace(input.TokenID); tokenID != "" {
+			outcome := journalTokenOutcome(market, tokenID)
+			if outcome == "" {
+				return nil, fmt.Errorf("%w: token does not belong to market", ErrInvalidJournalEntry)
+			}
+			entry.Subject = models.JournalSubjectPosition
+			entry.TokenID = tokenID
+			entry.Outcome = outcome
+			entry.Side = string(models.OrderSideBuy)
+			if side := strings.ToUpper(strings.TrimSpace(input.Side)); side != "" {
+				if side != string(models.OrderSideBuy) && side != string(models.OrderSideSell) {
+					return nil, fmt.Errorf("%w: side must be BUY or SELL", ErrInvalidJournalEntry)
+				}
+				entry.Side = side
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: order_id or market_id is required", ErrInvalidJournalEntry)
+	}
+
+	if input.EntryPrice == nil {
+		input.EntryPrice = entry.EntryPrice
+	}
+	if err := applyJournalFields(entry, JournalEntryUpdate{
+		EntryPrice:       input.EntryPrice,
+		Notes:            input.Notes,
+		Tags:             input.Tags,
+		Confidence:       input.Confidence,
+		PlannedExitPrice: input.PlannedExitPrice,
+		PlannedExitAt:    input.PlannedExitAt,
+		PostMortem:       input.PostMortem,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.WithContext(ctx).Create(entry).Error; err != nil {
+		return nil, fmt.Errorf("failed to create journal entry: %w", err)
+	}
+	views, err := s.buildViews(ctx, []models.JournalEntry{*entry})
+	if err != nil {
+		return nil, err
+	}
+	return &views[0], nil
+}
+
+// UpdateEntry replaces the editable fields of an entry. The subject cannot change.
+func (s *JournalService) UpdateEntry(ctx context.Context, userID, entryID uuid.UUID, input JournalEntryUpdate) (*JournalEntryView, error) {
+	entry, err := s.loadEntry(ctx, userID, entryID)
+	if err != nil {
+		return nil, err
+	}
+	if input.EntryPrice == nil && entry.Subject == models.JournalSubjectOrder {
+		input.EntryPrice = entry.EntryPrice
+	}
+	if err := applyJournalFields(entry, input); err != nil {
+		return nil, err
+	}
+	if err := s.db.WithContext(ctx).Save(entry).Error; err != nil {
+		return nil, fmt.Errorf("failed to update journal entry: %w", err)
+	}
+	views, err := s.buildViews(ctx, []models.JournalEntry{*entry})
+	if err != nil {
+		return nil, err
+	}
+	return &views[0], nil
+}
+
+// DeleteEntry removes one of the user's entries.
+func (s *JournalService) DeleteEntry(ctx context.Context, userID, entryID uuid.UUID) error {
+	result := s.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", entryID, userID).
+		Delete(&models.JournalEntry{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrJournalEntryNotFound
+	}
+	return nil
+}
+
+func (s *JournalService) loadEntry(ctx context.Context, userID, entryID uuid.UUID) (*models.JournalEntry, error) {
+	var entry models.JournalEntry
+	if err := s.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", entryID, userID).
+		First(&entry).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrJournalEntryNotFound
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (s *JournalService) loadMarket(ctx context.Context, conditionID string) (*models.Market, error) {
+	var market models.Market
+	if err := s.db.WithContext(ctx).Where("condition_id = ?", conditionID).First(&market).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: market not found", ErrInvalidJournalEntry)
+		}
+		return nil, fmt.Errorf("failed to load market: %w", err)
+	}
+	return &market, nil
+}
+
+// buildViews attaches market context and outcomes to entries.
+func (s *JournalService) buildViews(ctx context.Context, entries []models.JournalEntry) ([]JournalEntryView, error) {
+	views := make([]JournalEntryView, 0, len(entries))
+	if len(entries) == 0 {
+		return views, nil
+	}
+
+	marketIDs := make([]string, 0, len(entries))
+	var orderIDs []uuid.UUID
+	for _, e := range entries {
+		marketIDs = append(marketIDs, e.MarketID)
+		if e.OrderID != nil {
+			orderIDs = append(orderIDs, *e.OrderID)
+		}
+	}
+
+	var markets []models.Market
+	if err := s.db.WithContext(ctx).Where("condition_id IN ?", marketIDs).Find(&markets).Error; err != nil {
+		return nil, fmt.Errorf("failed to load markets: %w", err)
+	}
+	marketByID := make(map[string]*models.Market, len(markets))
+	for i := range markets {
+		marketByID[markets[i].ConditionID] = &markets[i]
+	}
+
+	orderByID := make(map[uuid.UUID]*models.Order, len(orderIDs))
+	if len(orderIDs) > 0 {
+		var orders []models.Order
+		if err := s.db.WithContext(ctx).Where("id IN ?", orderIDs).Find(&orders).Error; err != nil {
+			return nil, fmt.Errorf("failed to load orders: %w", err)
+		}
+		for i := range orders {
+			orderByID[orders[i].ID] = &orders[i]
+		}
+	}
+
+	for _, e := range entries {
+		view := JournalEntryView{JournalEntry: e}
+		if view.Tags == nil {
+			view.Tags = models.StringArray{}
+		}
+		if market, ok := marketByID[e.MarketID]; ok {
+			view.MarketTitle = market.Title
+			view.MarketSlug = market.Slug
+			var order *models.Order
+			if e.OrderID != nil {
+				order = orderByID[*e.OrderID]
+			}
+			view.Outcome = journalOutcome(&e, market, order)
+		}
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+// journalFilterQuery narrows query to the entries matching filter.
+func journalFilterQuery(query *gorm.DB, filter JournalFilter) (*gorm.DB, error) {
+	for _, tag := range normalizeJournalTags(filter.Tags) {
+		query = query.Where("? = ANY(tags)", tag)
+	}
+	if filter.MarketID != "" {
+		query = query.Where("market_id = ?", filter.MarketID)
+	}
+	if filter.Subject != "" {
+		query = query.Where("subject = ?", filter.Subject)
+	}
+	switch filter.Status {
+	case "":
+	case "resolved":
+		query = query.Where("market_id IN (SELECT condition_id FROM markets WHERE closed = ?)", true)
+	case "open":
+		query = query.Where("market_id NOT IN (SELECT condition_id FROM markets WHERE closed = ?)", true)
+	default:
+		return nil, fmt.Errorf("%w: status must be open or resolved", ErrInvalidJournalEntry)
+	}
+	if q := strings.TrimSpace(filter.Query); q != "" {
+		pattern := "%" + strings.ToLower(q) + "%"
+		query = query.Where("(LOWER(notes) LIKE ? OR LOWER(post_mortem) LIKE ?)", pattern, pattern)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	return query, nil
+}
+
+// journalOutcome derives the realized outcome of an entry from its resolved market.
+func journalOutcome(entry *models.JournalEntry, market *models.Market, order *models.Order) JournalOutcome {
+	resolvedAt, payouts, ok := marketResolution(market)
+	if !ok {
+		return JournalOutcome{}
+	}
+	out := JournalOutcome{Resolved: true, ResolvedAt: &resolvedAt}
+	for tokenID, payout := range payouts {
+		if payout == 1 {
+			out.WinningOutcome = journalTokenOutcome(market, tokenID)
+		}
+	}
+	if entry.TokenID == "" {
+		return out
+	}
+
+	payout, ok := payouts[entry.TokenID]
+	if !ok {
+		return out
+	}
+	out.Payout = &payout
+	correct := payout == 1
+	if entry.Side == string(models.OrderSideSell) {
+		correct = payout == 0
+	}
+	out.Correct = &correct
+	if entry.EntryPrice != nil {
+		move := payout - *entry.EntryPrice
+		out.PriceMove = &move
+	}
+	if order != nil {
+		if size := order.FilledSize(); size > 0 {
+			pnl := (payout - order.Price) * size
+			if order.Side == models.OrderSideSell {
+				pnl = -pnl
+			}
+			pnl = math.Round(pnl*1e6) / 1e6
+			out.RealizedPnL = &pnl
+		}
+	}
+	return out
+}
+
+// journalTokenOutcome returns the YES / NO label of a token in a market.
+func journalTokenOutcome(market *models.Market, tokenID string) string {
+	switch tokenID {
+	case market.TokenIDYes:
+		return "YES"
+	case market.TokenIDNo:
+		return "NO"
+	}
+	return ""
+}
+
+// applyJournalFields validates and copies the editable fields onto entry.
+func applyJournalFields(entry *models.JournalEntry, input JournalEntryUpdate) error {
+	notes := strings.TrimSpace(input.Notes)
+	postMortem := strings.TrimSpace(input.PostMortem)
+	if len(notes) > maxJournalNotesLength || len(postMortem) > maxJournalNotesLength {
+		return fmt.Errorf("%w: notes are limited to %d characters", ErrInvalidJournalEntry, maxJournalNotesLength)
+	}
+	if input.Confidence != nil && (*input.Confidence < 0 || *input.Confidence > 100) {
+		return fmt.Errorf("%w: confidence must be between 0 and 100", ErrInvalidJournalEntry)
+	}
+	for _, price := range []*float64{input.EntryPrice, input.PlannedExitPrice} {
+		if price != nil && (*price < 0 || *price > 1) {
+			return fmt.Errorf("%w: prices must be between 0 and 1", ErrInvalidJournalEntry)
+		}
+	}
+	tags := normalizeJournalTags(input.Tags)
+	if len(tags) > maxJournalTags {
+		return fmt.Errorf("%w: at most %d tags", ErrInvalidJournalEntry, maxJournalTags)
+	}
+
+	entry.Notes = notes
+	entry.PostMortem = postMortem
+	entry.Tags = models.StringArray(tags)
+	entry.Confidence = input.Confidence
+	entry.EntryPrice = input.EntryPrice
+	entry.PlannedExitPrice = input.PlannedExitPrice
+	entry.PlannedExitAt = input.PlannedExitAt
+	return nil
+}
+
+// normalizeJournalTags lowercases, strips unsupported characters and de-duplicates tags.
+func normalizeJournalTags(raw []string) []string {
+	seen := make(map[string]bool, len(raw))
+	tags := make([]string, 0, len(raw))
+	for _, tag := range raw {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		tag = strings.ReplaceAll(tag, " ", "-")
+		tag = journalTagInvalidChars.ReplaceAllString(tag, "")
+		if len(tag) > maxJournalTagLength {
+			tag = tag[:maxJournalTagLength]
+		}
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
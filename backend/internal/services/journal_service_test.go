@@ -0,0 +1,175 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bankai-project/backend/internal/models"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// dryRunDB builds statements without a database connection.
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1 sslmode=disable"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatalf("open dry-run db: %v", err)
+	}
+	return db
+}
+
+func TestJournalFilterQuery(t *testing.T) {
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	cases := []struct {
+		name     string
+		filter   JournalFilter
+		wantSQL  string
+		wantVars []interface{}
+	}{
+		{"no filter", JournalFilter{}, `SELECT * FROM "journal_entries"`, nil},
+		{"tags are normalized and de-duplicated",
+			JournalFilter{Tags: []string{" Earnings Play ", "earnings-play", "fomo!"}},
+			`SELECT * FROM "journal_entries" WHERE $1 = ANY(tags) AND $2 = ANY(tags)`,
+			[]interface{}{"earnings-play", "fomo"}},
+		{"market and subject",
+			JournalFilter{MarketID: "0xm", Subject: models.JournalSubjectOrder},
+			`SELECT * FROM "journal_entries" WHERE market_id = $1 AND subject = $2`,
+			[]interface{}{"0xm", models.JournalSubjectOrder}},
+		{"resolved",
+			JournalFilter{Status: "resolved"},
+			`SELECT * FROM "journal_entries" WHERE market_id IN (SELECT condition_id FROM markets WHERE closed = $1)`,
+			[]interface{}{true}},
+		{"open",
+			JournalFilter{Status: "open"},
+			`SELECT * FROM "journal_entries" WHERE market_id NOT IN (SELECT condition_id FROM markets WHERE closed = $1)`,
+			[]interface{}{true}},
+		{"text search is case-insensitive",
+			JournalFilter{Query: "  Fed Cut "},
+			`SELECT * FROM "journal_entries" WHERE (LOWER(notes) LIKE $1 OR LOWER(post_mortem) LIKE $2)`,
+			[]interface{}{"%fed cut%", "%fed cut%"}},
+		{"blank search ignored", JournalFilter{Query: "   "}, `SELECT * FROM "journal_entries"`, nil},
+		{"date range is half-open",
+			JournalFilter{From: &from, To: &to},
+			`SELECT * FROM "journal_entries" WHERE created_at >= $1 AND created_at < $2`,
+			[]interface{}{from, to}},
+	}
+	db := dryRunDB(t)
+	for _, tc := range cases {
+		query, err := journalFilterQuery(db.Model(&models.JournalEntry{}), tc.filter)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tc.name, err)
+			continue
+		}
+		stmt := query.Find(&[]models.JournalEntry{}).Statement
+		if got := stmt.SQL.String(); got != tc.wantSQL {
+			t.Errorf("%s: sql = %s\nwant %s", tc.name, got, tc.wantSQL)
+		}
+		if len(stmt.Vars) != 0 || len(tc.wantVars) != 0 {
+			if !reflect.DeepEqual(stmt.Vars, tc.wantVars) {
+				t.Errorf("%s: vars = %v, want %v", tc.name, stmt.Vars, tc.wantVars)
+			}
+		}
+	}
+
+	if _, err := journalFilterQuery(db.Model(&models.JournalEntry{}), JournalFilter{Status: "pending"}); !errors.Is(err, ErrInvalidJournalEntry) {
+		t.Errorf("unknown status: err = %v, want %v", err, ErrInvalidJournalEntry)
+	}
+}
+
+func TestJournalOutcome(t *testing.T) {
+	end := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	resolved := &models.Market{
+		ConditionID:   "0xm",
+		TokenIDYes:    "yes",
+		TokenIDNo:     "no",
+		Closed:        true,
+		OutcomePrices: `["1", "0"]`,
+		EndDate:       &end,
+	}
+	price := func(v float64) *float64 { return &v }
+
+	cases := []struct {
+		name        string
+		entry       models.JournalEntry
+		market      *models.Market
+		order       *models.Order
+		wantPayout  *float64
+		wantCorrect *bool
+		wantMove    *float64
+		wantPnL     *float64
+	}{
+		{name: "market entry has no token", entry: models.JournalEntry{}, market: resolved},
+		{name: "unknown token", entry: models.JournalEntry{TokenID: "other"}, market: resolved},
+		{name: "bought the winner",
+			entry:  models.JournalEntry{TokenID: "yes", Side: "BUY", EntryPrice: price(0.4)},
+			market: resolved, wantPayout: price(1), wantCorrect: boolPtr(true), wantMove: price(0.6)},
+		{name: "bought the loser",
+			entry:  models.JournalEntry{TokenID: "no", Side: "BUY", EntryPrice: price(0.6)},
+			market: resolved, wantPayout: price(0), wantCorrect: boolPtr(false), wantMove: price(-0.6)},
+		{name: "sold the loser",
+			entry:  models.JournalEntry{TokenID: "no", Side: "SELL"},
+			market: resolved, wantPayout: price(0), wantCorrect: boolPtr(true)},
+		{name: "filled buy order",
+			entry:  models.JournalEntry{TokenID: "yes", Side: "BUY"},
+			market: resolved, order: &models.Order{Side: models.OrderSideBuy, Price: 0.25, SizeMatched: 40},
+			wantPayout: price(1), wantCorrect: boolPtr(true), wantPnL: price(30)},
+		{name: "filled sell order of the winner",
+			entry:  models.JournalEntry{TokenID: "yes", Side: "SELL"},
+			market: resolved, order: &models.Order{Side: models.OrderSideSell, Price: 0.7, Size: 10, Status: models.OrderStatusFilled},
+			wantPayout: price(1), wantCorrect: boolPtr(false), wantPnL: price(-3)},
+		{name: "unfilled order has no pnl",
+			entry:  models.JournalEntry{TokenID: "yes", Side: "BUY"},
+			market: resolved, order: &models.Order{Side: models.OrderSideBuy, Price: 0.25, Size: 40},
+			wantPayout: price(1), wantCorrect: boolPtr(true)},
+	}
+	for _, tc := range cases {
+		out := journalOutcome(&tc.entry, tc.market, tc.order)
+		if !out.Resolved || out.ResolvedAt == nil || !out.ResolvedAt.Equal(end) || out.WinningOutcome != "YES" {
+			t.Errorf("%s: resolution = %+v", tc.name, out)
+		}
+		checkFloat(t, tc.name+" payout", out.Payout, tc.wantPayout)
+		checkFloat(t, tc.name+" price move", out.PriceMove, tc.wantMove)
+		checkFloat(t, tc.name+" pnl", out.RealizedPnL, tc.wantPnL)
+		if (out.Correct == nil) != (tc.wantCorrect == nil) || (out.Correct != nil && *out.Correct != *tc.wantCorrect) {
+			t.Errorf("%s: correct = %v, want %v", tc.name, out.Correct, tc.wantCorrect)
+		}
+	}
+
+	open := *resolved
+	open.Closed = false
+	if out := journalOutcome(&models.JournalEntry{TokenID: "yes"}, &open, nil); out.Resolved || out.Payout != nil {
+		t.Errorf("open market: outcome = %+v, want unresolved", out)
+	}
+	disputed := *resolved
+	disputed.OutcomePrices = `["0.5", "0.5"]`
+	if out := journalOutcome(&models.JournalEntry{TokenID: "yes"}, &disputed, nil); out.Resolved {
+		t.Errorf("unsettled prices: outcome = %+v, want unresolved", out)
+	}
+}
+
+func TestNormalizeJournalTags(t *testing.T) {
+	long := "abcdefghijklmnopqrstuvwxyz0123456789"
+	got := normalizeJournalTags([]string{"Macro", " macro ", "Rate Hike", "$$$", "", "swing_trade", long})
+	want := []string{"macro", "rate-hike", "swing_trade", long[:maxJournalTagLength]}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("tags = %v, want %v", got, want)
+	}
+}
+
+func boolPtr(v bool) *bool { return &v }
+
+func checkFloat(t *testing.T, name string, got, want *float64) {
+	t.Helper()
+	if (got == nil) != (want == nil) {
+		t.Errorf("%s = %v, want %v", name, got, want)
+		return
+	}
+	if got != nil && !closeTo(*got, *want) {
+		t.Errorf("%s = %v, want %v", name, *got, *want)
+	}
+}
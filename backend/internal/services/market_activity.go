@@ -0,0 +1,271 @@
+/**
+ * @description
+ * Market activity metrics backed by Redis.
+ * Replaces the ever-growing velocity sorted set with minute/hour buckets for
+ * price updates, trades and traded notional, plus exponentially decayed scores
+ * used for trending ranking.
+ *
+ * @dependencies
+ * - github.com/redis/go-redis/v9
+ *
+ * @notes
+ * - Minute buckets back the 5m/1h windows, hour buckets back the 24h window.
+ * - Decayed scores use the window length as time constant, so a decayed value
+ *   approximates the exponentially weighted count over that window.
+ * - All writes for one event run in a single Lua script (one round trip from RTDS).
+ */
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	activityMinuteKey = "activity:%s:m:%d"
+	activityHourKey   = "activity:%s:h:%d"
+	activityDecayKey  = "activity:%s:decay"
+
+	activityMinuteTTL = 2 * time.Hour
+	activityHourTTL   = 26 * time.Hour
+	activityDecayTTL  = 48 * time.Hour
+
+	ActivityWindow5m  = "5m"
+	ActivityWindow1h  = "1h"
+	ActivityWindow24h = "24h"
+)
+
+// activityWindows lists the rolling windows in ascending order with their decay time constants.
+var activityWindows = []struct {
+	Name string
+	Span time.Duration
+}{
+	{ActivityWindow5m, 5 * time.Minute},
+	{ActivityWindow1h, time.Hour},
+	{ActivityWindow24h, 24 * time.Hour},
+}
+
+// recordActivityScript increments the minute/hour buckets and folds the event into the decayed scores.
+// KEYS: minute bucket, hour bucket, decay hash
+// ARGV: now (ms), updates, trades, notional, minute TTL (s), hour TTL (s), decay TTL (s), then window spans (ms)
+var recordActivityScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local inc = {updates = tonumber(ARGV[2]), trades = tonumber(ARGV[3]), notional = tonumber(ARGV[4])}
+
+for _, key in ipairs({KEYS[1], KEYS[2]}) do
+	if inc.updates ~= 0 then redis.call('HINCRBY', key, 'updates', inc.updates) end
+	if inc.trades ~= 0 then redis.call('HINCRBY', key, 'trades', inc.trades) end
+	if inc.notional ~= 0 then redis.call('HINCRBYFLOAT', key, 'notional', inc.notional) end
+end
+redis.call('EXPIRE', KEYS[1], ARGV[5])
+redis.call('EXPIRE', KEYS[2], ARGV[6])
+
+local last = tonumber(redis.call('HGET', KEYS[3], 'ts') or now)
+local dt = now - last
+if dt < 0 then dt = 0 end
+
+local names = {'5m', '1h', '24h'}
+for i, name in ipairs(names) do
+	local factor = math.exp(-dt / tonumber(ARGV[7 + i]))
+	for metric, value in pairs(inc) do
+		local field = metric .. ':' .. name
+		local current = tonumber(redis.call('HGET', KEYS[3], field) or '0')
+		redis.call('HSET', KEYS[3], field, tostring(current * factor + value))
+	end
+end
+redis.call('HSET', KEYS[3], 'ts', tostring(now))
+redis.call('EXPIRE', KEYS[3], ARGV[7])
+return 1
+`)
+
+// ActivityCounts holds update/trade/notional totals for a window.
+type ActivityCounts struct {
+	Updates  float64 `json:"updates"`
+	Trades   float64 `json:"trades"`
+	Notional float64 `json:"notional"`
+}
+
+// MarketActivityStats summarises recent activity for a single market.
+type MarketActivityStats struct {
+	ConditionID string                    `json:"condition_id"`
+	Windows     map[string]ActivityCounts `json:"windows"`
+	Decayed     map[string]ActivityCounts `json:"decayed"`
+	Score       float64                   `json:"score"`
+	UpdatedAt   *time.Time                `json:"updated_at,omitempty"`
+}
+
+// MarketActivityTracker records and reads per-market activity metrics
+type MarketActivityTracker struct {
+	redis *redis.Client
+}
+
+// NewMarketActivityTracker creates a new MarketActivityTracker
+func NewMarketActivityTracker(redis *redis.Client) *MarketActivityTracker {
+	return &MarketActivityTracker{redis: redis}
+}
+
+// RecordPriceUpdate counts a price change message for the market.
+func (t *MarketActivityTracker) RecordPriceUpdate(ctx context.Context, conditionID string) error {
+	return t.record(ctx, conditionID, time.Now(), 1, 0, 0)
+}
+
+// RecordTrade counts an executed trade and its notional (price * size) for the market.
+func (t *MarketActivityTracker) RecordTrade(ctx context.Context, conditionID string, notional float64) error {
+	if notional < 0 || math.IsNaN(notional) || math.IsInf(notional, 0) {
+		notional = 0
+	}
+	return t.record(ctx, conditionID, time.Now(), 0, 1, notional)
+}
+
+func (t *MarketActivityTracker) record(ctx context.Context, conditionID string, at time.Time, updates, trades int64, notional float64) error {
+	if t == nil || t.redis == nil || conditionID == "" {
+		return nil
+	}
+
+	keys := []string{
+		fmt.Sprintf(activityMinuteKey, conditionID, at.Unix()/60),
+		fmt.Sprintf(activityHourKey, conditionID, at.Unix()/3600),
+		fmt.Sprintf(activityDecayKey, conditionID),
+	}
+	args := []interface{}{
+		at.UnixMilli(),
+		updates,
+		trades,
+		strconv.FormatFloat(notional, 'f', -1, 64),
+		int(activityMinuteTTL.Seconds()),
+		int(activityHourTTL.Seconds()),
+		int(activityDecayTTL.Seconds()),
+	}
+	for _, window := range activityWindows {
+		args = append(args, window.Span.Milliseconds())
+	}
+
+	return recordActivityScript.Run(ctx, t.redis, keys, args...).Err()
+}
+
+// GetStats returns exact bucketed windows and decayed scores for a market.
+func (t *MarketActivityTracker) GetStats(ctx context.Context, conditionID string) (*MarketActivityStats, error) {
+	now := time.Now()
+	minute := now.Unix() / 60
+	hour := now.Unix() / 3600
+
+	pipe := t.redis.Pipeline()
+	minuteCmds := make([]*redis.MapStringStringCmd, 60)
+	for i := range minuteCmds {
+		minuteCmds[i] = pipe.HGetAll(ctx, fmt.Sprintf(activityMinuteKey, conditionID, minute-int64(i)))
+	}
+	hourCmds := make([]*redis.MapStringStringCmd, 24)
+	for i := range hourCmds {
+		hourCmds[i] = pipe.HGetAll(ctx, fmt.Sprintf(activityHourKey, conditionID, hour-int64(i)))
+	}
+	decayCmd := pipe.HGetAll(ctx, fmt.Sprintf(activityDecayKey, conditionID))
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to read activity buckets: %w", err)
+	}
+
+	var last5m, last1h, last24h ActivityCounts
+	for i, cmd := range minuteCmds {
+		counts := parseActivityCounts(cmd.Val(), "")
+		if i < 5 {
+			last5m = addActivityCounts(last5m, counts)
+		}
+		last1h = addActivityCounts(last1h, counts)
+	}
+	for _, cmd := range hourCmds {
+		last24h = addActivityCounts(last24h, parseActivityCounts(cmd.Val(), ""))
+	}
+
+	stats := &MarketActivityStats{
+		ConditionID: conditionID,
+		Windows: map[string]ActivityCounts{
+			ActivityWindow5m:  last5m,
+			ActivityWindow1h:  last1h,
+			ActivityWindow24h: last24h,
+		},
+		Decayed: decayedActivity(decayCmd.Val(), now),
+	}
+	stats.Score = activityScore(stats.Decayed[ActivityWindow1h])
+	if ts, err := strconv.ParseInt(decayCmd.Val()["ts"], 10, 64); err == nil {
+		updated := time.UnixMilli(ts).UTC()
+		stats.UpdatedAt = &updated
+	}
+
+	return stats, nil
+}
+
+// Scores returns the 1h decayed activity score for each market, decayed to the current time.
+func (t *MarketActivityTracker) Scores(ctx context.Context, conditionIDs []string) map[string]float64 {
+	result := make(map[string]float64, len(conditionIDs))
+	if t == nil || t.redis == nil || len(conditionIDs) == 0 {
+		return result
+	}
+
+	pipe := t.redis.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, len(conditionIDs))
+	for i, id := range conditionIDs {
+		cmds[i] = pipe.HGetAll(ctx, fmt.Sprintf(activityDecayKey, id))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return result
+	}
+
+	now := time.Now()
+	for i, id := range conditionIDs {
+		decayed := decayedActivity(cmds[i].Val(), now)
+		result[id] = activityScore(decayed[ActivityWindow1h])
+	}
+
+	return result
+}
+
+// decayedActivity applies the decay accrued since the last write so idle markets cool off on read.
+func decayedActivity(fields map[string]string, now time.Time) map[string]ActivityCounts {
+	result := make(map[string]ActivityCounts, len(activityWindows))
+	elapsed := 0.0
+	if ts, err := strconv.ParseInt(fields["ts"], 10, 64); err == nil {
+		elapsed = float64(now.UnixMilli() - ts)
+		if elapsed < 0 {
+			elapsed = 0
+		}
+	}
+
+	for _, window := range activityWindows {
+		factor := math.Exp(-elapsed / float64(window.Span.Milliseconds()))
+		counts := parseActivityCounts(fields, ":"+window.Name)
+		result[window.Name] = ActivityCounts{
+			Updates:  counts.Updates * factor,
+			Trades:   counts.Trades * factor,
+			Notional: counts.Notional * factor,
+		}
+	}
+
+	return result
+}
+
+// activityScore collapses decayed counts into a single velocity score.
+// Log scaling keeps a single whale print from drowning out sustained flow.
+func activityScore(c ActivityCounts) float64 {
+	return math.Log1p(c.Notional) + 0.5*math.Log1p(c.Trades) + 0.25*math.Log1p(c.Updates)
+}
+
+func parseActivityCounts(fields map[string]string, suffix string) ActivityCounts {
+	return ActivityCounts{
+		Updates:  parseStringFloat(fields["updates"+suffix]),
+		Trades:   parseStringFloat(fields["trades"+suffix]),
+		Notional: parseStringFloat(fields["notional"+suffix]),
+	}
+}
+
+func addActivityCounts(a, b ActivityCounts) ActivityCounts {
+	return ActivityCounts{
+		Updates:  a.Updates + b.Updates,
+		Trades:   a.Trades + b.Trades,
+		Notional: a.Notional + b.Notional,
+	}
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"math"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestDecayedActivity(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	fields := func(at time.Time) map[string]string {
+		f := map[string]string{"ts": strconv.FormatInt(at.UnixMilli(), 10)}
+		for _, name := range []string{ActivityWindow5m, ActivityWindow1h, ActivityWindow24h} {
+			f["updates:"+name] = "100"
+			f["trades:"+name] = "10"
+			f["notional:"+name] = "1000"
+		}
+		return f
+	}
+
+	cases := []struct {
+		name    string
+		fields  map[string]string
+		factors map[string]float64
+	}{
+		{"just written", fields(now), map[string]float64{ActivityWindow5m: 1, ActivityWindow1h: 1, ActivityWindow24h: 1}},
+		{"one hour idle", fields(now.Add(-time.Hour)), map[string]float64{
+			ActivityWindow5m:  math.Exp(-12),
+			ActivityWindow1h:  math.Exp(-1),
+			ActivityWindow24h: math.Exp(-1.0 / 24),
+		}},
+		{"timestamp ahead of the clock", fields(now.Add(time.Minute)), map[string]float64{ActivityWindow5m: 1, ActivityWindow1h: 1, ActivityWindow24h: 1}},
+		{"missing timestamp", map[string]string{"trades:1h": "10", "notional:1h": "1000", "updates:1h": "100"}, map[string]float64{ActivityWindow1h: 1}},
+	}
+	for _, tc := range cases {
+		got := decayedActivity(tc.fields, now)
+		if len(got) != len(activityWindows) {
+			t.Errorf("%s: got %d windows, want %d", tc.name, len(got), len(activityWindows))
+		}
+		for window, factor := range tc.factors {
+			c := got[window]
+			if !closeTo(c.Updates, 100*factor) || !closeTo(c.Trades, 10*factor) || !closeTo(c.Notional, 1000*factor) {
+				t.Errorf("%s: %s = %+v, want factor %v", tc.name, window, c, factor)
+			}
+		}
+	}
+
+	if got := decayedActivity(nil, now); got[ActivityWindow1h] != (ActivityCounts{}) {
+		t.Errorf("empty hash: got %+v, want zero counts", got[ActivityWindow1h])
+	}
+}
+
+func TestActivityScoreRanking(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	score := func(lastWrite time.Time, updates, trades, notional float64) float64 {
+		fields := map[string]string{
+			"ts":          strconv.FormatInt(lastWrite.UnixMilli(), 10),
+			"updates:1h":  strconv.FormatFloat(updates, 'f', -1, 64),
+			"trades:1h":   strconv.FormatFloat(trades, 'f', -1, 64),
+			"notional:1h": strconv.FormatFloat(notional, 'f', -1, 64),
+		}
+		return activityScore(decayedActivity(fields, now)[ActivityWindow1h])
+	}
+
+	if got := activityScore(ActivityCounts{}); got != 0 {
+		t.Errorf("idle market score = %v, want 0", got)
+	}
+
+	sustained := score(now, 500, 200, 20000)
+	whale := score(now, 1, 1, 100000)
+	if sustained <= whale {
+		t.Errorf("sustained flow %v should outrank a single large print %v", sustained, whale)
+	}
+
+	live := score(now, 50, 20, 2000)
+	cooled := score(now.Add(-6*time.Hour), 50, 20, 20000)
+	if live <= cooled {
+		t.Errorf("live market %v should outrank one idle for six hours %v", live, cooled)
+	}
+
+	if more, less := score(now, 10, 10, 500), score(now, 10, 5, 500); more <= less {
+		t.Errorf("more trades %v should score above fewer %v", more, less)
+	}
+}
+
+func TestActivityCountsParseAndAdd(t *testing.T) {
+	fields := map[string]string{"updates": "3", "trades": "2", "notional": "12.5", "trades:1h": "9"}
+	got := addActivityCounts(parseActivityCounts(fields, ""), parseActivityCounts(map[string]string{"notional": "bad"}, ""))
+	want := ActivityCounts{Updates: 3, Trades: 2, Notional: 12.5}
+	if got != want {
+		t.Errorf("counts = %+v, want %+v", got, want)
+	}
+	if got := parseActivityCounts(fields, ":1h"); got != (ActivityCounts{Trades: 9}) {
+		t.Errorf("suffixed counts = %+v, want 9 trades", got)
+	}
+}
+
+func closeTo(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*math.Max(1, math.Abs(b))
+}
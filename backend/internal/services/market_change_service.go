@@ -0,0 +1,277 @@
+/**
+ * @description
+ * Market Change Log Service.
+ * Diffs incoming Gamma market snapshots against stored rows inside the upsert transaction,
+ * records field-level edits in market_changes and alerts users who bookmarked the market once it commits.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - backend/internal/models
+ *
+ * @notes
+ * - Only markets already present in Postgres are diffed; first inserts have nothing to compare against.
+ * - Rule and end date edits raise MARKET_CHANGE notifications; status flips are only logged.
+ * - Callers run RecordChanges in a savepoint of the upsert transaction and NotifyChanges after commit, so a
+ *   rolled-back upsert leaves neither a change row nor a notification behind.
+ */
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	MarketFieldResolutionRules = "resolution_rules"
+	MarketFieldEndDate         = "end_date"
+	MarketFieldAcceptingOrders = "accepting_orders"
+	MarketFieldActive          = "active"
+	MarketFieldClosed          = "closed"
+	MarketFieldArchived        = "archived"
+
+	marketChangeLookupBatch = 500
+)
+
+// trackedMarketFields maps each diffed column to a formatter producing its stored text value.
+var trackedMarketFields = []struct {
+	Field  string
+	Format func(models.Market) string
+}{
+	{MarketFieldResolutionRules, func(m models.Market) string { return m.ResolutionRules }},
+	{MarketFieldEndDate, func(m models.Market) string { return formatChangeTime(m.EndDate) }},
+	{MarketFieldAcceptingOrders, func(m models.Market) string { return strconv.FormatBool(m.AcceptingOrders) }},
+	{MarketFieldActive, func(m models.Market) string { return strconv.FormatBool(m.Active) }},
+	{MarketFieldClosed, func(m models.Market) string { return strconv.FormatBool(m.Closed) }},
+	{MarketFieldArchived, func(m models.Market) string { return strconv.FormatBool(m.Archived) }},
+}
+
+// notifyMarketFields lists the fields whose edits alert bookmarking users.
+var notifyMarketFields = map[string]bool{
+	MarketFieldResolutionRules: true,
+	MarketFieldEndDate:         true,
+}
+
+// MarketChangeService records and serves the market change log
+type MarketChangeService struct {
+	db *gorm.DB
+}
+
+// NewMarketChangeService creates a new MarketChangeService
+func NewMarketChangeService(db *gorm.DB) *MarketChangeService {
+	return &MarketChangeService{db: db}
+}
+
+// MarketChangeAlertData is the payload stored on MARKET_CHANGE notifications
+type MarketChangeAlertData struct {
+	ConditionID string `json:"condition_id"`
+	MarketSlug  string `json:"market_slug"`
+	MarketTitle string `json:"market_title"`
+	Field       string `json:"field"`
+	OldValue    string `json:"old_value"`
+	NewValue    string `json:"new_value"`
+	DetectedAt  string `json:"detected_at"`
+}
+
+// DetectChanges compares incoming markets with their stored rows in tx and returns the field-level differences.
+func (s *MarketChangeService) DetectChanges(ctx context.Context, tx *gorm.DB, incoming []models.Market) ([]models.MarketChange, error) {
+	if len(incoming) == 0 {
+		return nil, nil
+	}
+
+	existing, err := loadStoredMarkets(ctx, tx, incoming)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffMarkets(existing, incoming, time.Now().UTC()), nil
+}
+
+// RecordChanges diffs incoming markets and persists any detected changes in tx, which must be the transaction
+// that then upserts the markets. Pass the returned changes to NotifyChanges once it commits.
+func (s *MarketChangeService) RecordChanges(ctx context.Context, tx *gorm.DB, incoming []models.Market) ([]models.MarketChange, error) {
+	changes, err := s.DetectChanges(ctx, tx, incoming)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	if err := tx.WithContext(ctx).CreateInBatches(&changes, 100).Error; err != nil {
+		return nil, fmt.Errorf("failed to record market changes: %w", err)
+	}
+
+	logger.Info("MarketChangeService: Recorded %d market field changes", len(changes))
+	return changes, nil
+}
+
+// NotifyChanges alerts bookmarking users about committed changes to the given markets.
+func (s *MarketChangeService) NotifyChanges(ctx context.Context, incoming []models.Market, changes []models.MarketChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	byID := make(map[string]models.Market, len(incoming))
+	for _, market := range incoming {
+		byID[market.ConditionID] = market
+	}
+
+	for _, change := range changes {
+		if !notifyMarketFields[change.Field] {
+			continue
+		}
+		if err := s.notifyBookmarks(ctx, byID[change.MarketID], change); err != nil {
+			logger.Error("MarketChangeService: Failed to notify bookmarks for %s: %v", change.MarketID, err)
+		}
+	}
+}
+
+// GetChanges returns the most recent recorded changes for a market, newest first.
+func (s *MarketChangeService) GetChanges(ctx context.Context, conditionID string, limit, offset int) ([]models.MarketChange, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	var changes []models.MarketChange
+	result := s.db.WithContext(ctx).
+		Where("market_id = ?", conditionID).
+		Order("detected_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&changes)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return changes, nil
+}
+
+func loadStoredMarkets(ctx context.Context, tx *gorm.DB, incoming []models.Market) (map[string]models.Market, error) {
+	ids := make([]string, 0, len(incoming))
+	for _, market := range incoming {
+		if market.ConditionID != "" {
+			ids = append(ids, market.ConditionID)
+		}
+	}
+
+	existing := make(map[string]models.Market, len(ids))
+	for start := 0; start < len(ids); start += marketChangeLookupBatch {
+		end := start + marketChangeLookupBatch
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		var rows []models.Market
+		if err := tx.WithContext(ctx).
+			Select("condition_id", "resolution_rules", "end_date", "accepting_orders", "active", "closed", "archived").
+			Where("condition_id IN ?", ids[start:end]).
+			Find(&rows).Error; err != nil {
+			return nil, fmt.Errorf("failed to load stored markets: %w", err)
+		}
+		for _, row := range rows {
+			existing[row.ConditionID] = row
+		}
+	}
+
+	return existing, nil
+}
+
+func (s *MarketChangeService) notifyBookmarks(ctx context.Context, market models.Market, change models.MarketChange) error {
+	var userIDs []uuid.UUID
+	if err := s.db.WithContext(ctx).
+		Model(&models.MarketBookmark{}).
+		Where("market_id = ?", change.MarketID).
+		Pluck("user_id", &userIDs).Error; err != nil {
+		return err
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	dataJSON, err := json.Marshal(MarketChangeAlertData{
+		ConditionID: change.MarketID,
+		MarketSlug:  market.Slug,
+		MarketTitle: market.Title,
+		Field:       change.Field,
+		OldValue:    change.OldValue,
+		NewValue:    change.NewValue,
+		DetectedAt:  change.DetectedAt.Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+
+	title := "Resolution rules changed"
+	message := fmt.Sprintf("Polymarket edited the resolution rules for %s", market.Title)
+	if change.Field == MarketFieldEndDate {
+		title = "End date changed"
+		message = fmt.Sprintf("The end date for %s moved from %s to %s",
+			market.Title, displayChangeValue(change.OldValue), displayChangeValue(change.NewValue))
+	}
+
+	notifications := make([]models.Notification, len(userIDs))
+	for i, userID := range userIDs {
+		notifications[i] = models.Notification{
+			ID:        uuid.New(),
+			UserID:    userID,
+			Type:      models.NotificationTypeMarketChange,
+			Title:     title,
+			Message:   message,
+			Data:      string(dataJSON),
+			Read:      false,
+			CreatedAt: change.DetectedAt,
+		}
+	}
+
+	return s.db.WithContext(ctx).Create(&notifications).Error
+}
+
+// diffMarkets returns one change per tracked field that differs between each incoming market and its stored row.
+// Markets without a stored row are new and produce no changes.
+func diffMarkets(existing map[string]models.Market, incoming []models.Market, now time.Time) []models.MarketChange {
+	var changes []models.MarketChange
+	for _, market := range incoming {
+		stored, ok := existing[market.ConditionID]
+		if !ok {
+			continue
+		}
+		for _, tracked := range trackedMarketFields {
+			oldValue := tracked.Format(stored)
+			newValue := tracked.Format(market)
+			if oldValue == newValue {
+				continue
+			}
+			changes = append(changes, models.MarketChange{
+				MarketID:   market.ConditionID,
+				Field:      tracked.Field,
+				OldValue:   oldValue,
+				NewValue:   newValue,
+				DetectedAt: now,
+			})
+		}
+	}
+	return changes
+}
+
+func formatChangeTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Truncate(time.Second).Format(time.RFC3339)
+}
+
+func displayChangeValue(value string) string {
+	if value == "" {
+		return "unset"
+	}
+	return value
+}
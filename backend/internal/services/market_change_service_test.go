@@ -0,0 +1,72 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bankai-project/backend/internal/models"
+)
+
+func TestDiffMarkets(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	endLocal := end.In(time.FixedZone("EST", -5*3600)).Add(400 * time.Millisecond)
+	later := end.Add(24 * time.Hour)
+
+	stored := models.Market{
+		ConditionID:     "0xa",
+		ResolutionRules: "Resolves YES if it rains.",
+		EndDate:         &end,
+		AcceptingOrders: true,
+		Active:          true,
+	}
+	with := func(edit func(*models.Market)) models.Market {
+		m := stored
+		edit(&m)
+		return m
+	}
+
+	type change struct{ field, old, new string }
+	cases := []struct {
+		name     string
+		incoming models.Market
+		want     []change
+	}{
+		{"unchanged", stored, nil},
+		{"end date in another zone with sub-second noise", with(func(m *models.Market) { m.EndDate = &endLocal }), nil},
+		{"rules edited", with(func(m *models.Market) { m.ResolutionRules = "Resolves YES if it snows." }),
+			[]change{{MarketFieldResolutionRules, "Resolves YES if it rains.", "Resolves YES if it snows."}}},
+		{"end date moved", with(func(m *models.Market) { m.EndDate = &later }),
+			[]change{{MarketFieldEndDate, "2025-07-01T00:00:00Z", "2025-07-02T00:00:00Z"}}},
+		{"end date cleared", with(func(m *models.Market) { m.EndDate = nil }),
+			[]change{{MarketFieldEndDate, "2025-07-01T00:00:00Z", ""}}},
+		{"market closed", with(func(m *models.Market) {
+			m.AcceptingOrders = false
+			m.Active = false
+			m.Closed = true
+		}), []change{
+			{MarketFieldAcceptingOrders, "true", "false"},
+			{MarketFieldActive, "true", "false"},
+			{MarketFieldClosed, "false", "true"},
+		}},
+		{"archived", with(func(m *models.Market) { m.Archived = true }),
+			[]change{{MarketFieldArchived, "false", "true"}}},
+	}
+	for _, tc := range cases {
+		got := diffMarkets(map[string]models.Market{"0xa": stored}, []models.Market{tc.incoming}, now)
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: got %d changes %+v, want %d", tc.name, len(got), got, len(tc.want))
+			continue
+		}
+		for i, w := range tc.want {
+			c := got[i]
+			if c.MarketID != "0xa" || c.Field != w.field || c.OldValue != w.old || c.NewValue != w.new || !c.DetectedAt.Equal(now) {
+				t.Errorf("%s: change %d = %+v, want %+v", tc.name, i, c, w)
+			}
+		}
+	}
+
+	if got := diffMarkets(map[string]models.Market{}, []models.Market{stored}, now); len(got) != 0 {
+		t.Errorf("new market: got %d changes, want none", len(got))
+	}
+}
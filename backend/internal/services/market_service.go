@@ -29,7 +29,6 @@ import (
 	"github.com/bankai-project/backend/internal/models"
 	"github.com/bankai-project/backend/internal/polymarket/clob"
 	"github.com/bankai-project/backend/internal/polymarket/gamma"
-	"github.com/jackc/pgconn"
 	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -50,10 +49,35 @@ const (
 
 	PriceUpdateChannel = "market:price_updates"
 
-	marketSyncLockKey = 42
-	lanePoolCap       = 2000
+	marketSyncLockKey   = 42
+	velocityLanePool    = 200
+	gammaEventsPageSize = 100
+	gammaEventsMaxPages = 500
 )
 
+// marketUpsertColumns are overwritten when a synced market already exists.
+// Every field diffed by the change log must be listed here, otherwise the same edit is re-detected on each pass.
+var marketUpsertColumns = []string{
+	"volume_24h",
+	"liquidity",
+	"active",
+	"closed",
+	"archived",
+	"accepting_orders",
+	"title",
+	"description",
+	"resolution_rules",
+	"category",
+	"tags",
+	"token_id_yes",
+	"token_id_no",
+	"end_date",
+	"event_start_time",
+	"accepting_orders_at",
+	"market_updated_at",
+	"event_id",
+}
+
 var (
 	ErrOrderBookUnavailable = errors.New("order book snapshot not available")
 	ErrMarketHasNoTokens    = errors.New("market has no tradable tokens")
@@ -65,6 +89,8 @@ type MarketService struct {
 	GammaClient *gamma.Client
 	ClobClient  *clob.Client
 	streamHub   *PriceStreamHub
+	changeLog   *MarketChangeService
+	activity    *MarketActivityTracker
 }
 
 type StreamRequestPayload struct {
@@ -146,6 +172,8 @@ func NewMarketService(db *gorm.DB, redis *redis.Client, gammaClient *gamma.Clien
 		GammaClient: gammaClient,
 		ClobClient:  clobClient,
 		streamHub:   NewPriceStreamHub(redis, PriceUpdateChannel),
+		changeLog:   NewMarketChangeService(db),
+		activity:    NewMarketActivityTracker(redis),
 	}
 }
 
@@ -220,72 +248,41 @@ func (s *MarketService) SyncActiveMarkets(ctx context.Context) error {
 	return s.syncActiveMarketsCache(ctx)
 }
 
-func (s *MarketService) PersistActiveMarkets(ctx context.Context) error {
-	markets, err := s.loadAllActiveMarkets(ctx)
-	if err != nil {
-		return err
-	}
+// activeMarketFetch is the result of paging through Gamma's active events.
+type activeMarketFetch struct {
+	Markets  []models.Market
+	Pages    int
+	Events   int
+	Complete bool
+}
 
-	if len(markets) == 0 {
-		return nil
+// fetchActiveMarkets pages through every active, unclosed Gamma event and flattens them into markets.
+// On a mid-way failure the markets gathered so far are returned alongside the error with Complete=false.
+func (s *MarketService) fetchActiveMarkets(ctx context.Context, pageSize, maxPages int) (*activeMarketFetch, error) {
+	if pageSize <= 0 {
+		pageSize = gammaEventsPageSize
 	}
-
-	top := selectTopMarkets(markets, 800)
-
-	unlock, lockErr := s.acquireMarketSyncLock(ctx)
-	if lockErr != nil {
-		return fmt.Errorf("failed to acquire market sync lock: %w", lockErr)
-	}
-	defer unlock()
-
-	const maxRetries = 5
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		err = s.DB.Clauses(clause.OnConflict{
-			Columns: []clause.Column{{Name: "condition_id"}},
-			DoUpdates: clause.AssignmentColumns([]string{
-				"volume_24h",
-				"liquidity",
-				"active",
-				"closed",
-				"archived",
-				"title",
-				"description",
-				"resolution_rules",
-				"category",
-				"tags",
-				"token_id_yes",
-				"token_id_no",
-				"end_date",
-			}),
-		}).CreateInBatches(top, 50).Error
-		if err == nil {
-			return nil
-		}
-
-		if pgErr, ok := err.(*pgconn.PgError); ok && (pgErr.Code == "40P01" || pgErr.Code == "40001") {
-			backoff := time.Duration(attempt*100+rand.Intn(100)) * time.Millisecond
-			time.Sleep(backoff)
-			continue
-		}
-		break
+	if maxPages <= 0 {
+		maxPages = gammaEventsMaxPages
 	}
 
-	return fmt.Errorf("failed to persist markets to db: %w", err)
-}
-
-func (s *MarketService) syncActiveMarketsCache(ctx context.Context) error {
 	active := true
 	closed := false
 	desc := false
-	limit := 100
 	offset := 0
 
-	var allMarkets []models.Market
+	result := &activeMarketFetch{}
 	dedup := make(map[string]models.Market)
+	flatten := func() {
+		result.Markets = make([]models.Market, 0, len(dedup))
+		for _, market := range dedup {
+			result.Markets = append(result.Markets, market)
+		}
+	}
 
-	for {
+	for result.Pages < maxPages {
 		events, err := s.GammaClient.GetEvents(ctx, gamma.GetEventsParams{
-			Limit:     limit,
+			Limit:     pageSize,
 			Offset:    offset,
 			Active:    &active,
 			Closed:    &closed,
@@ -293,69 +290,98 @@ func (s *MarketService) syncActiveMarketsCache(ctx context.Context) error {
 			Ascending: &desc,
 		})
 		if err != nil {
-			return fmt.Errorf("failed to fetch events from gamma: %w", err)
-		}
-
-		if len(events) == 0 {
-			break
+			flatten()
+			return result, fmt.Errorf("failed to fetch events from gamma (offset %d): %w", offset, err)
 		}
+		result.Pages++
+		result.Events += len(events)
 
 		for _, event := range events {
-			for _, gm := range event.Markets {
-				market := gm.ToDBModel()
-
-				var tags []string
-				for _, t := range event.Tags {
-					tags = append(tags, t.Slug)
-				}
-				market.Tags = tags
-				market.Category = "general"
-				market.Archived = event.Archived
-
-				yes, no := gamma.ParseTokenIDs(gm.ClobTokenIds)
-				market.TokenIDYes = yes
-				market.TokenIDNo = no
-
-				if market.ConditionID == "" {
-					continue
-				}
-
+			for _, market := range marketsFromEvent(event) {
 				// Keep latest version if Gamma re-sends the same condition_id within a page
-				dedup[market.ConditionID] = *market
+				dedup[market.ConditionID] = market
 			}
 		}
 
-		if len(events) < limit {
+		if len(events) < pageSize {
+			result.Complete = true
 			break
 		}
-		offset += limit
+		offset += pageSize
 	}
 
-	if len(dedup) == 0 {
-		return nil
+	flatten()
+	return result, nil
+}
+
+// marketsFromEvent converts a Gamma event's markets into DB models, inheriting the event's tags and archive flag.
+func marketsFromEvent(event gamma.GammaEvent) []models.Market {
+	var tags []string
+	for _, t := range event.Tags {
+		tags = append(tags, t.Slug)
 	}
 
-	allMarkets = allMarkets[:0]
-	for _, market := range dedup {
-		allMarkets = append(allMarkets, market)
+	markets := make([]models.Market, 0, len(event.Markets))
+	for _, gm := range event.Markets {
+		market := gm.ToDBModel()
+		if market.ConditionID == "" {
+			continue
+		}
+
+		market.Tags = tags
+		market.Category = "general"
+		market.EventID = event.ID
+		market.Archived = event.Archived
+
+		yes, no := gamma.ParseTokenIDs(gm.ClobTokenIds)
+		market.TokenIDYes = yes
+		market.TokenIDNo = no
+
+		markets = append(markets, *market)
 	}
+	return markets
+}
 
-	data, err := json.Marshal(allMarkets)
+func (s *MarketService) syncActiveMarketsCache(ctx context.Context) error {
+	fetched, err := s.fetchActiveMarkets(ctx, gammaEventsPageSize, gammaEventsMaxPages)
 	if err != nil {
-		log.Printf("Failed to marshal markets for cache: %v", err)
-	} else {
-		if err := s.Redis.Set(ctx, CacheKeyActiveMarkets, data, CacheTTL).Err(); err != nil {
-			log.Printf("Failed to set active markets cache: %v", err)
-		}
-		s.cacheDerivedSnapshots(ctx, allMarkets)
+		return err
 	}
 
+	s.storeActiveSnapshot(ctx, fetched.Markets)
 	return nil
 }
 
+// storeActiveSnapshot replaces the cached Gamma snapshot and the lanes/meta/assets derived from it.
+func (s *MarketService) storeActiveSnapshot(ctx context.Context, markets []models.Market) {
+	if len(markets) == 0 {
+		return
+	}
+
+	data, err := json.Marshal(markets)
+	if err != nil {
+		log.Printf("Failed to marshal markets for cache: %v", err)
+		return
+	}
+	if err := s.Redis.Set(ctx, CacheKeyActiveMarkets, data, CacheTTL).Err(); err != nil {
+		log.Printf("Failed to set active markets cache: %v", err)
+	}
+	s.cacheDerivedSnapshots(ctx, markets)
+}
+
 const activeWhereClause = "active = ? AND closed = ? AND accepting_orders = ?"
 
 func (s *MarketService) loadActiveMarketsFromCache(ctx context.Context) ([]models.Market, error) {
+	markets, err := s.loadMarketSnapshotFromCache(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterActiveMarkets(markets, time.Now().UTC()), nil
+}
+
+// loadMarketSnapshotFromCache returns the raw Gamma snapshot, including markets that are no longer tradable.
+func (s *MarketService) loadMarketSnapshotFromCache(ctx context.Context) ([]models.Market, error) {
 	val, err := s.Redis.Get(ctx, CacheKeyActiveMarkets).Result()
 	if err != nil {
 		return nil, err
@@ -366,7 +392,17 @@ func (s *MarketService) loadActiveMarketsFromCache(ctx context.Context) ([]model
 		return nil, err
 	}
 
-	return filterActiveMarkets(markets, time.Now().UTC()), nil
+	return markets, nil
+}
+
+// GetMarketChanges returns the recorded change log for a market, newest first.
+func (s *MarketService) GetMarketChanges(ctx context.Context, conditionID string, limit, offset int) ([]models.MarketChange, error) {
+	conditionID = strings.TrimSpace(conditionID)
+	if conditionID == "" {
+		return nil, fmt.Errorf("condition_id is required")
+	}
+
+	return s.changeLog.GetChanges(ctx, conditionID, limit, offset)
 }
 
 func (s *MarketService) loadAllActiveMarkets(ctx context.Context) ([]models.Market, error) {
@@ -501,53 +537,32 @@ func (s *MarketService) SyncFreshDrops(ctx context.Context) error {
 
 	var dbMarkets []models.Market
 	for _, event := range events {
-		for _, gm := range event.Markets {
-			m := gm.ToDBModel()
-
-			// Extract tags from event
-			var tags []string
-			for _, t := range event.Tags {
-				tags = append(tags, t.Slug)
-			}
-			m.Tags = tags
-			m.Category = "general"
-
-			// Set archived status from event
-			m.Archived = event.Archived
-
-			// Parse Token IDs
-			yes, no := gamma.ParseTokenIDs(gm.ClobTokenIds)
-			m.TokenIDYes = yes
-			m.TokenIDNo = no
-
-			dbMarkets = append(dbMarkets, *m)
-		}
+		dbMarkets = append(dbMarkets, marketsFromEvent(event)...)
 	}
 
 	if len(dbMarkets) > 0 {
-		// Upsert to DB
-		err = s.DB.Clauses(clause.OnConflict{
-			Columns: []clause.Column{{Name: "condition_id"}},
-			DoUpdates: clause.AssignmentColumns([]string{
-				"volume_24h",
-				"liquidity",
-				"active",
-				"closed",
-				"archived",
-				"title",
-				"description",
-				"resolution_rules",
-				"category",
-				"tags",
-				"token_id_yes",
-				"token_id_no",
-				"end_date",
-			}),
-		}).CreateInBatches(dbMarkets, 100).Error
+		// Upsert to DB, recording rule / status edits in the same transaction
+		var changes []models.MarketChange
+		err = s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Transaction(func(sp *gorm.DB) error {
+				var err error
+				changes, err = s.changeLog.RecordChanges(ctx, sp, dbMarkets)
+				return err
+			}); err != nil {
+				changes = nil
+				log.Printf("Failed to record market changes: %v", err)
+			}
+
+			return tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "condition_id"}},
+				DoUpdates: clause.AssignmentColumns(marketUpsertColumns),
+			}).CreateInBatches(dbMarkets, 100).Error
+		})
 
 		if err != nil {
 			return fmt.Errorf("failed to upsert fresh drops to db: %w", err)
 		}
+		s.changeLog.NotifyChanges(ctx, dbMarkets, changes)
 
 		// Update Redis Cache
 		data, err := json.Marshal(dbMarkets)
@@ -660,62 +675,16 @@ func (s *MarketService) GetDepthEstimate(ctx context.Context, marketID, tokenID,
 		return nil, fmt.Errorf("size must be greater than zero")
 	}
 
-	key := fmt.Sprintf("book:%s:%s", marketID, tokenID)
-	raw, err := s.Redis.Get(ctx, key).Result()
-	if errors.Is(err, redis.Nil) {
-		// Ask RTDS worker to (re)subscribe in case the book never landed.
-		s.publishStreamRequest(ctx, []string{tokenID})
-		// Try a synchronous fetch from CLOB as a fallback.
-		if fetched, fetchErr := s.fetchAndCacheOrderBook(ctx, marketID, tokenID); fetchErr == nil {
-			raw = fetched
-		} else {
-			return nil, ErrOrderBookUnavailable
-		}
-	}
+	snapshot, err := s.loadOrderBookSnapshot(ctx, marketID, tokenID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read order book snapshot: %w", err)
-	}
-
-	var snapshot orderBookSnapshot
-	if err := json.Unmarshal([]byte(raw), &snapshot); err != nil {
-		return nil, fmt.Errorf("failed to decode order book snapshot: %w", err)
-	}
-
-	source := snapshot.Asks
-	if side == "SELL" {
-		source = snapshot.Bids
-	}
-	if len(source) == 0 {
-		return nil, ErrOrderBookUnavailable
-	}
-
-	levels := make([]depthOrderSummary, 0, len(source))
-	for _, lvl := range source {
-		price, err := strconv.ParseFloat(lvl.Price, 64)
-		if err != nil || price <= 0 {
-			continue
-		}
-		sizeFloat, err := strconv.ParseFloat(lvl.Size, 64)
-		if err != nil || sizeFloat <= 0 {
-			continue
-		}
-		levels = append(levels, depthOrderSummary{
-			Price: price,
-			Size:  sizeFloat,
-		})
+		return nil, err
 	}
 
+	levels := snapshot.sideLevels(side)
 	if len(levels) == 0 {
 		return nil, ErrOrderBookUnavailable
 	}
 
-	sort.Slice(levels, func(i, j int) bool {
-		if side == "BUY" {
-			return levels[i].Price < levels[j].Price
-		}
-		return levels[i].Price > levels[j].Price
-	})
-
 	remaining := size
 	var cumulativeSize float64
 	var cumulativeValue float64
@@ -766,6 +735,63 @@ func (s *MarketService) GetDepthEstimate(ctx context.Context, marketID, tokenID,
 	return estimate, nil
 }
 
+// loadOrderBookSnapshot reads the cached book for a token, falling back to a synchronous CLOB fetch.
+func (s *MarketService) loadOrderBookSnapshot(ctx context.Context, marketID, tokenID string) (*orderBookSnapshot, error) {
+	key := fmt.Sprintf("book:%s:%s", marketID, tokenID)
+	raw, err := s.Redis.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		// Ask RTDS worker to (re)subscribe in case the book never landed.
+		s.publishStreamRequest(ctx, []string{tokenID})
+		// Try a synchronous fetch from CLOB as a fallback.
+		fetched, fetchErr := s.fetchAndCacheOrderBook(ctx, marketID, tokenID)
+		if fetchErr != nil {
+			return nil, ErrOrderBookUnavailable
+		}
+		raw, err = fetched, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read order book snapshot: %w", err)
+	}
+
+	var snapshot orderBookSnapshot
+	if err := json.Unmarshal([]byte(raw), &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode order book snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// sideLevels returns the parsed levels a taker on side would hit, best price first.
+func (b *orderBookSnapshot) sideLevels(side string) []depthOrderSummary {
+	source := b.Asks
+	if side == "SELL" {
+		source = b.Bids
+	}
+
+	levels := make([]depthOrderSummary, 0, len(source))
+	for _, lvl := range source {
+		price, err := strconv.ParseFloat(lvl.Price, 64)
+		if err != nil || price <= 0 {
+			continue
+		}
+		sizeFloat, err := strconv.ParseFloat(lvl.Size, 64)
+		if err != nil || sizeFloat <= 0 {
+			continue
+		}
+		levels = append(levels, depthOrderSummary{
+			Price: price,
+			Size:  sizeFloat,
+		})
+	}
+
+	sort.Slice(levels, func(i, j int) bool {
+		if side == "BUY" {
+			return levels[i].Price < levels[j].Price
+		}
+		return levels[i].Price > levels[j].Price
+	})
+	return levels
+}
+
 // fetchAndCacheOrderBook pulls a book snapshot from the CLOB API when RTDS hasn't provided one yet.
 func (s *MarketService) fetchAndCacheOrderBook(ctx context.Context, marketID, tokenID string) (string, error) {
 	if s.ClobClient == nil {
@@ -811,6 +837,16 @@ func (s *MarketService) fetchAndCacheOrderBook(ctx context.Context, marketID, to
 }
 
 func (s *MarketService) attachRealtimePrices(ctx context.Context, markets []models.Market) {
+	s.attachPrices(ctx, markets, true)
+}
+
+// attachCachedPrices attaches only the Redis price snapshot, skipping the per-token CLOB fallback.
+// Used when scanning the whole active set, where a fallback call per cold token would be prohibitive.
+func (s *MarketService) attachCachedPrices(ctx context.Context, markets []models.Market) {
+	s.attachPrices(ctx, markets, false)
+}
+
+func (s *MarketService) attachPrices(ctx context.Context, markets []models.Market, allowFallback bool) {
 	if len(markets) == 0 {
 		return
 	}
@@ -884,7 +920,7 @@ func (s *MarketService) attachRealtimePrices(ctx context.Context, markets []mode
 		}
 	}
 
-	if len(fallbackTargets) == 0 || s.ClobClient == nil {
+	if !allowFallback || len(fallbackTargets) == 0 || s.ClobClient == nil {
 		return
 	}
 
@@ -1118,11 +1154,16 @@ func (s *MarketService) buildMarketLanesFromSnapshot(ctx context.Context, market
 		return marketCreatedAt(a).After(marketCreatedAt(b))
 	})
 
-	velocity := topMarkets(filtered, 20, func(a, b models.Market) bool {
-		if a.VolumeAllTime == b.VolumeAllTime {
+	// Rank the most traded markets by decayed live activity rather than lifetime volume.
+	velocityPool := topMarkets(filtered, velocityLanePool, func(a, b models.Market) bool {
+		return a.Volume24h > b.Volume24h
+	})
+	scores := s.fetchVelocityScores(ctx, velocityPool)
+	velocity := topMarkets(velocityPool, 20, func(a, b models.Market) bool {
+		if scores[a.ConditionID] == scores[b.ConditionID] {
 			return a.Volume24h > b.Volume24h
 		}
-		return a.VolumeAllTime > b.VolumeAllTime
+		return scores[a.ConditionID] > scores[b.ConditionID]
 	})
 
 	liquidity := topMarkets(filtered, 20, func(a, b models.Market) bool {
@@ -1444,24 +1485,6 @@ func trimMarketAssets(assets []MarketAsset, maxCount int) []MarketAsset {
 	return assets[:maxCount]
 }
 
-func selectTopMarkets(markets []models.Market, max int) []models.Market {
-	if max <= 0 || len(markets) <= max {
-		return markets
-	}
-
-	sorted := make([]models.Market, len(markets))
-	copy(sorted, markets)
-
-	sort.SliceStable(sorted, func(i, j int) bool {
-		if sorted[i].Liquidity == sorted[j].Liquidity {
-			return sorted[i].Volume24h > sorted[j].Volume24h
-		}
-		return sorted[i].Liquidity > sorted[j].Liquidity
-	})
-
-	return sorted[:max]
-}
-
 func (s *MarketService) SubscribeStreamRequests(ctx context.Context) *redis.PubSub {
 	return s.Redis.Subscribe(ctx, streamRequestPubSubChan)
 }
@@ -1593,29 +1616,20 @@ func (s *MarketService) QueryActiveMarkets(ctx context.Context, params QueryActi
 	return page, nil
 }
 
+// fetchVelocityScores returns the decayed activity score for each market (see MarketActivityTracker).
 func (s *MarketService) fetchVelocityScores(ctx context.Context, markets []models.Market) map[string]float64 {
-	result := make(map[string]float64, len(markets))
-	if len(markets) == 0 {
-		return result
-	}
-
-	pipe := s.Redis.Pipeline()
-	cmds := make([]*redis.FloatCmd, len(markets))
+	ids := make([]string, len(markets))
 	for i, market := range markets {
-		cmds[i] = pipe.ZScore(ctx, "market:velocity", market.ConditionID)
-	}
-
-	if _, err := pipe.Exec(ctx); err != nil {
-		return result
+		ids[i] = market.ConditionID
 	}
+	return s.activity.Scores(ctx, ids)
+}
 
-	for i, market := range markets {
-		if score, err := cmds[i].Result(); err == nil {
-			result[market.ConditionID] = score
-		} else {
-			result[market.ConditionID] = 0
-		}
+// GetMarketActivity returns rolling and decayed activity stats for a market.
+func (s *MarketService) GetMarketActivity(ctx context.Context, conditionID string) (*MarketActivityStats, error) {
+	conditionID = strings.TrimSpace(conditionID)
+	if conditionID == "" {
+		return nil, fmt.Errorf("condition_id is required")
 	}
-
-	return result
+	return s.activity.GetStats(ctx, conditionID)
 }
@@ -0,0 +1,397 @@
+/**
+ * @description
+ * Incremental Market Sync Engine.
+ * Pages through every active Gamma event, upserts only the markets whose market_updated_at moved,
+ * archives stored markets that dropped out of Gamma's active set and records per-run stats.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - backend/internal/models
+ * - backend/internal/services (MarketService, MarketChangeService)
+ *
+ * @notes
+ * - Full mode ignores market_updated_at and rewrites every fetched market (volume/liquidity refresh).
+ * - Archiving only runs after a complete, error-free pass and refuses to archive more than half the open set,
+ *   so a truncated Gamma response cannot wipe the catalogue.
+ * - Dry runs fetch and classify but write nothing, including the run row.
+ * - Change log rows commit with the upsert / archive they describe; bookmark alerts go out only after commit.
+ */
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/jackc/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	MarketSyncModeIncremental = "incremental"
+	MarketSyncModeFull        = "full"
+
+	MarketSyncTriggerWorker = "worker"
+	MarketSyncTriggerCLI    = "cli"
+
+	marketSyncUpsertBatch = 50
+	marketSyncLookupBatch = 500
+	marketSyncMaxErrors   = 20
+	marketArchiveMaxRatio = 0.5
+	marketSyncUpsertTries = 5
+)
+
+// MarketSyncOptions controls a single sync pass
+type MarketSyncOptions struct {
+	Trigger     string
+	Full        bool
+	PageSize    int
+	MaxPages    int
+	DryRun      bool
+	SkipArchive bool
+}
+
+// MarketSyncEngine runs incremental Gamma → Postgres syncs
+type MarketSyncEngine struct {
+	db        *gorm.DB
+	markets   *MarketService
+	changeLog *MarketChangeService
+}
+
+// NewMarketSyncEngine creates a new MarketSyncEngine
+func NewMarketSyncEngine(db *gorm.DB, markets *MarketService) *MarketSyncEngine {
+	return &MarketSyncEngine{
+		db:        db,
+		markets:   markets,
+		changeLog: markets.changeLog,
+	}
+}
+
+// storedSyncState is the slice of a stored market needed to classify deltas.
+type storedSyncState struct {
+	ConditionID     string
+	MarketUpdatedAt *time.Time
+	Archived        bool
+	Closed          bool
+}
+
+// Run executes one sync pass and returns its recorded stats. The run is returned even when err != nil.
+func (e *MarketSyncEngine) Run(ctx context.Context, opts MarketSyncOptions) (*models.MarketSyncRun, error) {
+	if opts.Trigger == "" {
+		opts.Trigger = MarketSyncTriggerWorker
+	}
+	mode := MarketSyncModeIncremental
+	if opts.Full {
+		mode = MarketSyncModeFull
+	}
+
+	run := &models.MarketSyncRun{
+		Trigger:   opts.Trigger,
+		Mode:      mode,
+		Status:    models.MarketSyncStatusRunning,
+		DryRun:    opts.DryRun,
+		Errors:    models.StringArray{},
+		StartedAt: time.Now().UTC(),
+	}
+	if !opts.DryRun {
+		if err := e.db.WithContext(ctx).Create(run).Error; err != nil {
+			logger.Error("MarketSyncEngine: Failed to record sync run start: %v", err)
+		}
+	}
+
+	err := e.sync(ctx, opts, run)
+	e.finish(ctx, run, err, opts.DryRun)
+
+	logger.Info("MarketSyncEngine: %s %s run %s in %dms (pages=%d markets=%d created=%d updated=%d unchanged=%d archived=%d errors=%d)",
+		run.Trigger, run.Mode, run.Status, run.DurationMs, run.Pages, run.MarketsSeen,
+		run.MarketsCreated, run.MarketsUpdated, run.MarketsUnchanged, run.MarketsArchived, run.ErrorCount)
+
+	return run, err
+}
+
+func (e *MarketSyncEngine) sync(ctx context.Context, opts MarketSyncOptions, run *models.MarketSyncRun) error {
+	fetched, fetchErr := e.markets.fetchActiveMarkets(ctx, opts.PageSize, opts.MaxPages)
+	if fetched != nil {
+		run.Pages = fetched.Pages
+		run.EventsSeen = fetched.Events
+		run.MarketsSeen = len(fetched.Markets)
+	}
+	if fetchErr != nil {
+		if fetched == nil || len(fetched.Markets) == 0 {
+			return fetchErr
+		}
+		addSyncError(run, fetchErr)
+	} else if !fetched.Complete {
+		addSyncError(run, fmt.Errorf("page limit reached after %d pages; archiving skipped", fetched.Pages))
+	}
+	complete := fetchErr == nil && fetched.Complete
+
+	if complete && !opts.DryRun {
+		e.markets.storeActiveSnapshot(ctx, fetched.Markets)
+	}
+
+	stored, err := e.loadStoredState(ctx)
+	if err != nil {
+		return err
+	}
+
+	plan := planMarketSync(fetched.Markets, stored, opts.Full)
+	run.MarketsCreated = plan.Created
+	run.MarketsUpdated = plan.Updated
+	run.MarketsUnchanged = plan.Unchanged
+	deltas, missing := plan.Deltas, plan.Missing
+
+	archive := complete && !opts.SkipArchive && len(missing) > 0
+	if archive && plan.archiveTooLarge() {
+		addSyncError(run, fmt.Errorf("refusing to archive %d of %d open markets", len(missing), plan.OpenStored))
+		archive = false
+	}
+
+	if opts.DryRun {
+		if archive {
+			run.MarketsArchived = len(missing)
+		}
+		return nil
+	}
+
+	if len(deltas) == 0 && !archive {
+		return nil
+	}
+
+	unlock, err := e.markets.acquireMarketSyncLock(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire market sync lock: %w", err)
+	}
+	defer unlock()
+
+	if len(deltas) > 0 {
+		changes, err := e.upsertMarkets(ctx, deltas, run)
+		if err != nil {
+			return err
+		}
+		e.changeLog.NotifyChanges(ctx, deltas, changes)
+	}
+
+	if archive {
+		archived, err := e.archiveMarkets(ctx, missing)
+		run.MarketsArchived = archived
+		if err != nil {
+			addSyncError(run, err)
+		}
+	}
+
+	return nil
+}
+
+// marketSyncPlan classifies one fetched pass against the stored catalogue.
+type marketSyncPlan struct {
+	Deltas     []models.Market // created or updated markets to upsert
+	Missing    []string        // open stored markets Gamma no longer lists
+	OpenStored int             // stored markets neither archived nor closed
+	Created    int
+	Updated    int
+	Unchanged  int
+}
+
+// planMarketSync picks the markets to upsert (new, changed, or all in full mode) and the open stored markets
+// missing from the fetched set.
+func planMarketSync(fetched []models.Market, stored map[string]storedSyncState, full bool) marketSyncPlan {
+	var plan marketSyncPlan
+	seen := make(map[string]bool, len(fetched))
+	for _, market := range fetched {
+		seen[market.ConditionID] = true
+
+		state, ok := stored[market.ConditionID]
+		switch {
+		case !ok:
+			plan.Created++
+			plan.Deltas = append(plan.Deltas, market)
+		case full || marketChangedSince(market, state):
+			plan.Updated++
+			plan.Deltas = append(plan.Deltas, market)
+		default:
+			plan.Unchanged++
+		}
+	}
+
+	for id, state := range stored {
+		if state.Archived || state.Closed {
+			continue
+		}
+		plan.OpenStored++
+		if !seen[id] {
+			plan.Missing = append(plan.Missing, id)
+		}
+	}
+	sort.Strings(plan.Missing)
+	return plan
+}
+
+// archiveTooLarge reports whether archiving the missing markets would exceed marketArchiveMaxRatio of the open set.
+func (p marketSyncPlan) archiveTooLarge() bool {
+	return float64(len(p.Missing)) > float64(p.OpenStored)*marketArchiveMaxRatio
+}
+
+// marketChangedSince reports whether Gamma's copy is newer than the stored row.
+// Markets without an updatedAt on either side are always treated as changed.
+func marketChangedSince(market models.Market, stored storedSyncState) bool {
+	if market.Archived != stored.Archived || market.Closed != stored.Closed {
+		return true
+	}
+	if market.MarketUpdatedAt == nil || stored.MarketUpdatedAt == nil {
+		return true
+	}
+	return market.MarketUpdatedAt.After(*stored.MarketUpdatedAt)
+}
+
+func (e *MarketSyncEngine) loadStoredState(ctx context.Context) (map[string]storedSyncState, error) {
+	var rows []storedSyncState
+	if err := e.db.WithContext(ctx).
+		Model(&models.Market{}).
+		Select("condition_id, market_updated_at, archived, closed").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load stored market state: %w", err)
+	}
+
+	stored := make(map[string]storedSyncState, len(rows))
+	for _, row := range rows {
+		stored[row.ConditionID] = row
+	}
+	return stored, nil
+}
+
+// upsertMarkets writes markets and their change log in one transaction and returns the recorded changes.
+// A change log failure is rolled back to its savepoint and reported on run without blocking the upsert.
+func (e *MarketSyncEngine) upsertMarkets(ctx context.Context, markets []models.Market, run *models.MarketSyncRun) ([]models.MarketChange, error) {
+	var err error
+	for attempt := 1; attempt <= marketSyncUpsertTries; attempt++ {
+		var changes []models.MarketChange
+		var changeErr error
+		err = e.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			changeErr = tx.Transaction(func(sp *gorm.DB) error {
+				var err error
+				changes, err = e.changeLog.RecordChanges(ctx, sp, markets)
+				return err
+			})
+			if changeErr != nil {
+				changes = nil
+			}
+			return tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "condition_id"}},
+				DoUpdates: clause.AssignmentColumns(marketUpsertColumns),
+			}).CreateInBatches(markets, marketSyncUpsertBatch).Error
+		})
+		if err == nil {
+			if changeErr != nil {
+				addSyncError(run, changeErr)
+			}
+			return changes, nil
+		}
+
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && (pgErr.Code == "40P01" || pgErr.Code == "40001") {
+			backoff := time.Duration(attempt*100+rand.Intn(100)) * time.Millisecond
+			time.Sleep(backoff)
+			continue
+		}
+		break
+	}
+
+	return nil, fmt.Errorf("failed to upsert markets: %w", err)
+}
+
+// archiveMarkets flags stored markets that left Gamma's active set, logging the flip in the change log.
+func (e *MarketSyncEngine) archiveMarkets(ctx context.Context, conditionIDs []string) (int, error) {
+	archived := 0
+	for start := 0; start < len(conditionIDs); start += marketSyncLookupBatch {
+		end := start + marketSyncLookupBatch
+		if end > len(conditionIDs) {
+			end = len(conditionIDs)
+		}
+		batch := conditionIDs[start:end]
+
+		var rows []models.Market
+		if err := e.db.WithContext(ctx).Where("condition_id IN ?", batch).Find(&rows).Error; err != nil {
+			return archived, fmt.Errorf("failed to load markets to archive: %w", err)
+		}
+		for i := range rows {
+			rows[i].Archived = true
+		}
+
+		var changes []models.MarketChange
+		var affected int64
+		err := e.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Transaction(func(sp *gorm.DB) error {
+				var err error
+				changes, err = e.changeLog.RecordChanges(ctx, sp, rows)
+				return err
+			}); err != nil {
+				changes = nil
+				logger.Error("MarketSyncEngine: Failed to record archive changes: %v", err)
+			}
+
+			result := tx.Model(&models.Market{}).
+				Where("condition_id IN ?", batch).
+				Update("archived", true)
+			affected = result.RowsAffected
+			return result.Error
+		})
+		if err != nil {
+			return archived, fmt.Errorf("failed to archive markets: %w", err)
+		}
+		archived += int(affected)
+		e.changeLog.NotifyChanges(ctx, rows, changes)
+	}
+	return archived, nil
+}
+
+func (e *MarketSyncEngine) finish(ctx context.Context, run *models.MarketSyncRun, err error, dryRun bool) {
+	finished := time.Now().UTC()
+	run.FinishedAt = &finished
+	run.DurationMs = finished.Sub(run.StartedAt).Milliseconds()
+
+	switch {
+	case err != nil:
+		addSyncError(run, err)
+		run.Status = models.MarketSyncStatusFailed
+	case run.ErrorCount > 0:
+		run.Status = models.MarketSyncStatusPartial
+	default:
+		run.Status = models.MarketSyncStatusSucceeded
+	}
+
+	if dryRun {
+		return
+	}
+	// Use a fresh context so a cancelled sync still records why it stopped.
+	if saveErr := e.db.WithContext(context.Background()).Save(run).Error; saveErr != nil {
+		logger.Error("MarketSyncEngine: Failed to record sync run: %v", saveErr)
+	}
+}
+
+// GetRecentRuns returns the latest sync runs, newest first.
+func (e *MarketSyncEngine) GetRecentRuns(ctx context.Context, limit int) ([]models.MarketSyncRun, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	var runs []models.MarketSyncRun
+	if err := e.db.WithContext(ctx).Order("started_at DESC").Limit(limit).Find(&runs).Error; err != nil {
+		return nil, fmt.Errorf("failed to load sync runs: %w", err)
+	}
+	return runs, nil
+}
+
+func addSyncError(run *models.MarketSyncRun, err error) {
+	run.ErrorCount++
+	if len(run.Errors) < marketSyncMaxErrors {
+		run.Errors = append(run.Errors, err.Error())
+	}
+}
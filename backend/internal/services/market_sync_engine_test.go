@@ -0,0 +1,92 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bankai-project/backend/internal/models"
+)
+
+func TestMarketChangedSince(t *testing.T) {
+	t0 := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Minute)
+
+	cases := []struct {
+		name   string
+		market models.Market
+		stored storedSyncState
+		want   bool
+	}{
+		{"same timestamp", models.Market{MarketUpdatedAt: &t0}, storedSyncState{MarketUpdatedAt: &t0}, false},
+		{"older timestamp", models.Market{MarketUpdatedAt: &t0}, storedSyncState{MarketUpdatedAt: &t1}, false},
+		{"newer timestamp", models.Market{MarketUpdatedAt: &t1}, storedSyncState{MarketUpdatedAt: &t0}, true},
+		{"no incoming timestamp", models.Market{}, storedSyncState{MarketUpdatedAt: &t0}, true},
+		{"no stored timestamp", models.Market{MarketUpdatedAt: &t0}, storedSyncState{}, true},
+		{"closed flipped", models.Market{MarketUpdatedAt: &t0, Closed: true}, storedSyncState{MarketUpdatedAt: &t0}, true},
+		{"unarchived", models.Market{MarketUpdatedAt: &t0}, storedSyncState{MarketUpdatedAt: &t0, Archived: true}, true},
+	}
+	for _, tc := range cases {
+		if got := marketChangedSince(tc.market, tc.stored); got != tc.want {
+			t.Errorf("%s: changed = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestPlanMarketSync(t *testing.T) {
+	t0 := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Minute)
+
+	stored := map[string]storedSyncState{
+		"same":     {ConditionID: "same", MarketUpdatedAt: &t0},
+		"changed":  {ConditionID: "changed", MarketUpdatedAt: &t0},
+		"gone":     {ConditionID: "gone", MarketUpdatedAt: &t0},
+		"gone2":    {ConditionID: "gone2", MarketUpdatedAt: &t0},
+		"closed":   {ConditionID: "closed", MarketUpdatedAt: &t0, Closed: true},
+		"archived": {ConditionID: "archived", MarketUpdatedAt: &t0, Archived: true},
+	}
+	fetched := []models.Market{
+		{ConditionID: "same", MarketUpdatedAt: &t0},
+		{ConditionID: "changed", MarketUpdatedAt: &t1},
+		{ConditionID: "new", MarketUpdatedAt: &t1},
+	}
+	ids := func(markets []models.Market) []string {
+		var out []string
+		for _, m := range markets {
+			out = append(out, m.ConditionID)
+		}
+		return out
+	}
+
+	plan := planMarketSync(fetched, stored, false)
+	if plan.Created != 1 || plan.Updated != 1 || plan.Unchanged != 1 {
+		t.Errorf("incremental counts = %d/%d/%d, want 1/1/1", plan.Created, plan.Updated, plan.Unchanged)
+	}
+	if got := ids(plan.Deltas); !reflect.DeepEqual(got, []string{"changed", "new"}) {
+		t.Errorf("incremental deltas = %v", got)
+	}
+	// Closed and archived rows are not open, so they are neither counted nor archived again.
+	if plan.OpenStored != 4 || !reflect.DeepEqual(plan.Missing, []string{"gone", "gone2"}) {
+		t.Errorf("open = %d, missing = %v; want 4, [gone gone2]", plan.OpenStored, plan.Missing)
+	}
+	if plan.archiveTooLarge() {
+		t.Error("archiving half the open set should be allowed")
+	}
+
+	full := planMarketSync(fetched, stored, true)
+	if full.Created != 1 || full.Updated != 2 || full.Unchanged != 0 {
+		t.Errorf("full counts = %d/%d/%d, want 1/2/0", full.Created, full.Updated, full.Unchanged)
+	}
+	if got := ids(full.Deltas); !reflect.DeepEqual(got, []string{"same", "changed", "new"}) {
+		t.Errorf("full deltas = %v", got)
+	}
+
+	truncated := planMarketSync(fetched[:1], stored, false)
+	if !reflect.DeepEqual(truncated.Missing, []string{"changed", "gone", "gone2"}) || !truncated.archiveTooLarge() {
+		t.Errorf("truncated pass: missing = %v, too large = %v; want 3 missing refused", truncated.Missing, truncated.archiveTooLarge())
+	}
+
+	if empty := planMarketSync(nil, nil, false); len(empty.Deltas) != 0 || len(empty.Missing) != 0 || empty.archiveTooLarge() {
+		t.Errorf("empty pass = %+v", empty)
+	}
+}
@@ -0,0 +1,931 @@
+/**
+ * @description
+ * Order Group Service.
+ * Links orders into one-cancels-other (OCO) and bracket groups. A fill or trigger on one leg cancels its
+ * siblings: CLOB legs through TradeService.CancelOrdersWithCredentials, server-held legs by disarming them.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - backend/internal/models
+ * - backend/internal/polymarket/clob
+ * - backend/internal/services (TradeService, ConditionalOrderService)
+ *
+ * @notes
+ * - Legs are either resting CLOB orders (placed by the client and synced) or server-held conditional orders.
+ * - Bracket exits are conditional orders held PENDING until the entry fills, then armed.
+ * - The group is reconciled whenever a leg changes: order sync, cancellation, conditional completion,
+ *   and a periodic sweep in the worker for anything missed.
+ * - A SUBMITTED conditional leg follows the order it posted, so a stop whose FOK / FAK order matched nothing
+ *   releases its claim instead of completing the group. A CLOB sibling can still fill in the seconds before it
+ *   is cancelled, which the CLOB offers no way to prevent.
+ * - Any match counts as a fill: an OCO leg that matches in part cancels its siblings at once.
+ * - Exits are pre-signed for a fixed size and cannot be resized. When the entry ends partly filled, only exits
+ *   that fit within the matched size are armed; larger exits are canceled so the user can place new ones.
+ */
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/polymarket/clob"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	minOCOLegs = 2
+	maxOCOLegs = 4
+
+	// legSizeEpsilon absorbs rounding between share amounts in base units and decimals.
+	legSizeEpsilon = 1e-6
+)
+
+var (
+	ErrOrderGroupNotFound = errors.New("order group not found")
+	ErrOrderGroupClosed   = errors.New("order group is no longer open")
+	ErrInvalidOrderGroup  = errors.New("invalid order group")
+
+	openOrderGroupStatuses = []models.OrderGroupStatus{models.OrderGroupPendingEntry, models.OrderGroupActive}
+)
+
+// OrderGroupService manages OCO and bracket order groups
+type OrderGroupService struct {
+	db          *gorm.DB
+	trades      *TradeService
+	conditional *ConditionalOrderService
+}
+
+// NewOrderGroupService creates a new OrderGroupService. Groups share the conditional order encryption key.
+func NewOrderGroupService(db *gorm.DB, trades *TradeService, conditional *ConditionalOrderService) *OrderGroupService {
+	return &OrderGroupService{
+		db:          db,
+		trades:      trades,
+		conditional: conditional,
+	}
+}
+
+// OrderGroupLegInput describes one leg: either an existing CLOB order or a conditional order to hold
+type OrderGroupLegInput struct {
+	Role        models.OrderGroupLegRole `json:"role"`
+	OrderID     string                   `json:"orderId,omitempty"`
+	Conditional *ConditionalOrderInput   `json:"conditional,omitempty"`
+}
+
+// OrderGroupInput is the request to create an order group
+type OrderGroupInput struct {
+	Kind        models.OrderGroupKind  `json:"kind"`
+	Legs        []OrderGroupLegInput   `json:"legs"`
+	Credentials clob.APIKeyCredentials `json:"credentials"`
+}
+
+// OrderGroupAlertData is the payload stored on ORDER_GROUP notifications
+type OrderGroupAlertData struct {
+	OrderGroupID string `json:"order_group_id"`
+	Kind         string `json:"kind"`
+	Status       string `json:"status"`
+	MarketID     string `json:"market_id"`
+	FiredLegRole string `json:"fired_leg_role,omitempty"`
+	Reason       string `json:"reason,omitempty"`
+}
+
+// groupTransition is the set of changes reconciliation decided to apply to a group.
+type groupTransition struct {
+	status            models.OrderGroupStatus
+	reason            string
+	firedLegID        *uuid.UUID
+	releaseClaim      bool
+	armConditional    []uuid.UUID
+	cancelConditional []uuid.UUID
+	cancelCLOB        []string
+}
+
+func (t *groupTransition) empty() bool {
+	return t.status == "" && t.firedLegID == nil && !t.releaseClaim &&
+		len(t.armConditional) == 0 && len(t.cancelConditional) == 0 && len(t.cancelCLOB) == 0
+}
+
+// Create validates the legs, stores the group and holds any conditional legs.
+func (s *OrderGroupService) Create(ctx context.Context, user *models.User, input OrderGroupInput) (*models.OrderGroup, error) {
+	if !s.conditional.Enabled() {
+		return nil, ErrConditionalOrdersDisabled
+	}
+	if user == nil {
+		return nil, errors.New("user context is required")
+	}
+	invalid := func(format string, args ...interface{}) error {
+		return fmt.Errorf("%w: %s", ErrInvalidOrderGroup, fmt.Sprintf(format, args...))
+	}
+
+	kind := models.OrderGroupKind(strings.ToUpper(strings.TrimSpace(string(input.Kind))))
+	if kind != models.OrderGroupOCO && kind != models.OrderGroupBracket {
+		return nil, invalid("kind must be OCO or BRACKET")
+	}
+
+	creds := input.Credentials
+	if strings.TrimSpace(creds.Key) == "" || strings.TrimSpace(creds.Secret) == "" || strings.TrimSpace(creds.Passphrase) == "" {
+		return nil, invalid("credentials key, secret and passphrase are required")
+	}
+
+	if err := validateGroupRoles(kind, input.Legs); err != nil {
+		return nil, invalid("%v", err)
+	}
+
+	group := &models.OrderGroup{
+		ID:     uuid.New(),
+		UserID: user.ID,
+		Kind:   kind,
+		Status: models.OrderGroupActive,
+	}
+	if kind == models.OrderGroupBracket {
+		group.Status = models.OrderGroupPendingEntry
+	}
+
+	var (
+		legs       []models.OrderGroupLeg
+		held       []*models.ConditionalOrder
+		entryToken string
+		exitTokens []string
+		seen       = make(map[string]bool)
+	)
+	for i, legInput := range input.Legs {
+		role := models.OrderGroupLegRole(strings.ToUpper(strings.TrimSpace(string(legInput.Role))))
+		leg := models.OrderGroupLeg{ID: uuid.New(), GroupID: group.ID, Role: role}
+
+		var marketID, tokenID string
+		switch {
+		case legInput.OrderID != "" && legInput.Conditional == nil:
+			if kind == models.OrderGroupBracket && role != models.OrderGroupLegEntry {
+				return nil, invalid("leg %d: bracket exits must be conditional orders", i+1)
+			}
+			orderID := strings.TrimSpace(legInput.OrderID)
+			if seen[orderID] {
+				return nil, invalid("leg %d: order %s is used twice", i+1, orderID)
+			}
+			seen[orderID] = true
+
+			order, err := s.loadWorkingOrder(ctx, user.ID, orderID)
+			if err != nil {
+				return nil, invalid("leg %d: %v", i+1, err)
+			}
+			marketID, tokenID = order.MarketID, order.OutcomeTokenID
+			leg.CLOBOrderID = orderID
+			leg.Status = models.OrderGroupLegWorking
+			leg.Size = order.Size
+
+		case legInput.Conditional != nil && legInput.OrderID == "":
+			if role == models.OrderGroupLegEntry {
+				return nil, invalid("leg %d: the entry must be a CLOB order", i+1)
+			}
+			condInput := *legInput.Conditional
+			if condInput.Kind == "" {
+				condInput.Kind = models.ConditionalOrderKind(role)
+			}
+			if strings.ToUpper(strings.TrimSpace(string(condInput.Kind))) != string(role) {
+				return nil, invalid("leg %d: conditional kind must match the leg role", i+1)
+			}
+			if condInput.Credentials.Key == "" {
+				condInput.Credentials = creds
+			}
+
+			cond, err := s.conditional.prepare(ctx, user, condInput)
+			if err != nil {
+				return nil, err
+			}
+			cond.GroupID = &group.ID
+			leg.Status = models.OrderGroupLegWorking
+			if kind == models.OrderGroupBracket {
+				cond.Status = models.ConditionalOrderPending
+				leg.Status = models.OrderGroupLegWaiting
+			}
+			marketID, tokenID = cond.MarketID, cond.TokenID
+			leg.ConditionalOrderID = &cond.ID
+			leg.Size = cond.Size
+			held = append(held, cond)
+
+		default:
+			return nil, invalid("leg %d: set exactly one of orderId or conditional", i+1)
+		}
+
+		if group.MarketID == "" {
+			group.MarketID = marketID
+		} else if marketID != group.MarketID {
+			return nil, invalid("all legs must be in the same market")
+		}
+		if role == models.OrderGroupLegEntry {
+			entryToken = tokenID
+		} else {
+			exitTokens = append(exitTokens, tokenID)
+		}
+		legs = append(legs, leg)
+	}
+
+	if kind == models.OrderGroupBracket {
+		for _, token := range exitTokens {
+			if token != entryToken {
+				return nil, invalid("bracket exits must sell the outcome the entry buys")
+			}
+		}
+	}
+
+	if len(held) > 0 {
+		var open int64
+		if err := s.db.WithContext(ctx).Model(&models.ConditionalOrder{}).
+			Where("user_id = ? AND status IN ?", user.ID, openConditionalStatuses).
+			Count(&open).Error; err != nil {
+			return nil, fmt.Errorf("failed to count conditional orders: %w", err)
+		}
+		if open+int64(len(held)) > maxArmedConditionalOrders {
+			return nil, ErrConditionalOrderLimit
+		}
+	}
+
+	plaintext, err := json.Marshal(creds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode credentials: %w", err)
+	}
+	sealed, err := s.conditional.box.Seal(plaintext, []byte(group.ID.String()))
+	if err != nil {
+		return nil, err
+	}
+	group.EncryptedCredentials = sealed
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(group).Error; err != nil {
+			return err
+		}
+		for _, cond := range held {
+			if err := tx.Create(cond).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(&legs).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store order group: %w", err)
+	}
+
+	if len(held) > 0 {
+		s.conditional.publishChange(ctx)
+	}
+	group.Legs = legs
+	return group, nil
+}
+
+// validateGroupRoles checks the leg count and roles allowed for each group kind.
+func validateGroupRoles(kind models.OrderGroupKind, legs []OrderGroupLegInput) error {
+	counts := make(map[models.OrderGroupLegRole]int)
+	for _, leg := range legs {
+		role := models.OrderGroupLegRole(strings.ToUpper(strings.TrimSpace(string(leg.Role))))
+		switch role {
+		case models.OrderGroupLegEntry, models.OrderGroupLegTakeProfit, models.OrderGroupLegStopLoss:
+			counts[role]++
+		default:
+			return fmt.Errorf("role must be ENTRY, TAKE_PROFIT or STOP_LOSS")
+		}
+	}
+
+	if kind == models.OrderGroupOCO {
+		if len(legs) < minOCOLegs || len(legs) > maxOCOLegs {
+			return fmt.Errorf("OCO groups need %d to %d legs", minOCOLegs, maxOCOLegs)
+		}
+		if counts[models.OrderGroupLegEntry] > 0 {
+			return fmt.Errorf("OCO groups have no entry leg")
+		}
+		return nil
+	}
+
+	if counts[models.OrderGroupLegEntry] != 1 {
+		return fmt.Errorf("bracket groups need exactly one ENTRY leg")
+	}
+	if counts[models.OrderGroupLegTakeProfit] > 1 || counts[models.OrderGroupLegStopLoss] > 1 {
+		return fmt.Errorf("bracket groups allow one TAKE_PROFIT and one STOP_LOSS leg")
+	}
+	if counts[models.OrderGroupLegTakeProfit]+counts[models.OrderGroupLegStopLoss] == 0 {
+		return fmt.Errorf("bracket groups need at least one exit leg")
+	}
+	return nil
+}
+
+// loadWorkingOrder returns the user's order if it is still working and not already in an open group.
+func (s *OrderGroupService) loadWorkingOrder(ctx context.Context, userID uuid.UUID, clobOrderID string) (*models.Order, error) {
+	var order models.Order
+	if err := s.db.WithContext(ctx).
+		Where("user_id = ? AND clob_order_id = ?", userID, clobOrderID).
+		First(&order).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("order %s not found; sync orders first", clobOrderID)
+		}
+		return nil, err
+	}
+	if order.Status != models.OrderStatusOpen && order.Status != models.OrderStatusPending {
+		return nil, fmt.Errorf("order %s is %s", clobOrderID, strings.ToLower(string(order.Status)))
+	}
+
+	var grouped int64
+	if err := s.db.WithContext(ctx).Model(&models.OrderGroupLeg{}).
+		Joins("JOIN order_groups ON order_groups.id = order_group_legs.group_id").
+		Where("order_group_legs.clob_order_id = ? AND order_groups.status IN ?", clobOrderID, openOrderGroupStatuses).
+		Count(&grouped).Error; err != nil {
+		return nil, err
+	}
+	if grouped > 0 {
+		return nil, fmt.Errorf("order %s already belongs to an open group", clobOrderID)
+	}
+	return &order, nil
+}
+
+// List returns the user's order groups with their legs, newest first.
+func (s *OrderGroupService) List(ctx context.Context, userID uuid.UUID, status string, limit, offset int) ([]models.OrderGroup, int64, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	query := s.db.WithContext(ctx).Model(&models.OrderGroup{}).Where("user_id = ?", userID)
+	if status = strings.ToUpper(strings.TrimSpace(status)); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count order groups: %w", err)
+	}
+
+	var groups []models.OrderGroup
+	if err := query.Preload("Legs").Order("created_at DESC").Limit(limit).Offset(offset).Find(&groups).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch order groups: %w", err)
+	}
+	return groups, total, nil
+}
+
+// Get returns one of the user's order groups with its legs.
+func (s *OrderGroupService) Get(ctx context.Context, userID, groupID uuid.UUID) (*models.OrderGroup, error) {
+	var group models.OrderGroup
+	if err := s.db.WithContext(ctx).Preload("Legs").
+		Where("id = ? AND user_id = ?", groupID, userID).
+		First(&group).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderGroupNotFound
+		}
+		return nil, err
+	}
+	return &group, nil
+}
+
+// Cancel closes an open group and cancels every leg that is still working.
+func (s *OrderGroupService) Cancel(ctx context.Context, userID, groupID uuid.UUID) (*models.OrderGroup, error) {
+	var owned int64
+	if err := s.db.WithContext(ctx).Model(&models.OrderGroup{}).
+		Where("id = ? AND user_id = ?", groupID, userID).
+		Count(&owned).Error; err != nil {
+		return nil, err
+	}
+	if owned == 0 {
+		return nil, ErrOrderGroupNotFound
+	}
+
+	err := s.transition(ctx, groupID, func(group *models.OrderGroup) (groupTransition, error) {
+		if !group.IsOpen() {
+			return groupTransition{}, ErrOrderGroupClosed
+		}
+		t := groupTransition{status: models.OrderGroupCanceled, reason: "canceled by user"}
+		cancelWorkingLegs(&t, group.Legs, nil)
+		return t, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, userID, groupID)
+}
+
+// ReconcileForOrders reconciles the user's open groups that contain any of the given CLOB orders, either as a
+// leg or as the order a conditional leg posted.
+func (s *OrderGroupService) ReconcileForOrders(ctx context.Context, userID uuid.UUID, clobOrderIDs []string) error {
+	var groupIDs []uuid.UUID
+	if err := s.db.WithContext(ctx).Model(&models.OrderGroupLeg{}).
+		Distinct("order_group_legs.group_id").
+		Joins("JOIN order_groups ON order_groups.id = order_group_legs.group_id").
+		Joins("LEFT JOIN conditional_orders ON conditional_orders.id = order_group_legs.conditional_order_id").
+		Where("order_groups.user_id = ?", userID).
+		Where("order_group_legs.clob_order_id IN ? OR conditional_orders.clob_order_id IN ?", clobOrderIDs, clobOrderIDs).
+		Pluck("order_group_legs.group_id", &groupIDs).Error; err != nil {
+		return fmt.Errorf("failed to find order groups: %w", err)
+	}
+
+	var errs []error
+	for _, id := range groupIDs {
+		if err := s.ReconcileGroup(ctx, id); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ReconcileOpen sweeps every open group. Run periodically from the worker.
+func (s *OrderGroupService) ReconcileOpen(ctx context.Context) (int, error) {
+	var groupIDs []uuid.UUID
+	if err := s.db.WithContext(ctx).Model(&models.OrderGroup{}).
+		Where("status IN ?", openOrderGroupStatuses).
+		Pluck("id", &groupIDs).Error; err != nil {
+		return 0, fmt.Errorf("failed to load open order groups: %w", err)
+	}
+
+	var errs []error
+	for _, id := range groupIDs {
+		if err := s.ReconcileGroup(ctx, id); err != nil {
+			errs = append(errs, fmt.Errorf("group %s: %w", id, err))
+		}
+	}
+	return len(groupIDs), errors.Join(errs...)
+}
+
+// ReconcileGroup refreshes leg states and advances the group's state machine.
+func (s *OrderGroupService) ReconcileGroup(ctx context.Context, groupID uuid.UUID) error {
+	return s.transition(ctx, groupID, func(group *models.OrderGroup) (groupTransition, error) {
+		if !group.IsOpen() {
+			return groupTransition{}, nil
+		}
+		return planGroupTransition(group), nil
+	})
+}
+
+// claimExit records which conditional leg fired, so only one exit of a group is ever submitted.
+func (s *OrderGroupService) claimExit(ctx context.Context, groupID, conditionalOrderID uuid.UUID) (bool, error) {
+	var leg models.OrderGroupLeg
+	if err := s.db.WithContext(ctx).
+		Where("group_id = ? AND conditional_order_id = ?", groupID, conditionalOrderID).
+		First(&leg).Error; err != nil {
+		return false, err
+	}
+
+	result := s.db.WithContext(ctx).Model(&models.OrderGroup{}).
+		Where("id = ? AND status = ? AND fired_leg_id IS NULL", groupID, models.OrderGroupActive).
+		Update("fired_leg_id", leg.ID)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// transition locks the group, refreshes its legs, applies the planned changes and then performs the
+// side effects (CLOB cancels, notifications) outside the transaction.
+func (s *OrderGroupService) transition(ctx context.Context, groupID uuid.UUID, plan func(*models.OrderGroup) (groupTransition, error)) error {
+	var (
+		group models.OrderGroup
+		t     groupTransition
+	)
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", groupID).
+			First(&group).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrOrderGroupNotFound
+			}
+			return err
+		}
+		if err := tx.Where("group_id = ?", groupID).Order("created_at").Find(&group.Legs).Error; err != nil {
+			return err
+		}
+		if err := refreshLegs(tx, &group); err != nil {
+			return err
+		}
+
+		var err error
+		if t, err = plan(&group); err != nil {
+			return err
+		}
+		if t.empty() {
+			return nil
+		}
+		if err := s.apply(ctx, tx, &group, t); err != nil {
+			return err
+		}
+		return refreshLegs(tx, &group)
+	})
+	if err != nil || t.empty() {
+		return err
+	}
+
+	if len(t.armConditional) > 0 || len(t.cancelConditional) > 0 {
+		s.conditional.publishChange(ctx)
+	}
+	if len(t.cancelCLOB) > 0 {
+		s.cancelCLOBLegs(ctx, &group, t.cancelCLOB)
+	}
+	if t.status != "" && !group.IsOpen() {
+		if err := s.notify(ctx, &group); err != nil {
+			logger.Error("OrderGroupService: Failed to notify user for group %s: %v", group.ID, err)
+		}
+	}
+	return nil
+}
+
+// apply writes a transition inside the reconciliation transaction.
+func (s *OrderGroupService) apply(ctx context.Context, tx *gorm.DB, group *models.OrderGroup, t groupTransition) error {
+	if err := s.conditional.armPending(ctx, tx, t.armConditional); err != nil {
+		return fmt.Errorf("failed to arm exits: %w", err)
+	}
+	reason := t.reason
+	if reason == "" {
+		reason = "another leg in the order group filled"
+	}
+	if err := s.conditional.cancelOpen(ctx, tx, t.cancelConditional, reason); err != nil {
+		return fmt.Errorf("failed to cancel conditional legs: %w", err)
+	}
+
+	updates := map[string]interface{}{}
+	if t.firedLegID != nil {
+		updates["fired_leg_id"] = *t.firedLegID
+		group.FiredLegID = t.firedLegID
+	} else if t.releaseClaim {
+		updates["fired_leg_id"] = nil
+		group.FiredLegID = nil
+	}
+	if t.status != "" {
+		updates["status"] = t.status
+		updates["status_reason"] = t.reason
+		group.Status = t.status
+		group.StatusReason = t.reason
+		if !group.IsOpen() {
+			now := time.Now().UTC()
+			updates["completed_at"] = now
+			group.CompletedAt = &now
+		}
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+	return tx.Model(group).Updates(updates).Error
+}
+
+// refreshLegs derives each leg's status and sizes from its underlying order and persists any change.
+func refreshLegs(tx *gorm.DB, group *models.OrderGroup) error {
+	var clobIDs []string
+	var condIDs []uuid.UUID
+	for _, leg := range group.Legs {
+		if leg.CLOBOrderID != "" {
+			clobIDs = append(clobIDs, leg.CLOBOrderID)
+		}
+		if leg.ConditionalOrderID != nil {
+			condIDs = append(condIDs, *leg.ConditionalOrderID)
+		}
+	}
+
+	conditionals := make(map[uuid.UUID]models.ConditionalOrder)
+	if len(condIDs) > 0 {
+		var orders []models.ConditionalOrder
+		if err := tx.Select("id, status, size, order_id, clob_order_id").Where("id IN ?", condIDs).Find(&orders).Error; err != nil {
+			return fmt.Errorf("failed to load conditional legs: %w", err)
+		}
+		for _, o := range orders {
+			conditionals[o.ID] = o
+			if o.Status == models.ConditionalOrderSubmitted && o.CLOBOrderID != "" {
+				clobIDs = append(clobIDs, o.CLOBOrderID)
+			}
+		}
+	}
+
+	// Orders are keyed by CLOB ID; orders a conditional leg posted are also keyed by row ID.
+	byCLOBID := make(map[string]*models.Order)
+	byID := make(map[uuid.UUID]*models.Order)
+	var postedIDs []uuid.UUID
+	for _, o := range conditionals {
+		if o.Status == models.ConditionalOrderSubmitted && o.OrderID != nil {
+			postedIDs = append(postedIDs, *o.OrderID)
+		}
+	}
+	if len(clobIDs) > 0 || len(postedIDs) > 0 {
+		query := tx.Select("id, clob_order_id, status, size, size_matched").Where("user_id = ?", group.UserID)
+		switch {
+		case len(clobIDs) > 0 && len(postedIDs) > 0:
+			query = query.Where("clob_order_id IN ? OR id IN ?", clobIDs, postedIDs)
+		case len(clobIDs) > 0:
+			query = query.Where("clob_order_id IN ?", clobIDs)
+		default:
+			query = query.Where("id IN ?", postedIDs)
+		}
+		var orders []models.Order
+		if err := query.Find(&orders).Error; err != nil {
+			return fmt.Errorf("failed to load leg orders: %w", err)
+		}
+		for i := range orders {
+			if orders[i].CLOBOrderID != "" {
+				byCLOBID[orders[i].CLOBOrderID] = &orders[i]
+			}
+			byID[orders[i].ID] = &orders[i]
+		}
+	}
+
+	for i := range group.Legs {
+		leg := &group.Legs[i]
+		next := legState{status: leg.Status, size: leg.Size, filled: leg.FilledSize}
+		if leg.CLOBOrderID != "" {
+			if order, ok := byCLOBID[leg.CLOBOrderID]; ok {
+				next = legStateForOrder(order)
+			}
+		} else if leg.ConditionalOrderID != nil {
+			if cond, ok := conditionals[*leg.ConditionalOrderID]; ok {
+				var posted *models.Order
+				if cond.OrderID != nil {
+					posted = byID[*cond.OrderID]
+				}
+				if posted == nil && cond.CLOBOrderID != "" {
+					posted = byCLOBID[cond.CLOBOrderID]
+				}
+				next = legStateForConditional(&cond, posted)
+			}
+		}
+		if next.status == leg.Status && next.size == leg.Size && next.filled == leg.FilledSize {
+			continue
+		}
+		if err := tx.Model(leg).Updates(map[string]interface{}{
+			"status":      next.status,
+			"size":        next.size,
+			"filled_size": next.filled,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to update leg %s: %w", leg.ID, err)
+		}
+		leg.Status, leg.Size, leg.FilledSize = next.status, next.size, next.filled
+	}
+	return nil
+}
+
+// legState is a leg's status and sizes as derived from its underlying order.
+type legState struct {
+	status models.OrderGroupLegStatus
+	size   float64
+	filled float64
+}
+
+// legStateForOrder maps a CLOB order onto a leg. An order that ended after matching part of its size is FILLED.
+func legStateForOrder(order *models.Order) legState {
+	state := legState{status: models.OrderGroupLegWorking, size: order.Size, filled: order.FilledSize()}
+	switch {
+	case order.Status == models.OrderStatusFilled:
+		state.status = models.OrderGroupLegFilled
+	case state.filled > 0 && (order.Status == models.OrderStatusCanceled || order.Status == models.OrderStatusFailed):
+		state.status = models.OrderGroupLegFilled
+	case order.Status == models.OrderStatusCanceled:
+		state.status = models.OrderGroupLegCanceled
+	case order.Status == models.OrderStatusFailed:
+		state.status = models.OrderGroupLegFailed
+	case state.filled > 0:
+		state.status = models.OrderGroupLegPartial
+	}
+	return state
+}
+
+// legStateForConditional maps a conditional order onto a leg. Once submitted the leg follows the posted order;
+// until that order is recorded the leg stays WORKING.
+func legStateForConditional(cond *models.ConditionalOrder, posted *models.Order) legState {
+	state := legState{status: models.OrderGroupLegWorking, size: cond.Size}
+	switch cond.Status {
+	case models.ConditionalOrderPending:
+		state.status = models.OrderGroupLegWaiting
+	case models.ConditionalOrderSubmitted:
+		if posted != nil {
+			state = legStateForOrder(posted)
+			state.size = cond.Size
+		}
+	case models.ConditionalOrderFailed:
+		state.status = models.OrderGroupLegFailed
+	case models.ConditionalOrderCanceled, models.ConditionalOrderExpired:
+		state.status = models.OrderGroupLegCanceled
+	}
+	return state
+}
+
+// planGroupTransition is the group state machine. It only reads the group; apply performs the result.
+//
+//	PENDING_ENTRY --entry filled--> ACTIVE (exits covered by the matched size armed, larger exits canceled)
+//	PENDING_ENTRY --entry matched too little for any exit--> FAILED (exits canceled)
+//	PENDING_ENTRY --entry canceled/failed unfilled--> CANCELED / FAILED (exits canceled)
+//	ACTIVE --any exit filled, even in part--> COMPLETED (siblings canceled)
+//	ACTIVE --every exit ended unfilled--> CANCELED / FAILED
+func planGroupTransition(group *models.OrderGroup) groupTransition {
+	var t groupTransition
+	var entry *models.OrderGroupLeg
+	var exits []models.OrderGroupLeg
+	for i := range group.Legs {
+		if group.Legs[i].Role == models.OrderGroupLegEntry {
+			entry = &group.Legs[i]
+			continue
+		}
+		exits = append(exits, group.Legs[i])
+	}
+
+	if group.Status == models.OrderGroupPendingEntry {
+		if entry == nil {
+			t.status = models.OrderGroupFailed
+			t.reason = "entry leg is missing"
+			cancelWorkingLegs(&t, exits, nil)
+			return t
+		}
+		switch entry.Status {
+		case models.OrderGroupLegFilled:
+			planEntryFilled(&t, entry, exits)
+		case models.OrderGroupLegCanceled, models.OrderGroupLegFailed:
+			t.status = models.OrderGroupCanceled
+			t.reason = "entry order was canceled"
+			if entry.Status == models.OrderGroupLegFailed {
+				t.status = models.OrderGroupFailed
+				t.reason = "entry order failed"
+			}
+			cancelWorkingLegs(&t, exits, nil)
+		}
+		return t
+	}
+
+	for i := range exits {
+		if exits[i].Status != models.OrderGroupLegFilled && exits[i].Status != models.OrderGroupLegPartial {
+			continue
+		}
+		filled := exits[i]
+		verb := "filled"
+		if filled.Status == models.OrderGroupLegPartial || (filled.Size > 0 && filled.FilledSize < filled.Size-legSizeEpsilon) {
+			verb = "partly filled"
+		}
+		t.status = models.OrderGroupCompleted
+		t.reason = fmt.Sprintf("%s leg %s", strings.ToLower(strings.ReplaceAll(string(filled.Role), "_", "-")), verb)
+		if group.FiredLegID == nil || *group.FiredLegID != filled.ID {
+			t.firedLegID = &filled.ID
+		}
+		cancelWorkingLegs(&t, exits, &filled.ID)
+		return t
+	}
+
+	allEnded, anyFailed := true, false
+	for _, exit := range exits {
+		if !exit.IsTerminal() {
+			allEnded = false
+		}
+		if exit.Status == models.OrderGroupLegFailed {
+			anyFailed = true
+		}
+		// A fired conditional leg that failed or was canceled releases its claim so the siblings stay live.
+		if group.FiredLegID != nil && *group.FiredLegID == exit.ID && exit.IsTerminal() {
+			t.releaseClaim = true
+		}
+	}
+	if allEnded {
+		t.status = models.OrderGroupCanceled
+		t.reason = "every leg ended without a fill"
+		if anyFailed {
+			t.status = models.OrderGroupFailed
+			t.reason = "a leg failed and no leg filled"
+		}
+	}
+	return t
+}
+
+// planEntryFilled arms the exits the entry's matched size covers and cancels the rest. Exits are pre-signed, so
+// an exit larger than the position would be rejected by the CLOB when it fires.
+func planEntryFilled(t *groupTransition, entry *models.OrderGroupLeg, exits []models.OrderGroupLeg) {
+	matched := entry.FilledSize
+	if matched <= 0 {
+		matched = entry.Size
+	}
+
+	var oversized []models.OrderGroupLeg
+	armed := 0
+	for _, exit := range exits {
+		if exit.IsTerminal() {
+			continue
+		}
+		if matched > 0 && exit.Size > matched+legSizeEpsilon {
+			oversized = append(oversized, exit)
+			continue
+		}
+		if exit.Status == models.OrderGroupLegWaiting && exit.ConditionalOrderID != nil {
+			t.armConditional = append(t.armConditional, *exit.ConditionalOrderID)
+		}
+		armed++
+	}
+
+	partial := entry.Size > 0 && matched < entry.Size-legSizeEpsilon
+	switch {
+	case armed == 0 && len(oversized) > 0:
+		t.status = models.OrderGroupFailed
+		t.reason = fmt.Sprintf("entry filled %s shares, fewer than every exit is signed for; place new exits for the filled size", formatLegSize(matched))
+	case len(oversized) > 0:
+		t.status = models.OrderGroupActive
+		t.reason = fmt.Sprintf("entry filled %s shares; exits covering the filled size armed, larger exits canceled", formatLegSize(matched))
+	case partial:
+		t.status = models.OrderGroupActive
+		t.reason = fmt.Sprintf("entry partly filled (%s shares); exits armed", formatLegSize(matched))
+	default:
+		t.status = models.OrderGroupActive
+		t.reason = "entry filled; exits armed"
+	}
+	cancelWorkingLegs(t, oversized, nil)
+}
+
+func formatLegSize(size float64) string {
+	return strconv.FormatFloat(size, 'f', -1, 64)
+}
+
+// cancelWorkingLegs queues every non-terminal leg except keep for cancellation.
+func cancelWorkingLegs(t *groupTransition, legs []models.OrderGroupLeg, keep *uuid.UUID) {
+	for _, leg := range legs {
+		if leg.IsTerminal() || (keep != nil && leg.ID == *keep) {
+			continue
+		}
+		if leg.ConditionalOrderID != nil {
+			t.cancelConditional = append(t.cancelConditional, *leg.ConditionalOrderID)
+		}
+		if leg.CLOBOrderID != "" {
+			t.cancelCLOB = append(t.cancelCLOB, leg.CLOBOrderID)
+		}
+	}
+}
+
+// cancelCLOBLegs cancels sibling CLOB orders with the credentials stored on the group.
+func (s *OrderGroupService) cancelCLOBLegs(ctx context.Context, group *models.OrderGroup, orderIDs []string) {
+	var user models.User
+	if err := s.db.WithContext(ctx).Where("id = ?", group.UserID).First(&user).Error; err != nil {
+		logger.Error("OrderGroupService: Failed to load owner of group %s: %v", group.ID, err)
+		return
+	}
+
+	var creds *clob.APIKeyCredentials
+	if plaintext, err := s.conditional.box.Open(group.EncryptedCredentials, []byte(group.ID.String())); err == nil {
+		var stored clob.APIKeyCredentials
+		if err := json.Unmarshal(plaintext, &stored); err == nil {
+			creds = &stored
+		}
+	}
+	if creds == nil {
+		logger.Error("OrderGroupService: Stored credentials for group %s are unreadable; sibling orders %v left open", group.ID, orderIDs)
+		return
+	}
+
+	resp, err := s.trades.CancelOrdersWithCredentials(ctx, &user, orderIDs, creds)
+	if err != nil {
+		logger.Error("OrderGroupService: Failed to cancel sibling orders for group %s: %v", group.ID, err)
+		return
+	}
+	for id, reason := range resp.NotCanceled {
+		logger.Info("OrderGroupService: Sibling order %s of group %s not canceled: %s", id, group.ID, reason)
+	}
+}
+
+func (s *OrderGroupService) notify(ctx context.Context, group *models.OrderGroup) error {
+	label := "Bracket order"
+	if group.Kind == models.OrderGroupOCO {
+		label = "OCO order"
+	}
+
+	var firedRole string
+	if group.FiredLegID != nil {
+		for _, leg := range group.Legs {
+			if leg.ID == *group.FiredLegID {
+				firedRole = string(leg.Role)
+			}
+		}
+	}
+
+	title := fmt.Sprintf("%s completed", label)
+	switch group.Status {
+	case models.OrderGroupCanceled:
+		title = fmt.Sprintf("%s canceled", label)
+	case models.OrderGroupFailed:
+		title = fmt.Sprintf("%s failed", label)
+	}
+	message := fmt.Sprintf("%s closed: %s.", label, group.StatusReason)
+	if group.Status == models.OrderGroupCompleted {
+		message = fmt.Sprintf("%s closed: %s; remaining legs were canceled.", label, group.StatusReason)
+	}
+
+	data, err := json.Marshal(OrderGroupAlertData{
+		OrderGroupID: group.ID.String(),
+		Kind:         string(group.Kind),
+		Status:       string(group.Status),
+		MarketID:     group.MarketID,
+		FiredLegRole: firedRole,
+		Reason:       group.StatusReason,
+	})
+	if err != nil {
+		return err
+	}
+
+	notification := models.Notification{
+		ID:        uuid.New(),
+		UserID:    group.UserID,
+		Type:      models.NotificationTypeOrderGroup,
+		Title:     title,
+		Message:   message,
+		Data:      string(data),
+		Read:      false,
+		CreatedAt: time.Now(),
+	}
+	return s.db.WithContext(ctx).Create(&notification).Error
+}
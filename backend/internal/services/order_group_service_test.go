@@ -0,0 +1,251 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestPlanGroupTransition(t *testing.T) {
+	entryID, tpID, slID := uuid.New(), uuid.New(), uuid.New()
+	tpCond, slCond := uuid.New(), uuid.New()
+
+	entry := func(status models.OrderGroupLegStatus) models.OrderGroupLeg {
+		return models.OrderGroupLeg{ID: entryID, Role: models.OrderGroupLegEntry, CLOBOrderID: "entry", Status: status}
+	}
+	takeProfit := func(status models.OrderGroupLegStatus) models.OrderGroupLeg {
+		return models.OrderGroupLeg{ID: tpID, Role: models.OrderGroupLegTakeProfit, CLOBOrderID: "tp", Status: status}
+	}
+	stopLoss := func(status models.OrderGroupLegStatus) models.OrderGroupLeg {
+		return models.OrderGroupLeg{ID: slID, Role: models.OrderGroupLegStopLoss, ConditionalOrderID: &slCond, Status: status}
+	}
+	heldTakeProfit := func(status models.OrderGroupLegStatus) models.OrderGroupLeg {
+		return models.OrderGroupLeg{ID: tpID, Role: models.OrderGroupLegTakeProfit, ConditionalOrderID: &tpCond, Status: status}
+	}
+	sized := func(leg models.OrderGroupLeg, size, filled float64) models.OrderGroupLeg {
+		leg.Size, leg.FilledSize = size, filled
+		return leg
+	}
+
+	cases := []struct {
+		name           string
+		status         models.OrderGroupStatus
+		firedLegID     *uuid.UUID
+		legs           []models.OrderGroupLeg
+		wantStatus     models.OrderGroupStatus
+		wantFired      *uuid.UUID
+		wantRelease    bool
+		wantArm        []uuid.UUID
+		wantCancelCond []uuid.UUID
+		wantCancelCLOB []string
+		wantEmpty      bool
+	}{
+		{
+			name:      "bracket entry still working",
+			status:    models.OrderGroupPendingEntry,
+			legs:      []models.OrderGroupLeg{entry(models.OrderGroupLegWorking), heldTakeProfit(models.OrderGroupLegWaiting), stopLoss(models.OrderGroupLegWaiting)},
+			wantEmpty: true,
+		},
+		{
+			name:       "bracket entry filled arms waiting exits",
+			status:     models.OrderGroupPendingEntry,
+			legs:       []models.OrderGroupLeg{entry(models.OrderGroupLegFilled), heldTakeProfit(models.OrderGroupLegWaiting), stopLoss(models.OrderGroupLegWaiting)},
+			wantStatus: models.OrderGroupActive,
+			wantArm:    []uuid.UUID{tpCond, slCond},
+		},
+		{
+			name:      "bracket entry partly filled and still working waits",
+			status:    models.OrderGroupPendingEntry,
+			legs:      []models.OrderGroupLeg{sized(entry(models.OrderGroupLegPartial), 100, 40), sized(stopLoss(models.OrderGroupLegWaiting), 40, 0)},
+			wantEmpty: true,
+		},
+		{
+			name:       "bracket entry canceled after a partial fill arms exits it covers",
+			status:     models.OrderGroupPendingEntry,
+			legs:       []models.OrderGroupLeg{sized(entry(models.OrderGroupLegFilled), 100, 60), sized(heldTakeProfit(models.OrderGroupLegWaiting), 60, 0), sized(stopLoss(models.OrderGroupLegWaiting), 50, 0)},
+			wantStatus: models.OrderGroupActive,
+			wantArm:    []uuid.UUID{tpCond, slCond},
+		},
+		{
+			name:           "bracket partial entry cancels exits larger than the fill",
+			status:         models.OrderGroupPendingEntry,
+			legs:           []models.OrderGroupLeg{sized(entry(models.OrderGroupLegFilled), 100, 60), sized(heldTakeProfit(models.OrderGroupLegWaiting), 100, 0), sized(stopLoss(models.OrderGroupLegWaiting), 60, 0)},
+			wantStatus:     models.OrderGroupActive,
+			wantArm:        []uuid.UUID{slCond},
+			wantCancelCond: []uuid.UUID{tpCond},
+		},
+		{
+			name:           "bracket partial entry smaller than every exit fails",
+			status:         models.OrderGroupPendingEntry,
+			legs:           []models.OrderGroupLeg{sized(entry(models.OrderGroupLegFilled), 100, 30), sized(heldTakeProfit(models.OrderGroupLegWaiting), 100, 0), sized(stopLoss(models.OrderGroupLegWaiting), 100, 0)},
+			wantStatus:     models.OrderGroupFailed,
+			wantCancelCond: []uuid.UUID{tpCond, slCond},
+		},
+		{
+			name:           "bracket entry canceled cancels exits",
+			status:         models.OrderGroupPendingEntry,
+			legs:           []models.OrderGroupLeg{entry(models.OrderGroupLegCanceled), heldTakeProfit(models.OrderGroupLegWaiting), stopLoss(models.OrderGroupLegWaiting)},
+			wantStatus:     models.OrderGroupCanceled,
+			wantCancelCond: []uuid.UUID{tpCond, slCond},
+		},
+		{
+			name:           "bracket entry failed fails the group",
+			status:         models.OrderGroupPendingEntry,
+			legs:           []models.OrderGroupLeg{entry(models.OrderGroupLegFailed), stopLoss(models.OrderGroupLegWaiting)},
+			wantStatus:     models.OrderGroupFailed,
+			wantCancelCond: []uuid.UUID{slCond},
+		},
+		{
+			name:           "bracket without entry fails",
+			status:         models.OrderGroupPendingEntry,
+			legs:           []models.OrderGroupLeg{takeProfit(models.OrderGroupLegWorking)},
+			wantStatus:     models.OrderGroupFailed,
+			wantCancelCLOB: []string{"tp"},
+		},
+		{
+			name:           "take profit fill completes and cancels the stop",
+			status:         models.OrderGroupActive,
+			legs:           []models.OrderGroupLeg{entry(models.OrderGroupLegFilled), takeProfit(models.OrderGroupLegFilled), stopLoss(models.OrderGroupLegWorking)},
+			wantStatus:     models.OrderGroupCompleted,
+			wantFired:      &tpID,
+			wantCancelCond: []uuid.UUID{slCond},
+		},
+		{
+			name:           "stop fill completes and cancels the resting take profit",
+			status:         models.OrderGroupActive,
+			firedLegID:     &slID,
+			legs:           []models.OrderGroupLeg{takeProfit(models.OrderGroupLegWorking), stopLoss(models.OrderGroupLegFilled)},
+			wantStatus:     models.OrderGroupCompleted,
+			wantCancelCLOB: []string{"tp"},
+		},
+		{
+			name:           "partly filled OCO leg cancels its siblings at once",
+			status:         models.OrderGroupActive,
+			legs:           []models.OrderGroupLeg{sized(takeProfit(models.OrderGroupLegPartial), 100, 90), stopLoss(models.OrderGroupLegWorking)},
+			wantStatus:     models.OrderGroupCompleted,
+			wantFired:      &tpID,
+			wantCancelCond: []uuid.UUID{slCond},
+		},
+		{
+			name:       "submitted stop still working keeps the take profit",
+			status:     models.OrderGroupActive,
+			firedLegID: &slID,
+			legs:       []models.OrderGroupLeg{takeProfit(models.OrderGroupLegWorking), stopLoss(models.OrderGroupLegWorking)},
+			wantEmpty:  true,
+		},
+		{
+			name:        "fired stop canceled releases the claim",
+			status:      models.OrderGroupActive,
+			firedLegID:  &slID,
+			legs:        []models.OrderGroupLeg{takeProfit(models.OrderGroupLegWorking), stopLoss(models.OrderGroupLegCanceled)},
+			wantRelease: true,
+		},
+		{
+			name:       "every exit ended unfilled",
+			status:     models.OrderGroupActive,
+			legs:       []models.OrderGroupLeg{takeProfit(models.OrderGroupLegCanceled), stopLoss(models.OrderGroupLegCanceled)},
+			wantStatus: models.OrderGroupCanceled,
+		},
+		{
+			name:       "a failed exit and no fill fails the group",
+			status:     models.OrderGroupActive,
+			legs:       []models.OrderGroupLeg{takeProfit(models.OrderGroupLegCanceled), stopLoss(models.OrderGroupLegFailed)},
+			wantStatus: models.OrderGroupFailed,
+		},
+	}
+
+	for _, tc := range cases {
+		group := &models.OrderGroup{Status: tc.status, FiredLegID: tc.firedLegID, Legs: tc.legs}
+		got := planGroupTransition(group)
+
+		if got.empty() != tc.wantEmpty {
+			t.Errorf("%s: empty() = %v, want %v (%+v)", tc.name, got.empty(), tc.wantEmpty, got)
+		}
+		if got.status != tc.wantStatus {
+			t.Errorf("%s: status = %q, want %q", tc.name, got.status, tc.wantStatus)
+		}
+		if (got.firedLegID == nil) != (tc.wantFired == nil) || (got.firedLegID != nil && *got.firedLegID != *tc.wantFired) {
+			t.Errorf("%s: firedLegID = %v, want %v", tc.name, got.firedLegID, tc.wantFired)
+		}
+		if got.releaseClaim != tc.wantRelease {
+			t.Errorf("%s: releaseClaim = %v, want %v", tc.name, got.releaseClaim, tc.wantRelease)
+		}
+		if !equalUUIDs(got.armConditional, tc.wantArm) {
+			t.Errorf("%s: armConditional = %v, want %v", tc.name, got.armConditional, tc.wantArm)
+		}
+		if !equalUUIDs(got.cancelConditional, tc.wantCancelCond) {
+			t.Errorf("%s: cancelConditional = %v, want %v", tc.name, got.cancelConditional, tc.wantCancelCond)
+		}
+		if len(got.cancelCLOB) != len(tc.wantCancelCLOB) {
+			t.Errorf("%s: cancelCLOB = %v, want %v", tc.name, got.cancelCLOB, tc.wantCancelCLOB)
+			continue
+		}
+		for i := range got.cancelCLOB {
+			if got.cancelCLOB[i] != tc.wantCancelCLOB[i] {
+				t.Errorf("%s: cancelCLOB = %v, want %v", tc.name, got.cancelCLOB, tc.wantCancelCLOB)
+			}
+		}
+	}
+}
+
+func TestLegStateMapping(t *testing.T) {
+	orderID := uuid.New()
+	orderCases := []struct {
+		name       string
+		order      models.Order
+		wantStatus models.OrderGroupLegStatus
+		wantFilled float64
+	}{
+		{"filled", models.Order{Status: models.OrderStatusFilled, Size: 10}, models.OrderGroupLegFilled, 10},
+		{"canceled unfilled", models.Order{Status: models.OrderStatusCanceled, Size: 10}, models.OrderGroupLegCanceled, 0},
+		{"canceled after partial fill", models.Order{Status: models.OrderStatusCanceled, Size: 10, SizeMatched: 4}, models.OrderGroupLegFilled, 4},
+		{"failed", models.Order{Status: models.OrderStatusFailed, Size: 10}, models.OrderGroupLegFailed, 0},
+		{"open", models.Order{Status: models.OrderStatusOpen, Size: 10}, models.OrderGroupLegWorking, 0},
+		{"open partly matched", models.Order{Status: models.OrderStatusOpen, Size: 10, SizeMatched: 9}, models.OrderGroupLegPartial, 9},
+	}
+	for _, tc := range orderCases {
+		got := legStateForOrder(&tc.order)
+		if got.status != tc.wantStatus || got.filled != tc.wantFilled || got.size != tc.order.Size {
+			t.Errorf("legStateForOrder(%s) = %+v, want %s filled %v", tc.name, got, tc.wantStatus, tc.wantFilled)
+		}
+	}
+
+	condCases := []struct {
+		name       string
+		status     models.ConditionalOrderStatus
+		posted     *models.Order
+		wantStatus models.OrderGroupLegStatus
+		wantFilled float64
+	}{
+		{"pending", models.ConditionalOrderPending, nil, models.OrderGroupLegWaiting, 0},
+		{"armed", models.ConditionalOrderArmed, nil, models.OrderGroupLegWorking, 0},
+		{"submitted, order not recorded yet", models.ConditionalOrderSubmitted, nil, models.OrderGroupLegWorking, 0},
+		{"submitted, order open", models.ConditionalOrderSubmitted, &models.Order{ID: orderID, Status: models.OrderStatusOpen, Size: 5}, models.OrderGroupLegWorking, 0},
+		{"submitted, order filled", models.ConditionalOrderSubmitted, &models.Order{ID: orderID, Status: models.OrderStatusFilled, Size: 5}, models.OrderGroupLegFilled, 5},
+		{"submitted, FAK partly matched", models.ConditionalOrderSubmitted, &models.Order{ID: orderID, Status: models.OrderStatusCanceled, Size: 5, SizeMatched: 2}, models.OrderGroupLegFilled, 2},
+		{"submitted, FOK killed", models.ConditionalOrderSubmitted, &models.Order{ID: orderID, Status: models.OrderStatusCanceled, Size: 5}, models.OrderGroupLegCanceled, 0},
+		{"failed", models.ConditionalOrderFailed, nil, models.OrderGroupLegFailed, 0},
+		{"canceled", models.ConditionalOrderCanceled, nil, models.OrderGroupLegCanceled, 0},
+		{"expired", models.ConditionalOrderExpired, nil, models.OrderGroupLegCanceled, 0},
+	}
+	for _, tc := range condCases {
+		cond := &models.ConditionalOrder{Status: tc.status, Size: 5}
+		got := legStateForConditional(cond, tc.posted)
+		if got.status != tc.wantStatus || got.filled != tc.wantFilled || got.size != 5 {
+			t.Errorf("legStateForConditional(%s) = %+v, want %s filled %v", tc.name, got, tc.wantStatus, tc.wantFilled)
+		}
+	}
+}
+
+func equalUUIDs(a, b []uuid.UUID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
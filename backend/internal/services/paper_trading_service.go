@@ -0,0 +1,1095 @@
+/**
+ * @description
+ * Paper Trading Service.
+ * Runs a virtual USDC account per user and simulates order execution against the live order books:
+ * marketable size takes the cached Redis book level by level, and the remainder of GTC / GTD orders rests
+ * with an estimated queue position that live trades and level updates work down.
+ *
+ * @dependencies
+ * - backend/internal/services (MarketService)
+ * - backend/internal/models
+ * - github.com/redis/go-redis/v9
+ *
+ * @notes
+ * - Resting orders join the back of their price level: QueueAhead starts at the visible size there.
+ *   Trades at the level consume the queue first; level updates can only shrink it (cancels ahead of us).
+ * - A resting order fills at its own limit price (maker); trades through the level or a crossed book fill it.
+ * - Taker fills use up simulated depth: the size taken from each level is kept per user and book snapshot in
+ *   Redis, so repeated orders against an unchanged book do not fill the same liquidity twice.
+ * - Fees are not simulated, and positions in resolved markets stay marked at the last price.
+ * - Matching of resting orders runs in the worker (Run); API instances publish PaperOrdersChannel on changes.
+ */
+
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/polymarket/clob"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	PaperOrdersChannel = "paper_orders:updated"
+
+	DefaultPaperBalance = 10000.0
+	maxPaperBalance     = 1000000.0
+	maxOpenPaperOrders  = 200
+	paperReloadInterval = 30 * time.Second
+	paperDepthTTL       = 10 * time.Minute
+	paperEpsilon        = 1e-9
+)
+
+var (
+	ErrInvalidPaperOrder      = errors.New("invalid paper order")
+	ErrPaperOrderNotFound     = errors.New("paper order not found")
+	ErrPaperInsufficientCash  = errors.New("insufficient paper balance")
+	ErrPaperInsufficientSize  = errors.New("insufficient paper position")
+	ErrPaperOrderLimit        = fmt.Errorf("a maximum of %d open paper orders is allowed", maxOpenPaperOrders)
+	ErrInvalidPaperBalance    = fmt.Errorf("starting balance must be between 1 and %.0f USDC", maxPaperBalance)
+	paperRestingOrderTypes    = map[string]bool{string(clob.OrderTypeGTC): true, string(clob.OrderTypeGTD): true}
+	paperSupportedOrderTypes  = map[string]bool{string(clob.OrderTypeGTC): true, string(clob.OrderTypeGTD): true, string(clob.OrderTypeFOK): true, string(clob.OrderTypeFAK): true}
+	paperCancelableStatuses   = []models.PaperOrderStatus{models.PaperOrderOpen}
+	errPaperOrderNoLongerOpen = errors.New("paper order is no longer open")
+)
+
+// PaperTradingService simulates trading for paper accounts
+type PaperTradingService struct {
+	db      *gorm.DB
+	redis   *redis.Client
+	markets *MarketService
+
+	mu      sync.Mutex
+	resting map[string][]*restingPaperOrder // token_id -> open orders
+}
+
+// restingPaperOrder is the in-memory matching state of an open order.
+type restingPaperOrder struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Side       models.OrderSide
+	Price      float64
+	Remaining  float64
+	QueueAhead float64
+}
+
+// NewPaperTradingService creates a new PaperTradingService
+func NewPaperTradingService(db *gorm.DB, rdb *redis.Client, markets *MarketService) *PaperTradingService {
+	return &PaperTradingService{
+		db:      db,
+		redis:   rdb,
+		markets: markets,
+		resting: make(map[string][]*restingPaperOrder),
+	}
+}
+
+// PaperOrderInput is a simulated order. Price is the limit (worst acceptable) price, also for FOK / FAK.
+type PaperOrderInput struct {
+	TokenID   string     `json:"tokenId"`
+	Side      string     `json:"side"`
+	OrderType string     `json:"orderType"`
+	Price     float64    `json:"price"`
+	Size      float64    `json:"size"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"` // GTD only
+}
+
+// PaperOrderResult is the order after immediate matching, with the fills it took
+type PaperOrderResult struct {
+	Order *models.PaperOrder `json:"order"`
+	Fills []models.PaperFill `json:"fills"`
+}
+
+// PaperPositionView is a position marked to the live price
+type PaperPositionView struct {
+	models.PaperPosition
+	MarkPrice     float64 `json:"mark_price"`
+	MarketValue   float64 `json:"market_value"`
+	UnrealizedPnL float64 `json:"unrealized_pnl"`
+}
+
+// PaperPortfolio is the account marked to market
+type PaperPortfolio struct {
+	Account       *models.PaperAccount `json:"account"`
+	Positions     []PaperPositionView  `json:"positions"`
+	PositionValue float64              `json:"position_value"`
+	Equity        float64              `json:"equity"` // cash + reserved cash + positions at mark
+	UnrealizedPnL float64              `json:"unrealized_pnl"`
+	TotalPnL      float64              `json:"total_pnl"` // equity - starting balance
+	OpenOrders    int64                `json:"open_orders"`
+}
+
+// paperPriceUpdate mirrors the RTDS payload published on PriceUpdateChannel.
+type paperPriceUpdate struct {
+	AssetID        string   `json:"asset_id"`
+	Price          *float64 `json:"price,omitempty"`
+	Size           *float64 `json:"size,omitempty"`
+	Side           string   `json:"side,omitempty"`
+	BestBid        *float64 `json:"best_bid,omitempty"`
+	BestAsk        *float64 `json:"best_ask,omitempty"`
+	LastTradePrice *float64 `json:"last_trade_price,omitempty"`
+	LastTradeSize  *float64 `json:"last_trade_size,omitempty"`
+	LastTradeSide  string   `json:"last_trade_side,omitempty"`
+}
+
+// paperMatch is a queued change to a resting order, applied outside the lock.
+type paperMatch struct {
+	order      restingPaperOrder
+	fillSize   float64
+	queueAhead float64
+}
+
+// GetAccount returns the user's paper account, opening it with the default balance on first use.
+func (s *PaperTradingService) GetAccount(ctx context.Context, userID uuid.UUID) (*models.PaperAccount, error) {
+	return s.ensureAccount(s.db.WithContext(ctx), userID)
+}
+
+func (s *PaperTradingService) ensureAccount(tx *gorm.DB, userID uuid.UUID) (*models.PaperAccount, error) {
+	account := models.PaperAccount{
+		UserID:          userID,
+		StartingBalance: DefaultPaperBalance,
+		CashBalance:     DefaultPaperBalance,
+	}
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoNothing: true,
+	}).Create(&account).Error; err != nil {
+		return nil, fmt.Errorf("failed to open paper account: %w", err)
+	}
+
+	var existing models.PaperAccount
+	if err := tx.Where("user_id = ?", userID).First(&existing).Error; err != nil {
+		return nil, fmt.Errorf("failed to load paper account: %w", err)
+	}
+	return &existing, nil
+}
+
+// lockedAccount loads the account FOR UPDATE, opening it first if needed.
+func (s *PaperTradingService) lockedAccount(tx *gorm.DB, userID uuid.UUID) (*models.PaperAccount, error) {
+	if _, err := s.ensureAccount(tx, userID); err != nil {
+		return nil, err
+	}
+	var account models.PaperAccount
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ?", userID).
+		First(&account).Error; err != nil {
+		return nil, fmt.Errorf("failed to lock paper account: %w", err)
+	}
+	return &account, nil
+}
+
+// ResetAccount wipes the user's paper orders and positions and restores cash to startingBalance.
+func (s *PaperTradingService) ResetAccount(ctx context.Context, userID uuid.UUID, startingBalance float64) (*models.PaperAccount, error) {
+	if startingBalance == 0 {
+		startingBalance = DefaultPaperBalance
+	}
+	if startingBalance < 1 || startingBalance > maxPaperBalance {
+		return nil, ErrInvalidPaperBalance
+	}
+
+	var account *models.PaperAccount
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		locked, err := s.lockedAccount(tx, userID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.PaperOrder{}).Error; err != nil {
+			return fmt.Errorf("failed to clear paper orders: %w", err)
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.PaperPosition{}).Error; err != nil {
+			return fmt.Errorf("failed to clear paper positions: %w", err)
+		}
+
+		now := time.Now().UTC()
+		locked.StartingBalance = startingBalance
+		locked.CashBalance = startingBalance
+		locked.ReservedCash = 0
+		locked.RealizedPnL = 0
+		locked.ResetAt = &now
+		if err := tx.Save(locked).Error; err != nil {
+			return fmt.Errorf("failed to reset paper account: %w", err)
+		}
+		account = locked
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishChange(ctx)
+	return account, nil
+}
+
+// PlaceOrder matches the order against the live book and rests any GTC / GTD remainder.
+func (s *PaperTradingService) PlaceOrder(ctx context.Context, user *models.User, input PaperOrderInput) (*PaperOrderResult, error) {
+	if user == nil {
+		return nil, errors.New("user context is required")
+	}
+	if err := normalizePaperOrder(&input); err != nil {
+		return nil, err
+	}
+
+	var market models.Market
+	if err := s.db.WithContext(ctx).
+		Where("token_id_yes = ? OR token_id_no = ?", input.TokenID, input.TokenID).
+		First(&market).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: unknown token %s", ErrInvalidPaperOrder, input.TokenID)
+		}
+		return nil, fmt.Errorf("failed to load market: %w", err)
+	}
+	if market.Closed || market.Archived || !market.AcceptingOrders {
+		return nil, fmt.Errorf("%w: market is not accepting orders", ErrInvalidPaperOrder)
+	}
+
+	book, err := s.markets.loadOrderBookSnapshot(ctx, market.ConditionID, input.TokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	side := models.OrderSide(input.Side)
+	levels := book.sideLevels(input.Side)
+	depthKey := paperDepthKey(user.ID, input.TokenID, input.Side, levels)
+	order := &models.PaperOrder{
+		UserID:    user.ID,
+		MarketID:  market.ConditionID,
+		TokenID:   input.TokenID,
+		Outcome:   deriveOutcomeLabel(&market, input.TokenID, ""),
+		Side:      side,
+		OrderType: input.OrderType,
+		Price:     input.Price,
+		Size:      input.Size,
+		Status:    models.PaperOrderOpen,
+		ExpiresAt: input.ExpiresAt,
+	}
+
+	var fills []models.PaperFill
+	var takes []depthOrderSummary
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		account, err := s.lockedAccount(tx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		// Matched under the account lock; the depth used is recorded as soon as the transaction commits.
+		takes = paperTakerFills(paperRemainingDepth(levels, s.depthUsed(ctx, depthKey)), side, input.Price, input.Size)
+		taken := 0.0
+		for _, t := range takes {
+			taken += t.Size
+		}
+
+		now := time.Now().UTC()
+		rests := paperRestingOrderTypes[input.OrderType]
+		switch {
+		case input.OrderType == string(clob.OrderTypeFOK) && taken+paperEpsilon < input.Size:
+			// Killed: nothing executes.
+			takes = nil
+			order.Status = models.PaperOrderCanceled
+			order.StatusReason = fmt.Sprintf("FOK order could not be fully filled (%.2f of %.2f shares available)", taken, input.Size)
+			order.CompletedAt = &now
+		case taken+paperEpsilon >= input.Size:
+			order.Status = models.PaperOrderFilled
+			order.CompletedAt = &now
+		case !rests:
+			order.Status = models.PaperOrderCanceled
+			order.StatusReason = "unfilled FAK remainder canceled"
+			order.CompletedAt = &now
+		default:
+			order.QueueAhead = paperLevelSize(book.sideLevels(oppositeBookSide(input.Side)), input.Price)
+		}
+
+		if order.Status == models.PaperOrderOpen {
+			var open int64
+			if err := tx.Model(&models.PaperOrder{}).
+				Where("user_id = ? AND status = ?", user.ID, models.PaperOrderOpen).
+				Count(&open).Error; err != nil {
+				return fmt.Errorf("failed to count open paper orders: %w", err)
+			}
+			if open >= maxOpenPaperOrders {
+				return ErrPaperOrderLimit
+			}
+		}
+
+		position, err := lockedPaperPosition(tx, user.ID, market.ConditionID, input.TokenID, order.Outcome)
+		if err != nil {
+			return err
+		}
+
+		remaining := 0.0
+		if order.Status == models.PaperOrderOpen {
+			remaining = input.Size - taken
+		}
+		if side == models.OrderSideBuy {
+			required := remaining * input.Price
+			for _, t := range takes {
+				required += t.Size * t.Price
+			}
+			if required > account.CashBalance+paperEpsilon {
+				return fmt.Errorf("%w: %.2f USDC required, %.2f available", ErrPaperInsufficientCash, required, account.CashBalance)
+			}
+		} else {
+			available := position.Size - position.ReservedSize
+			if input.Size > available+paperEpsilon {
+				return fmt.Errorf("%w: %.2f shares available to sell", ErrPaperInsufficientSize, math.Max(available, 0))
+			}
+		}
+
+		if err := tx.Create(order).Error; err != nil {
+			return fmt.Errorf("failed to create paper order: %w", err)
+		}
+		for _, t := range takes {
+			fill := applyPaperFill(account, position, order, t.Price, t.Size, models.PaperLiquidityTaker)
+			fills = append(fills, fill)
+		}
+		if remaining > 0 {
+			if side == models.OrderSideBuy {
+				account.CashBalance -= remaining * input.Price
+				account.ReservedCash += remaining * input.Price
+			} else {
+				position.ReservedSize += remaining
+			}
+		}
+
+		return savePaperState(tx, account, position, order, fills)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Depth is only used up once the fills are committed; a rolled back order takes nothing from the book.
+	s.useDepth(ctx, depthKey, takes)
+
+	if order.Status == models.PaperOrderOpen {
+		// Resting orders need live data for their token even outside the tracked active set.
+		s.markets.publishStreamRequest(ctx, []string{order.TokenID})
+		s.publishChange(ctx)
+	}
+	if fills == nil {
+		fills = []models.PaperFill{}
+	}
+	return &PaperOrderResult{Order: order, Fills: fills}, nil
+}
+
+func normalizePaperOrder(input *PaperOrderInput) error {
+	input.TokenID = strings.TrimSpace(input.TokenID)
+	input.Side = strings.ToUpper(strings.TrimSpace(input.Side))
+	input.OrderType = strings.ToUpper(strings.TrimSpace(input.OrderType))
+	if input.OrderType == "" {
+		input.OrderType = string(clob.OrderTypeGTC)
+	}
+
+	if input.TokenID == "" {
+		return fmt.Errorf("%w: tokenId is required", ErrInvalidPaperOrder)
+	}
+	if input.Side != string(models.OrderSideBuy) && input.Side != string(models.OrderSideSell) {
+		return fmt.Errorf("%w: side must be BUY or SELL", ErrInvalidPaperOrder)
+	}
+	if !paperSupportedOrderTypes[input.OrderType] {
+		return fmt.Errorf("%w: orderType must be GTC, GTD, FOK or FAK", ErrInvalidPaperOrder)
+	}
+	if input.Price <= 0 || input.Price >= 1 {
+		return fmt.Errorf("%w: price must be between 0 and 1", ErrInvalidPaperOrder)
+	}
+	if input.Size <= 0 {
+		return fmt.Errorf("%w: size must be positive", ErrInvalidPaperOrder)
+	}
+	if input.OrderType == string(clob.OrderTypeGTD) {
+		if input.ExpiresAt == nil || !input.ExpiresAt.After(time.Now().Add(time.Minute)) {
+			return fmt.Errorf("%w: GTD orders need an expiresAt at least a minute out", ErrInvalidPaperOrder)
+		}
+		expires := input.ExpiresAt.UTC()
+		input.ExpiresAt = &expires
+	} else {
+		input.ExpiresAt = nil
+	}
+	return nil
+}
+
+// paperTakerFills walks levels (best first) while they cross limit, up to size.
+func paperTakerFills(levels []depthOrderSummary, side models.OrderSide, limit, size float64) []depthOrderSummary {
+	var fills []depthOrderSummary
+	remaining := size
+	for _, lvl := range levels {
+		if remaining <= paperEpsilon {
+			break
+		}
+		if side == models.OrderSideBuy && lvl.Price > limit+paperEpsilon {
+			break
+		}
+		if side == models.OrderSideSell && lvl.Price < limit-paperEpsilon {
+			break
+		}
+		use := math.Min(lvl.Size, remaining)
+		fills = append(fills, depthOrderSummary{Price: lvl.Price, Size: use})
+		remaining -= use
+	}
+	return fills
+}
+
+// paperDepthKey identifies one side of a book snapshot for a user; a book update yields a new key.
+func paperDepthKey(userID uuid.UUID, tokenID, side string, levels []depthOrderSummary) string {
+	h := sha256.New()
+	for _, lvl := range levels {
+		fmt.Fprintf(h, "%s:%s;", paperLevelField(lvl.Price), strconv.FormatFloat(lvl.Size, 'f', -1, 64))
+	}
+	return fmt.Sprintf("paper:depth:%s:%s:%s:%x", userID, tokenID, side, h.Sum(nil)[:12])
+}
+
+func paperLevelField(price float64) string {
+	return strconv.FormatFloat(price, 'f', -1, 64)
+}
+
+// depthUsed returns the shares already taken per level of a snapshot. Redis errors count as nothing used.
+func (s *PaperTradingService) depthUsed(ctx context.Context, key string) map[string]float64 {
+	raw, err := s.redis.HGetAll(ctx, key).Result()
+	if err != nil {
+		logger.Error("PaperTradingService: Failed to read used depth: %v", err)
+		return nil
+	}
+	used := make(map[string]float64, len(raw))
+	for field, value := range raw {
+		if v, err := strconv.ParseFloat(value, 64); err == nil {
+			used[field] = v
+		}
+	}
+	return used
+}
+
+// useDepth records taker fills against their snapshot.
+func (s *PaperTradingService) useDepth(ctx context.Context, key string, takes []depthOrderSummary) {
+	if len(takes) == 0 {
+		return
+	}
+	pipe := s.redis.TxPipeline()
+	for _, t := range takes {
+		pipe.HIncrByFloat(ctx, key, paperLevelField(t.Price), t.Size)
+	}
+	pipe.Expire(ctx, key, paperDepthTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		logger.Error("PaperTradingService: Failed to record used depth: %v", err)
+	}
+}
+
+// paperRemainingDepth subtracts used shares from levels, dropping levels that are used up.
+func paperRemainingDepth(levels []depthOrderSummary, used map[string]float64) []depthOrderSummary {
+	if len(used) == 0 {
+		return levels
+	}
+	remaining := make([]depthOrderSummary, 0, len(levels))
+	for _, lvl := range levels {
+		size := lvl.Size - used[paperLevelField(lvl.Price)]
+		if size <= paperEpsilon {
+			continue
+		}
+		remaining = append(remaining, depthOrderSummary{Price: lvl.Price, Size: size})
+	}
+	return remaining
+}
+
+// paperLevelSize returns the visible size resting at price.
+func paperLevelSize(levels []depthOrderSummary, price float64) float64 {
+	for _, lvl := range levels {
+		if math.Abs(lvl.Price-price) < paperEpsilon {
+			return lvl.Size
+		}
+	}
+	return 0
+}
+
+// oppositeBookSide maps an order side to the sideLevels argument that returns its own side of the book.
+func oppositeBookSide(side string) string {
+	if side == string(models.OrderSideBuy) {
+		return string(models.OrderSideSell)
+	}
+	return string(models.OrderSideBuy)
+}
+
+// lockedPaperPosition loads the position FOR UPDATE, opening an empty one first if needed.
+func lockedPaperPosition(tx *gorm.DB, userID uuid.UUID, marketID, tokenID, outcome string) (*models.PaperPosition, error) {
+	opened := models.PaperPosition{
+		UserID:   userID,
+		MarketID: marketID,
+		TokenID:  tokenID,
+		Outcome:  outcome,
+	}
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "token_id"}},
+		DoNothing: true,
+	}).Create(&opened).Error; err != nil {
+		return nil, fmt.Errorf("failed to open paper position: %w", err)
+	}
+
+	var position models.PaperPosition
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND token_id = ?", userID, tokenID).
+		First(&position).Error; err != nil {
+		return nil, fmt.Errorf("failed to lock paper position: %w", err)
+	}
+	return &position, nil
+}
+
+// applyPaperFill moves cash and shares for one execution. Maker fills release the order's reservation first.
+func applyPaperFill(account *models.PaperAccount, position *models.PaperPosition, order *models.PaperOrder, price, size float64, liquidity models.PaperLiquidity) models.PaperFill {
+	if order.Side == models.OrderSideBuy {
+		if liquidity == models.PaperLiquidityMaker {
+			account.ReservedCash -= size * order.Price
+			account.CashBalance += size * order.Price
+		}
+		account.CashBalance -= size * price
+		total := position.Size + size
+		if total > 0 {
+			position.AvgPrice = (position.AvgPrice*position.Size + price*size) / total
+		}
+		position.Size = total
+	} else {
+		if liquidity == models.PaperLiquidityMaker {
+			position.ReservedSize -= size
+		}
+		realized := (price - position.AvgPrice) * size
+		position.Size -= size
+		position.RealizedPnL += realized
+		account.RealizedPnL += realized
+		account.CashBalance += size * price
+		if position.Size <= paperEpsilon {
+			position.Size = 0
+			position.AvgPrice = 0
+		}
+	}
+	// Float drift from repeated reservations must not leave dust behind.
+	if math.Abs(account.ReservedCash) < 1e-6 {
+		account.ReservedCash = 0
+	}
+	if math.Abs(position.ReservedSize) < paperEpsilon {
+		position.ReservedSize = 0
+	}
+
+	order.FilledSize += size
+	order.FilledNotional += size * price
+	return models.PaperFill{
+		OrderID:   order.ID,
+		UserID:    order.UserID,
+		MarketID:  order.MarketID,
+		TokenID:   order.TokenID,
+		Side:      order.Side,
+		Price:     price,
+		Size:      size,
+		Liquidity: liquidity,
+	}
+}
+
+func savePaperState(tx *gorm.DB, account *models.PaperAccount, position *models.PaperPosition, order *models.PaperOrder, fills []models.PaperFill) error {
+	if err := tx.Save(account).Error; err != nil {
+		return fmt.Errorf("failed to update paper account: %w", err)
+	}
+	if err := tx.Save(position).Error; err != nil {
+		return fmt.Errorf("failed to update paper position: %w", err)
+	}
+	if err := tx.Save(order).Error; err != nil {
+		return fmt.Errorf("failed to update paper order: %w", err)
+	}
+	if len(fills) > 0 {
+		if err := tx.Create(&fills).Error; err != nil {
+			return fmt.Errorf("failed to record paper fills: %w", err)
+		}
+	}
+	return nil
+}
+
+// ListOrders returns the user's paper orders, newest first. status filters when set.
+func (s *PaperTradingService) ListOrders(ctx context.Context, userID uuid.UUID, status string, limit, offset int) ([]models.PaperOrder, int64, error) {
+	query := s.db.WithContext(ctx).Model(&models.PaperOrder{}).Where("user_id = ?", userID)
+	if status = strings.ToUpper(strings.TrimSpace(status)); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count paper orders: %w", err)
+	}
+
+	orders := []models.PaperOrder{}
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&orders).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list paper orders: %w", err)
+	}
+	return orders, total, nil
+}
+
+// ListFills returns the user's simulated executions, newest first.
+func (s *PaperTradingService) ListFills(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.PaperFill, int64, error) {
+	query := s.db.WithContext(ctx).Model(&models.PaperFill{}).Where("user_id = ?", userID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count paper fills: %w", err)
+	}
+
+	fills := []models.PaperFill{}
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&fills).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list paper fills: %w", err)
+	}
+	return fills, total, nil
+}
+
+// CancelOrders cancels open paper orders by id, in the CLOB cancel response shape.
+func (s *PaperTradingService) CancelOrders(ctx context.Context, userID uuid.UUID, orderIDs []string) (*clob.CancelResponse, error) {
+	resp := &clob.CancelResponse{Canceled: []string{}, NotCanceled: map[string]string{}}
+
+	ids := make([]uuid.UUID, 0, len(orderIDs))
+	for _, raw := range orderIDs {
+		id, err := uuid.Parse(strings.TrimSpace(raw))
+		if err != nil {
+			resp.NotCanceled[raw] = "invalid order id"
+			continue
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return resp, nil
+	}
+
+	canceled, err := s.cancel(ctx, userID, func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("id IN ?", ids)
+	}, models.PaperOrderCanceled, "canceled by user")
+	if err != nil {
+		return nil, err
+	}
+
+	done := make(map[uuid.UUID]bool, len(canceled))
+	for _, order := range canceled {
+		done[order.ID] = true
+		resp.Canceled = append(resp.Canceled, order.ID.String())
+	}
+	for _, id := range ids {
+		if !done[id] {
+			resp.NotCanceled[id.String()] = ErrPaperOrderNotFound.Error()
+		}
+	}
+	return resp, nil
+}
+
+// CancelAll cancels every open paper order, optionally narrowed to a market and outcome token.
+func (s *PaperTradingService) CancelAll(ctx context.Context, userID uuid.UUID, marketID, tokenID string) (*BulkCancelResult, error) {
+	marketID = strings.TrimSpace(marketID)
+	tokenID = strings.TrimSpace(tokenID)
+
+	canceled, err := s.cancel(ctx, userID, func(tx *gorm.DB) *gorm.DB {
+		if marketID != "" {
+			tx = tx.Where("market_id = ?", marketID)
+		}
+		if tokenID != "" {
+			tx = tx.Where("token_id = ?", tokenID)
+		}
+		return tx
+	}, models.PaperOrderCanceled, "canceled by user")
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BulkCancelResult{
+		MarketID: marketID,
+		TokenID:  tokenID,
+		Canceled: len(canceled),
+		Orders:   make([]CancelOutcome, 0, len(canceled)),
+	}
+	for _, order := range canceled {
+		result.Orders = append(result.Orders, CancelOutcome{
+			OrderID:  order.ID.String(),
+			Canceled: true,
+			MarketID: order.MarketID,
+			Outcome:  order.Outcome,
+			Side:     string(order.Side),
+			Tracked:  true,
+		})
+	}
+	return result, nil
+}
+
+// cancel closes the user's open orders matched by scope and releases their reservations.
+func (s *PaperTradingService) cancel(ctx context.Context, userID uuid.UUID, scope func(*gorm.DB) *gorm.DB, status models.PaperOrderStatus, reason string) ([]models.PaperOrder, error) {
+	var canceled []models.PaperOrder
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		account, err := s.lockedAccount(tx, userID)
+		if err != nil {
+			return err
+		}
+
+		var orders []models.PaperOrder
+		if err := scope(tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND status IN ?", userID, paperCancelableStatuses)).
+			Order("created_at").
+			Find(&orders).Error; err != nil {
+			return fmt.Errorf("failed to load paper orders: %w", err)
+		}
+
+		now := time.Now().UTC()
+		for i := range orders {
+			order := &orders[i]
+			if err := releasePaperReservation(tx, account, order); err != nil {
+				return err
+			}
+			order.Status = status
+			order.StatusReason = reason
+			order.CompletedAt = &now
+			if err := tx.Save(order).Error; err != nil {
+				return fmt.Errorf("failed to update paper order: %w", err)
+			}
+		}
+		if err := tx.Save(account).Error; err != nil {
+			return fmt.Errorf("failed to update paper account: %w", err)
+		}
+		canceled = orders
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(canceled) > 0 {
+		s.publishChange(ctx)
+	}
+	return canceled, nil
+}
+
+func releasePaperReservation(tx *gorm.DB, account *models.PaperAccount, order *models.PaperOrder) error {
+	remaining := order.Remaining()
+	if remaining <= 0 {
+		return nil
+	}
+	if order.Side == models.OrderSideBuy {
+		account.ReservedCash -= remaining * order.Price
+		account.CashBalance += remaining * order.Price
+		if math.Abs(account.ReservedCash) < 1e-6 {
+			account.ReservedCash = 0
+		}
+		return nil
+	}
+
+	if err := tx.Model(&models.PaperPosition{}).
+		Where("user_id = ? AND token_id = ?", order.UserID, order.TokenID).
+		Update("reserved_size", gorm.Expr("GREATEST(reserved_size - ?, 0)", remaining)).Error; err != nil {
+		return fmt.Errorf("failed to release paper position: %w", err)
+	}
+	return nil
+}
+
+// Portfolio returns the account with positions marked to the live mid (or last trade).
+func (s *PaperTradingService) Portfolio(ctx context.Context, userID uuid.UUID) (*PaperPortfolio, error) {
+	account, err := s.GetAccount(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var positions []models.PaperPosition
+	if err := s.db.WithContext(ctx).
+		Where("user_id = ? AND (size > 0 OR realized_pnl <> 0)", userID).
+		Order("updated_at DESC").
+		Find(&positions).Error; err != nil {
+		return nil, fmt.Errorf("failed to load paper positions: %w", err)
+	}
+
+	var open int64
+	if err := s.db.WithContext(ctx).Model(&models.PaperOrder{}).
+		Where("user_id = ? AND status = ?", userID, models.PaperOrderOpen).
+		Count(&open).Error; err != nil {
+		return nil, fmt.Errorf("failed to count open paper orders: %w", err)
+	}
+
+	portfolio := &PaperPortfolio{
+		Account:    account,
+		Positions:  make([]PaperPositionView, 0, len(positions)),
+		OpenOrders: open,
+	}
+
+	marks := s.markPrices(ctx, positions)
+	for _, position := range positions {
+		mark, ok := marks[position.TokenID]
+		if !ok {
+			mark = position.AvgPrice
+		}
+		view := PaperPositionView{
+			PaperPosition: position,
+			MarkPrice:     mark,
+			MarketValue:   position.Size * mark,
+			UnrealizedPnL: position.Size * (mark - position.AvgPrice),
+		}
+		portfolio.PositionValue += view.MarketValue
+		portfolio.UnrealizedPnL += view.UnrealizedPnL
+		portfolio.Positions = append(portfolio.Positions, view)
+	}
+
+	portfolio.Equity = account.CashBalance + account.ReservedCash + portfolio.PositionValue
+	portfolio.TotalPnL = portfolio.Equity - account.StartingBalance
+	return portfolio, nil
+}
+
+// markPrices reads the cached RTDS price hash for each held token.
+func (s *PaperTradingService) markPrices(ctx context.Context, positions []models.PaperPosition) map[string]float64 {
+	marks := make(map[string]float64)
+	if len(positions) == 0 {
+		return marks
+	}
+
+	pipe := s.redis.Pipeline()
+	cmds := make(map[string]*redis.MapStringStringCmd)
+	for _, position := range positions {
+		if position.Size <= 0 {
+			continue
+		}
+		cmds[position.TokenID] = pipe.HGetAll(ctx, priceRedisKey(position.MarketID, position.TokenID))
+	}
+	if len(cmds) == 0 {
+		return marks
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		logger.Error("PaperTradingService: Failed to read mark prices: %v", err)
+	}
+
+	for tokenID, cmd := range cmds {
+		result, err := cmd.Result()
+		if err != nil || len(result) == 0 {
+			continue
+		}
+		bid := parseStringFloat(result["best_bid"])
+		ask := parseStringFloat(result["best_ask"])
+		switch {
+		case bid > 0 && ask > 0 && ask >= bid:
+			marks[tokenID] = (bid + ask) / 2
+		case parseStringFloat(result["last_trade_price"]) > 0:
+			marks[tokenID] = parseStringFloat(result["last_trade_price"])
+		}
+	}
+	return marks
+}
+
+func (s *PaperTradingService) publishChange(ctx context.Context) {
+	if err := s.redis.Publish(ctx, PaperOrdersChannel, "reload").Err(); err != nil {
+		logger.Error("PaperTradingService: Failed to publish change: %v", err)
+	}
+}
+
+// Run matches resting paper orders against live market data until ctx is cancelled. Run from the worker only.
+func (s *PaperTradingService) Run(ctx context.Context) {
+	sub := s.redis.Subscribe(ctx, PriceUpdateChannel, PaperOrdersChannel)
+	defer sub.Close()
+
+	s.maintain(ctx)
+
+	ticker := time.NewTicker(paperReloadInterval)
+	defer ticker.Stop()
+
+	messages := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.maintain(ctx)
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			if msg.Channel == PaperOrdersChannel {
+				s.reload(ctx)
+				continue
+			}
+			s.handlePriceUpdate(ctx, msg.Payload)
+		}
+	}
+}
+
+// maintain expires GTD orders past their expiry and reloads the resting set.
+func (s *PaperTradingService) maintain(ctx context.Context) {
+	var expired []models.PaperOrder
+	if err := s.db.WithContext(ctx).
+		Select("id, user_id").
+		Where("status = ? AND expires_at IS NOT NULL AND expires_at <= ?", models.PaperOrderOpen, time.Now().UTC()).
+		Find(&expired).Error; err != nil {
+		logger.Error("PaperTradingService: Failed to load expired orders: %v", err)
+	}
+	for _, order := range expired {
+		id := order.ID
+		if _, err := s.cancel(ctx, order.UserID, func(tx *gorm.DB) *gorm.DB {
+			return tx.Where("id = ?", id)
+		}, models.PaperOrderExpired, "GTD order expired"); err != nil {
+			logger.Error("PaperTradingService: Failed to expire order %s: %v", id, err)
+		}
+	}
+
+	s.reload(ctx)
+}
+
+func (s *PaperTradingService) reload(ctx context.Context) {
+	var orders []models.PaperOrder
+	if err := s.db.WithContext(ctx).
+		Select("id, user_id, token_id, side, price, size, filled_size, queue_ahead").
+		Where("status = ?", models.PaperOrderOpen).
+		Order("created_at").
+		Find(&orders).Error; err != nil {
+		logger.Error("PaperTradingService: Failed to load open orders: %v", err)
+		return
+	}
+
+	resting := make(map[string][]*restingPaperOrder)
+	for i := range orders {
+		order := &orders[i]
+		resting[order.TokenID] = append(resting[order.TokenID], &restingPaperOrder{
+			ID:         order.ID,
+			UserID:     order.UserID,
+			Side:       order.Side,
+			Price:      order.Price,
+			Remaining:  order.Remaining(),
+			QueueAhead: order.QueueAhead,
+		})
+	}
+
+	s.mu.Lock()
+	s.resting = resting
+	s.mu.Unlock()
+}
+
+func (s *PaperTradingService) handlePriceUpdate(ctx context.Context, payload string) {
+	var update paperPriceUpdate
+	if err := json.Unmarshal([]byte(payload), &update); err != nil || update.AssetID == "" {
+		return
+	}
+
+	s.mu.Lock()
+	orders := s.resting[update.AssetID]
+	if len(orders) == 0 {
+		s.mu.Unlock()
+		return
+	}
+
+	var matches []paperMatch
+	kept := orders[:0]
+	for _, order := range orders {
+		prevQueue := order.QueueAhead
+		fill := order.match(&update)
+		if fill > 0 || order.QueueAhead != prevQueue {
+			matches = append(matches, paperMatch{order: *order, fillSize: fill, queueAhead: order.QueueAhead})
+		}
+		order.Remaining -= fill
+		if order.Remaining > paperEpsilon {
+			kept = append(kept, order)
+		}
+	}
+	s.resting[update.AssetID] = kept
+	s.mu.Unlock()
+
+	for _, m := range matches {
+		if m.fillSize <= 0 {
+			if err := s.db.WithContext(ctx).Model(&models.PaperOrder{}).
+				Where("id = ? AND status = ?", m.order.ID, models.PaperOrderOpen).
+				UpdateColumn("queue_ahead", m.queueAhead).Error; err != nil {
+				logger.Error("PaperTradingService: Failed to update queue for %s: %v", m.order.ID, err)
+			}
+			continue
+		}
+		if err := s.fillResting(ctx, m); err != nil {
+			if !errors.Is(err, errPaperOrderNoLongerOpen) {
+				logger.Error("PaperTradingService: Failed to fill order %s: %v", m.order.ID, err)
+			}
+			s.reload(ctx)
+		}
+	}
+}
+
+// match applies one market data update to the order and returns the size it fills.
+func (o *restingPaperOrder) match(u *paperPriceUpdate) float64 {
+	buy := o.Side == models.OrderSideBuy
+
+	// A level update on our own side: cancels ahead of us shrink the queue, new size joins behind.
+	if u.Price != nil && u.Size != nil && models.OrderSide(strings.ToUpper(u.Side)) == o.Side &&
+		math.Abs(*u.Price-o.Price) < paperEpsilon {
+		o.QueueAhead = math.Min(o.QueueAhead, math.Max(*u.Size, 0))
+	}
+
+	// The opposite side crossed our price: everything ahead of us and our order traded.
+	if buy && u.BestAsk != nil && *u.BestAsk > 0 && *u.BestAsk <= o.Price+paperEpsilon {
+		o.QueueAhead = 0
+		return o.Remaining
+	}
+	if !buy && u.BestBid != nil && *u.BestBid > 0 && *u.BestBid >= o.Price-paperEpsilon {
+		o.QueueAhead = 0
+		return o.Remaining
+	}
+
+	if u.LastTradePrice == nil || *u.LastTradePrice <= 0 || u.LastTradeSize == nil || *u.LastTradeSize <= 0 {
+		return 0
+	}
+	price, size := *u.LastTradePrice, *u.LastTradeSize
+
+	// Traded through our level: the level, us included, was cleared.
+	if (buy && price < o.Price-paperEpsilon) || (!buy && price > o.Price+paperEpsilon) {
+		o.QueueAhead = 0
+		return math.Min(o.Remaining, size)
+	}
+	if math.Abs(price-o.Price) >= paperEpsilon {
+		return 0
+	}
+	// At our level only an aggressor from the other side consumes the queue.
+	if taker := models.OrderSide(strings.ToUpper(u.LastTradeSide)); taker == o.Side {
+		return 0
+	}
+	if size <= o.QueueAhead {
+		o.QueueAhead -= size
+		return 0
+	}
+	fill := math.Min(o.Remaining, size-o.QueueAhead)
+	o.QueueAhead = 0
+	return fill
+}
+
+// fillResting applies a maker fill to an open order at its limit price.
+func (s *PaperTradingService) fillResting(ctx context.Context, m paperMatch) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		account, err := s.lockedAccount(tx, m.order.UserID)
+		if err != nil {
+			return err
+		}
+
+		var order models.PaperOrder
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", m.order.ID).
+			First(&order).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errPaperOrderNoLongerOpen
+			}
+			return fmt.Errorf("failed to lock paper order: %w", err)
+		}
+		if order.Status != models.PaperOrderOpen {
+			return errPaperOrderNoLongerOpen
+		}
+
+		size := math.Min(m.fillSize, order.Remaining())
+		if size <= paperEpsilon {
+			return errPaperOrderNoLongerOpen
+		}
+
+		position, err := lockedPaperPosition(tx, order.UserID, order.MarketID, order.TokenID, order.Outcome)
+		if err != nil {
+			return err
+		}
+
+		fill := applyPaperFill(account, position, &order, order.Price, size, models.PaperLiquidityMaker)
+		order.QueueAhead = m.queueAhead
+		if order.Remaining() <= paperEpsilon {
+			now := time.Now().UTC()
+			order.Status = models.PaperOrderFilled
+			order.CompletedAt = &now
+		}
+		return savePaperState(tx, account, position, &order, []models.PaperFill{fill})
+	})
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestPaperTakerFills(t *testing.T) {
+	asks := []depthOrderSummary{{Price: 0.40, Size: 50}, {Price: 0.42, Size: 50}, {Price: 0.45, Size: 100}}
+	bids := []depthOrderSummary{{Price: 0.38, Size: 30}, {Price: 0.35, Size: 100}}
+
+	cases := []struct {
+		name   string
+		levels []depthOrderSummary
+		side   models.OrderSide
+		limit  float64
+		size   float64
+		want   []depthOrderSummary
+	}{
+		{"buy within first level", asks, models.OrderSideBuy, 0.45, 20, []depthOrderSummary{{0.40, 20}}},
+		{"buy stops at limit", asks, models.OrderSideBuy, 0.42, 500, []depthOrderSummary{{0.40, 50}, {0.42, 50}}},
+		{"buy below the book", asks, models.OrderSideBuy, 0.39, 10, nil},
+		{"sell sweeps to limit", bids, models.OrderSideSell, 0.35, 60, []depthOrderSummary{{0.38, 30}, {0.35, 30}}},
+		{"sell above the book", bids, models.OrderSideSell, 0.39, 10, nil},
+	}
+	for _, tc := range cases {
+		got := paperTakerFills(tc.levels, tc.side, tc.limit, tc.size)
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: fills = %v, want %v", tc.name, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if math.Abs(got[i].Price-tc.want[i].Price) > 1e-9 || math.Abs(got[i].Size-tc.want[i].Size) > 1e-9 {
+				t.Errorf("%s: fills = %v, want %v", tc.name, got, tc.want)
+				break
+			}
+		}
+	}
+}
+
+func TestPaperRemainingDepth(t *testing.T) {
+	asks := []depthOrderSummary{{Price: 0.40, Size: 50}, {Price: 0.42, Size: 50}, {Price: 0.45, Size: 100}}
+
+	// A first order takes 70 shares; a second against the same snapshot must not reuse them.
+	first := paperTakerFills(asks, models.OrderSideBuy, 0.45, 70)
+	used := map[string]float64{}
+	for _, f := range first {
+		used[paperLevelField(f.Price)] += f.Size
+	}
+	second := paperTakerFills(paperRemainingDepth(asks, used), models.OrderSideBuy, 0.45, 100)
+	want := []depthOrderSummary{{0.42, 30}, {0.45, 70}}
+	if len(second) != len(want) {
+		t.Fatalf("second fills = %v, want %v", second, want)
+	}
+	for i := range want {
+		if math.Abs(second[i].Price-want[i].Price) > 1e-9 || math.Abs(second[i].Size-want[i].Size) > 1e-9 {
+			t.Fatalf("second fills = %v, want %v", second, want)
+		}
+	}
+
+	if got := paperRemainingDepth(asks, nil); len(got) != len(asks) {
+		t.Errorf("no usage should leave the book untouched, got %v", got)
+	}
+
+	user := uuid.New()
+	key := paperDepthKey(user, "yes", "BUY", asks)
+	if key != paperDepthKey(user, "yes", "BUY", asks) {
+		t.Error("depth key should be stable for an unchanged snapshot")
+	}
+	changed := append([]depthOrderSummary{}, asks...)
+	changed[0].Size = 60
+	if key == paperDepthKey(user, "yes", "BUY", changed) || key == paperDepthKey(uuid.New(), "yes", "BUY", asks) {
+		t.Error("depth key should change with the book and the user")
+	}
+}
+
+func TestRestingPaperOrderMatch(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+
+	cases := []struct {
+		name      string
+		side      models.OrderSide
+		queue     float64
+		update    paperPriceUpdate
+		wantFill  float64
+		wantQueue float64
+	}{
+		{"own level shrinks the queue", models.OrderSideBuy, 100, paperPriceUpdate{Price: f(0.50), Size: f(60), Side: "BUY"}, 0, 60},
+		{"own level growth joins behind", models.OrderSideBuy, 100, paperPriceUpdate{Price: f(0.50), Size: f(300), Side: "BUY"}, 0, 100},
+		{"other level ignored", models.OrderSideBuy, 100, paperPriceUpdate{Price: f(0.49), Size: f(10), Side: "BUY"}, 0, 100},
+		{"ask crosses a bid", models.OrderSideBuy, 100, paperPriceUpdate{BestAsk: f(0.50)}, 40, 0},
+		{"bid crosses an ask", models.OrderSideSell, 100, paperPriceUpdate{BestBid: f(0.51)}, 40, 0},
+		{"ask above a bid", models.OrderSideBuy, 100, paperPriceUpdate{BestAsk: f(0.52)}, 0, 100},
+		{"trade through a bid", models.OrderSideBuy, 100, paperPriceUpdate{LastTradePrice: f(0.48), LastTradeSize: f(25), LastTradeSide: "SELL"}, 25, 0},
+		{"trade through an ask", models.OrderSideSell, 100, paperPriceUpdate{LastTradePrice: f(0.55), LastTradeSize: f(90), LastTradeSide: "BUY"}, 40, 0},
+		{"trade at level eats the queue", models.OrderSideBuy, 100, paperPriceUpdate{LastTradePrice: f(0.50), LastTradeSize: f(70), LastTradeSide: "SELL"}, 0, 30},
+		{"trade at level reaches us", models.OrderSideBuy, 100, paperPriceUpdate{LastTradePrice: f(0.50), LastTradeSize: f(120), LastTradeSide: "SELL"}, 20, 0},
+		{"same-side aggressor at level", models.OrderSideBuy, 0, paperPriceUpdate{LastTradePrice: f(0.50), LastTradeSize: f(120), LastTradeSide: "BUY"}, 0, 0},
+		{"trade away from level", models.OrderSideBuy, 0, paperPriceUpdate{LastTradePrice: f(0.53), LastTradeSize: f(120), LastTradeSide: "BUY"}, 0, 0},
+	}
+	for _, tc := range cases {
+		order := &restingPaperOrder{Side: tc.side, Price: 0.50, Remaining: 40, QueueAhead: tc.queue}
+		if got := order.match(&tc.update); math.Abs(got-tc.wantFill) > 1e-9 {
+			t.Errorf("%s: fill = %.2f, want %.2f", tc.name, got, tc.wantFill)
+		}
+		if math.Abs(order.QueueAhead-tc.wantQueue) > 1e-9 {
+			t.Errorf("%s: queue ahead = %.2f, want %.2f", tc.name, order.QueueAhead, tc.wantQueue)
+		}
+	}
+}
+
+func TestApplyPaperFill(t *testing.T) {
+	account := &models.PaperAccount{CashBalance: 1000, ReservedCash: 50}
+	position := &models.PaperPosition{}
+
+	// Resting BUY 100 @ 0.50 reserved 50 USDC; half fills as maker.
+	buy := &models.PaperOrder{Side: models.OrderSideBuy, Price: 0.50}
+	applyPaperFill(account, position, buy, 0.50, 50, models.PaperLiquidityMaker)
+	if account.CashBalance != 1000 || account.ReservedCash != 25 || position.Size != 50 || position.AvgPrice != 0.50 {
+		t.Fatalf("after maker buy: account %+v, position %+v", account, position)
+	}
+
+	// Taker BUY 50 @ 0.60 averages up.
+	taker := &models.PaperOrder{Side: models.OrderSideBuy, Price: 0.65}
+	applyPaperFill(account, position, taker, 0.60, 50, models.PaperLiquidityTaker)
+	if math.Abs(account.CashBalance-970) > 1e-9 || math.Abs(position.AvgPrice-0.55) > 1e-9 || position.Size != 100 {
+		t.Fatalf("after taker buy: account %+v, position %+v", account, position)
+	}
+
+	// SELL everything @ 0.70 realizes 15 and resets the position.
+	sell := &models.PaperOrder{Side: models.OrderSideSell, Price: 0.70}
+	fill := applyPaperFill(account, position, sell, 0.70, 100, models.PaperLiquidityTaker)
+	if math.Abs(account.RealizedPnL-15) > 1e-9 || math.Abs(account.CashBalance-1040) > 1e-9 || position.Size != 0 || position.AvgPrice != 0 {
+		t.Fatalf("after sell: account %+v, position %+v", account, position)
+	}
+	if fill.Size != 100 || fill.Price != 0.70 || sell.FilledSize != 100 || math.Abs(sell.FilledNotional-70) > 1e-9 {
+		t.Errorf("unexpected fill %+v / order %+v", fill, sell)
+	}
+}
@@ -0,0 +1,262 @@
+/**
+ * @description
+ * Position Reconciliation Service.
+ * Compares the Data API's view of a vault's positions with on-chain CTF balances, which update as soon as
+ * fills settle, and flags tokens where the two disagree.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - backend/internal/services (ProfileService, BlockchainService)
+ *
+ * @notes
+ * - Token IDs checked on chain: everything the Data API reports plus every token the user has ordered.
+ * - On-chain size is authoritative for the returned size; Data API fields (avg price, PnL) are kept as reported.
+ * - Sizes within positionReconcileTolerance shares count as matching (Data API sizes are rounded).
+ * - Without a blockchain service the Data API positions are returned unreconciled.
+ */
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/polymarket/data_api"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	positionReconcileTolerance   = 0.01
+	positionReconcilePageSize    = 500
+	positionReconcileOrderTokens = 1000
+)
+
+// Position reconciliation statuses
+const (
+	PositionStatusMatched        = "MATCHED"
+	PositionStatusSizeMismatch   = "SIZE_MISMATCH"
+	PositionStatusMissingDataAPI = "MISSING_FROM_DATA_API"
+	PositionStatusMissingOnChain = "MISSING_ON_CHAIN"
+	PositionStatusUnverified     = "UNVERIFIED"
+)
+
+// PositionReconcileService reconciles Data API positions against on-chain balances
+type PositionReconcileService struct {
+	db         *gorm.DB
+	profiles   *ProfileService
+	blockchain *BlockchainService
+}
+
+// NewPositionReconcileService creates a new PositionReconcileService
+func NewPositionReconcileService(db *gorm.DB, profiles *ProfileService, blockchain *BlockchainService) *PositionReconcileService {
+	return &PositionReconcileService{
+		db:         db,
+		profiles:   profiles,
+		blockchain: blockchain,
+	}
+}
+
+// ReconciledPosition is one token position with both sources side by side
+type ReconciledPosition struct {
+	TokenID     string             `json:"tokenId"`
+	ConditionID string             `json:"conditionId"`
+	Title       string             `json:"title"`
+	Outcome     string             `json:"outcome"`
+	Size        float64            `json:"size"` // On-chain when available, otherwise Data API
+	DataAPISize float64            `json:"dataApiSize"`
+	OnChainSize *float64           `json:"onChainSize"`
+	Difference  float64            `json:"difference"` // On-chain minus Data API
+	Status      string             `json:"status"`
+	Position    *data_api.Position `json:"position,omitempty"` // Data API row, when reported
+}
+
+// ReconciledPortfolio is the user's positions with discrepancy counts
+type ReconciledPortfolio struct {
+	VaultAddress  string               `json:"vaultAddress"`
+	OnChain       bool                 `json:"onChain"` // False when balances could not be read
+	Positions     []ReconciledPosition `json:"positions"`
+	Discrepancies int                  `json:"discrepancies"`
+}
+
+// Reconcile returns the user's positions reconciled against on-chain CTF balances.
+func (s *PositionReconcileService) Reconcile(ctx context.Context, user *models.User) (*ReconciledPortfolio, error) {
+	portfolio := &ReconciledPortfolio{
+		VaultAddress: user.VaultAddress,
+		Positions:    []ReconciledPosition{},
+	}
+	if user.VaultAddress == "" {
+		return portfolio, nil
+	}
+
+	reported, err := s.dataAPIPositions(ctx, user.VaultAddress)
+	if err != nil {
+		return nil, err
+	}
+	byToken := make(map[string]*ReconciledPosition)
+	tokenIDs := make([]string, 0, len(reported))
+	for i := range reported {
+		p := &reported[i]
+		tokenID := p.Asset
+		if tokenID == "" {
+			tokenID = p.TokenID
+		}
+		if tokenID == "" {
+			continue
+		}
+		if existing, ok := byToken[tokenID]; ok {
+			existing.DataAPISize += p.Size
+			continue
+		}
+		byToken[tokenID] = &ReconciledPosition{
+			TokenID:     tokenID,
+			ConditionID: p.ConditionID,
+			Title:       p.Title,
+			Outcome:     p.Outcome,
+			DataAPISize: p.Size,
+			Position:    p,
+		}
+		tokenIDs = append(tokenIDs, tokenID)
+	}
+
+	ordered, err := s.orderedTokens(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, tokenID := range ordered {
+		if _, ok := byToken[tokenID]; !ok {
+			tokenIDs = append(tokenIDs, tokenID)
+		}
+	}
+
+	var balances map[string]*big.Int
+	if s.blockchain != nil && len(tokenIDs) > 0 {
+		balances, err = s.blockchain.GetConditionalTokenBalances(ctx, user.VaultAddress, tokenIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read on-chain balances: %w", err)
+		}
+		portfolio.OnChain = true
+	}
+
+	missingMeta := make([]string, 0)
+	for _, tokenID := range tokenIDs {
+		pos, reportedByAPI := byToken[tokenID]
+		if !reportedByAPI {
+			pos = &ReconciledPosition{TokenID: tokenID}
+		}
+
+		if !portfolio.OnChain {
+			pos.Size = pos.DataAPISize
+			pos.Status = PositionStatusUnverified
+		} else {
+			onChain := 0.0
+			if units := balances[tokenID]; units != nil {
+				onChain, _ = new(big.Float).Quo(new(big.Float).SetInt(units), big.NewFloat(ctfShareDecimalsFactor)).Float64()
+			}
+			if !reportedByAPI && onChain == 0 {
+				continue
+			}
+			pos.OnChainSize = &onChain
+			pos.Size = onChain
+			pos.Difference = math.Round((onChain-pos.DataAPISize)*1e6) / 1e6
+			pos.Status = reconcileStatus(pos.DataAPISize, onChain)
+		}
+
+		if pos.Status != PositionStatusMatched && pos.Status != PositionStatusUnverified {
+			portfolio.Discrepancies++
+		}
+		if pos.ConditionID == "" {
+			missingMeta = append(missingMeta, tokenID)
+		}
+		portfolio.Positions = append(portfolio.Positions, *pos)
+	}
+
+	if err := s.attachMarketMetadata(ctx, portfolio.Positions, missingMeta); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(portfolio.Positions, func(i, j int) bool {
+		a, b := portfolio.Positions[i], portfolio.Positions[j]
+		if (a.Status == PositionStatusMatched) != (b.Status == PositionStatusMatched) {
+			return a.Status != PositionStatusMatched
+		}
+		return a.Size > b.Size
+	})
+	return portfolio, nil
+}
+
+// dataAPIPositions pages through every open position the Data API reports for the vault.
+func (s *PositionReconcileService) dataAPIPositions(ctx context.Context, vault string) ([]data_api.Position, error) {
+	var all []data_api.Position
+	for offset := 0; ; offset += positionReconcilePageSize {
+		page, err := s.profiles.GetOpenPositions(ctx, vault, positionReconcilePageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load positions: %w", err)
+		}
+		all = append(all, page...)
+		if len(page) < positionReconcilePageSize {
+			return all, nil
+		}
+	}
+}
+
+// orderedTokens returns the tokens the user has ordered, most recent first.
+func (s *PositionReconcileService) orderedTokens(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	var tokenIDs []string
+	if err := s.db.WithContext(ctx).Model(&models.Order{}).
+		Select("outcome_token_id").
+		Where("user_id = ? AND outcome_token_id <> ''", userID).
+		Group("outcome_token_id").
+		Order("MAX(created_at) DESC").
+		Limit(positionReconcileOrderTokens).
+		Pluck("outcome_token_id", &tokenIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to load ordered tokens: %w", err)
+	}
+	return tokenIDs, nil
+}
+
+// attachMarketMetadata fills condition, title and outcome for tokens the Data API did not report.
+func (s *PositionReconcileService) attachMarketMetadata(ctx context.Context, positions []ReconciledPosition, tokenIDs []string) error {
+	if len(tokenIDs) == 0 {
+		return nil
+	}
+	var markets []models.Market
+	if err := s.db.WithContext(ctx).
+		Where("token_id_yes IN ? OR token_id_no IN ?", tokenIDs, tokenIDs).
+		Find(&markets).Error; err != nil {
+		return fmt.Errorf("failed to load markets: %w", err)
+	}
+	byToken := make(map[string]*models.Market, len(markets)*2)
+	for i := range markets {
+		byToken[markets[i].TokenIDYes] = &markets[i]
+		byToken[markets[i].TokenIDNo] = &markets[i]
+	}
+	for i := range positions {
+		if positions[i].ConditionID != "" {
+			continue
+		}
+		if market, ok := byToken[positions[i].TokenID]; ok {
+			positions[i].ConditionID = market.ConditionID
+			positions[i].Title = market.Title
+			positions[i].Outcome = journalTokenOutcome(market, positions[i].TokenID)
+		}
+	}
+	return nil
+}
+
+func reconcileStatus(dataAPISize, onChainSize float64) string {
+	switch {
+	case math.Abs(onChainSize-dataAPISize) <= positionReconcileTolerance:
+		return PositionStatusMatched
+	case dataAPISize <= positionReconcileTolerance:
+		return PositionStatusMissingDataAPI
+	case onChainSize <= positionReconcileTolerance:
+		return PositionStatusMissingOnChain
+	default:
+		return PositionStatusSizeMismatch
+	}
+}
@@ -0,0 +1,222 @@
+/**
+ * @description
+ * Proxy Wallet Service.
+ * Deploys Polymarket proxy wallets (PROXY type) through the relayer. The proxy factory creates the wallet on
+ * its first relayed call, so a deployment is a single signed batch of the trading approvals: one signature
+ * both deploys the proxy and makes it ready to trade.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - github.com/redis/go-redis/v9
+ * - backend/internal/polymarket/relayer
+ *
+ * @notes
+ * - Plans live in Redis for proxyPlanTTL, bound to the user, and are consumed on submit.
+ * - The relay node and nonce come from the relayer's relay-payload endpoint and are part of the signed hash.
+ * - The vault address is the CREATE2-derived proxy, so it is stored as soon as the relayer accepts the batch.
+ */
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/polymarket/relayer"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+const proxyPlanTTL = 10 * time.Minute
+
+var (
+	ErrInvalidProxyRequest = errors.New("invalid proxy wallet request")
+	ErrProxyPlanNotFound   = errors.New("proxy wallet plan not found or expired")
+	ErrProxyVaultExists    = errors.New("user already has a vault")
+)
+
+// ProxyWalletService deploys Polymarket proxy wallets via the relayer
+type ProxyWalletService struct {
+	db      *gorm.DB
+	redis   *redis.Client
+	relayer *relayer.Client
+
+	Transactions *RelayerTransactionService // Optional history of relayed transactions
+}
+
+// NewProxyWalletService creates a new ProxyWalletService
+func NewProxyWalletService(db *gorm.DB, rdb *redis.Client, relayerClient *relayer.Client) *ProxyWalletService {
+	return &ProxyWalletService{
+		db:      db,
+		redis:   rdb,
+		relayer: relayerClient,
+	}
+}
+
+// ProxyDeploymentPlan is a prepared, unsigned proxy deployment
+type ProxyDeploymentPlan struct {
+	ID          string                   `json:"id"`
+	Proxy       string                   `json:"proxy"` // Derived proxy wallet address
+	Signer      string                   `json:"signer"`
+	Approvals   []relayer.TokenApproval  `json:"approvals"`
+	Transaction relayer.ProxyTransaction `json:"transaction"`
+	StructHash  string                   `json:"structHash"` // personal_sign this hash
+	ExpiresAt   time.Time                `json:"expiresAt"`
+}
+
+// ProxyDeployment is the relayer's answer to a submitted deployment
+type ProxyDeployment struct {
+	TransactionID   string `json:"transactionId"`
+	TransactionHash string `json:"transactionHash,omitempty"`
+	State           string `json:"state"`
+	Proxy           string `json:"proxy"`
+}
+
+// storedProxyPlan is the Redis copy of a plan, bound to its user.
+type storedProxyPlan struct {
+	UserID      uuid.UUID                `json:"user_id"`
+	Signer      string                   `json:"signer"`
+	Proxy       string                   `json:"proxy"`
+	Transaction relayer.ProxyTransaction `json:"transaction"`
+	StructHash  string                   `json:"struct_hash"`
+}
+
+// PrepareDeployment builds the proxy factory batch that deploys the user's proxy and sets the trading approvals.
+func (s *ProxyWalletService) PrepareDeployment(ctx context.Context, user *models.User) (*ProxyDeploymentPlan, error) {
+	if user.EOAAddress == "" {
+		return nil, fmt.Errorf("%w: no connected signer wallet", ErrInvalidProxyRequest)
+	}
+	if user.VaultAddress != "" {
+		return nil, ErrProxyVaultExists
+	}
+	proxy, err := relayer.DeriveProxyAddress(user.EOAAddress)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProxyRequest, err)
+	}
+
+	approvals := relayer.RequiredTradingApprovals()
+	calls := make([]relayer.SafeTransaction, 0, len(approvals))
+	for _, approval := range approvals {
+		call, err := relayer.BuildApprovalTransaction(approval)
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, call)
+	}
+	data, err := relayer.EncodeProxyCalls(calls)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := s.relayer.GetRelayPayload(ctx, user.EOAAddress, relayer.TransactionTypeProxy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch relay payload: %w", err)
+	}
+	tx := relayer.ProxyTransaction{
+		To:       relayer.ProxyFactoryAddress,
+		Data:     data,
+		Nonce:    payload.Nonce.String(),
+		GasLimit: relayer.DefaultProxyGasLimit,
+		Relay:    payload.Address,
+		RelayHub: relayer.RelayHubAddress,
+	}
+	hash, err := relayer.BuildProxyStructHash(user.EOAAddress, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &ProxyDeploymentPlan{
+		ID:          uuid.NewString(),
+		Proxy:       proxy,
+		Signer:      user.EOAAddress,
+		Approvals:   approvals,
+		Transaction: tx,
+		StructHash:  hash,
+		ExpiresAt:   time.Now().Add(proxyPlanTTL).UTC(),
+	}
+	stored, err := json.Marshal(storedProxyPlan{
+		UserID:      user.ID,
+		Signer:      plan.Signer,
+		Proxy:       proxy,
+		Transaction: tx,
+		StructHash:  hash,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode proxy plan: %w", err)
+	}
+	if err := s.redis.Set(ctx, proxyPlanKey(plan.ID), stored, proxyPlanTTL).Err(); err != nil {
+		return nil, fmt.Errorf("failed to store proxy plan: %w", err)
+	}
+	return plan, nil
+}
+
+// SubmitDeployment relays a prepared deployment signed by the user's EOA and links the proxy as their vault.
+func (s *ProxyWalletService) SubmitDeployment(ctx context.Context, user *models.User, planID, signature string) (*ProxyDeployment, error) {
+	raw, err := s.redis.GetDel(ctx, proxyPlanKey(planID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrProxyPlanNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load proxy plan: %w", err)
+	}
+
+	var plan storedProxyPlan
+	if err := json.Unmarshal(raw, &plan); err != nil {
+		return nil, fmt.Errorf("failed to decode proxy plan: %w", err)
+	}
+	if plan.UserID != user.ID {
+		return nil, ErrProxyPlanNotFound
+	}
+
+	signer, err := relayer.RecoverSafeTxSigner(plan.StructHash, signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProxyRequest, err)
+	}
+	if !strings.EqualFold(signer, plan.Signer) {
+		return nil, fmt.Errorf("%w: signature is from %s, expected %s", ErrInvalidProxyRequest, signer, plan.Signer)
+	}
+
+	req, err := relayer.BuildProxyTransactionRequest(plan.Signer, plan.Transaction, signature, "deploy")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProxyRequest, err)
+	}
+	resp, err := s.relayer.SubmitProxyTransaction(ctx, req)
+	if s.Transactions != nil {
+		if _, recErr := s.Transactions.Record(ctx, user.ID, models.RelayerTransactionDeploy, req, plan.StructHash, resp, err); recErr != nil {
+			logger.Error("ProxyWalletService: %v", recErr)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	wType := models.WalletTypeProxy
+	if err := s.db.WithContext(ctx).Model(&models.User{}).
+		Where("id = ? AND (vault_address IS NULL OR vault_address = '')", user.ID).
+		Updates(map[string]interface{}{
+			"vault_address": plan.Proxy,
+			"wallet_type":   wType,
+			"updated_at":    time.Now(),
+		}).Error; err != nil {
+		logger.Error("ProxyWalletService: Failed to link proxy %s to user %s: %v", plan.Proxy, user.ID, err)
+	}
+
+	logger.Info("ProxyWalletService: Relayed proxy deployment %s for user %s (relayer tx %s)", plan.Proxy, user.ID, resp.ID())
+	return &ProxyDeployment{
+		TransactionID:   resp.ID(),
+		TransactionHash: resp.TransactionHash,
+		State:           resp.State,
+		Proxy:           plan.Proxy,
+	}, nil
+}
+
+func proxyPlanKey(id string) string {
+	return fmt.Sprintf("proxy:plan:%s", id)
+}
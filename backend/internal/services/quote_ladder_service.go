@@ -0,0 +1,489 @@
+/**
+ * @description
+ * Quote Ladder Service.
+ * Computes a market maker's desired quote ladder around the live midpoint with inventory skew, diffs it against
+ * the user's resting orders and returns the exact cancels and new order requests needed to reach it.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - backend/internal/services (MarketService, ProfileService)
+ *
+ * @notes
+ * - The server cannot sign, so new quotes come back as unsigned GTC order requests for the SDK to sign and post;
+ *   cancels reference CLOB order IDs for the existing cancel endpoints.
+ * - Skew: with inventory q and max inventory Q, the ladder centre moves down by skew * q / Q and bid sizes shrink
+ *   by the same ratio, so a long book leans towards selling. Bids stop entirely at max inventory.
+ * - Asks are sized from the held inventory (outcome tokens cannot be shorted); levels without inventory are dropped.
+ * - Quotes never cross the book: bids stay below the best ask and asks above the best bid.
+ * - An existing order is kept when it sits at a desired price on the same side and its remaining size is within
+ *   size_tolerance of the desired size; everything else is cancelled and re-quoted.
+ * - Dry runs only compute; applied runs are recorded in quote_adjustments.
+ */
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/polymarket/clob"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	quoteLadderMaxLevels        = 10
+	quoteLadderDefaultTolerance = 0.10
+	quoteLadderDefaultTick      = 0.01
+	quoteLadderPositionsLimit   = 500
+)
+
+var ErrInvalidQuoteLadder = errors.New("invalid quote ladder request")
+
+// QuoteLadderService plans market-maker quote ladders
+type QuoteLadderService struct {
+	db       *gorm.DB
+	markets  *MarketService
+	profiles *ProfileService
+}
+
+// NewQuoteLadderService creates a new QuoteLadderService
+func NewQuoteLadderService(db *gorm.DB, markets *MarketService, profiles *ProfileService) *QuoteLadderService {
+	return &QuoteLadderService{
+		db:       db,
+		markets:  markets,
+		profiles: profiles,
+	}
+}
+
+// QuoteLadderParams are the market maker's quoting parameters. Prices are in probability units (0-1).
+type QuoteLadderParams struct {
+	MarketID      string   `json:"marketId"`
+	TokenID       string   `json:"tokenId"`
+	Spread        float64  `json:"spread"`        // Distance between the innermost bid and ask
+	Size          float64  `json:"size"`          // Shares per level
+	Levels        int      `json:"levels"`        // Levels per side
+	LevelStep     float64  `json:"levelStep"`     // Distance between levels; defaults to the tick size
+	MaxInventory  float64  `json:"maxInventory"`  // Shares
+	Skew          *float64 `json:"skew"`          // Centre shift at max inventory; defaults to half the spread
+	Inventory     *float64 `json:"inventory"`     // Overrides the position read from the data API
+	SizeTolerance float64  `json:"sizeTolerance"` // Relative size difference tolerated when keeping an order
+	DryRun        bool     `json:"dryRun"`
+}
+
+// QuoteLevel is one desired quote
+type QuoteLevel struct {
+	Side  string  `json:"side"`
+	Level int     `json:"level"` // 0 = innermost
+	Price float64 `json:"price"`
+	Size  float64 `json:"size"`
+}
+
+// QuoteCancel is a resting order to cancel
+type QuoteCancel struct {
+	OrderID     uuid.UUID `json:"orderId"`
+	CLOBOrderID string    `json:"clobOrderId"`
+	Side        string    `json:"side"`
+	Price       float64   `json:"price"`
+	Size        float64   `json:"size"` // Remaining
+	Reason      string    `json:"reason"`
+}
+
+// QuoteOrderRequest is a new order for the client to sign and post
+type QuoteOrderRequest struct {
+	TokenID   string         `json:"tokenId"`
+	Side      string         `json:"side"`
+	Price     float64        `json:"price"`
+	Size      float64        `json:"size"`
+	OrderType clob.OrderType `json:"orderType"`
+	TickSize  float64        `json:"tickSize"`
+	NegRisk   bool           `json:"negRisk"`
+}
+
+// QuoteLadderPlan is the diff between the desired ladder and the user's resting orders
+type QuoteLadderPlan struct {
+	AdjustmentID *uuid.UUID          `json:"adjustmentId,omitempty"`
+	DryRun       bool                `json:"dryRun"`
+	MarketID     string              `json:"marketId"`
+	TokenID      string              `json:"tokenId"`
+	Midpoint     float64             `json:"midpoint"`
+	BestBid      float64             `json:"bestBid"`
+	BestAsk      float64             `json:"bestAsk"`
+	TickSize     float64             `json:"tickSize"`
+	Inventory    float64             `json:"inventory"`
+	Skew         float64             `json:"skew"` // Applied centre shift
+	Centre       float64             `json:"centre"`
+	Desired      []QuoteLevel        `json:"desired"`
+	Keep         []QuoteCancel       `json:"keep"`
+	Cancels      []QuoteCancel       `json:"cancels"`
+	Places       []QuoteOrderRequest `json:"places"`
+}
+
+// QuoteAdjustmentView is a recorded adjustment with its JSON columns decoded
+type QuoteAdjustmentView struct {
+	ID        uuid.UUID       `json:"id"`
+	MarketID  string          `json:"marketId"`
+	TokenID   string          `json:"tokenId"`
+	Params    json.RawMessage `json:"params"`
+	Midpoint  float64         `json:"midpoint"`
+	Inventory float64         `json:"inventory"`
+	Skew      float64         `json:"skew"`
+	Desired   json.RawMessage `json:"desired"`
+	Cancels   json.RawMessage `json:"cancels"`
+	Places    json.RawMessage `json:"places"`
+	Kept      int             `json:"kept"`
+	CreatedAt string          `json:"createdAt"`
+}
+
+// PlanLadder computes the ladder for params and the order changes needed to reach it.
+func (s *QuoteLadderService) PlanLadder(ctx context.Context, user *models.User, params QuoteLadderParams) (*QuoteLadderPlan, error) {
+	if err := normalizeQuoteLadderParams(&params); err != nil {
+		return nil, err
+	}
+
+	market, err := s.markets.GetMarketByConditionID(ctx, params.MarketID)
+	if err != nil {
+		return nil, err
+	}
+	if market == nil {
+		return nil, fmt.Errorf("%w: market not found", ErrInvalidQuoteLadder)
+	}
+	if params.TokenID != market.TokenIDYes && params.TokenID != market.TokenIDNo {
+		return nil, fmt.Errorf("%w: token does not belong to market", ErrInvalidQuoteLadder)
+	}
+	if market.Closed || !market.AcceptingOrders {
+		return nil, fmt.Errorf("%w: market is not accepting orders", ErrInvalidQuoteLadder)
+	}
+
+	book, err := s.markets.loadOrderBookSnapshot(ctx, market.ConditionID, params.TokenID)
+	if err != nil {
+		return nil, err
+	}
+	bids := book.sideLevels("SELL")
+	asks := book.sideLevels("BUY")
+	if len(bids) == 0 || len(asks) == 0 {
+		return nil, fmt.Errorf("%w: book needs both bids and asks to find a midpoint", ErrInvalidQuoteLadder)
+	}
+
+	inventory, err := s.inventory(ctx, user, params)
+	if err != nil {
+		return nil, err
+	}
+
+	tick := market.OrderPriceMinTickSize
+	if tick <= 0 {
+		tick = quoteLadderDefaultTick
+	}
+	plan := &QuoteLadderPlan{
+		DryRun:    params.DryRun,
+		MarketID:  market.ConditionID,
+		TokenID:   params.TokenID,
+		BestBid:   bids[0].Price,
+		BestAsk:   asks[0].Price,
+		Midpoint:  (bids[0].Price + asks[0].Price) / 2,
+		TickSize:  tick,
+		Inventory: inventory,
+		Keep:      []QuoteCancel{},
+		Cancels:   []QuoteCancel{},
+		Places:    []QuoteOrderRequest{},
+	}
+	plan.Desired, plan.Skew, plan.Centre = buildQuoteLadder(params, plan.Midpoint, plan.BestBid, plan.BestAsk, tick, inventory, market.OrderMinSize)
+
+	var open []models.Order
+	if err := s.db.WithContext(ctx).
+		Where("user_id = ? AND outcome_token_id = ? AND status = ?", user.ID, params.TokenID, models.OrderStatusOpen).
+		Order("created_at ASC").
+		Find(&open).Error; err != nil {
+		return nil, fmt.Errorf("failed to load open orders: %w", err)
+	}
+	diffQuoteLadder(plan, open, params.SizeTolerance, market.NegRisk)
+
+	if params.DryRun {
+		return plan, nil
+	}
+
+	adjustment, err := s.record(ctx, user.ID, params, plan)
+	if err != nil {
+		return nil, err
+	}
+	plan.AdjustmentID = &adjustment.ID
+	return plan, nil
+}
+
+// ListAdjustments returns the user's recorded ladder adjustments, newest first.
+func (s *QuoteLadderService) ListAdjustments(ctx context.Context, userID uuid.UUID, tokenID string, limit, offset int) ([]QuoteAdjustmentView, int64, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 200 {
+		limit = 200
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	query := s.db.WithContext(ctx).Model(&models.QuoteAdjustment{}).Where("user_id = ?", userID)
+	if tokenID != "" {
+		query = query.Where("token_id = ?", tokenID)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count quote adjustments: %w", err)
+	}
+	var rows []models.QuoteAdjustment
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&rows).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to load quote adjustments: %w", err)
+	}
+
+	views := make([]QuoteAdjustmentView, 0, len(rows))
+	for _, row := range rows {
+		views = append(views, QuoteAdjustmentView{
+			ID:        row.ID,
+			MarketID:  row.MarketID,
+			TokenID:   row.TokenID,
+			Params:    json.RawMessage(row.Params),
+			Midpoint:  row.Midpoint,
+			Inventory: row.Inventory,
+			Skew:      row.Skew,
+			Desired:   json.RawMessage(row.Desired),
+			Cancels:   json.RawMessage(row.Cancels),
+			Places:    json.RawMessage(row.Places),
+			Kept:      row.Kept,
+			CreatedAt: row.CreatedAt.UTC().Format("2006-01-02T15:04:05Z07:00"),
+		})
+	}
+	return views, total, nil
+}
+
+func normalizeQuoteLadderParams(p *QuoteLadderParams) error {
+	p.MarketID = strings.TrimSpace(p.MarketID)
+	p.TokenID = strings.TrimSpace(p.TokenID)
+	switch {
+	case p.MarketID == "" || p.TokenID == "":
+		return fmt.Errorf("%w: marketId and tokenId are required", ErrInvalidQuoteLadder)
+	case p.Spread <= 0 || p.Spread >= 1:
+		return fmt.Errorf("%w: spread must be between 0 and 1", ErrInvalidQuoteLadder)
+	case p.Size <= 0:
+		return fmt.Errorf("%w: size must be positive", ErrInvalidQuoteLadder)
+	case p.MaxInventory <= 0:
+		return fmt.Errorf("%w: maxInventory must be positive", ErrInvalidQuoteLadder)
+	case p.LevelStep < 0:
+		return fmt.Errorf("%w: levelStep cannot be negative", ErrInvalidQuoteLadder)
+	case p.Inventory != nil && *p.Inventory < 0:
+		return fmt.Errorf("%w: inventory cannot be negative", ErrInvalidQuoteLadder)
+	case p.Skew != nil && *p.Skew < 0:
+		return fmt.Errorf("%w: skew cannot be negative", ErrInvalidQuoteLadder)
+	}
+	if p.Levels <= 0 {
+		p.Levels = 1
+	}
+	if p.Levels > quoteLadderMaxLevels {
+		return fmt.Errorf("%w: at most %d levels per side", ErrInvalidQuoteLadder, quoteLadderMaxLevels)
+	}
+	if p.SizeTolerance <= 0 {
+		p.SizeTolerance = quoteLadderDefaultTolerance
+	}
+	return nil
+}
+
+// inventory returns the user's position in the token, preferring an explicit override.
+func (s *QuoteLadderService) inventory(ctx context.Context, user *models.User, params QuoteLadderParams) (float64, error) {
+	if params.Inventory != nil {
+		return *params.Inventory, nil
+	}
+	if user.VaultAddress == "" || s.profiles == nil {
+		return 0, nil
+	}
+	positions, err := s.profiles.GetOpenPositions(ctx, user.VaultAddress, quoteLadderPositionsLimit, 0)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load positions: %w", err)
+	}
+	for _, p := range positions {
+		if p.Asset == params.TokenID || p.TokenID == params.TokenID {
+			return p.Size, nil
+		}
+	}
+	return 0, nil
+}
+
+// buildQuoteLadder lays out bids and asks around the skewed centre. Returns the levels, the applied
+// skew and the centre price.
+func buildQuoteLadder(p QuoteLadderParams, mid, bestBid, bestAsk, tick, inventory, minSize float64) ([]QuoteLevel, float64, float64) {
+	step := p.LevelStep
+	if step <= 0 {
+		step = tick
+	}
+	maxSkew := p.Spread / 2
+	if p.Skew != nil {
+		maxSkew = *p.Skew
+	}
+	ratio := math.Min(inventory/p.MaxInventory, 1)
+	skew := maxSkew * ratio
+	centre := mid - skew
+
+	levels := []QuoteLevel{}
+	bidSize := roundQuoteSize(p.Size * (1 - ratio))
+	for i := 0; i < p.Levels && bidSize > 0; i++ {
+		price := floorToTick(centre-p.Spread/2-float64(i)*step, tick)
+		if price >= bestAsk {
+			price = floorToTick(bestAsk-tick, tick)
+		}
+		if price < tick || bidSize < minSize {
+			break
+		}
+		levels = append(levels, QuoteLevel{Side: string(models.OrderSideBuy), Level: i, Price: price, Size: bidSize})
+	}
+
+	available := inventory
+	for i := 0; i < p.Levels && available > 0; i++ {
+		price := ceilToTick(centre+p.Spread/2+float64(i)*step, tick)
+		if price <= bestBid {
+			price = ceilToTick(bestBid+tick, tick)
+		}
+		size := roundQuoteSize(math.Min(p.Size, available))
+		if price > 1-tick || size <= 0 || size < minSize {
+			break
+		}
+		levels = append(levels, QuoteLevel{Side: string(models.OrderSideSell), Level: i, Price: price, Size: size})
+		available -= size
+	}
+	return dedupeQuoteLevels(levels), skew, centre
+}
+
+// dedupeQuoteLevels merges levels that rounded onto the same price.
+func dedupeQuoteLevels(levels []QuoteLevel) []QuoteLevel {
+	out := make([]QuoteLevel, 0, len(levels))
+	index := make(map[string]int)
+	for _, lvl := range levels {
+		key := fmt.Sprintf("%s:%.4f", lvl.Side, lvl.Price)
+		if i, ok := index[key]; ok {
+			out[i].Size = roundQuoteSize(out[i].Size + lvl.Size)
+			continue
+		}
+		index[key] = len(out)
+		out = append(out, lvl)
+	}
+	return out
+}
+
+// diffQuoteLadder fills the plan's keep / cancel / place lists from the user's resting orders.
+func diffQuoteLadder(plan *QuoteLadderPlan, open []models.Order, tolerance float64, negRisk bool) {
+	matched := make([]bool, len(plan.Desired))
+	for _, o := range open {
+		remaining := roundQuoteSize(o.Size - o.SizeMatched)
+		entry := QuoteCancel{
+			OrderID:     o.ID,
+			CLOBOrderID: o.CLOBOrderID,
+			Side:        string(o.Side),
+			Price:       o.Price,
+			Size:        remaining,
+		}
+
+		kept := false
+		priceMatched := false
+		for i, want := range plan.Desired {
+			if matched[i] || want.Side != string(o.Side) || math.Abs(want.Price-o.Price) > plan.TickSize/2 {
+				continue
+			}
+			priceMatched = true
+			if math.Abs(remaining-want.Size) <= want.Size*tolerance {
+				matched[i] = true
+				kept = true
+				break
+			}
+		}
+		switch {
+		case kept:
+			plan.Keep = append(plan.Keep, entry)
+		case priceMatched:
+			entry.Reason = "size differs from the ladder"
+			plan.Cancels = append(plan.Cancels, entry)
+		default:
+			entry.Reason = "price not in the ladder"
+			plan.Cancels = append(plan.Cancels, entry)
+		}
+	}
+
+	for i, want := range plan.Desired {
+		if matched[i] {
+			continue
+		}
+		plan.Places = append(plan.Places, QuoteOrderRequest{
+			TokenID:   plan.TokenID,
+			Side:      want.Side,
+			Price:     want.Price,
+			Size:      want.Size,
+			OrderType: clob.OrderTypeGTC,
+			TickSize:  plan.TickSize,
+			NegRisk:   negRisk,
+		})
+	}
+	sort.SliceStable(plan.Places, func(i, j int) bool {
+		return plan.Places[i].Side < plan.Places[j].Side
+	})
+}
+
+func (s *QuoteLadderService) record(ctx context.Context, userID uuid.UUID, params QuoteLadderParams, plan *QuoteLadderPlan) (*models.QuoteAdjustment, error) {
+	encode := func(v interface{}) (string, error) {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode quote adjustment: %w", err)
+		}
+		return string(raw), nil
+	}
+	paramsJSON, err := encode(params)
+	if err != nil {
+		return nil, err
+	}
+	desired, err := encode(plan.Desired)
+	if err != nil {
+		return nil, err
+	}
+	cancels, err := encode(plan.Cancels)
+	if err != nil {
+		return nil, err
+	}
+	places, err := encode(plan.Places)
+	if err != nil {
+		return nil, err
+	}
+
+	adjustment := &models.QuoteAdjustment{
+		UserID:    userID,
+		MarketID:  plan.MarketID,
+		TokenID:   plan.TokenID,
+		Params:    paramsJSON,
+		Midpoint:  plan.Midpoint,
+		Inventory: plan.Inventory,
+		Skew:      plan.Skew,
+		Desired:   desired,
+		Cancels:   cancels,
+		Places:    places,
+		Kept:      len(plan.Keep),
+	}
+	if err := s.db.WithContext(ctx).Create(adjustment).Error; err != nil {
+		return nil, fmt.Errorf("failed to record quote adjustment: %w", err)
+	}
+	return adjustment, nil
+}
+
+func floorToTick(price, tick float64) float64 {
+	return math.Round(math.Floor(price/tick+1e-9)*tick*1e6) / 1e6
+}
+
+func ceilToTick(price, tick float64) float64 {
+	return math.Round(math.Ceil(price/tick-1e-9)*tick*1e6) / 1e6
+}
+
+func roundQuoteSize(size float64) float64 {
+	if size <= 0 {
+		return 0
+	}
+	return math.Floor(size*100) / 100
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/polymarket/clob"
+	"github.com/google/uuid"
+)
+
+func TestBuildQuoteLadder(t *testing.T) {
+	skew := func(v float64) *float64 { return &v }
+	base := QuoteLadderParams{Spread: 0.04, Size: 100, Levels: 2, MaxInventory: 1000}
+
+	cases := []struct {
+		name       string
+		params     QuoteLadderParams
+		mid        float64
+		bestBid    float64
+		bestAsk    float64
+		inventory  float64
+		minSize    float64
+		want       []QuoteLevel
+		wantSkew   float64
+		wantCentre float64
+	}{
+		{
+			"flat book, no inventory", base, 0.50, 0.49, 0.51, 0, 5,
+			[]QuoteLevel{{"BUY", 0, 0.48, 100}, {"BUY", 1, 0.47, 100}},
+			0, 0.50,
+		},
+		{
+			"half inventory skews down and shrinks bids", base, 0.50, 0.49, 0.51, 500, 5,
+			[]QuoteLevel{{"BUY", 0, 0.47, 50}, {"BUY", 1, 0.46, 50}, {"SELL", 0, 0.51, 100}, {"SELL", 1, 0.52, 100}},
+			0.01, 0.49,
+		},
+		{
+			"full inventory quotes asks only", base, 0.50, 0.49, 0.51, 1000, 5,
+			[]QuoteLevel{{"SELL", 0, 0.50, 100}, {"SELL", 1, 0.51, 100}},
+			0.02, 0.48,
+		},
+		{
+			"asks limited by inventory", base, 0.50, 0.49, 0.51, 130, 5,
+			[]QuoteLevel{{"BUY", 0, 0.47, 87}, {"BUY", 1, 0.46, 87}, {"SELL", 0, 0.52, 100}, {"SELL", 1, 0.53, 30}},
+			0.0026, 0.4974,
+		},
+		{
+			"bids behind the ask merge at one tick inside", base, 0.55, 0.50, 0.52, 0, 5,
+			[]QuoteLevel{{"BUY", 0, 0.51, 200}},
+			0, 0.55,
+		},
+		{
+			"levels below the minimum size are skipped", base, 0.50, 0.49, 0.51, 500, 60,
+			[]QuoteLevel{{"SELL", 0, 0.51, 100}, {"SELL", 1, 0.52, 100}},
+			0.01, 0.49,
+		},
+		{
+			"explicit skew and level step; asks lifted above the bid",
+			QuoteLadderParams{Spread: 0.02, Size: 10, Levels: 2, LevelStep: 0.05, MaxInventory: 100, Skew: skew(0.1)},
+			0.50, 0.49, 0.51, 50, 5,
+			[]QuoteLevel{{"BUY", 0, 0.44, 5}, {"BUY", 1, 0.39, 5}, {"SELL", 0, 0.50, 10}, {"SELL", 1, 0.51, 10}},
+			0.05, 0.45,
+		},
+	}
+
+	for _, tc := range cases {
+		levels, skew, centre := buildQuoteLadder(tc.params, tc.mid, tc.bestBid, tc.bestAsk, 0.01, tc.inventory, tc.minSize)
+		if math.Abs(skew-tc.wantSkew) > 1e-9 || math.Abs(centre-tc.wantCentre) > 1e-9 {
+			t.Errorf("%s: skew/centre = %.4f/%.4f, want %.4f/%.4f", tc.name, skew, centre, tc.wantSkew, tc.wantCentre)
+		}
+		if len(levels) != len(tc.want) {
+			t.Errorf("%s: levels = %+v, want %+v", tc.name, levels, tc.want)
+			continue
+		}
+		for i := range levels {
+			got, want := levels[i], tc.want[i]
+			if got.Side != want.Side || got.Level != want.Level || math.Abs(got.Price-want.Price) > 1e-9 || math.Abs(got.Size-want.Size) > 1e-9 {
+				t.Errorf("%s: level %d = %+v, want %+v", tc.name, i, got, want)
+			}
+		}
+	}
+}
+
+func TestDiffQuoteLadder(t *testing.T) {
+	order := func(side models.OrderSide, price, size, matched float64) models.Order {
+		return models.Order{ID: uuid.New(), CLOBOrderID: uuid.NewString(), Side: side, Price: price, Size: size, SizeMatched: matched}
+	}
+
+	cases := []struct {
+		name        string
+		desired     []QuoteLevel
+		open        []models.Order
+		wantKeep    int
+		wantCancels []string // reasons
+		wantPlaces  []QuoteLevel
+	}{
+		{
+			"empty book places the whole ladder",
+			[]QuoteLevel{{"SELL", 0, 0.52, 50}, {"BUY", 0, 0.48, 100}},
+			nil,
+			0, nil,
+			[]QuoteLevel{{"BUY", 0, 0.48, 100}, {"SELL", 0, 0.52, 50}},
+		},
+		{
+			"matching orders are kept within tolerance",
+			[]QuoteLevel{{"BUY", 0, 0.48, 100}, {"SELL", 0, 0.52, 50}},
+			[]models.Order{order(models.OrderSideBuy, 0.48, 100, 5), order(models.OrderSideSell, 0.52, 50, 0)},
+			2, nil, nil,
+		},
+		{
+			"size drift, stray price and duplicates are canceled",
+			[]QuoteLevel{{"BUY", 0, 0.48, 100}, {"BUY", 1, 0.47, 100}, {"SELL", 0, 0.52, 50}},
+			[]models.Order{
+				order(models.OrderSideBuy, 0.48, 100, 0),
+				order(models.OrderSideBuy, 0.47, 100, 60),
+				order(models.OrderSideSell, 0.55, 50, 0),
+				order(models.OrderSideBuy, 0.48, 100, 0),
+			},
+			1,
+			[]string{"size differs from the ladder", "price not in the ladder", "price not in the ladder"},
+			[]QuoteLevel{{"BUY", 1, 0.47, 100}, {"SELL", 0, 0.52, 50}},
+		},
+		{
+			"same price on the other side does not match",
+			[]QuoteLevel{{"SELL", 0, 0.48, 100}},
+			[]models.Order{order(models.OrderSideBuy, 0.48, 100, 0)},
+			0,
+			[]string{"price not in the ladder"},
+			[]QuoteLevel{{"SELL", 0, 0.48, 100}},
+		},
+	}
+
+	for _, tc := range cases {
+		plan := &QuoteLadderPlan{TokenID: "tok", TickSize: 0.01, Desired: tc.desired}
+		diffQuoteLadder(plan, tc.open, 0.1, true)
+
+		if len(plan.Keep) != tc.wantKeep {
+			t.Errorf("%s: kept %d, want %d", tc.name, len(plan.Keep), tc.wantKeep)
+		}
+		if len(plan.Cancels) != len(tc.wantCancels) {
+			t.Errorf("%s: cancels = %+v, want reasons %v", tc.name, plan.Cancels, tc.wantCancels)
+		} else {
+			for i, c := range plan.Cancels {
+				if c.Reason != tc.wantCancels[i] {
+					t.Errorf("%s: cancel %d reason = %q, want %q", tc.name, i, c.Reason, tc.wantCancels[i])
+				}
+			}
+		}
+		if len(plan.Places) != len(tc.wantPlaces) {
+			t.Errorf("%s: places = %+v, want %+v", tc.name, plan.Places, tc.wantPlaces)
+			continue
+		}
+		for i, p := range plan.Places {
+			want := tc.wantPlaces[i]
+			if p.Side != want.Side || p.Price != want.Price || p.Size != want.Size || p.TokenID != "tok" ||
+				p.OrderType != clob.OrderTypeGTC || p.TickSize != 0.01 || !p.NegRisk {
+				t.Errorf("%s: place %d = %+v, want %+v", tc.name, i, p, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,292 @@
+/**
+ * @description
+ * Pre-trade Quote Service.
+ * Walks the cached order book for a token to price a taker order given in shares or USDC notional, adding
+ * the CLOB fee, price impact against mid, payout / loss bounds and warnings about the market's state.
+ *
+ * @dependencies
+ * - backend/internal/services (MarketService)
+ * - backend/internal/polymarket/clob
+ *
+ * @notes
+ * - Fees follow the CLOB formula: baseRate * min(price, 1 - price) * shares, charged per level filled.
+ * - Fee rates are cached in Redis; markets with fees disabled skip the CLOB lookup entirely.
+ * - Quotes are estimates against a snapshot; the book can move before the order lands.
+ */
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+const (
+	feeRateCacheTTL     = 10 * time.Minute
+	quoteClosingSoon    = 24 * time.Hour
+	quoteHighImpact     = 0.05 // 5% away from mid
+	quoteThinBookLevels = 5
+	quoteWideSpread     = 0.05
+)
+
+// Quote warning codes
+const (
+	QuoteWarningInsufficientLiquidity = "INSUFFICIENT_LIQUIDITY"
+	QuoteWarningThinBook              = "THIN_BOOK"
+	QuoteWarningHighImpact            = "HIGH_PRICE_IMPACT"
+	QuoteWarningWideSpread            = "WIDE_SPREAD"
+	QuoteWarningClosingSoon           = "MARKET_CLOSING_SOON"
+	QuoteWarningNotAccepting          = "NOT_ACCEPTING_ORDERS"
+	QuoteWarningClosed                = "MARKET_CLOSED"
+	QuoteWarningFeeUnknown            = "FEE_RATE_UNAVAILABLE"
+)
+
+var ErrInvalidQuote = errors.New("invalid quote request")
+
+// QuoteService prices taker orders against the live book
+type QuoteService struct {
+	markets *MarketService
+}
+
+// NewQuoteService creates a new QuoteService
+func NewQuoteService(markets *MarketService) *QuoteService {
+	return &QuoteService{markets: markets}
+}
+
+// QuoteRequest describes the order to price. Exactly one of Shares or Amount (USDC) is set.
+type QuoteRequest struct {
+	TokenID string
+	Side    string
+	Shares  float64
+	Amount  float64
+}
+
+// QuoteWarning flags a condition the trader should see before submitting
+type QuoteWarning struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// TradeQuote is a full pre-trade estimate
+type TradeQuote struct {
+	MarketID        string           `json:"marketId"`
+	TokenID         string           `json:"tokenId"`
+	Outcome         string           `json:"outcome"`
+	Side            string           `json:"side"`
+	RequestedShares float64          `json:"requestedShares,omitempty"`
+	RequestedAmount float64          `json:"requestedAmount,omitempty"`
+	Shares          float64          `json:"shares"`
+	Notional        float64          `json:"notional"` // USDC exchanged before fees
+	AveragePrice    float64          `json:"averagePrice"`
+	BestPrice       float64          `json:"bestPrice"`
+	WorstPrice      float64          `json:"worstPrice"`
+	MidPrice        float64          `json:"midPrice"`
+	Spread          float64          `json:"spread"`
+	PriceImpact     float64          `json:"priceImpact"` // |average - mid| / mid
+	FeeRateBps      int              `json:"feeRateBps"`
+	FeeAmount       float64          `json:"feeAmount"`
+	TotalCost       float64          `json:"totalCost,omitempty"`   // BUY: notional + fee
+	NetProceeds     float64          `json:"netProceeds,omitempty"` // SELL: notional - fee
+	MaxPayout       float64          `json:"maxPayout"`             // USDC received in the best case
+	MaxLoss         float64          `json:"maxLoss"`               // USDC lost in the worst case
+	Fillable        bool             `json:"fillable"`
+	Levels          []DepthLevel     `json:"levels"`
+	Warnings        []QuoteWarning   `json:"warnings"`
+	Risk            *RiskCheckResult `json:"risk,omitempty"` // Set when the caller's risk limits were checked
+	QuotedAt        time.Time        `json:"quotedAt"`
+}
+
+// Quote prices req against the current book.
+func (s *QuoteService) Quote(ctx context.Context, req QuoteRequest) (*TradeQuote, error) {
+	req.TokenID = strings.TrimSpace(req.TokenID)
+	req.Side = strings.ToUpper(strings.TrimSpace(req.Side))
+	if req.TokenID == "" {
+		return nil, fmt.Errorf("%w: tokenId is required", ErrInvalidQuote)
+	}
+	if req.Side != "BUY" && req.Side != "SELL" {
+		return nil, fmt.Errorf("%w: side must be BUY or SELL", ErrInvalidQuote)
+	}
+	if (req.Shares > 0) == (req.Amount > 0) {
+		return nil, fmt.Errorf("%w: set exactly one of shares or amount", ErrInvalidQuote)
+	}
+
+	var market models.Market
+	if err := s.markets.DB.WithContext(ctx).
+		Where("token_id_yes = ? OR token_id_no = ?", req.TokenID, req.TokenID).
+		First(&market).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: unknown token %s", ErrInvalidQuote, req.TokenID)
+		}
+		return nil, fmt.Errorf("failed to load market: %w", err)
+	}
+
+	book, err := s.markets.loadOrderBookSnapshot(ctx, market.ConditionID, req.TokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	quote := &TradeQuote{
+		MarketID:        market.ConditionID,
+		TokenID:         req.TokenID,
+		Outcome:         deriveOutcomeLabel(&market, req.TokenID, ""),
+		Side:            req.Side,
+		RequestedShares: req.Shares,
+		RequestedAmount: req.Amount,
+		Warnings:        []QuoteWarning{},
+		QuotedAt:        time.Now().UTC(),
+	}
+
+	bids, asks := book.sideLevels("SELL"), book.sideLevels("BUY")
+	if len(bids) > 0 && len(asks) > 0 {
+		quote.MidPrice = (bids[0].Price + asks[0].Price) / 2
+		quote.Spread = asks[0].Price - bids[0].Price
+	}
+
+	levels := asks
+	if req.Side == "SELL" {
+		levels = bids
+	}
+	if len(levels) == 0 {
+		return nil, ErrOrderBookUnavailable
+	}
+
+	feeRate := 0
+	if market.FeesEnabled {
+		if feeRate, err = s.feeRateBps(ctx, req.TokenID); err != nil {
+			logger.Error("QuoteService: Failed to fetch fee rate for %s: %v", req.TokenID, err)
+			quote.addWarning(QuoteWarningFeeUnknown, "Fee rate could not be loaded; fees are not included")
+		}
+	}
+	quote.FeeRateBps = feeRate
+
+	fillQuote(quote, levels, req.Shares, req.Amount, feeRate)
+	quote.addMarketWarnings(&market, len(levels))
+	return quote, nil
+}
+
+// fillQuote walks levels (best first) until the requested shares or notional is reached.
+func fillQuote(q *TradeQuote, levels []depthOrderSummary, shares, amount float64, feeRateBps int) {
+	rate := float64(feeRateBps) / 10000
+	var filled, notional, fee float64
+
+	for _, lvl := range levels {
+		var use float64
+		if shares > 0 {
+			use = math.Min(lvl.Size, shares-filled)
+		} else {
+			use = math.Min(lvl.Size, (amount-notional)/lvl.Price)
+		}
+		if use <= 1e-9 {
+			break
+		}
+
+		filled += use
+		notional += use * lvl.Price
+		fee += rate * math.Min(lvl.Price, 1-lvl.Price) * use
+		if q.BestPrice == 0 {
+			q.BestPrice = lvl.Price
+		}
+		q.WorstPrice = lvl.Price
+		q.Levels = append(q.Levels, DepthLevel{
+			Price:           lvl.Price,
+			Available:       lvl.Size,
+			Used:            use,
+			CumulativeSize:  filled,
+			CumulativeValue: notional,
+		})
+	}
+
+	q.Shares = filled
+	q.Notional = notional
+	q.FeeAmount = fee
+	if filled > 0 {
+		q.AveragePrice = notional / filled
+	}
+	if q.MidPrice > 0 && q.AveragePrice > 0 {
+		q.PriceImpact = math.Abs(q.AveragePrice-q.MidPrice) / q.MidPrice
+	}
+
+	if shares > 0 {
+		q.Fillable = filled+1e-9 >= shares
+	} else {
+		q.Fillable = notional+1e-6 >= amount
+	}
+
+	// Each share settles at 1 USDC or 0.
+	if q.Side == "BUY" {
+		q.TotalCost = notional + fee
+		q.MaxPayout = filled
+		q.MaxLoss = q.TotalCost
+	} else {
+		q.NetProceeds = notional - fee
+		q.MaxPayout = q.NetProceeds
+		q.MaxLoss = math.Max(filled-q.NetProceeds, 0) // forgone payout if the sold outcome wins
+	}
+}
+
+func (q *TradeQuote) addMarketWarnings(market *models.Market, depth int) {
+	if !q.Fillable {
+		q.addWarning(QuoteWarningInsufficientLiquidity,
+			fmt.Sprintf("Only %.2f shares (%.2f USDC) are available on this side of the book", q.Shares, q.Notional))
+	}
+	if len(q.Levels) >= quoteThinBookLevels || (depth <= 2 && len(q.Levels) == depth) {
+		q.addWarning(QuoteWarningThinBook,
+			fmt.Sprintf("The order sweeps %d price levels; the worst fill is %.3f", len(q.Levels), q.WorstPrice))
+	}
+	if q.PriceImpact >= quoteHighImpact {
+		q.addWarning(QuoteWarningHighImpact,
+			fmt.Sprintf("Average price is %.1f%% away from mid", q.PriceImpact*100))
+	}
+	if q.Spread >= quoteWideSpread {
+		q.addWarning(QuoteWarningWideSpread, fmt.Sprintf("Spread is %.3f", q.Spread))
+	}
+	if market.Closed || market.Archived {
+		q.addWarning(QuoteWarningClosed, "This market is closed")
+	} else if !market.AcceptingOrders {
+		q.addWarning(QuoteWarningNotAccepting, "This market is not accepting orders")
+	}
+	if market.EndDate != nil {
+		if left := time.Until(*market.EndDate); left > 0 && left <= quoteClosingSoon {
+			q.addWarning(QuoteWarningClosingSoon,
+				fmt.Sprintf("Market closes in %s", left.Round(time.Minute)))
+		}
+	}
+}
+
+func (q *TradeQuote) addWarning(code, message string) {
+	q.Warnings = append(q.Warnings, QuoteWarning{Code: code, Message: message})
+}
+
+// feeRateBps returns the token's taker fee rate, cached in Redis.
+func (s *QuoteService) feeRateBps(ctx context.Context, tokenID string) (int, error) {
+	key := fmt.Sprintf("fee_rate:%s", tokenID)
+	if cached, err := s.markets.Redis.Get(ctx, key).Result(); err == nil {
+		if rate, convErr := strconv.Atoi(cached); convErr == nil {
+			return rate, nil
+		}
+	} else if !errors.Is(err, redis.Nil) {
+		logger.Error("QuoteService: Failed to read cached fee rate: %v", err)
+	}
+
+	if s.markets.ClobClient == nil {
+		return 0, errors.New("clob client not configured")
+	}
+	rate, err := s.markets.ClobClient.GetFeeRateBps(ctx, tokenID)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.markets.Redis.Set(ctx, key, strconv.Itoa(rate), feeRateCacheTTL).Err(); err != nil {
+		logger.Error("QuoteService: Failed to cache fee rate: %v", err)
+	}
+	return rate, nil
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bankai-project/backend/internal/models"
+)
+
+func TestFillQuote(t *testing.T) {
+	asks := []depthOrderSummary{{Price: 0.40, Size: 100}, {Price: 0.45, Size: 100}, {Price: 0.50, Size: 100}}
+	bids := []depthOrderSummary{{Price: 0.60, Size: 50}, {Price: 0.55, Size: 100}}
+
+	cases := []struct {
+		name         string
+		side         string
+		levels       []depthOrderSummary
+		shares       float64
+		amount       float64
+		feeRateBps   int
+		wantShares   float64
+		wantNotional float64
+		wantFee      float64
+		wantCost     float64 // TotalCost for BUY, NetProceeds for SELL
+		wantMaxLoss  float64
+		wantWorst    float64
+		wantLevels   int
+		wantFillable bool
+	}{
+		{"buy shares across two levels with fees", "BUY", asks, 150, 0, 200, 150, 62.5, 1.25, 63.75, 63.75, 0.45, 2, true},
+		{"buy by notional", "BUY", asks, 0, 50, 0, 100 + 10/0.45, 50, 0, 50, 50, 0.45, 2, true},
+		{"buy beyond the book", "BUY", asks, 400, 0, 0, 300, 135, 0, 135, 135, 0.50, 3, false},
+		{"sell shares with fees", "SELL", bids, 100, 0, 100, 100, 57.5, 0.425, 57.075, 42.925, 0.55, 2, true},
+	}
+
+	for _, tc := range cases {
+		q := &TradeQuote{Side: tc.side}
+		fillQuote(q, tc.levels, tc.shares, tc.amount, tc.feeRateBps)
+
+		cost := q.TotalCost
+		if tc.side == "SELL" {
+			cost = q.NetProceeds
+		}
+		got := []float64{q.Shares, q.Notional, q.FeeAmount, cost, q.MaxLoss, q.WorstPrice}
+		want := []float64{tc.wantShares, tc.wantNotional, tc.wantFee, tc.wantCost, tc.wantMaxLoss, tc.wantWorst}
+		for i := range got {
+			if math.Abs(got[i]-want[i]) > 1e-6 {
+				t.Errorf("%s: shares/notional/fee/cost/maxLoss/worst = %v, want %v", tc.name, got, want)
+				break
+			}
+		}
+		if len(q.Levels) != tc.wantLevels {
+			t.Errorf("%s: %d levels used, want %d", tc.name, len(q.Levels), tc.wantLevels)
+		}
+		if q.Fillable != tc.wantFillable {
+			t.Errorf("%s: fillable = %v, want %v", tc.name, q.Fillable, tc.wantFillable)
+		}
+		if q.BestPrice != tc.levels[0].Price {
+			t.Errorf("%s: best price = %.4f, want %.4f", tc.name, q.BestPrice, tc.levels[0].Price)
+		}
+	}
+
+	q := &TradeQuote{Side: "BUY", MidPrice: 0.5}
+	fillQuote(q, asks, 150, 0, 0)
+	if want := (0.5 - 62.5/150) / 0.5; math.Abs(q.PriceImpact-want) > 1e-9 {
+		t.Errorf("price impact = %.6f, want %.6f", q.PriceImpact, want)
+	}
+}
+
+func TestQuoteMarketWarnings(t *testing.T) {
+	cases := []struct {
+		name   string
+		quote  TradeQuote
+		market models.Market
+		depth  int
+		want   []string
+	}{
+		{"clean quote", TradeQuote{Fillable: true, Levels: make([]DepthLevel, 1), Spread: 0.01}, models.Market{AcceptingOrders: true}, 5, nil},
+		{"unfillable and wide", TradeQuote{Levels: make([]DepthLevel, 1), Spread: 0.2}, models.Market{AcceptingOrders: true}, 5, []string{QuoteWarningInsufficientLiquidity, QuoteWarningWideSpread}},
+		{"whole shallow book swept", TradeQuote{Fillable: true, Levels: make([]DepthLevel, 2)}, models.Market{AcceptingOrders: true}, 2, []string{QuoteWarningThinBook}},
+		{"high impact", TradeQuote{Fillable: true, Levels: make([]DepthLevel, 1), PriceImpact: 0.5}, models.Market{AcceptingOrders: true}, 5, []string{QuoteWarningHighImpact}},
+		{"closed", TradeQuote{Fillable: true, Levels: make([]DepthLevel, 1)}, models.Market{Closed: true}, 5, []string{QuoteWarningClosed}},
+		{"not accepting", TradeQuote{Fillable: true, Levels: make([]DepthLevel, 1)}, models.Market{}, 5, []string{QuoteWarningNotAccepting}},
+	}
+
+	for _, tc := range cases {
+		q := tc.quote
+		q.addMarketWarnings(&tc.market, tc.depth)
+		if len(q.Warnings) != len(tc.want) {
+			t.Errorf("%s: warnings = %+v, want %v", tc.name, q.Warnings, tc.want)
+			continue
+		}
+		for i, w := range q.Warnings {
+			if w.Code != tc.want[i] {
+				t.Errorf("%s: warning %d = %s, want %s", tc.name, i, w.Code, tc.want[i])
+			}
+		}
+	}
+}
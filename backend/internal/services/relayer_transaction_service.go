@@ -0,0 +1,286 @@
+/**
+ * @description
+ * Relayer Transaction Service.
+ * Persists every transaction relayed for a user (Safe deployments, approvals, split / merge, redemptions,
+ * withdrawals) and polls the relayer until each one confirms or fails.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - backend/internal/polymarket/relayer
+ *
+ * @notes
+ * - Submissions the relayer rejects are recorded as FAILED with the error, so users see failed attempts too.
+ * - Polling backs off linearly with the attempt count and gives up after relayerTxMaxAge.
+ * - A confirmed deployment fills in the user's vault address when it is still empty (Safe or proxy, by relayer type),
+ *   on the primary linked wallet as well as the users row.
+ */
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/polymarket/relayer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	relayerTxPollBatch    = 100
+	relayerTxPollInterval = 15 * time.Second
+	relayerTxMaxBackoff   = 10 * time.Minute
+	relayerTxMaxAge       = 24 * time.Hour
+)
+
+var ErrRelayerTransactionNotFound = errors.New("relayer transaction not found")
+
+// RelayerTransactionService records and tracks relayed transactions
+type RelayerTransactionService struct {
+	db      *gorm.DB
+	relayer *relayer.Client
+}
+
+// NewRelayerTransactionService creates a new RelayerTransactionService
+func NewRelayerTransactionService(db *gorm.DB, relayerClient *relayer.Client) *RelayerTransactionService {
+	return &RelayerTransactionService{
+		db:      db,
+		relayer: relayerClient,
+	}
+}
+
+// Record stores a relayer submission. submitErr is the relayer's rejection, if any; resp may then be nil.
+func (s *RelayerTransactionService) Record(ctx context.Context, userID uuid.UUID, txType models.RelayerTransactionType, req *relayer.TransactionRequest, payloadHash string, resp *relayer.RelayerResponse, submitErr error) (*models.RelayerTransaction, error) {
+	if req == nil {
+		return nil, fmt.Errorf("transaction request cannot be nil")
+	}
+	if payloadHash == "" {
+		hash, err := req.PayloadHash()
+		if err != nil {
+			return nil, err
+		}
+		payloadHash = hash
+	}
+
+	record := &models.RelayerTransaction{
+		UserID:       userID,
+		Type:         txType,
+		RelayerType:  string(req.Type),
+		PayloadHash:  payloadHash,
+		FromAddress:  req.From,
+		ProxyAddress: req.ProxyWallet,
+		Status:       models.RelayerTransactionPending,
+	}
+	switch {
+	case submitErr != nil:
+		record.Status = models.RelayerTransactionFailed
+		record.Error = submitErr.Error()
+	case resp != nil:
+		if id := resp.ID(); id != "" {
+			record.RelayerTxID = &id
+		}
+		record.TransactionHash = resp.TransactionHash
+		record.State = resp.State
+		if resp.ProxyAddress != "" {
+			record.ProxyAddress = resp.ProxyAddress
+		}
+		applyRelayerState(record, resp.State, time.Now())
+	}
+	if record.Status == models.RelayerTransactionPending && record.RelayerTxID == nil {
+		record.Status = models.RelayerTransactionFailed
+		record.Error = "relayer returned no transaction id"
+	}
+
+	if err := s.db.WithContext(ctx).Create(record).Error; err != nil {
+		return nil, fmt.Errorf("failed to record relayer transaction: %w", err)
+	}
+	return record, nil
+}
+
+// List returns the user's relayed transactions, newest first, optionally filtered by type.
+func (s *RelayerTransactionService) List(ctx context.Context, userID uuid.UUID, txType models.RelayerTransactionType, limit, offset int) ([]models.RelayerTransaction, int64, error) {
+	query := s.db.WithContext(ctx).Model(&models.RelayerTransaction{}).Where("user_id = ?", userID)
+	if txType != "" {
+		query = query.Where("type = ?", txType)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count relayer transactions: %w", err)
+	}
+	var txs []models.RelayerTransaction
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&txs).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to load relayer transactions: %w", err)
+	}
+	return txs, total, nil
+}
+
+// Get returns one of the user's relayed transactions by ID.
+func (s *RelayerTransactionService) Get(ctx context.Context, userID, id uuid.UUID) (*models.RelayerTransaction, error) {
+	var tx models.RelayerTransaction
+	if err := s.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&tx).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRelayerTransactionNotFound
+		}
+		return nil, fmt.Errorf("failed to load relayer transaction: %w", err)
+	}
+	return &tx, nil
+}
+
+// PollPending refreshes pending transactions from the relayer. Returns how many reached a final status.
+func (s *RelayerTransactionService) PollPending(ctx context.Context) (int, error) {
+	now := time.Now()
+	var pending []models.RelayerTransaction
+	if err := s.db.WithContext(ctx).
+		Where("status = ? AND relayer_tx_id IS NOT NULL", models.RelayerTransactionPending).
+		Where("last_polled_at IS NULL OR last_polled_at + LEAST(poll_attempts * ?, ?) * INTERVAL '1 second' <= ?",
+			relayerTxPollInterval.Seconds(), relayerTxMaxBackoff.Seconds(), now).
+		Order("last_polled_at ASC NULLS FIRST").
+		Limit(relayerTxPollBatch).
+		Find(&pending).Error; err != nil {
+		return 0, fmt.Errorf("failed to load pending relayer transactions: %w", err)
+	}
+
+	settled := 0
+	for i := range pending {
+		if ctx.Err() != nil {
+			return settled, ctx.Err()
+		}
+		if s.poll(ctx, &pending[i], now) {
+			settled++
+		}
+	}
+	return settled, nil
+}
+
+// poll refreshes one transaction and reports whether it reached a final status.
+func (s *RelayerTransactionService) poll(ctx context.Context, tx *models.RelayerTransaction, now time.Time) bool {
+	tx.PollAttempts++
+	tx.LastPolledAt = &now
+
+	remote, err := s.relayer.GetTransaction(ctx, *tx.RelayerTxID)
+	if err != nil {
+		logger.Error("RelayerTransactionService: Poll failed for %s: %v", *tx.RelayerTxID, err)
+	} else {
+		tx.State = remote.State
+		if remote.TransactionHash != "" {
+			tx.TransactionHash = remote.TransactionHash
+		}
+		if remote.ProxyAddress != "" {
+			tx.ProxyAddress = remote.ProxyAddress
+		}
+		applyRelayerState(tx, remote.State, now)
+	}
+	if tx.Status == models.RelayerTransactionPending && now.Sub(tx.CreatedAt) > relayerTxMaxAge {
+		tx.Status = models.RelayerTransactionFailed
+		tx.Error = fmt.Sprintf("not confirmed within %s (last state %s)", relayerTxMaxAge, tx.State)
+	}
+
+	if err := s.db.WithContext(ctx).Model(tx).Updates(map[string]interface{}{
+		"status":           tx.Status,
+		"state":            tx.State,
+		"transaction_hash": tx.TransactionHash,
+		"proxy_address":    tx.ProxyAddress,
+		"error":            tx.Error,
+		"poll_attempts":    tx.PollAttempts,
+		"last_polled_at":   tx.LastPolledAt,
+		"confirmed_at":     tx.ConfirmedAt,
+		"updated_at":       now,
+	}).Error; err != nil {
+		logger.Error("RelayerTransactionService: Failed to update %s: %v", tx.ID, err)
+		return false
+	}
+
+	if tx.Status == models.RelayerTransactionConfirmed && tx.Type == models.RelayerTransactionDeploy && tx.ProxyAddress != "" {
+		s.linkDeployedVault(ctx, tx)
+	}
+	return tx.Status != models.RelayerTransactionPending
+}
+
+// linkDeployedVault stores a confirmed Safe or proxy as the user's vault when they have none yet. When the user
+// has a primary linked wallet, the vault is attached to it (if it signed the deployment) and mirrored onto the
+// users row, so the two never disagree.
+func (s *RelayerTransactionService) linkDeployedVault(ctx context.Context, tx *models.RelayerTransaction) {
+	walletType := models.WalletTypeSafe
+	if tx.RelayerType == string(relayer.TransactionTypeProxy) {
+		walletType = models.WalletTypeProxy
+	}
+	vault := common.HexToAddress(tx.ProxyAddress).Hex()
+
+	linked := false
+	err := s.db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		var user models.User
+		if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", tx.UserID).First(&user).Error; err != nil {
+			return fmt.Errorf("failed to load user: %w", err)
+		}
+		if user.VaultAddress != "" {
+			return nil
+		}
+
+		var primary models.UserWallet
+		if err := db.Where("user_id = ? AND is_primary", tx.UserID).Limit(1).Find(&primary).Error; err != nil {
+			return fmt.Errorf("failed to load primary wallet: %w", err)
+		}
+		if primary.ID == uuid.Nil {
+			// No linked wallet yet; the users row is the only place the vault lives.
+			linked = true
+			return db.Model(&user).Updates(map[string]interface{}{
+				"vault_address": vault,
+				"wallet_type":   walletType,
+				"updated_at":    time.Now(),
+			}).Error
+		}
+		if primary.VaultAddress != "" || !strings.EqualFold(primary.EOAAddress, tx.FromAddress) {
+			return nil
+		}
+
+		primary.VaultAddress = vault
+		primary.WalletType = &walletType
+		if err := db.Save(&primary).Error; err != nil {
+			return fmt.Errorf("failed to save wallet: %w", err)
+		}
+		linked = true
+		return setPrimaryWallet(db, tx.UserID, &primary)
+	})
+	if err != nil {
+		logger.Error("RelayerTransactionService: Failed to link deployed %s %s to user %s: %v", walletType, vault, tx.UserID, err)
+		return
+	}
+	if linked {
+		logger.Info("RelayerTransactionService: Linked deployed %s %s to user %s", walletType, vault, tx.UserID)
+	}
+}
+
+// applyRelayerState maps a raw relayer state onto the record's status.
+func applyRelayerState(tx *models.RelayerTransaction, state string, now time.Time) {
+	switch state {
+	case relayer.TransactionStateConfirmed:
+		tx.Status = models.RelayerTransactionConfirmed
+		tx.ConfirmedAt = &now
+	case relayer.TransactionStateFailed, relayer.TransactionStateInvalid:
+		tx.Status = models.RelayerTransactionFailed
+		if tx.Error == "" {
+			tx.Error = fmt.Sprintf("relayer reported %s", state)
+		}
+	}
+}
+
+// relayerPollDue backs off linearly: attempt n waits n poll intervals since the last poll, up to relayerTxMaxBackoff.
+// PollPending applies the same rule in its query.
+func relayerPollDue(tx *models.RelayerTransaction, now time.Time) bool {
+	if tx.LastPolledAt == nil {
+		return true
+	}
+	wait := time.Duration(tx.PollAttempts) * relayerTxPollInterval
+	if wait > relayerTxMaxBackoff {
+		wait = relayerTxMaxBackoff
+	}
+	return now.Sub(*tx.LastPolledAt) >= wait
+}
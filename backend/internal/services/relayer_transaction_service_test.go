@@ -0,0 +1,73 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/polymarket/relayer"
+)
+
+func TestApplyRelayerState(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name      string
+		state     string
+		prevError string
+		want      models.RelayerTransactionStatus
+		wantError string
+		confirmed bool
+	}{
+		{"new", relayer.TransactionStateNew, "", models.RelayerTransactionPending, "", false},
+		{"executed", relayer.TransactionStateExecuted, "", models.RelayerTransactionPending, "", false},
+		{"mined", relayer.TransactionStateMined, "", models.RelayerTransactionPending, "", false},
+		{"confirmed", relayer.TransactionStateConfirmed, "", models.RelayerTransactionConfirmed, "", true},
+		{"failed", relayer.TransactionStateFailed, "", models.RelayerTransactionFailed, "relayer reported STATE_FAILED", false},
+		{"invalid", relayer.TransactionStateInvalid, "", models.RelayerTransactionFailed, "relayer reported STATE_INVALID", false},
+		{"failed keeps error", relayer.TransactionStateFailed, "reverted", models.RelayerTransactionFailed, "reverted", false},
+		{"unknown state", "STATE_SOMETHING", "", models.RelayerTransactionPending, "", false},
+	}
+	for _, tc := range cases {
+		tx := &models.RelayerTransaction{Status: models.RelayerTransactionPending, Error: tc.prevError}
+		applyRelayerState(tx, tc.state, now)
+		if tx.Status != tc.want {
+			t.Errorf("%s: status = %s, want %s", tc.name, tx.Status, tc.want)
+		}
+		if tx.Error != tc.wantError {
+			t.Errorf("%s: error = %q, want %q", tc.name, tx.Error, tc.wantError)
+		}
+		if confirmed := tx.ConfirmedAt != nil && tx.ConfirmedAt.Equal(now); confirmed != tc.confirmed {
+			t.Errorf("%s: confirmed_at = %v, want set %v", tc.name, tx.ConfirmedAt, tc.confirmed)
+		}
+	}
+}
+
+func TestRelayerPollDue(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) *time.Time {
+		at := now.Add(-d)
+		return &at
+	}
+
+	cases := []struct {
+		name     string
+		attempts int
+		last     *time.Time
+		want     bool
+	}{
+		{"never polled", 0, nil, true},
+		{"first attempt waits one interval", 1, ago(relayerTxPollInterval - time.Second), false},
+		{"first attempt due", 1, ago(relayerTxPollInterval), true},
+		{"third attempt waits three intervals", 3, ago(2 * relayerTxPollInterval), false},
+		{"third attempt due", 3, ago(3 * relayerTxPollInterval), true},
+		{"backoff capped", 1000, ago(relayerTxMaxBackoff), true},
+		{"capped backoff not yet due", 1000, ago(relayerTxMaxBackoff - time.Second), false},
+	}
+	for _, tc := range cases {
+		tx := &models.RelayerTransaction{PollAttempts: tc.attempts, LastPolledAt: tc.last}
+		if got := relayerPollDue(tx, now); got != tc.want {
+			t.Errorf("%s: due = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
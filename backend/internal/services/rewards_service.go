@@ -0,0 +1,382 @@
+/**
+ * @description
+ * Liquidity Rewards Service.
+ * Checks a user's resting orders against each market's reward parameters (rewards_min_size, rewards_max_spread)
+ * and the live midpoint, estimates the order's share of the reward pool from the current book, and alerts users
+ * when a qualifying order drifts out of the reward band.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - github.com/redis/go-redis/v9
+ * - backend/internal/services (MarketService)
+ *
+ * @notes
+ * - Scoring follows Polymarket's liquidity rewards formula: S = ((v - s) / v)^2 * size, with v the max spread and
+ *   s the distance from the midpoint, both in cents. Orders below the min size or outside the band score 0.
+ * - Outside [0.10, 0.90] only two-sided quotes score; inside, single-sided quotes score at 1/3.
+ * - The share estimate compares the order's score with every level on the same side of the token's book within the
+ *   band. Complementary liquidity on the other outcome token and sampling over the epoch are not modelled.
+ * - The pool size is not stored, so shares are fractions of the market's pool, not USDC.
+ * - Qualifying orders are remembered in Redis; the next check that finds one out of band sends a single alert.
+ */
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+const (
+	rewardsTwoSidedMin       = 0.10
+	rewardsTwoSidedMax       = 0.90
+	rewardsSingleSidedFactor = 3.0
+	rewardsQualifyingTTL     = 7 * 24 * time.Hour
+	rewardsSweepBatchSize    = 1000
+)
+
+// Reward eligibility reasons
+const (
+	RewardReasonQualifying    = "QUALIFYING"
+	RewardReasonNoRewards     = "NO_REWARDS"
+	RewardReasonBelowMinSize  = "BELOW_MIN_SIZE"
+	RewardReasonOutsideSpread = "OUTSIDE_SPREAD"
+	RewardReasonNeedsTwoSided = "NEEDS_TWO_SIDED"
+	RewardReasonNoMidpoint    = "NO_MIDPOINT"
+)
+
+// RewardsService evaluates resting orders for liquidity rewards
+type RewardsService struct {
+	db      *gorm.DB
+	redis   *redis.Client
+	markets *MarketService
+}
+
+// NewRewardsService creates a new RewardsService
+func NewRewardsService(db *gorm.DB, rdb *redis.Client, markets *MarketService) *RewardsService {
+	return &RewardsService{
+		db:      db,
+		redis:   rdb,
+		markets: markets,
+	}
+}
+
+// RewardOrderStatus is the reward eligibility of one resting order
+type RewardOrderStatus struct {
+	OrderID               uuid.UUID `json:"orderId"`
+	CLOBOrderID           string    `json:"clobOrderId"`
+	MarketID              string    `json:"marketId"`
+	Title                 string    `json:"title"`
+	TokenID               string    `json:"tokenId"`
+	Outcome               string    `json:"outcome"`
+	Side                  string    `json:"side"`
+	Price                 float64   `json:"price"`
+	RemainingSize         float64   `json:"remainingSize"`
+	Midpoint              float64   `json:"midpoint"`
+	SpreadCents           float64   `json:"spreadCents"` // Distance from the midpoint
+	MaxSpreadCents        float64   `json:"maxSpreadCents"`
+	MinSize               float64   `json:"minSize"`
+	Qualifies             bool      `json:"qualifies"`
+	Reason                string    `json:"reason"`
+	Score                 float64   `json:"score"`
+	BookScore             float64   `json:"bookScore"`      // Score of all in-band liquidity on the same side
+	EstimatedShare        float64   `json:"estimatedShare"` // 0-1 share of the market's pool on this side
+	HoldingRewardsEnabled bool      `json:"holdingRewardsEnabled"`
+}
+
+// RewardsReport summarises reward eligibility across a user's resting orders
+type RewardsReport struct {
+	Orders     []RewardOrderStatus `json:"orders"`
+	Qualifying int                 `json:"qualifying"`
+	Markets    int                 `json:"markets"`
+	CheckedAt  time.Time           `json:"checkedAt"`
+}
+
+// rewardsBook is a token's book and midpoint, loaded once per evaluation.
+type rewardsBook struct {
+	bids []depthOrderSummary // Best first
+	asks []depthOrderSummary
+	mid  float64
+}
+
+// GetUserRewards evaluates the user's open orders.
+func (s *RewardsService) GetUserRewards(ctx context.Context, userID uuid.UUID) (*RewardsReport, error) {
+	var orders []models.Order
+	if err := s.db.WithContext(ctx).
+		Where("user_id = ? AND status = ? AND market_id <> '' AND outcome_token_id <> ''", userID, models.OrderStatusOpen).
+		Order("created_at ASC").
+		Find(&orders).Error; err != nil {
+		return nil, fmt.Errorf("failed to load open orders: %w", err)
+	}
+	return s.evaluate(ctx, orders)
+}
+
+// CheckAlerts evaluates every open order in a rewarded market and notifies users whose previously
+// qualifying orders drifted out of the reward band.
+func (s *RewardsService) CheckAlerts(ctx context.Context) (int, error) {
+	sent := 0
+	for offset := 0; ; offset += rewardsSweepBatchSize {
+		var orders []models.Order
+		if err := s.db.WithContext(ctx).
+			Where("status = ? AND outcome_token_id <> ''", models.OrderStatusOpen).
+			Where("market_id IN (SELECT condition_id FROM markets WHERE rewards_max_spread > 0 AND closed = ?)", false).
+			Order("user_id, market_id, created_at").
+			Limit(rewardsSweepBatchSize).
+			Offset(offset).
+			Find(&orders).Error; err != nil {
+			return sent, fmt.Errorf("failed to load rewarded orders: %w", err)
+		}
+		if len(orders) == 0 {
+			return sent, nil
+		}
+
+		report, err := s.evaluate(ctx, orders)
+		if err != nil {
+			return sent, err
+		}
+		owners := make(map[uuid.UUID]uuid.UUID, len(orders))
+		for _, o := range orders {
+			owners[o.ID] = o.UserID
+		}
+
+		for _, status := range report.Orders {
+			key := fmt.Sprintf("rewards:qualifying:%s", status.OrderID)
+			if status.Qualifies {
+				if err := s.redis.Set(ctx, key, "1", rewardsQualifyingTTL).Err(); err != nil {
+					logger.Error("RewardsService: Failed to remember qualifying order %s: %v", status.OrderID, err)
+				}
+				continue
+			}
+			if status.Reason != RewardReasonOutsideSpread && status.Reason != RewardReasonNeedsTwoSided {
+				continue
+			}
+			removed, err := s.redis.Del(ctx, key).Result()
+			if err != nil || removed == 0 {
+				continue
+			}
+			if s.notifyDrift(ctx, owners[status.OrderID], status) {
+				sent++
+			}
+		}
+
+		if len(orders) < rewardsSweepBatchSize {
+			return sent, nil
+		}
+	}
+}
+
+// evaluate scores orders against their markets and books.
+func (s *RewardsService) evaluate(ctx context.Context, orders []models.Order) (*RewardsReport, error) {
+	report := &RewardsReport{Orders: []RewardOrderStatus{}, CheckedAt: time.Now().UTC()}
+	if len(orders) == 0 {
+		return report, nil
+	}
+
+	marketIDs := make([]string, 0, len(orders))
+	seen := make(map[string]bool)
+	for _, o := range orders {
+		if !seen[o.MarketID] {
+			seen[o.MarketID] = true
+			marketIDs = append(marketIDs, o.MarketID)
+		}
+	}
+	var markets []models.Market
+	if err := s.db.WithContext(ctx).Where("condition_id IN ?", marketIDs).Find(&markets).Error; err != nil {
+		return nil, fmt.Errorf("failed to load markets: %w", err)
+	}
+	marketByID := make(map[string]*models.Market, len(markets))
+	for i := range markets {
+		marketByID[markets[i].ConditionID] = &markets[i]
+	}
+	report.Markets = len(markets)
+
+	// Two-sided quoting is judged per user and market: a bid on one outcome and an ask on the same outcome
+	// (or a bid on the complement) count as both sides.
+	type userMarket struct {
+		user   uuid.UUID
+		market string
+	}
+	sides := make(map[userMarket]map[string]bool)
+	for _, o := range orders {
+		market := marketByID[o.MarketID]
+		if market == nil {
+			continue
+		}
+		key := userMarket{o.UserID, o.MarketID}
+		if sides[key] == nil {
+			sides[key] = make(map[string]bool)
+		}
+		sides[key][rewardsYesSide(market, &o)] = true
+	}
+
+	books := make(map[string]*rewardsBook)
+	for i := range orders {
+		o := &orders[i]
+		market := marketByID[o.MarketID]
+		if market == nil {
+			continue
+		}
+		status := RewardOrderStatus{
+			OrderID:               o.ID,
+			CLOBOrderID:           o.CLOBOrderID,
+			MarketID:              o.MarketID,
+			Title:                 market.Title,
+			TokenID:               o.OutcomeTokenID,
+			Outcome:               o.Outcome,
+			Side:                  string(o.Side),
+			Price:                 o.Price,
+			RemainingSize:         math.Max(o.Size-o.SizeMatched, 0),
+			MaxSpreadCents:        market.RewardsMaxSpread,
+			MinSize:               market.RewardsMinSize,
+			HoldingRewardsEnabled: market.HoldingRewardsEnabled,
+		}
+
+		if market.RewardsMaxSpread <= 0 {
+			status.Reason = RewardReasonNoRewards
+			report.Orders = append(report.Orders, status)
+			continue
+		}
+
+		book, ok := books[o.OutcomeTokenID]
+		if !ok {
+			book = s.loadBook(ctx, o.MarketID, o.OutcomeTokenID)
+			books[o.OutcomeTokenID] = book
+		}
+		if book == nil {
+			status.Reason = RewardReasonNoMidpoint
+			report.Orders = append(report.Orders, status)
+			continue
+		}
+		status.Midpoint = book.mid
+		status.SpreadCents = math.Round(math.Abs(o.Price-book.mid)*100*1e4) / 1e4
+
+		twoSided := len(sides[userMarket{o.UserID, o.MarketID}]) > 1
+		switch {
+		case status.RemainingSize < market.RewardsMinSize:
+			status.Reason = RewardReasonBelowMinSize
+		case status.SpreadCents > market.RewardsMaxSpread:
+			status.Reason = RewardReasonOutsideSpread
+		case !twoSided && (book.mid < rewardsTwoSidedMin || book.mid > rewardsTwoSidedMax):
+			status.Reason = RewardReasonNeedsTwoSided
+		default:
+			status.Qualifies = true
+			status.Reason = RewardReasonQualifying
+			status.Score = rewardScore(market.RewardsMaxSpread, status.SpreadCents, status.RemainingSize)
+			if !twoSided {
+				status.Score /= rewardsSingleSidedFactor
+			}
+
+			levels := book.bids
+			if o.Side == models.OrderSideSell {
+				levels = book.asks
+			}
+			for _, lvl := range levels {
+				if lvl.Size < market.RewardsMinSize {
+					continue
+				}
+				status.BookScore += rewardScore(market.RewardsMaxSpread, math.Abs(lvl.Price-book.mid)*100, lvl.Size)
+			}
+			// The live book should already include the order; make sure it is never counted as more than all of it.
+			if status.BookScore < status.Score {
+				status.BookScore = status.Score
+			}
+			if status.BookScore > 0 {
+				status.EstimatedShare = status.Score / status.BookScore
+			}
+			report.Qualifying++
+		}
+		report.Orders = append(report.Orders, status)
+	}
+
+	sort.SliceStable(report.Orders, func(i, j int) bool {
+		if report.Orders[i].Qualifies != report.Orders[j].Qualifies {
+			return report.Orders[i].Qualifies
+		}
+		return report.Orders[i].EstimatedShare > report.Orders[j].EstimatedShare
+	})
+	return report, nil
+}
+
+// loadBook returns the token's book and midpoint, or nil when either side is empty.
+func (s *RewardsService) loadBook(ctx context.Context, marketID, tokenID string) *rewardsBook {
+	snapshot, err := s.markets.loadOrderBookSnapshot(ctx, marketID, tokenID)
+	if err != nil {
+		logger.Error("RewardsService: Failed to load book for %s: %v", tokenID, err)
+		return nil
+	}
+	book := &rewardsBook{
+		bids: snapshot.sideLevels("SELL"),
+		asks: snapshot.sideLevels("BUY"),
+	}
+	if len(book.bids) == 0 || len(book.asks) == 0 {
+		return nil
+	}
+	book.mid = (book.bids[0].Price + book.asks[0].Price) / 2
+	return book
+}
+
+// rewardScore applies the liquidity rewards scoring function.
+func rewardScore(maxSpreadCents, spreadCents, size float64) float64 {
+	if maxSpreadCents <= 0 || spreadCents > maxSpreadCents {
+		return 0
+	}
+	ratio := (maxSpreadCents - spreadCents) / maxSpreadCents
+	return ratio * ratio * size
+}
+
+// rewardsYesSide maps an order onto the side of the YES book it provides liquidity to.
+func rewardsYesSide(market *models.Market, order *models.Order) string {
+	side := string(order.Side)
+	if order.OutcomeTokenID == market.TokenIDNo {
+		if side == string(models.OrderSideBuy) {
+			return string(models.OrderSideSell)
+		}
+		return string(models.OrderSideBuy)
+	}
+	return side
+}
+
+func (s *RewardsService) notifyDrift(ctx context.Context, userID uuid.UUID, status RewardOrderStatus) bool {
+	if userID == uuid.Nil {
+		return false
+	}
+	data, err := json.Marshal(status)
+	if err != nil {
+		logger.Error("RewardsService: Failed to encode drift data: %v", err)
+		return false
+	}
+
+	message := fmt.Sprintf("Your %s %s order at %.3f is %.1f¢ from the midpoint (max %.1f¢) and no longer earns rewards.",
+		status.Side, status.Outcome, status.Price, status.SpreadCents, status.MaxSpreadCents)
+	if status.Reason == RewardReasonNeedsTwoSided {
+		message = fmt.Sprintf("The midpoint moved to %.3f; your %s %s order only earns rewards with a quote on the other side.",
+			status.Midpoint, status.Side, status.Outcome)
+	}
+
+	notification := models.Notification{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Type:      models.NotificationTypeRewardsDrift,
+		Title:     fmt.Sprintf("Order out of reward band: %s", strings.TrimSpace(status.Title)),
+		Message:   message,
+		Data:      string(data),
+		Read:      false,
+		CreatedAt: time.Now(),
+	}
+	if err := s.db.WithContext(ctx).Create(&notification).Error; err != nil {
+		logger.Error("RewardsService: Failed to create drift notification: %v", err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bankai-project/backend/internal/models"
+)
+
+func TestRewardScore(t *testing.T) {
+	cases := []struct {
+		maxSpread float64
+		spread    float64
+		size      float64
+		want      float64
+	}{
+		{3, 0, 100, 100},
+		{3, 1.5, 100, 25},
+		{3, 2, 90, 10},
+		{3, 3, 100, 0},
+		{3, 3.5, 100, 0},
+		{0, 0, 100, 0},
+	}
+	for _, tc := range cases {
+		if got := rewardScore(tc.maxSpread, tc.spread, tc.size); math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("rewardScore(%.1f, %.1f, %.0f) = %.4f, want %.4f", tc.maxSpread, tc.spread, tc.size, got, tc.want)
+		}
+	}
+}
+
+func TestRewardsYesSide(t *testing.T) {
+	market := &models.Market{TokenIDYes: "yes", TokenIDNo: "no"}
+	cases := []struct {
+		token string
+		side  models.OrderSide
+		want  string
+	}{
+		{"yes", models.OrderSideBuy, "BUY"},
+		{"yes", models.OrderSideSell, "SELL"},
+		{"no", models.OrderSideBuy, "SELL"},
+		{"no", models.OrderSideSell, "BUY"},
+	}
+	for _, tc := range cases {
+		order := &models.Order{OutcomeTokenID: tc.token, Side: tc.side}
+		if got := rewardsYesSide(market, order); got != tc.want {
+			t.Errorf("rewardsYesSide(%s %s) = %s, want %s", tc.side, tc.token, got, tc.want)
+		}
+	}
+}
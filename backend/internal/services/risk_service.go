@@ -0,0 +1,599 @@
+/**
+ * @description
+ * Pre-trade Risk Service.
+ * Stores per-user risk limits and evaluates orders against them: max notional per order, max exposure per
+ * market and per Gamma event, a daily loss limit and blocked market categories.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - github.com/redis/go-redis/v9
+ * - backend/internal/services (ProfileService)
+ *
+ * @notes
+ * - Exposure = current value of open positions + the unmatched part of resting BUY orders
+ *   (price * (size - size_matched)) + the order being checked. Positions and PnL are summed across the primary
+ *   vault and every linked wallet's vault.
+ * - Exposure, category and daily loss checks only apply to BUY orders; selling reduces risk.
+ * - Daily loss = today's realized PnL + change in unrealized PnL since the first check of the UTC day.
+ *   The unrealized baseline is stored in Redis, so the first check each day anchors the window.
+ * - Limits set through SetManagedLimits can be locked so the user cannot edit them (shared desk accounts). Only
+ *   admins may set them, since there is no desk membership to scope a lead to their own accounts.
+ * - The sync path cannot block an order that is already on the CLOB; it notifies the user instead.
+ * - Orders the backend posts on the user's behalf (conditional triggers, order-group exits, algo children) go
+ *   through CheckSignedOrder and are not posted when a limit blocks them.
+ */
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/polymarket/clob"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	riskPositionsLimit   = 500
+	riskBaselineTTL      = 48 * time.Hour
+	riskBreachDedupTTL   = 7 * 24 * time.Hour
+	riskReviewWindow     = 24 * time.Hour
+	riskMaxBlockedLength = 50
+)
+
+// Risk violation codes
+const (
+	RiskViolationMaxOrderNotional  = "MAX_ORDER_NOTIONAL"
+	RiskViolationMaxMarketExposure = "MAX_MARKET_EXPOSURE"
+	RiskViolationMaxEventExposure  = "MAX_EVENT_EXPOSURE"
+	RiskViolationDailyLossLimit    = "DAILY_LOSS_LIMIT"
+	RiskViolationBlockedCategory   = "BLOCKED_CATEGORY"
+)
+
+var (
+	ErrRiskLimitsLocked    = errors.New("risk limits are managed by your desk and cannot be changed")
+	ErrInvalidRiskCheck    = errors.New("invalid risk check")
+	ErrInvalidRiskLimit    = errors.New("invalid risk limit")
+	ErrRiskCheckFailed     = errors.New("order blocked by risk limits")
+	ErrRiskLimitsForbidden = errors.New("only admins can manage another user's risk limits")
+)
+
+// RiskService manages risk limits and pre-trade checks
+type RiskService struct {
+	db       *gorm.DB
+	redis    *redis.Client
+	profiles *ProfileService
+}
+
+// NewRiskService creates a new RiskService
+func NewRiskService(db *gorm.DB, rdb *redis.Client, profiles *ProfileService) *RiskService {
+	return &RiskService{
+		db:       db,
+		redis:    rdb,
+		profiles: profiles,
+	}
+}
+
+// RiskLimitsInput updates a user's limits. Nil clears a limit.
+type RiskLimitsInput struct {
+	MaxOrderNotional  *float64 `json:"max_order_notional"`
+	MaxMarketExposure *float64 `json:"max_market_exposure"`
+	MaxEventExposure  *float64 `json:"max_event_exposure"`
+	DailyLossLimit    *float64 `json:"daily_loss_limit"`
+	BlockedCategories []string `json:"blocked_categories"`
+	LockedReason      string   `json:"locked_reason,omitempty"` // Managed limits only
+}
+
+// RiskCheckRequest describes an order about to be signed. Notional (USDC) wins over Price * Size.
+type RiskCheckRequest struct {
+	TokenID  string  `json:"tokenId"`
+	Side     string  `json:"side"`
+	Price    float64 `json:"price"`
+	Size     float64 `json:"size"`
+	Notional float64 `json:"notional"`
+}
+
+// RiskViolation is a single failed limit
+type RiskViolation struct {
+	Code    string  `json:"code"`
+	Message string  `json:"message"`
+	Limit   float64 `json:"limit,omitempty"`
+	Value   float64 `json:"value,omitempty"`
+}
+
+// RiskCheckResult is the pass/fail outcome of a pre-trade check
+type RiskCheckResult struct {
+	Pass           bool            `json:"pass"`
+	Violations     []RiskViolation `json:"violations"`
+	Notional       float64         `json:"notional"`
+	MarketExposure float64         `json:"marketExposure"` // Including this order
+	EventExposure  float64         `json:"eventExposure"`  // Including this order
+	DailyLoss      float64         `json:"dailyLoss"`
+	Locked         bool            `json:"locked"`
+	CheckedAt      time.Time       `json:"checkedAt"`
+}
+
+// riskState caches per-user data needed by several checks in one evaluation.
+type riskState struct {
+	positions map[string]float64 // condition ID -> current value
+	openBuys  map[string]float64 // condition ID -> resting BUY notional
+	dailyLoss float64
+}
+
+// GetLimits returns the user's limits, or an empty (unlimited) set when none are stored.
+func (s *RiskService) GetLimits(ctx context.Context, userID uuid.UUID) (*models.RiskLimits, error) {
+	var limits models.RiskLimits
+	err := s.db.WithContext(ctx).Where("user_id = ?", userID).First(&limits).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &models.RiskLimits{UserID: userID, BlockedCategories: models.StringArray{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load risk limits: %w", err)
+	}
+	return &limits, nil
+}
+
+// UpdateLimits replaces the user's own limits. Locked (desk-managed) limits are rejected.
+func (s *RiskService) UpdateLimits(ctx context.Context, userID uuid.UUID, input RiskLimitsInput) (*models.RiskLimits, error) {
+	current, err := s.GetLimits(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if current.Locked {
+		return nil, ErrRiskLimitsLocked
+	}
+	return s.saveLimits(ctx, userID, input, false, nil)
+}
+
+// SetManagedLimits sets (and optionally locks) a user's limits on behalf of an admin, recording who did it.
+func (s *RiskService) SetManagedLimits(ctx context.Context, actor *models.User, userID uuid.UUID, input RiskLimitsInput, locked bool) (*models.RiskLimits, error) {
+	if actor == nil || !actor.CanManageRiskLimits() {
+		return nil, ErrRiskLimitsForbidden
+	}
+	limits, err := s.saveLimits(ctx, userID, input, locked, &actor.ID)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("RiskService: Managed risk limits for user %s set by %s (%s, locked=%t)",
+		userID, actor.ID, actor.Role, locked)
+	return limits, nil
+}
+
+func (s *RiskService) saveLimits(ctx context.Context, userID uuid.UUID, input RiskLimitsInput, locked bool, managedBy *uuid.UUID) (*models.RiskLimits, error) {
+	for name, v := range map[string]*float64{
+		"max_order_notional":  input.MaxOrderNotional,
+		"max_market_exposure": input.MaxMarketExposure,
+		"max_event_exposure":  input.MaxEventExposure,
+		"daily_loss_limit":    input.DailyLossLimit,
+	} {
+		if v != nil && (*v <= 0 || math.IsNaN(*v) || math.IsInf(*v, 0)) {
+			return nil, fmt.Errorf("%w: %s must be a positive number", ErrInvalidRiskLimit, name)
+		}
+	}
+	if len(input.BlockedCategories) > riskMaxBlockedLength {
+		return nil, fmt.Errorf("%w: at most %d blocked categories", ErrInvalidRiskLimit, riskMaxBlockedLength)
+	}
+
+	blocked := make(models.StringArray, 0, len(input.BlockedCategories))
+	seen := make(map[string]bool)
+	for _, raw := range input.BlockedCategories {
+		cat := strings.ToLower(strings.TrimSpace(raw))
+		if cat == "" || seen[cat] {
+			continue
+		}
+		seen[cat] = true
+		blocked = append(blocked, cat)
+	}
+
+	limits := models.RiskLimits{
+		UserID:            userID,
+		MaxOrderNotional:  input.MaxOrderNotional,
+		MaxMarketExposure: input.MaxMarketExposure,
+		MaxEventExposure:  input.MaxEventExposure,
+		DailyLossLimit:    input.DailyLossLimit,
+		BlockedCategories: blocked,
+		Locked:            locked,
+		ManagedBy:         managedBy,
+	}
+	if locked {
+		limits.LockedReason = strings.TrimSpace(input.LockedReason)
+	}
+
+	if err := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"max_order_notional", "max_market_exposure", "max_event_exposure", "daily_loss_limit",
+			"blocked_categories", "locked", "locked_reason", "managed_by", "updated_at",
+		}),
+	}).Create(&limits).Error; err != nil {
+		return nil, fmt.Errorf("failed to save risk limits: %w", err)
+	}
+	return s.GetLimits(ctx, userID)
+}
+
+// Check evaluates an order against the user's limits before it is signed.
+func (s *RiskService) Check(ctx context.Context, user *models.User, req RiskCheckRequest) (*RiskCheckResult, error) {
+	req.TokenID = strings.TrimSpace(req.TokenID)
+	side := strings.ToUpper(strings.TrimSpace(req.Side))
+	if req.TokenID == "" {
+		return nil, fmt.Errorf("%w: tokenId is required", ErrInvalidRiskCheck)
+	}
+	if side != "BUY" && side != "SELL" {
+		return nil, fmt.Errorf("%w: side must be BUY or SELL", ErrInvalidRiskCheck)
+	}
+	notional := req.Notional
+	if notional <= 0 {
+		notional = req.Price * req.Size
+	}
+	if notional <= 0 {
+		return nil, fmt.Errorf("%w: set notional or a positive price and size", ErrInvalidRiskCheck)
+	}
+
+	market, err := s.marketForToken(ctx, req.TokenID)
+	if err != nil {
+		return nil, err
+	}
+	limits, err := s.GetLimits(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RiskCheckResult{
+		Pass:       true,
+		Violations: []RiskViolation{},
+		Notional:   notional,
+		Locked:     limits.Locked,
+		CheckedAt:  time.Now().UTC(),
+	}
+	if !limits.HasLimits() {
+		return result, nil
+	}
+
+	state, err := s.loadState(ctx, user, limits)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.evaluate(ctx, result, limits, state, market, side, notional, notional); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CheckSignedOrder runs Check for a signed order the backend is about to post for the user. It returns
+// ErrRiskCheckFailed listing the violations when a limit blocks the order. Users without limits pass without
+// a market lookup.
+func (s *RiskService) CheckSignedOrder(ctx context.Context, user *models.User, order *clob.Order) error {
+	limits, err := s.GetLimits(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if !limits.HasLimits() {
+		return nil
+	}
+
+	price, size, err := signedOrderTerms(order)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRiskCheck, err)
+	}
+	result, err := s.Check(ctx, user, RiskCheckRequest{
+		TokenID: order.TokenID,
+		Side:    string(normalizeClobSide(order.Side)),
+		Price:   price,
+		Size:    size,
+	})
+	if err != nil {
+		return err
+	}
+	if !result.Pass {
+		messages := make([]string, len(result.Violations))
+		for i, v := range result.Violations {
+			messages[i] = v.Message
+		}
+		return fmt.Errorf("%w: %s", ErrRiskCheckFailed, strings.Join(messages, "; "))
+	}
+	return nil
+}
+
+// riskFailureMessage is the user-facing reason for an order CheckSignedOrder stopped. Unexpected errors are not
+// exposed; the order still fails closed.
+func riskFailureMessage(err error) string {
+	if errors.Is(err, ErrRiskCheckFailed) || errors.Is(err, ErrInvalidRiskCheck) {
+		return err.Error()
+	}
+	return "risk check could not be completed"
+}
+
+// ReviewSynced re-checks freshly synced orders. They are already live, so breaches become notifications.
+func (s *RiskService) ReviewSynced(ctx context.Context, user *models.User, orders []models.Order) {
+	limits, err := s.GetLimits(ctx, user.ID)
+	if err != nil {
+		logger.Error("RiskService: %v", err)
+		return
+	}
+	if !limits.HasLimits() {
+		return
+	}
+
+	cutoff := time.Now().Add(-riskReviewWindow)
+	var state *riskState
+	for i := range orders {
+		order := &orders[i]
+		if order.CLOBOrderID == "" || order.CreatedAt.Before(cutoff) {
+			continue
+		}
+		if order.Status == models.OrderStatusCanceled || order.Status == models.OrderStatusFailed {
+			continue
+		}
+		market, err := s.marketForToken(ctx, order.OutcomeTokenID)
+		if err != nil {
+			continue
+		}
+		if state == nil {
+			if state, err = s.loadState(ctx, user, limits); err != nil {
+				logger.Error("RiskService: Failed to load risk state for user %s: %v", user.ID, err)
+				return
+			}
+		}
+
+		// The synced order is already counted in the open-orders snapshot.
+		result := &RiskCheckResult{Pass: true, Violations: []RiskViolation{}}
+		notional := order.Price * order.Size
+		if err := s.evaluate(ctx, result, limits, state, market, string(order.Side), notional, 0); err != nil {
+			logger.Error("RiskService: Failed to evaluate order %s: %v", order.CLOBOrderID, err)
+			continue
+		}
+		if !result.Pass {
+			s.notifyBreach(ctx, user.ID, order, result)
+		}
+	}
+}
+
+// evaluate appends violations to result. added is the notional the order adds on top of current exposure.
+func (s *RiskService) evaluate(ctx context.Context, result *RiskCheckResult, limits *models.RiskLimits, state *riskState, market *models.Market, side string, notional, added float64) error {
+	if limits.MaxOrderNotional != nil && notional > *limits.MaxOrderNotional {
+		result.addViolation(RiskViolationMaxOrderNotional, *limits.MaxOrderNotional, notional,
+			fmt.Sprintf("Order notional %.2f USDC exceeds the %.2f USDC per-order limit", notional, *limits.MaxOrderNotional))
+	}
+	if side != "BUY" {
+		return nil
+	}
+
+	if blocked := blockedCategory(limits.BlockedCategories, market); blocked != "" {
+		result.addViolation(RiskViolationBlockedCategory, 0, 0,
+			fmt.Sprintf("Trading in category %q is blocked", blocked))
+	}
+
+	result.MarketExposure = state.exposure(market.ConditionID) + added
+	if limits.MaxMarketExposure != nil && result.MarketExposure > *limits.MaxMarketExposure {
+		result.addViolation(RiskViolationMaxMarketExposure, *limits.MaxMarketExposure, result.MarketExposure,
+			fmt.Sprintf("Exposure in this market would be %.2f USDC, above the %.2f USDC limit", result.MarketExposure, *limits.MaxMarketExposure))
+	}
+
+	if limits.MaxEventExposure != nil {
+		result.EventExposure = result.MarketExposure
+		if market.EventID != "" {
+			var conditionIDs []string
+			if err := s.db.WithContext(ctx).Model(&models.Market{}).
+				Where("event_id = ? AND condition_id <> ?", market.EventID, market.ConditionID).
+				Pluck("condition_id", &conditionIDs).Error; err != nil {
+				return fmt.Errorf("failed to load event markets: %w", err)
+			}
+			for _, id := range conditionIDs {
+				result.EventExposure += state.exposure(id)
+			}
+		}
+		if result.EventExposure > *limits.MaxEventExposure {
+			result.addViolation(RiskViolationMaxEventExposure, *limits.MaxEventExposure, result.EventExposure,
+				fmt.Sprintf("Exposure across this event would be %.2f USDC, above the %.2f USDC limit", result.EventExposure, *limits.MaxEventExposure))
+		}
+	}
+
+	result.DailyLoss = state.dailyLoss
+	if limits.DailyLossLimit != nil && state.dailyLoss >= *limits.DailyLossLimit {
+		result.addViolation(RiskViolationDailyLossLimit, *limits.DailyLossLimit, state.dailyLoss,
+			fmt.Sprintf("Today's loss of %.2f USDC has reached the %.2f USDC daily limit", state.dailyLoss, *limits.DailyLossLimit))
+	}
+	return nil
+}
+
+func (r *RiskCheckResult) addViolation(code string, limit, value float64, message string) {
+	r.Pass = false
+	r.Violations = append(r.Violations, RiskViolation{Code: code, Message: message, Limit: limit, Value: value})
+}
+
+func (st *riskState) exposure(conditionID string) float64 {
+	return st.positions[conditionID] + st.openBuys[conditionID]
+}
+
+// loadState fetches positions, resting orders and today's loss, skipping sources no configured limit needs.
+func (s *RiskService) loadState(ctx context.Context, user *models.User, limits *models.RiskLimits) (*riskState, error) {
+	state := &riskState{
+		positions: make(map[string]float64),
+		openBuys:  make(map[string]float64),
+	}
+	needExposure := limits.MaxMarketExposure != nil || limits.MaxEventExposure != nil
+	if !needExposure && limits.DailyLossLimit == nil {
+		return state, nil
+	}
+
+	if needExposure {
+		var rows []struct {
+			MarketID string
+			Notional float64
+		}
+		// Only the unmatched remainder rests on the book; the matched part is already a position.
+		if err := s.db.WithContext(ctx).Model(&models.Order{}).
+			Select("market_id, COALESCE(SUM(price * GREATEST(size - COALESCE(size_matched, 0), 0)), 0) AS notional").
+			Where("user_id = ? AND side = ? AND status IN ?", user.ID, models.OrderSideBuy,
+				[]models.OrderStatus{models.OrderStatusOpen, models.OrderStatusPending}).
+			Group("market_id").
+			Scan(&rows).Error; err != nil {
+			return nil, fmt.Errorf("failed to load open orders: %w", err)
+		}
+		for _, row := range rows {
+			state.openBuys[row.MarketID] = row.Notional
+		}
+	}
+
+	if s.profiles == nil {
+		return state, nil
+	}
+	vaults, err := userVaultAddresses(ctx, s.db, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(vaults) == 0 {
+		return state, nil
+	}
+
+	unrealized := 0.0
+	for _, vault := range vaults {
+		positions, err := s.profiles.GetOpenPositions(ctx, vault, riskPositionsLimit, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load positions for %s: %w", vault, err)
+		}
+		for _, p := range positions {
+			state.positions[p.ConditionID] += p.CurrentValue
+			unrealized += p.CashPnL
+		}
+	}
+
+	if limits.DailyLossLimit != nil {
+		loss, err := s.dailyLoss(ctx, user, vaults, unrealized)
+		if err != nil {
+			return nil, err
+		}
+		state.dailyLoss = loss
+	}
+	return state, nil
+}
+
+// dailyLoss returns today's loss (positive number) from realized PnL across vaults and the unrealized move
+// since baseline.
+func (s *RiskService) dailyLoss(ctx context.Context, user *models.User, vaults []string, unrealized float64) (float64, error) {
+	now := time.Now().UTC()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	key := fmt.Sprintf("risk:pnl_baseline:%s:%s", user.ID, dayStart.Format("2006-01-02"))
+	baseline := unrealized
+	if s.redis != nil {
+		if err := s.redis.SetNX(ctx, key, strconv.FormatFloat(unrealized, 'f', -1, 64), riskBaselineTTL).Err(); err != nil {
+			logger.Error("RiskService: Failed to store PnL baseline: %v", err)
+		} else if raw, err := s.redis.Get(ctx, key).Result(); err == nil {
+			if v, convErr := strconv.ParseFloat(raw, 64); convErr == nil {
+				baseline = v
+			}
+		}
+	}
+
+	realized := 0.0
+	for _, vault := range vaults {
+		r, err := s.realizedSince(ctx, vault, dayStart)
+		if err != nil {
+			return 0, err
+		}
+		realized += r
+	}
+	pnl := realized + (unrealized - baseline)
+	return math.Max(-pnl, 0), nil
+}
+
+// realizedSince sums realized PnL of positions closed after since. Closed positions come newest first.
+func (s *RiskService) realizedSince(ctx context.Context, address string, since time.Time) (float64, error) {
+	const pageSize = 100
+	total := 0.0
+	for offset := 0; offset < riskPositionsLimit; offset += pageSize {
+		page, err := s.profiles.dataAPIClient.GetClosedPositions(ctx, address, pageSize, offset)
+		if err != nil {
+			return 0, fmt.Errorf("failed to load closed positions: %w", err)
+		}
+		for _, p := range page {
+			if p.ClosedAt.Before(since) {
+				return total, nil
+			}
+			total += p.RealizedPnL
+		}
+		if len(page) < pageSize {
+			break
+		}
+	}
+	return total, nil
+}
+
+func (s *RiskService) marketForToken(ctx context.Context, tokenID string) (*models.Market, error) {
+	var market models.Market
+	if err := s.db.WithContext(ctx).
+		Where("token_id_yes = ? OR token_id_no = ?", tokenID, tokenID).
+		First(&market).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: unknown token %s", ErrInvalidRiskCheck, tokenID)
+		}
+		return nil, fmt.Errorf("failed to load market: %w", err)
+	}
+	return &market, nil
+}
+
+// blockedCategory returns the first blocked category matching the market's category or tags.
+func blockedCategory(blocked models.StringArray, market *models.Market) string {
+	if len(blocked) == 0 {
+		return ""
+	}
+	labels := append([]string{market.Category}, market.Tags...)
+	for _, b := range blocked {
+		for _, label := range labels {
+			if strings.EqualFold(b, strings.TrimSpace(label)) {
+				return b
+			}
+		}
+	}
+	return ""
+}
+
+func (s *RiskService) notifyBreach(ctx context.Context, userID uuid.UUID, order *models.Order, result *RiskCheckResult) {
+	if s.redis != nil {
+		key := fmt.Sprintf("risk:breach:%s", order.CLOBOrderID)
+		ok, err := s.redis.SetNX(ctx, key, "1", riskBreachDedupTTL).Result()
+		if err == nil && !ok {
+			return
+		}
+	}
+
+	codes := make([]string, 0, len(result.Violations))
+	for _, v := range result.Violations {
+		codes = append(codes, v.Code)
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"clob_order_id": order.CLOBOrderID,
+		"market_id":     order.MarketID,
+		"violations":    result.Violations,
+	})
+	if err != nil {
+		logger.Error("RiskService: Failed to encode breach data: %v", err)
+		return
+	}
+
+	notification := models.Notification{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Type:      models.NotificationTypeRiskBreach,
+		Title:     "Order breaches risk limits",
+		Message:   fmt.Sprintf("A %s order in %s breached: %s", order.Side, order.MarketID, strings.Join(codes, ", ")),
+		Data:      string(data),
+		Read:      false,
+		CreatedAt: time.Now(),
+	}
+	if err := s.db.WithContext(ctx).Create(&notification).Error; err != nil {
+		logger.Error("RiskService: Failed to create breach notification: %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestRiskEvaluate(t *testing.T) {
+	limit := func(v float64) *float64 { return &v }
+	market := &models.Market{ConditionID: "0xabc", Category: "Sports", Tags: models.StringArray{"nba", " Politics "}}
+	state := &riskState{
+		positions: map[string]float64{"0xabc": 300},
+		openBuys:  map[string]float64{"0xabc": 50, "0xother": 1000},
+		dailyLoss: 80,
+	}
+
+	cases := []struct {
+		name         string
+		limits       models.RiskLimits
+		side         string
+		notional     float64
+		added        float64
+		want         []string
+		wantExposure float64
+	}{
+		{"no limits", models.RiskLimits{}, "BUY", 500, 500, nil, 850},
+		{"order notional", models.RiskLimits{MaxOrderNotional: limit(100)}, "BUY", 150, 150, []string{RiskViolationMaxOrderNotional}, 500},
+		{"order notional applies to sells", models.RiskLimits{MaxOrderNotional: limit(100)}, "SELL", 150, 0, []string{RiskViolationMaxOrderNotional}, 0},
+		{"sells skip exposure and loss", models.RiskLimits{MaxMarketExposure: limit(10), DailyLossLimit: limit(1), BlockedCategories: models.StringArray{"sports"}}, "SELL", 50, 0, nil, 0},
+		{"blocked category", models.RiskLimits{BlockedCategories: models.StringArray{"sports"}}, "BUY", 10, 10, []string{RiskViolationBlockedCategory}, 360},
+		{"blocked tag", models.RiskLimits{BlockedCategories: models.StringArray{"politics"}}, "BUY", 10, 10, []string{RiskViolationBlockedCategory}, 360},
+		{"market exposure under", models.RiskLimits{MaxMarketExposure: limit(400)}, "BUY", 40, 40, nil, 390},
+		{"market exposure over", models.RiskLimits{MaxMarketExposure: limit(400)}, "BUY", 60, 60, []string{RiskViolationMaxMarketExposure}, 410},
+		{"event exposure without event", models.RiskLimits{MaxEventExposure: limit(355)}, "BUY", 10, 10, []string{RiskViolationMaxEventExposure}, 360},
+		{"daily loss reached", models.RiskLimits{DailyLossLimit: limit(80)}, "BUY", 10, 10, []string{RiskViolationDailyLossLimit}, 360},
+		{"daily loss below", models.RiskLimits{DailyLossLimit: limit(100)}, "BUY", 10, 10, nil, 360},
+		{
+			"several violations",
+			models.RiskLimits{MaxOrderNotional: limit(5), MaxMarketExposure: limit(100), DailyLossLimit: limit(50)},
+			"BUY", 10, 10,
+			[]string{RiskViolationMaxOrderNotional, RiskViolationMaxMarketExposure, RiskViolationDailyLossLimit},
+			360,
+		},
+	}
+
+	s := &RiskService{}
+	for _, tc := range cases {
+		result := &RiskCheckResult{Pass: true, Violations: []RiskViolation{}}
+		limits := tc.limits
+		if err := s.evaluate(context.Background(), result, &limits, state, market, tc.side, tc.notional, tc.added); err != nil {
+			t.Fatalf("%s: evaluate: %v", tc.name, err)
+		}
+		if result.Pass != (len(tc.want) == 0) {
+			t.Errorf("%s: pass = %v with %+v", tc.name, result.Pass, result.Violations)
+		}
+		if len(result.Violations) != len(tc.want) {
+			t.Errorf("%s: violations = %+v, want %v", tc.name, result.Violations, tc.want)
+			continue
+		}
+		for i, v := range result.Violations {
+			if v.Code != tc.want[i] {
+				t.Errorf("%s: violation %d = %s, want %s", tc.name, i, v.Code, tc.want[i])
+			}
+		}
+		if math.Abs(result.MarketExposure-tc.wantExposure) > 1e-9 {
+			t.Errorf("%s: market exposure = %.2f, want %.2f", tc.name, result.MarketExposure, tc.wantExposure)
+		}
+	}
+}
+
+func TestSetManagedLimitsRequiresAdmin(t *testing.T) {
+	s := &RiskService{}
+	for _, actor := range []*models.User{nil, {Role: models.UserRoleUser}, {Role: "DESK_LEAD"}} {
+		if _, err := s.SetManagedLimits(context.Background(), actor, uuid.New(), RiskLimitsInput{}, true); !errors.Is(err, ErrRiskLimitsForbidden) {
+			t.Errorf("actor %+v: err = %v, want ErrRiskLimitsForbidden", actor, err)
+		}
+	}
+	if !(&models.User{Role: models.UserRoleAdmin}).CanManageRiskLimits() {
+		t.Error("admins should manage risk limits")
+	}
+}
@@ -0,0 +1,465 @@
+/**
+ * @description
+ * Market Screener Service.
+ * Evaluates screener expressions against the active market set (plus live Redis prices),
+ * manages per-user saved screens and raises alerts when new markets start matching.
+ *
+ * @dependencies
+ * - backend/internal/screener
+ * - backend/internal/models
+ * - gorm.io/gorm
+ *
+ * @notes
+ * - Live prices come from the Redis snapshot only; cold tokens are not back-filled from the CLOB.
+ * - Saved screens are seeded with their current matches so the first alert only covers genuine new entrants.
+ * - Matches are remembered for screenSeenTTL after they last matched, so a market flapping in and out of a screen
+ *   alerts once. A screen matching more than maxScreenKnownMarkets is not tracked; the first check after it narrows
+ *   again only re-seeds.
+ */
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/screener"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	maxSavedScreensPerUser = 25
+	maxScreenAlertMarkets  = 5
+	maxScreenKnownMarkets  = 2000
+	screenSeenTTL          = 7 * 24 * time.Hour
+)
+
+var (
+	ErrScreenNotFound     = errors.New("saved screen not found")
+	ErrScreenLimitReached = errors.New("saved screen limit reached")
+	ErrInvalidScreen      = errors.New("invalid screen")
+)
+
+// validScreenSorts are the sort keys accepted by screens (see sortMarketsByParam).
+var validScreenSorts = map[string]bool{
+	"":                true,
+	"volume":          true,
+	"liquidity":       true,
+	"volume_all_time": true,
+	"spread":          true,
+	"created":         true,
+	"trending":        true,
+}
+
+// ScreenerService runs market screens and manages saved screens
+type ScreenerService struct {
+	db      *gorm.DB
+	markets *MarketService
+}
+
+// NewScreenerService creates a new ScreenerService
+func NewScreenerService(db *gorm.DB, markets *MarketService) *ScreenerService {
+	return &ScreenerService{
+		db:      db,
+		markets: markets,
+	}
+}
+
+// ScreenRequest describes an ad-hoc screen run
+type ScreenRequest struct {
+	Expression string `json:"expression"`
+	Sort       string `json:"sort"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+}
+
+// ScreenResult is a page of matching markets
+type ScreenResult struct {
+	Expression string          `json:"expression"`
+	Total      int             `json:"total"`
+	Markets    []models.Market `json:"markets"`
+}
+
+// SavedScreenInput carries the mutable fields of a saved screen
+type SavedScreenInput struct {
+	Name          string `json:"name"`
+	Expression    string `json:"expression"`
+	Sort          string `json:"sort"`
+	AlertsEnabled bool   `json:"alerts_enabled"`
+}
+
+// ScreenAlertData is the payload stored on SCREEN_ALERT notifications
+type ScreenAlertData struct {
+	ScreenID   string              `json:"screen_id"`
+	ScreenName string              `json:"screen_name"`
+	Markets    []ScreenAlertMarket `json:"markets"`
+	NewCount   int                 `json:"new_count"`
+}
+
+// ScreenAlertMarket summarises a newly matching market
+type ScreenAlertMarket struct {
+	ConditionID string `json:"condition_id"`
+	Slug        string `json:"slug"`
+	Title       string `json:"title"`
+}
+
+// Validate parses an expression and sort key without running it.
+func (s *ScreenerService) Validate(expression, sortKey string) (*screener.Expression, error) {
+	if !validScreenSorts[sortKey] {
+		return nil, fmt.Errorf("%w: unsupported sort %q", ErrInvalidScreen, sortKey)
+	}
+	return screener.Parse(expression)
+}
+
+// Run evaluates an expression against the active market set and returns a sorted page of matches.
+func (s *ScreenerService) Run(ctx context.Context, req ScreenRequest) (*ScreenResult, error) {
+	expr, err := s.Validate(req.Expression, req.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := s.match(ctx, expr)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Sort == "trending" {
+		s.markets.rankTrendingMarkets(ctx, matches)
+	} else {
+		sortMarketsByParam(matches, req.Sort)
+	}
+
+	if req.Limit <= 0 {
+		req.Limit = 100
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+
+	result := &ScreenResult{Expression: expr.String(), Total: len(matches), Markets: []models.Market{}}
+	if req.Offset >= len(matches) {
+		return result, nil
+	}
+	end := req.Offset + req.Limit
+	if end > len(matches) {
+		end = len(matches)
+	}
+
+	page := make([]models.Market, end-req.Offset)
+	copy(page, matches[req.Offset:end])
+	if !expr.UsesLivePrices() {
+		s.markets.attachRealtimePrices(ctx, page)
+	}
+	result.Markets = page
+
+	return result, nil
+}
+
+func (s *ScreenerService) match(ctx context.Context, expr *screener.Expression) ([]models.Market, error) {
+	markets, err := s.markets.loadAllActiveMarkets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load active markets: %w", err)
+	}
+
+	if expr.UsesLivePrices() {
+		s.markets.attachCachedPrices(ctx, markets)
+	}
+
+	now := time.Now().UTC()
+	matches := make([]models.Market, 0)
+	for i := range markets {
+		if expr.Match(&markets[i], now) {
+			matches = append(matches, markets[i])
+		}
+	}
+
+	return matches, nil
+}
+
+// ListScreens returns a user's saved screens, newest first.
+func (s *ScreenerService) ListScreens(ctx context.Context, userID uuid.UUID) ([]models.SavedScreen, error) {
+	var screens []models.SavedScreen
+	result := s.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&screens)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return screens, nil
+}
+
+// GetScreen returns a single saved screen owned by the user.
+func (s *ScreenerService) GetScreen(ctx context.Context, userID, screenID uuid.UUID) (*models.SavedScreen, error) {
+	var screen models.SavedScreen
+	if err := s.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", screenID, userID).
+		First(&screen).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrScreenNotFound
+		}
+		return nil, err
+	}
+	return &screen, nil
+}
+
+// CreateScreen validates and stores a new saved screen.
+func (s *ScreenerService) CreateScreen(ctx context.Context, userID uuid.UUID, input SavedScreenInput) (*models.SavedScreen, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidScreen)
+	}
+
+	expr, err := s.Validate(input.Expression, input.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	var count int64
+	if err := s.db.WithContext(ctx).Model(&models.SavedScreen{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count >= maxSavedScreensPerUser {
+		return nil, ErrScreenLimitReached
+	}
+
+	screen := &models.SavedScreen{
+		UserID:        userID,
+		Name:          input.Name,
+		Expression:    expr.String(),
+		Sort:          input.Sort,
+		AlertsEnabled: input.AlertsEnabled,
+	}
+	if input.AlertsEnabled {
+		s.seedKnownMarkets(ctx, screen, expr)
+	}
+
+	if err := s.db.WithContext(ctx).Create(screen).Error; err != nil {
+		return nil, fmt.Errorf("failed to create saved screen: %w", err)
+	}
+	return screen, nil
+}
+
+// UpdateScreen replaces the mutable fields of a saved screen.
+func (s *ScreenerService) UpdateScreen(ctx context.Context, userID, screenID uuid.UUID, input SavedScreenInput) (*models.SavedScreen, error) {
+	screen, err := s.GetScreen(ctx, userID, screenID)
+	if err != nil {
+		return nil, err
+	}
+
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidScreen)
+	}
+	expr, err := s.Validate(input.Expression, input.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	reseed := input.AlertsEnabled && (!screen.AlertsEnabled || screen.Expression != expr.String())
+	screen.Name = input.Name
+	screen.Expression = expr.String()
+	screen.Sort = input.Sort
+	screen.AlertsEnabled = input.AlertsEnabled
+	if reseed {
+		s.seedKnownMarkets(ctx, screen, expr)
+	}
+
+	if err := s.db.WithContext(ctx).Save(screen).Error; err != nil {
+		return nil, fmt.Errorf("failed to update saved screen: %w", err)
+	}
+	return screen, nil
+}
+
+// DeleteScreen removes a saved screen owned by the user.
+func (s *ScreenerService) DeleteScreen(ctx context.Context, userID, screenID uuid.UUID) error {
+	result := s.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", screenID, userID).
+		Delete(&models.SavedScreen{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrScreenNotFound
+	}
+	return nil
+}
+
+// RunSavedScreen evaluates a saved screen for its owner.
+func (s *ScreenerService) RunSavedScreen(ctx context.Context, userID, screenID uuid.UUID, limit, offset int) (*ScreenResult, error) {
+	screen, err := s.GetScreen(ctx, userID, screenID)
+	if err != nil {
+		return nil, err
+	}
+	return s.Run(ctx, ScreenRequest{
+		Expression: screen.Expression,
+		Sort:       screen.Sort,
+		Limit:      limit,
+		Offset:     offset,
+	})
+}
+
+// CheckAlerts evaluates every alert-enabled screen and notifies owners about newly matching markets.
+// Called periodically by the worker.
+func (s *ScreenerService) CheckAlerts(ctx context.Context) error {
+	var screens []models.SavedScreen
+	if err := s.db.WithContext(ctx).Where("alerts_enabled = ?", true).Find(&screens).Error; err != nil {
+		return fmt.Errorf("failed to load alerting screens: %w", err)
+	}
+	if len(screens) == 0 {
+		return nil
+	}
+
+	markets, err := s.markets.loadAllActiveMarkets(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load active markets: %w", err)
+	}
+	s.markets.attachCachedPrices(ctx, markets)
+	now := time.Now().UTC()
+
+	for i := range screens {
+		screen := &screens[i]
+		expr, err := screener.Parse(screen.Expression)
+		if err != nil {
+			logger.Error("ScreenerService: Saved screen %s no longer parses: %v", screen.ID, err)
+			continue
+		}
+
+		var matched []models.Market
+		for j := range markets {
+			if expr.Match(&markets[j], now) {
+				matched = append(matched, markets[j])
+			}
+		}
+
+		updates := map[string]interface{}{
+			"last_match_count": len(matched),
+			"last_checked_at":  &now,
+		}
+		if len(matched) > maxScreenKnownMarkets {
+			// Too broad to track membership; skip alerting rather than re-alerting on untracked matches.
+			logger.Info("ScreenerService: Screen %s matches %d markets, skipping alerts", screen.ID, len(matched))
+		} else {
+			// Only alert against a tracked baseline: a screen that was never seeded or was too broad last time re-seeds.
+			tracked := screen.LastCheckedAt != nil && screen.LastMatchCount <= maxScreenKnownMarkets
+			fresh, seen := trackScreenMatches(screen.SeenMarkets, matched, now, tracked)
+			if len(fresh) > 0 {
+				if err := s.notifyScreenMatches(ctx, screen, fresh); err != nil {
+					logger.Error("ScreenerService: Failed to notify screen %s: %v", screen.ID, err)
+					continue
+				}
+			}
+			updates["seen_markets"] = seen
+		}
+
+		if err := s.db.WithContext(ctx).Model(screen).Updates(updates).Error; err != nil {
+			logger.Error("ScreenerService: Failed to update screen %s: %v", screen.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *ScreenerService) seedKnownMarkets(ctx context.Context, screen *models.SavedScreen, expr *screener.Expression) {
+	matches, err := s.match(ctx, expr)
+	if err != nil {
+		logger.Error("ScreenerService: Failed to seed screen matches: %v", err)
+		// Leave the screen unchecked so the next alert run seeds it instead of alerting.
+		screen.SeenMarkets = models.SeenMarkets{}
+		screen.LastCheckedAt = nil
+		return
+	}
+
+	now := time.Now().UTC()
+	screen.SeenMarkets = models.SeenMarkets{}
+	if len(matches) <= maxScreenKnownMarkets {
+		_, screen.SeenMarkets = trackScreenMatches(nil, matches, now, false)
+	}
+	screen.LastMatchCount = len(matches)
+	screen.LastCheckedAt = &now
+}
+
+// trackScreenMatches merges the current matches into the seen set and returns the matches not seen within
+// screenSeenTTL (none when the baseline is not tracked). Entries older than the TTL are dropped, then the oldest
+// ones beyond maxScreenKnownMarkets.
+func trackScreenMatches(seen models.SeenMarkets, matched []models.Market, now time.Time, tracked bool) ([]models.Market, models.SeenMarkets) {
+	next := make(models.SeenMarkets, len(seen)+len(matched))
+	for id, at := range seen {
+		if now.Sub(at) < screenSeenTTL {
+			next[id] = at
+		}
+	}
+
+	var fresh []models.Market
+	for _, market := range matched {
+		if _, ok := next[market.ConditionID]; !ok && tracked {
+			fresh = append(fresh, market)
+		}
+		next[market.ConditionID] = now
+	}
+
+	if len(next) > maxScreenKnownMarkets {
+		ids := make([]string, 0, len(next))
+		for id := range next {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return next[ids[i]].Before(next[ids[j]]) })
+		for _, id := range ids[:len(next)-maxScreenKnownMarkets] {
+			delete(next, id)
+		}
+	}
+	return fresh, next
+}
+
+func (s *ScreenerService) notifyScreenMatches(ctx context.Context, screen *models.SavedScreen, fresh []models.Market) error {
+	sort.SliceStable(fresh, func(i, j int) bool {
+		return fresh[i].Volume24h > fresh[j].Volume24h
+	})
+
+	data := ScreenAlertData{
+		ScreenID:   screen.ID.String(),
+		ScreenName: screen.Name,
+		NewCount:   len(fresh),
+	}
+	for i, market := range fresh {
+		if i >= maxScreenAlertMarkets {
+			break
+		}
+		data.Markets = append(data.Markets, ScreenAlertMarket{
+			ConditionID: market.ConditionID,
+			Slug:        market.Slug,
+			Title:       market.Title,
+		})
+	}
+
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("%s now matches \"%s\"", fresh[0].Title, screen.Name)
+	if len(fresh) > 1 {
+		message = fmt.Sprintf("%d new markets match \"%s\", including %s", len(fresh), screen.Name, fresh[0].Title)
+	}
+
+	notification := models.Notification{
+		ID:        uuid.New(),
+		UserID:    screen.UserID,
+		Type:      models.NotificationTypeScreenAlert,
+		Title:     fmt.Sprintf("Screen alert: %s", screen.Name),
+		Message:   message,
+		Data:      string(dataJSON),
+		Read:      false,
+		CreatedAt: time.Now(),
+	}
+
+	return s.db.WithContext(ctx).Create(&notification).Error
+}
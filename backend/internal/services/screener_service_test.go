@@ -0,0 +1,102 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bankai-project/backend/internal/models"
+)
+
+func TestTrackScreenMatches(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	markets := func(ids ...string) []models.Market {
+		out := make([]models.Market, len(ids))
+		for i, id := range ids {
+			out[i] = models.Market{ConditionID: id}
+		}
+		return out
+	}
+
+	cases := []struct {
+		name      string
+		seen      models.SeenMarkets
+		matched   []models.Market
+		tracked   bool
+		wantFresh []string
+		wantSeen  []string
+	}{
+		{
+			"new entrant alerts, current matches refreshed",
+			models.SeenMarkets{"a": now.Add(-time.Hour)},
+			markets("a", "b"), true,
+			[]string{"b"}, []string{"a", "b"},
+		},
+		{
+			"market that dropped out stays seen within the TTL",
+			models.SeenMarkets{"a": now.Add(-time.Hour), "b": now.Add(-2 * time.Hour)},
+			markets("a"), true,
+			nil, []string{"a", "b"},
+		},
+		{
+			"re-entry within the TTL does not alert",
+			models.SeenMarkets{"a": now.Add(-time.Hour), "b": now.Add(-screenSeenTTL + time.Minute)},
+			markets("a", "b"), true,
+			nil, []string{"a", "b"},
+		},
+		{
+			"re-entry after the TTL alerts again",
+			models.SeenMarkets{"b": now.Add(-screenSeenTTL - time.Minute)},
+			markets("b"), true,
+			[]string{"b"}, []string{"b"},
+		},
+		{
+			"untracked baseline seeds without alerting",
+			nil,
+			markets("a", "b", "c"), false,
+			nil, []string{"a", "b", "c"},
+		},
+	}
+
+	for _, tc := range cases {
+		fresh, seen := trackScreenMatches(tc.seen, tc.matched, now, tc.tracked)
+		if len(fresh) != len(tc.wantFresh) {
+			t.Errorf("%s: fresh = %v, want %v", tc.name, fresh, tc.wantFresh)
+		} else {
+			for i, m := range fresh {
+				if m.ConditionID != tc.wantFresh[i] {
+					t.Errorf("%s: fresh[%d] = %s, want %s", tc.name, i, m.ConditionID, tc.wantFresh[i])
+				}
+			}
+		}
+		if len(seen) != len(tc.wantSeen) {
+			t.Errorf("%s: seen = %v, want %v", tc.name, seen, tc.wantSeen)
+		}
+		for _, id := range tc.wantSeen {
+			if _, ok := seen[id]; !ok {
+				t.Errorf("%s: %s missing from seen %v", tc.name, id, seen)
+			}
+		}
+		for _, m := range tc.matched {
+			if !seen[m.ConditionID].Equal(now) {
+				t.Errorf("%s: %s last seen %v, want %v", tc.name, m.ConditionID, seen[m.ConditionID], now)
+			}
+		}
+	}
+
+	// The seen set is capped by evicting the markets seen longest ago.
+	seen := make(models.SeenMarkets, maxScreenKnownMarkets)
+	for i := 0; i < maxScreenKnownMarkets; i++ {
+		seen[fmt.Sprintf("old-%d", i)] = now.Add(-time.Duration(i+1) * time.Minute)
+	}
+	_, next := trackScreenMatches(seen, markets("new"), now, true)
+	if len(next) != maxScreenKnownMarkets {
+		t.Fatalf("capped seen set has %d entries, want %d", len(next), maxScreenKnownMarkets)
+	}
+	if _, ok := next[fmt.Sprintf("old-%d", maxScreenKnownMarkets-1)]; ok {
+		t.Errorf("oldest entry survived the cap")
+	}
+	if _, ok := next["new"]; !ok {
+		t.Errorf("current match evicted by the cap")
+	}
+}
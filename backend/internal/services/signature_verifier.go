@@ -8,6 +8,8 @@
  * - In a high-security environment, this would also recover the address from the EIP-712 signature/hash.
  *   However, since the frontend performs the signing and the Backend acts as a relay with
  *   authentication via Clerk, ensuring the Clerk User owns the Signer Address is the primary defense.
+ * - For proxy (1) and Safe (2) signature types the maker is also compared with the CREATE2 wallet derived from the
+ *   signer; a mismatch is logged, not rejected, since the stored vault is authoritative.
  */
 
 package services
@@ -16,8 +18,10 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/bankai-project/backend/internal/logger"
 	"github.com/bankai-project/backend/internal/models"
 	"github.com/bankai-project/backend/internal/polymarket/clob"
+	"github.com/bankai-project/backend/internal/polymarket/relayer"
 )
 
 type SignatureVerifier struct{}
@@ -70,12 +74,12 @@ func (v *SignatureVerifier) VerifyOrderOwnership(user *models.User, order *clob.
 	// Validate signature type loosely against wallet type.
 	// Polymarket accepts raw EOA signatures (type 0) even when the maker is a Proxy or Safe vault.
 	if user.WalletType != nil {
-		allowed := map[int]struct{}{0: {}} // always allow raw EOA signature
+		allowed := map[int]struct{}{clob.SignatureTypeEOA: {}} // always allow raw EOA signature
 		switch *user.WalletType {
 		case models.WalletTypeProxy:
-			allowed[1] = struct{}{}
+			allowed[clob.SignatureTypePolyProxy] = struct{}{}
 		case models.WalletTypeSafe:
-			allowed[2] = struct{}{}
+			allowed[clob.SignatureTypePolyGnosisSafe] = struct{}{}
 		}
 
 		if _, ok := allowed[order.SignatureType]; !ok {
@@ -88,6 +92,23 @@ func (v *SignatureVerifier) VerifyOrderOwnership(user *models.User, order *clob.
 		}
 	}
 
+	// The maker already matched the stored vault above, which stays authoritative. Derivation is only a
+	// cross-check until the factory constants are verified against observed wallets.
+	var derived string
+	var err error
+	switch order.SignatureType {
+	case clob.SignatureTypePolyProxy:
+		derived, err = relayer.DeriveProxyAddress(order.Signer)
+	case clob.SignatureTypePolyGnosisSafe:
+		derived, err = relayer.DeriveSafeAddress(order.Signer)
+	}
+	if err != nil {
+		logger.Error("SignatureVerifier: Failed to derive wallet for signer %s: %v", order.Signer, err)
+	} else if derived != "" && !strings.EqualFold(derived, order.Maker) {
+		logger.Info("SignatureVerifier: Maker %s is not the derived wallet %s of signer %s (signature type %d)",
+			order.Maker, derived, order.Signer, order.SignatureType)
+	}
+
 	// TODO: For strict cryptographic verification, we would:
 	// 1. Reconstruct the EIP-712 typed data hash from the Order struct fields
 	// 2. ecrecover(hash, order.Signature)
@@ -0,0 +1,653 @@
+/**
+ * @description
+ * Tax Lot Service.
+ * Builds cost-basis lots from the user's fills and reports realized PnL per closing trade for a calendar
+ * year under FIFO, LIFO or average-cost accounting, with a CSV export for accountants.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - backend/internal/models
+ * - backend/internal/polymarket/data_api
+ *
+ * @notes
+ * - Fills come from Data API trades for every linked vault: executed price, on-chain timestamp, and whether the
+ *   vault was the taker (takerOnly=true vs false listings of the same wallet).
+ * - Without the Data API (or when it fails) fills fall back to the orders table: matched size at the order price
+ *   on the order's created_at, every fill charged as taker. The report carries a warning saying so.
+ * - Fees follow the CLOB formula (feeRate * min(p, 1-p) * size) on taker fills only, at the fee rate of the
+ *   user's orders for that token: added to basis on buys, deducted from proceeds on sells.
+ * - Positions still open when their market resolves are disposed at the payout (1 or 0) on the market's end date,
+ *   whether or not the user has redeemed yet.
+ * - Shares sold without a recorded acquisition (splits, transfers, unsynced history) get a zero basis and a warning.
+ * - Lots acquired in earlier years carry into the requested year; only disposals inside the year are reported.
+ */
+
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/polymarket/data_api"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	taxLotDust = 1e-9
+
+	taxTradePageSize  = 500
+	taxTradeMaxOffset = 10000
+)
+
+const taxOrderFillWarning = "Data API trades were unavailable, so fills come from synced orders: prices are order limit prices, " +
+	"dates are order creation times and every fill is charged the taker fee"
+
+// TaxLotMethod selects how disposals are matched against lots
+type TaxLotMethod string
+
+const (
+	TaxLotFIFO    TaxLotMethod = "FIFO"
+	TaxLotLIFO    TaxLotMethod = "LIFO"
+	TaxLotAverage TaxLotMethod = "AVERAGE"
+)
+
+// Disposal kinds
+const (
+	TaxDisposalSell   = "SELL"
+	TaxDisposalRedeem = "REDEEM"
+)
+
+var ErrInvalidTaxReport = errors.New("invalid tax report request")
+
+// ParseTaxLotMethod parses a method name, defaulting to FIFO
+func ParseTaxLotMethod(raw string) (TaxLotMethod, error) {
+	switch strings.ToUpper(strings.TrimSpace(raw)) {
+	case "", "FIFO":
+		return TaxLotFIFO, nil
+	case "LIFO":
+		return TaxLotLIFO, nil
+	case "AVERAGE", "AVG", "AVERAGE-COST", "AVERAGE_COST":
+		return TaxLotAverage, nil
+	}
+	return "", fmt.Errorf("%w: method must be FIFO, LIFO or AVERAGE", ErrInvalidTaxReport)
+}
+
+// TaxLot is shares acquired by one buy fill
+type TaxLot struct {
+	OrderID    *uuid.UUID `json:"orderId,omitempty"` // Set for fills taken from the orders table
+	TxHash     string     `json:"txHash,omitempty"`  // Set for fills taken from Data API trades
+	MarketID   string     `json:"marketId"`
+	TokenID    string     `json:"tokenId"`
+	Outcome    string     `json:"outcome"`
+	AcquiredAt time.Time  `json:"acquiredAt"`
+	Size       float64    `json:"size"`
+	Remaining  float64    `json:"remaining"`
+	CostBasis  float64    `json:"costBasis"` // Basis of the remaining shares, fees included
+}
+
+// TaxLotMatch is the part of a lot consumed by a disposal
+type TaxLotMatch struct {
+	OrderID    *uuid.UUID `json:"orderId,omitempty"`
+	TxHash     string     `json:"txHash,omitempty"`
+	AcquiredAt time.Time  `json:"acquiredAt"`
+	Size       float64    `json:"size"`
+	CostBasis  float64    `json:"costBasis"`
+}
+
+// TaxDisposal is a closing trade or redemption with its realized PnL
+type TaxDisposal struct {
+	DisposedAt  time.Time     `json:"disposedAt"`
+	Kind        string        `json:"kind"`
+	OrderID     *uuid.UUID    `json:"orderId,omitempty"` // Nil for redemptions and Data API trades
+	TxHash      string        `json:"txHash,omitempty"`
+	MarketID    string        `json:"marketId"`
+	Title       string        `json:"title"`
+	TokenID     string        `json:"tokenId"`
+	Outcome     string        `json:"outcome"`
+	Size        float64       `json:"size"`
+	Price       float64       `json:"price"`
+	Proceeds    float64       `json:"proceeds"` // Net of fees
+	Fee         float64       `json:"fee"`
+	CostBasis   float64       `json:"costBasis"`
+	RealizedPnL float64       `json:"realizedPnl"`
+	AcquiredAt  *time.Time    `json:"acquiredAt,omitempty"` // Earliest matched lot
+	Lots        []TaxLotMatch `json:"lots"`
+}
+
+// TaxReport is a user's realized PnL for one year
+type TaxReport struct {
+	Year           int           `json:"year"`
+	Method         TaxLotMethod  `json:"method"`
+	Disposals      []TaxDisposal `json:"disposals"`
+	TotalProceeds  float64       `json:"totalProceeds"`
+	TotalCostBasis float64       `json:"totalCostBasis"`
+	TotalFees      float64       `json:"totalFees"`
+	RealizedPnL    float64       `json:"realizedPnl"`
+	OpenLots       []TaxLot      `json:"openLots"` // As of the end of the year
+	Warnings       []string      `json:"warnings,omitempty"`
+	GeneratedAt    time.Time     `json:"generatedAt"`
+}
+
+// TaxLotService computes cost basis and realized PnL from the user's fills
+type TaxLotService struct {
+	db *gorm.DB
+
+	DataAPI *data_api.Client // Optional: executed trades; without it fills are approximated from orders
+}
+
+// NewTaxLotService creates a new TaxLotService
+func NewTaxLotService(db *gorm.DB) *TaxLotService {
+	return &TaxLotService{db: db}
+}
+
+// taxEvent is a fill or redemption on the lot timeline.
+type taxEvent struct {
+	at       time.Time
+	kind     string // BUY, SELL or REDEEM
+	orderID  *uuid.UUID
+	txHash   string
+	marketID string
+	outcome  string
+	tokenID  string
+	price    float64
+	size     float64 // Fills only; redemptions dispose of whatever is left
+	fee      float64
+}
+
+// Report builds the tax report for a calendar year (UTC).
+func (s *TaxLotService) Report(ctx context.Context, userID uuid.UUID, year int, method TaxLotMethod) (*TaxReport, error) {
+	now := time.Now().UTC()
+	if year < 2020 || year > now.Year() {
+		return nil, fmt.Errorf("%w: year must be between 2020 and %d", ErrInvalidTaxReport, now.Year())
+	}
+	yearStart := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	yearEnd := yearStart.AddDate(1, 0, 0)
+
+	events, warnings, err := s.fillEvents(ctx, userID, yearEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &TaxReport{
+		Year:        year,
+		Method:      method,
+		Disposals:   []TaxDisposal{},
+		OpenLots:    []TaxLot{},
+		Warnings:    warnings,
+		GeneratedAt: now,
+	}
+	if len(events) == 0 {
+		report.Warnings = nil
+		return report, nil
+	}
+
+	marketIDs := make(map[string]struct{})
+	for _, ev := range events {
+		if ev.marketID != "" {
+			marketIDs[ev.marketID] = struct{}{}
+		}
+	}
+
+	markets, err := s.loadMarkets(ctx, marketIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, market := range markets {
+		resolvedAt, payouts, ok := marketResolution(market)
+		if !ok || !resolvedAt.Before(yearEnd) || resolvedAt.After(now) {
+			continue
+		}
+		for tokenID, payout := range payouts {
+			events = append(events, taxEvent{at: resolvedAt, kind: TaxDisposalRedeem, tokenID: tokenID, price: payout})
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].at.Before(events[j].at)
+	})
+
+	lots := make(map[string][]*TaxLot)
+	unmatched := make(map[string]float64)
+	for _, ev := range events {
+		switch ev.kind {
+		case string(models.OrderSideBuy):
+			lots[ev.tokenID] = append(lots[ev.tokenID], &TaxLot{
+				OrderID:    ev.orderID,
+				TxHash:     ev.txHash,
+				MarketID:   ev.marketID,
+				TokenID:    ev.tokenID,
+				Outcome:    ev.outcome,
+				AcquiredAt: ev.at,
+				Size:       ev.size,
+				Remaining:  ev.size,
+				CostBasis:  ev.price*ev.size + ev.fee,
+			})
+			continue
+		case TaxDisposalRedeem:
+			ev.size = 0
+			for _, lot := range lots[ev.tokenID] {
+				ev.size += lot.Remaining
+			}
+			if ev.size <= taxLotDust {
+				continue
+			}
+		}
+
+		disposal, missing := disposeLots(lots[ev.tokenID], ev, method)
+		lots[ev.tokenID] = openLots(lots[ev.tokenID])
+		unmatched[ev.tokenID] += missing
+		if ev.at.Before(yearStart) {
+			continue
+		}
+
+		if market, ok := markets[disposal.MarketID]; ok {
+			disposal.Title = market.Title
+		}
+		report.Disposals = append(report.Disposals, disposal)
+		report.TotalProceeds += disposal.Proceeds
+		report.TotalCostBasis += disposal.CostBasis
+		report.TotalFees += disposal.Fee
+		report.RealizedPnL += disposal.RealizedPnL
+	}
+
+	for _, tokenLots := range lots {
+		for _, lot := range tokenLots {
+			report.OpenLots = append(report.OpenLots, *lot)
+		}
+	}
+	sort.Slice(report.OpenLots, func(i, j int) bool {
+		return report.OpenLots[i].AcquiredAt.Before(report.OpenLots[j].AcquiredAt)
+	})
+	for tokenID, size := range unmatched {
+		if size > taxLotDust {
+			report.Warnings = append(report.Warnings,
+				fmt.Sprintf("%.4f shares of token %s were sold without a recorded acquisition and carry a zero cost basis", size, tokenID))
+		}
+	}
+	sort.Strings(report.Warnings)
+	return report, nil
+}
+
+// fillEvents returns the user's buy and sell fills before end, preferring executed Data API trades.
+func (s *TaxLotService) fillEvents(ctx context.Context, userID uuid.UUID, end time.Time) ([]taxEvent, []string, error) {
+	if s.DataAPI != nil {
+		vaults, err := userVaultAddresses(ctx, s.db, userID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(vaults) > 0 {
+			events, warnings, err := s.tradeEvents(ctx, userID, vaults, end)
+			if err == nil {
+				return events, warnings, nil
+			}
+			logger.Error("TaxLotService: Failed to load trades for user %s, falling back to orders: %v", userID, err)
+		}
+	}
+
+	events, err := s.orderEvents(ctx, userID, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	return events, []string{taxOrderFillWarning}, nil
+}
+
+// orderEvents approximates fills from the orders table: matched size at the order price on its creation date.
+func (s *TaxLotService) orderEvents(ctx context.Context, userID uuid.UUID, end time.Time) ([]taxEvent, error) {
+	var orders []models.Order
+	if err := s.db.WithContext(ctx).
+		Where("user_id = ? AND created_at < ?", userID, end).
+		Where("outcome_token_id <> '' AND (size_matched > 0 OR status = ?)", models.OrderStatusFilled).
+		Order("created_at ASC").
+		Find(&orders).Error; err != nil {
+		return nil, fmt.Errorf("failed to load fills: %w", err)
+	}
+
+	var events []taxEvent
+	for i := range orders {
+		o := &orders[i]
+		size := o.FilledSize()
+		if size <= taxLotDust || o.Price <= 0 {
+			continue
+		}
+		id := o.ID
+		events = append(events, taxEvent{
+			at:       o.CreatedAt.UTC(),
+			kind:     string(o.Side),
+			orderID:  &id,
+			marketID: o.MarketID,
+			outcome:  o.Outcome,
+			tokenID:  o.OutcomeTokenID,
+			price:    o.Price,
+			size:     size,
+			fee:      clobFee(o.FeeRateBps, o.Price, size),
+		})
+	}
+	return events, nil
+}
+
+// tradeEvents builds fills from the Data API trades of every vault, charging fees only where the vault was taker.
+func (s *TaxLotService) tradeEvents(ctx context.Context, userID uuid.UUID, vaults []string, end time.Time) ([]taxEvent, []string, error) {
+	var feeRates []struct {
+		OutcomeTokenID string
+		FeeRateBps     int
+	}
+	if err := s.db.WithContext(ctx).Model(&models.Order{}).
+		Select("outcome_token_id, MAX(fee_rate_bps) AS fee_rate_bps").
+		Where("user_id = ? AND outcome_token_id <> ''", userID).
+		Group("outcome_token_id").
+		Scan(&feeRates).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to load fee rates: %w", err)
+	}
+	rates := make(map[string]int, len(feeRates))
+	for _, r := range feeRates {
+		rates[r.OutcomeTokenID] = r.FeeRateBps
+	}
+
+	var events []taxEvent
+	var warnings []string
+	unknownFees := 0
+	for _, vault := range vaults {
+		all, truncated, err := s.fetchTrades(ctx, vault, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		taker, takerTruncated, err := s.fetchTrades(ctx, vault, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		if truncated || takerTruncated {
+			warnings = append(warnings, fmt.Sprintf("Trade history for %s exceeds %d fills; older fills are missing", vault, taxTradeMaxOffset))
+		}
+
+		takerFills := make(map[string]int, len(taker))
+		for _, t := range taker {
+			takerFills[taxTradeKey(t)]++
+		}
+		for _, t := range all {
+			ev, ok := tradeEvent(t)
+			if !ok || !ev.at.Before(end) {
+				continue
+			}
+			if key := taxTradeKey(t); takerFills[key] > 0 {
+				takerFills[key]--
+				rate, known := rates[ev.tokenID]
+				if !known {
+					unknownFees++
+				}
+				ev.fee = clobFee(rate, ev.price, ev.size)
+			}
+			events = append(events, ev)
+		}
+	}
+	if unknownFees > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d taker fills are in markets with no Bankai order to read the fee rate from; their fees are not included", unknownFees))
+	}
+	return events, warnings, nil
+}
+
+// fetchTrades pages through a wallet's trades, reporting whether the offset cap cut the history short.
+func (s *TaxLotService) fetchTrades(ctx context.Context, vault string, takerOnly bool) ([]data_api.Trade, bool, error) {
+	var trades []data_api.Trade
+	for offset := 0; ; offset += taxTradePageSize {
+		if offset > taxTradeMaxOffset {
+			return trades, true, nil
+		}
+		page, err := s.DataAPI.GetTrades(ctx, vault, &data_api.TradesParams{
+			Limit:     taxTradePageSize,
+			Offset:    offset,
+			TakerOnly: &takerOnly,
+		})
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to fetch trades for %s: %w", vault, err)
+		}
+		trades = append(trades, page...)
+		if len(page) < taxTradePageSize {
+			return trades, false, nil
+		}
+	}
+}
+
+// tradeEvent converts a Data API trade to a fill at its executed price and time.
+func tradeEvent(t data_api.Trade) (taxEvent, bool) {
+	tokenID := t.Asset
+	if tokenID == "" {
+		tokenID = t.TokenID
+	}
+	side := strings.ToUpper(t.Side)
+	if tokenID == "" || t.Size <= taxLotDust || t.Price <= 0 || t.Timestamp <= 0 ||
+		(side != string(models.OrderSideBuy) && side != string(models.OrderSideSell)) {
+		return taxEvent{}, false
+	}
+	return taxEvent{
+		at:       time.Unix(t.Timestamp, 0).UTC(),
+		kind:     side,
+		txHash:   t.TxHash,
+		marketID: t.ConditionID,
+		outcome:  t.Outcome,
+		tokenID:  tokenID,
+		price:    t.Price,
+		size:     t.Size,
+	}, true
+}
+
+// taxTradeKey identifies a fill across the takerOnly=true and takerOnly=false listings.
+func taxTradeKey(t data_api.Trade) string {
+	tokenID := t.Asset
+	if tokenID == "" {
+		tokenID = t.TokenID
+	}
+	return fmt.Sprintf("%s|%s|%s|%.6f|%.6f", strings.ToLower(t.TxHash), tokenID, strings.ToUpper(t.Side), t.Size, t.Price)
+}
+
+// disposeLots matches a sell or redemption against the token's open lots, returning the disposal
+// and the size that had no lot to match.
+func disposeLots(lots []*TaxLot, ev taxEvent, method TaxLotMethod) (TaxDisposal, float64) {
+	disposal := TaxDisposal{
+		DisposedAt: ev.at,
+		Kind:       TaxDisposalSell,
+		TokenID:    ev.tokenID,
+		Size:       ev.size,
+		Price:      ev.price,
+		Fee:        ev.fee,
+		Proceeds:   ev.price*ev.size - ev.fee,
+		Lots:       []TaxLotMatch{},
+	}
+	if ev.kind == TaxDisposalRedeem {
+		disposal.Kind = TaxDisposalRedeem
+	} else {
+		disposal.OrderID = ev.orderID
+		disposal.TxHash = ev.txHash
+		disposal.MarketID = ev.marketID
+		disposal.Outcome = ev.outcome
+	}
+
+	consume := func(lot *TaxLot, size float64) {
+		basis := lot.CostBasis * size / lot.Remaining
+		lot.CostBasis -= basis
+		lot.Remaining -= size
+		disposal.CostBasis += basis
+		disposal.Lots = append(disposal.Lots, TaxLotMatch{
+			OrderID:    lot.OrderID,
+			TxHash:     lot.TxHash,
+			AcquiredAt: lot.AcquiredAt,
+			Size:       size,
+			CostBasis:  basis,
+		})
+		if disposal.AcquiredAt == nil || lot.AcquiredAt.Before(*disposal.AcquiredAt) {
+			at := lot.AcquiredAt
+			disposal.AcquiredAt = &at
+		}
+		if disposal.MarketID == "" {
+			disposal.MarketID = lot.MarketID
+			disposal.Outcome = lot.Outcome
+		}
+	}
+
+	remaining := ev.size
+	switch method {
+	case TaxLotAverage:
+		held := 0.0
+		for _, lot := range lots {
+			held += lot.Remaining
+		}
+		if held > taxLotDust {
+			share := math.Min(remaining, held) / held
+			for _, lot := range lots {
+				if lot.Remaining > taxLotDust {
+					consume(lot, lot.Remaining*share)
+				}
+			}
+			remaining -= math.Min(remaining, held)
+		}
+	default:
+		order := make([]*TaxLot, len(lots))
+		copy(order, lots)
+		if method == TaxLotLIFO {
+			for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+				order[i], order[j] = order[j], order[i]
+			}
+		}
+		for _, lot := range order {
+			if remaining <= taxLotDust {
+				break
+			}
+			if lot.Remaining <= taxLotDust {
+				continue
+			}
+			size := math.Min(lot.Remaining, remaining)
+			consume(lot, size)
+			remaining -= size
+		}
+	}
+
+	disposal.RealizedPnL = disposal.Proceeds - disposal.CostBasis
+	if remaining < taxLotDust {
+		remaining = 0
+	}
+	return disposal, remaining
+}
+
+// openLots drops fully consumed lots.
+func openLots(lots []*TaxLot) []*TaxLot {
+	out := lots[:0]
+	for _, lot := range lots {
+		if lot.Remaining > taxLotDust {
+			out = append(out, lot)
+		}
+	}
+	return out
+}
+
+func (s *TaxLotService) loadMarkets(ctx context.Context, ids map[string]struct{}) (map[string]*models.Market, error) {
+	out := make(map[string]*models.Market, len(ids))
+	if len(ids) == 0 {
+		return out, nil
+	}
+	list := make([]string, 0, len(ids))
+	for id := range ids {
+		list = append(list, id)
+	}
+	var markets []models.Market
+	if err := s.db.WithContext(ctx).Where("condition_id IN ?", list).Find(&markets).Error; err != nil {
+		return nil, fmt.Errorf("failed to load markets: %w", err)
+	}
+	for i := range markets {
+		out[markets[i].ConditionID] = &markets[i]
+	}
+	return out, nil
+}
+
+// marketResolution returns when a closed market resolved and the payout per token, if its
+// outcome prices have settled to 0 / 1.
+func marketResolution(market *models.Market) (time.Time, map[string]float64, bool) {
+	if !market.Closed || market.TokenIDYes == "" || market.TokenIDNo == "" {
+		return time.Time{}, nil, false
+	}
+	var raw []interface{}
+	if err := json.Unmarshal([]byte(market.OutcomePrices), &raw); err != nil || len(raw) != 2 {
+		return time.Time{}, nil, false
+	}
+	prices := make([]float64, 0, 2)
+	for _, v := range raw {
+		var p float64
+		switch val := v.(type) {
+		case float64:
+			p = val
+		case string:
+			parsed, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return time.Time{}, nil, false
+			}
+			p = parsed
+		default:
+			return time.Time{}, nil, false
+		}
+		if p != 0 && p != 1 {
+			return time.Time{}, nil, false
+		}
+		prices = append(prices, p)
+	}
+
+	var at time.Time
+	switch {
+	case market.EndDate != nil:
+		at = market.EndDate.UTC()
+	case market.MarketUpdatedAt != nil:
+		at = market.MarketUpdatedAt.UTC()
+	default:
+		return time.Time{}, nil, false
+	}
+	return at, map[string]float64{market.TokenIDYes: prices[0], market.TokenIDNo: prices[1]}, true
+}
+
+// clobFee applies the CLOB fee formula: feeRate * min(p, 1-p) * size.
+func clobFee(feeRateBps int, price, size float64) float64 {
+	if feeRateBps <= 0 {
+		return 0
+	}
+	return float64(feeRateBps) / 10000 * math.Min(price, 1-price) * size
+}
+
+// WriteCSV writes one row per disposal followed by a totals row
+func (r *TaxReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	money := func(v float64) string { return strconv.FormatFloat(v, 'f', 6, 64) }
+	if err := cw.Write([]string{
+		"disposed_at", "acquired_at", "kind", "market_id", "title", "outcome", "token_id",
+		"size", "price", "proceeds", "fee", "cost_basis", "realized_pnl", "method",
+	}); err != nil {
+		return err
+	}
+	for _, d := range r.Disposals {
+		acquired := ""
+		if d.AcquiredAt != nil {
+			acquired = d.AcquiredAt.Format(time.RFC3339)
+		}
+		if err := cw.Write([]string{
+			d.DisposedAt.Format(time.RFC3339), acquired, d.Kind, d.MarketID, d.Title, d.Outcome, d.TokenID,
+			money(d.Size), money(d.Price), money(d.Proceeds), money(d.Fee), money(d.CostBasis), money(d.RealizedPnL),
+			string(r.Method),
+		}); err != nil {
+			return err
+		}
+	}
+	if err := cw.Write([]string{
+		"TOTAL", "", "", "", "", "", "", "", "",
+		money(r.TotalProceeds), money(r.TotalFees), money(r.TotalCostBasis), money(r.RealizedPnL), string(r.Method),
+	}); err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/bankai-project/backend/internal/polymarket/data_api"
+	"github.com/google/uuid"
+)
+
+func TestDisposeLots(t *testing.T) {
+	t1 := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	t2 := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+	sold := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	orderID := uuid.New()
+
+	newLots := func() []*TaxLot {
+		return []*TaxLot{
+			{MarketID: "0xm", TokenID: "tok", Outcome: "Yes", AcquiredAt: t1, Size: 100, Remaining: 100, CostBasis: 40},
+			{MarketID: "0xm", TokenID: "tok", Outcome: "Yes", AcquiredAt: t2, Size: 100, Remaining: 100, CostBasis: 60},
+		}
+	}
+	sell := func(size float64) taxEvent {
+		return taxEvent{at: sold, kind: "SELL", orderID: &orderID, marketID: "0xm", outcome: "Yes", tokenID: "tok", price: 0.7, size: size, fee: 1}
+	}
+
+	cases := []struct {
+		name          string
+		method        TaxLotMethod
+		ev            taxEvent
+		wantKind      string
+		wantProceeds  float64
+		wantBasis     float64
+		wantMissing   float64
+		wantMatches   int
+		wantRemaining []float64 // per lot, in acquisition order
+		wantLotBasis  []float64
+	}{
+		{"FIFO partial", TaxLotFIFO, sell(150), TaxDisposalSell, 104, 70, 0, 2, []float64{0, 50}, []float64{0, 30}},
+		{"LIFO partial", TaxLotLIFO, sell(150), TaxDisposalSell, 104, 80, 0, 2, []float64{50, 0}, []float64{20, 0}},
+		{"average partial", TaxLotAverage, sell(150), TaxDisposalSell, 104, 75, 0, 2, []float64{25, 25}, []float64{10, 15}},
+		{"FIFO within first lot", TaxLotFIFO, sell(40), TaxDisposalSell, 27, 16, 0, 1, []float64{60, 100}, []float64{24, 60}},
+		{"FIFO oversell", TaxLotFIFO, sell(250), TaxDisposalSell, 174, 100, 50, 2, []float64{0, 0}, []float64{0, 0}},
+		{"average oversell", TaxLotAverage, sell(250), TaxDisposalSell, 174, 100, 50, 2, []float64{0, 0}, []float64{0, 0}},
+		{
+			"redemption at payout", TaxLotFIFO,
+			taxEvent{at: sold, kind: TaxDisposalRedeem, tokenID: "tok", price: 1, size: 200},
+			TaxDisposalRedeem, 200, 100, 0, 2, []float64{0, 0}, []float64{0, 0},
+		},
+	}
+
+	for _, tc := range cases {
+		lots := newLots()
+		disposal, missing := disposeLots(lots, tc.ev, tc.method)
+
+		if disposal.Kind != tc.wantKind {
+			t.Errorf("%s: kind = %s, want %s", tc.name, disposal.Kind, tc.wantKind)
+		}
+		if math.Abs(disposal.Proceeds-tc.wantProceeds) > 1e-9 || math.Abs(disposal.CostBasis-tc.wantBasis) > 1e-9 {
+			t.Errorf("%s: proceeds/basis = %.4f/%.4f, want %.4f/%.4f", tc.name, disposal.Proceeds, disposal.CostBasis, tc.wantProceeds, tc.wantBasis)
+		}
+		if math.Abs(disposal.RealizedPnL-(tc.wantProceeds-tc.wantBasis)) > 1e-9 {
+			t.Errorf("%s: realized = %.4f, want %.4f", tc.name, disposal.RealizedPnL, tc.wantProceeds-tc.wantBasis)
+		}
+		if math.Abs(missing-tc.wantMissing) > 1e-9 {
+			t.Errorf("%s: missing = %.4f, want %.4f", tc.name, missing, tc.wantMissing)
+		}
+		if len(disposal.Lots) != tc.wantMatches {
+			t.Errorf("%s: %d lots matched, want %d", tc.name, len(disposal.Lots), tc.wantMatches)
+		}
+		if disposal.AcquiredAt == nil || !disposal.AcquiredAt.Equal(t1) {
+			t.Errorf("%s: acquiredAt = %v, want the earliest matched lot %v", tc.name, disposal.AcquiredAt, t1)
+		}
+		if disposal.MarketID != "0xm" || disposal.Outcome != "Yes" {
+			t.Errorf("%s: market/outcome = %s/%s, want 0xm/Yes", tc.name, disposal.MarketID, disposal.Outcome)
+		}
+		for i, lot := range lots {
+			if math.Abs(lot.Remaining-tc.wantRemaining[i]) > 1e-9 || math.Abs(lot.CostBasis-tc.wantLotBasis[i]) > 1e-9 {
+				t.Errorf("%s: lot %d remaining/basis = %.4f/%.4f, want %.4f/%.4f",
+					tc.name, i, lot.Remaining, lot.CostBasis, tc.wantRemaining[i], tc.wantLotBasis[i])
+			}
+		}
+		if open := openLots(lots); len(open) != countPositive(tc.wantRemaining) {
+			t.Errorf("%s: %d open lots, want %d", tc.name, len(open), countPositive(tc.wantRemaining))
+		}
+	}
+}
+
+func TestTradeEvent(t *testing.T) {
+	cases := []struct {
+		name   string
+		trade  data_api.Trade
+		wantOK bool
+		want   taxEvent
+	}{
+		{
+			"asset preferred over tokenId",
+			data_api.Trade{Asset: "tok", TokenID: "other", ConditionID: "0xm", Outcome: "No", Side: "buy", Price: 0.31, Size: 12, Timestamp: 1736467200, TxHash: "0xabc"},
+			true,
+			taxEvent{at: time.Unix(1736467200, 0).UTC(), kind: "BUY", txHash: "0xabc", marketID: "0xm", outcome: "No", tokenID: "tok", price: 0.31, size: 12},
+		},
+		{"tokenId fallback", data_api.Trade{TokenID: "tok", Side: "SELL", Price: 0.5, Size: 1, Timestamp: 1}, true,
+			taxEvent{at: time.Unix(1, 0).UTC(), kind: "SELL", tokenID: "tok", price: 0.5, size: 1}},
+		{"missing token", data_api.Trade{Side: "BUY", Price: 0.5, Size: 1, Timestamp: 1}, false, taxEvent{}},
+		{"zero size", data_api.Trade{Asset: "tok", Side: "BUY", Price: 0.5, Timestamp: 1}, false, taxEvent{}},
+		{"unknown side", data_api.Trade{Asset: "tok", Side: "MERGE", Price: 0.5, Size: 1, Timestamp: 1}, false, taxEvent{}},
+		{"no timestamp", data_api.Trade{Asset: "tok", Side: "BUY", Price: 0.5, Size: 1}, false, taxEvent{}},
+	}
+	for _, tc := range cases {
+		got, ok := tradeEvent(tc.trade)
+		if ok != tc.wantOK {
+			t.Errorf("%s: ok = %v, want %v", tc.name, ok, tc.wantOK)
+			continue
+		}
+		if ok && got != tc.want {
+			t.Errorf("%s: event = %+v, want %+v", tc.name, got, tc.want)
+		}
+	}
+
+	a := data_api.Trade{Asset: "tok", Side: "buy", Price: 0.31, Size: 12, TxHash: "0xABC"}
+	b := data_api.Trade{TokenID: "tok", Side: "BUY", Price: 0.31, Size: 12.0000001, TxHash: "0xabc"}
+	if taxTradeKey(a) != taxTradeKey(b) {
+		t.Errorf("taxTradeKey(%+v) = %s, taxTradeKey(%+v) = %s; want equal", a, taxTradeKey(a), b, taxTradeKey(b))
+	}
+}
+
+func TestClobFee(t *testing.T) {
+	cases := []struct {
+		bps   int
+		price float64
+		size  float64
+		want  float64
+	}{
+		{0, 0.5, 100, 0},
+		{100, 0.5, 100, 0.5},
+		{100, 0.2, 100, 0.2},
+		{100, 0.9, 100, 0.1},
+		{-5, 0.5, 100, 0},
+	}
+	for _, tc := range cases {
+		if got := clobFee(tc.bps, tc.price, tc.size); math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("clobFee(%d, %.2f, %.0f) = %.6f, want %.6f", tc.bps, tc.price, tc.size, got, tc.want)
+		}
+	}
+}
+
+func countPositive(values []float64) int {
+	n := 0
+	for _, v := range values {
+		if v > taxLotDust {
+			n++
+		}
+	}
+	return n
+}
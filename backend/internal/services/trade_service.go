@@ -17,6 +17,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -32,6 +33,12 @@ import (
 type TradeService struct {
 	DB   *gorm.DB
 	Clob *clob.Client
+
+	// Groups, when set, reconciles OCO/bracket groups whenever order state changes here.
+	Groups *OrderGroupService
+
+	// Risk, when set, reviews synced orders against the user's risk limits.
+	Risk *RiskService
 }
 
 func NewTradeService(db *gorm.DB, clobClient *clob.Client) *TradeService {
@@ -76,8 +83,11 @@ func (s *TradeService) SyncOrdersFromSDK(ctx context.Context, user *models.User,
 			Side:           side,
 			Outcome:        src.Outcome,
 			OutcomeTokenID: src.OutcomeTokenID,
+			MakerAddress:   strings.TrimSpace(src.MakerAddress),
 			Price:          src.Price,
 			Size:           src.Size,
+			SizeMatched:    src.SizeMatched,
+			FeeRateBps:     src.FeeRateBps,
 			OrderType:      strings.ToUpper(strings.TrimSpace(src.OrderType)),
 			Status:         status,
 			StatusDetail:   src.StatusDetail,
@@ -95,13 +105,39 @@ func (s *TradeService) SyncOrdersFromSDK(ctx context.Context, user *models.User,
 	}
 
 	// Upsert on (user_id, clob_order_id)
-	return s.DB.WithContext(ctx).Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "user_id"}, {Name: "clob_order_id"}},
-		DoUpdates: clause.AssignmentColumns([]string{"status", "status_detail", "price", "size", "order_type", "outcome", "outcome_token_id", "order_hashes", "market_id", "updated_at", "source"}),
-	}).Create(&orders).Error
+	if err := s.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "clob_order_id"}},
+		DoUpdates: append(
+			clause.AssignmentColumns([]string{"status", "status_detail", "price", "size", "size_matched", "fee_rate_bps", "order_type", "outcome", "outcome_token_id", "order_hashes", "market_id", "updated_at", "source"}),
+			// Keep the known maker when a later sync omits it
+			clause.Assignment{Column: clause.Column{Name: "maker_address"}, Value: gorm.Expr("COALESCE(NULLIF(EXCLUDED.maker_address, ''), orders.maker_address)")},
+		),
+	}).Create(&orders).Error; err != nil {
+		return err
+	}
+
+	clobIDs := make([]string, 0, len(orders))
+	for _, o := range orders {
+		clobIDs = append(clobIDs, o.CLOBOrderID)
+	}
+	s.reconcileGroups(ctx, user.ID, clobIDs)
+	if s.Risk != nil {
+		s.Risk.ReviewSynced(ctx, user, orders)
+	}
+	return nil
 }
 
-// SyncOrdersByAddress upserts orders and associates them to users by makerAddress (vault or EOA).
+// reconcileGroups lets order groups react to fills and cancellations of their CLOB legs.
+func (s *TradeService) reconcileGroups(ctx context.Context, userID uuid.UUID, clobOrderIDs []string) {
+	if s.Groups == nil || len(clobOrderIDs) == 0 {
+		return
+	}
+	if err := s.Groups.ReconcileForOrders(ctx, userID, clobOrderIDs); err != nil {
+		logger.Error("TradeService: Failed to reconcile order groups for user %s: %v", userID, err)
+	}
+}
+
+// SyncOrdersByAddress upserts orders and associates them to users by makerAddress (vault or EOA of any linked wallet).
 func (s *TradeService) SyncOrdersByAddress(ctx context.Context, synced []SyncedOrder) error {
 	if len(synced) == 0 {
 		return nil
@@ -113,17 +149,15 @@ func (s *TradeService) SyncOrdersByAddress(ctx context.Context, synced []SyncedO
 			continue
 		}
 
-		var user models.User
-		if err := s.DB.WithContext(ctx).
-			Where("LOWER(vault_address) = ? OR LOWER(eoa_address) = ?", addr, addr).
-			First(&user).Error; err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				continue
-			}
+		user, err := s.ownerOfAddress(ctx, addr)
+		if err != nil {
 			return err
 		}
+		if user == nil {
+			continue
+		}
 
-		if err := s.SyncOrdersFromSDK(ctx, &user, []SyncedOrder{src}); err != nil {
+		if err := s.SyncOrdersFromSDK(ctx, user, []SyncedOrder{src}); err != nil {
 			return err
 		}
 	}
@@ -131,6 +165,69 @@ func (s *TradeService) SyncOrdersByAddress(ctx context.Context, synced []SyncedO
 	return nil
 }
 
+// ownerOfAddress resolves a lowercased vault or EOA address to one user: a linked wallet first (primary links,
+// then the earliest), otherwise the earliest user row carrying it. Returns nil when nobody owns it.
+func (s *TradeService) ownerOfAddress(ctx context.Context, addr string) (*models.User, error) {
+	var wallets []models.UserWallet
+	if err := s.DB.WithContext(ctx).
+		Where("LOWER(vault_address) = ? OR LOWER(eoa_address) = ?", addr, addr).
+		Find(&wallets).Error; err != nil {
+		return nil, fmt.Errorf("failed to look up wallet owner: %w", err)
+	}
+
+	query := s.DB.WithContext(ctx)
+	if owner := walletAddressOwner(wallets); owner != uuid.Nil {
+		query = query.Where("id = ?", owner)
+	} else {
+		query = query.Where("LOWER(vault_address) = ? OR LOWER(eoa_address) = ?", addr, addr)
+	}
+	var users []models.User
+	if err := query.Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("failed to look up wallet owner: %w", err)
+	}
+	return earliestUser(users), nil
+}
+
+// walletAddressOwner returns the user owning the first of wallets by link precedence (primary links, then the
+// earliest), or uuid.Nil when there are none.
+func walletAddressOwner(wallets []models.UserWallet) uuid.UUID {
+	var best *models.UserWallet
+	for i := range wallets {
+		w := &wallets[i]
+		switch {
+		case best == nil:
+		case w.IsPrimary != best.IsPrimary:
+			if !w.IsPrimary {
+				continue
+			}
+		case !w.CreatedAt.Equal(best.CreatedAt):
+			if w.CreatedAt.After(best.CreatedAt) {
+				continue
+			}
+		case w.ID.String() > best.ID.String():
+			continue
+		}
+		best = w
+	}
+	if best == nil {
+		return uuid.Nil
+	}
+	return best.UserID
+}
+
+// earliestUser returns the earliest created of users (then the lowest ID), or nil.
+func earliestUser(users []models.User) *models.User {
+	var best *models.User
+	for i := range users {
+		u := &users[i]
+		if best == nil || u.CreatedAt.Before(best.CreatedAt) ||
+			(u.CreatedAt.Equal(best.CreatedAt) && u.ID.String() < best.ID.String()) {
+			best = u
+		}
+	}
+	return best
+}
+
 func mapSDKStatus(raw string) models.OrderStatus {
 	switch strings.ToLower(strings.TrimSpace(raw)) {
 	case "open", "live", "unmatched":
@@ -158,6 +255,8 @@ type SyncedOrder struct {
 	Side           string             `json:"side"`
 	Price          float64            `json:"price"`
 	Size           float64            `json:"size"`
+	SizeMatched    float64            `json:"sizeMatched"`
+	FeeRateBps     int                `json:"feeRateBps"`
 	OrderType      string             `json:"orderType"`
 	Status         string             `json:"status"`
 	StatusDetail   string             `json:"statusDetail"`
@@ -167,9 +266,10 @@ type SyncedOrder struct {
 	UpdatedAt      time.Time          `json:"updatedAt"`
 }
 
-func (s *TradeService) persistOrder(ctx context.Context, user *models.User, req *clob.PostOrderRequest, resp *clob.PostOrderResponse) error {
+// persistOrder records a Bankai-submitted order and the CLOB's response in the orders table.
+func (s *TradeService) persistOrder(ctx context.Context, user *models.User, req *clob.PostOrderRequest, resp *clob.PostOrderResponse) (*models.Order, error) {
 	if resp == nil {
-		return errors.New("clob response cannot be nil")
+		return nil, errors.New("clob response cannot be nil")
 	}
 
 	orderID := resp.OrderID
@@ -180,12 +280,14 @@ func (s *TradeService) persistOrder(ctx context.Context, user *models.User, req
 	// Parse numeric fields
 	makerAmt, err := strconv.ParseFloat(req.Order.MakerAmount, 64)
 	if err != nil {
-		return fmt.Errorf("invalid makerAmount: %w", err)
+		return nil, fmt.Errorf("invalid makerAmount: %w", err)
 	}
 	takerAmt, err := strconv.ParseFloat(req.Order.TakerAmount, 64)
 	if err != nil {
-		return fmt.Errorf("invalid takerAmount: %w", err)
+		return nil, fmt.Errorf("invalid takerAmount: %w", err)
 	}
+	side := normalizeClobSide(req.Order.Side)
+	feeRateBps, _ := strconv.Atoi(req.Order.FeeRateBps)
 
 	// Try to find market by token ID to determine outcome label
 	var market models.Market
@@ -204,11 +306,11 @@ func (s *TradeService) persistOrder(ctx context.Context, user *models.User, req
 			return &market
 		}
 		return nil
-	}(), req.Order.TokenID, req.Order.Side)
+	}(), req.Order.TokenID, side)
 
 	// Calculate price and size from atomic units
 	var dbPrice, dbSize float64
-	if req.Order.Side == clob.BUY {
+	if side == clob.BUY {
 		if takerAmt > 0 {
 			dbPrice = makerAmt / takerAmt
 		}
@@ -223,23 +325,40 @@ func (s *TradeService) persistOrder(ctx context.Context, user *models.User, req
 	order := models.Order{
 		UserID:         user.ID,
 		CLOBOrderID:    orderID,
-		Side:           models.OrderSide(req.Order.Side),
+		Side:           models.OrderSide(side),
 		Outcome:        outcomeLabel,
 		OutcomeTokenID: req.Order.TokenID,
+		MakerAddress:   req.Order.Maker,
 		Price:          dbPrice,
 		Size:           dbSize,
+		FeeRateBps:     feeRateBps,
 		OrderType:      string(req.OrderType),
 		Status:         mapClobStatus(resp, req.OrderType),
 		StatusDetail:   strings.ToLower(resp.Status),
 		OrderHashes:    models.StringArray(resp.OrderHashes),
 		ErrorMessage:   resp.ErrorMsg,
+		Source:         models.OrderSourceBankai,
 	}
 
 	if marketFound {
 		order.MarketID = market.ConditionID
 	}
 
-	return s.DB.WithContext(ctx).Create(&order).Error
+	if err := s.DB.WithContext(ctx).Create(&order).Error; err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// normalizeClobSide maps the numeric side encoding ("0"/"1") used by some signers onto BUY/SELL.
+func normalizeClobSide(side clob.OrderSide) clob.OrderSide {
+	switch strings.ToUpper(strings.TrimSpace(string(side))) {
+	case "0", string(clob.BUY):
+		return clob.BUY
+	case "1", string(clob.SELL):
+		return clob.SELL
+	}
+	return side
 }
 
 // validateOrderAmounts checks price/size alignment to market tick & min-size rules to catch payload issues pre-flight.
@@ -271,8 +390,9 @@ func (s *TradeService) validateOrderAmounts(ctx context.Context, order *clob.Ord
 	}
 
 	// Compute price from amounts (shares priced in USDC, 1e6 decimals).
+	side := normalizeClobSide(order.Side)
 	var price float64
-	switch order.Side {
+	switch side {
 	case clob.BUY:
 		price = makerAmt / takerAmt
 	case clob.SELL:
@@ -295,7 +415,7 @@ func (s *TradeService) validateOrderAmounts(ctx context.Context, order *clob.Ord
 
 	// Validate min size (shares are taker for BUY, maker for SELL, measured in full units not atomic).
 	shares := takerAmt / 1e6
-	if order.Side == clob.SELL {
+	if side == clob.SELL {
 		shares = makerAmt / 1e6
 	}
 	if shares < minSize {
@@ -529,6 +649,7 @@ func (s *TradeService) CancelOrder(ctx context.Context, user *models.User, order
 		}).Error; err != nil {
 			logger.Error("Failed to update order %s cancellation status: %v", orderID, err)
 		}
+		s.reconcileGroups(ctx, user.ID, resp.Canceled)
 	}
 
 	return resp, nil
@@ -536,6 +657,12 @@ func (s *TradeService) CancelOrder(ctx context.Context, user *models.User, order
 
 // CancelOrders cancels multiple orders for the user and updates their status.
 func (s *TradeService) CancelOrders(ctx context.Context, user *models.User, orderIDs []string) (*clob.CancelResponse, error) {
+	return s.CancelOrdersWithCredentials(ctx, user, orderIDs, nil)
+}
+
+// CancelOrdersWithCredentials cancels orders signing with the given user L2 credentials.
+// Used by server-side flows (order groups) that act on the user's behalf; nil sends builder headers only.
+func (s *TradeService) CancelOrdersWithCredentials(ctx context.Context, user *models.User, orderIDs []string, creds *clob.APIKeyCredentials) (*clob.CancelResponse, error) {
 	if user == nil {
 		return nil, errors.New("user context is required")
 	}
@@ -554,7 +681,7 @@ func (s *TradeService) CancelOrders(ctx context.Context, user *models.User, orde
 		return nil, fmt.Errorf("one or more orders do not belong to the user")
 	}
 
-	resp, err := s.Clob.CancelOrders(ctx, &clob.CancelOrdersRequest{OrderIDs: orderIDs}, nil)
+	resp, err := s.Clob.CancelOrders(ctx, &clob.CancelOrdersRequest{OrderIDs: orderIDs}, creds)
 	if err != nil {
 		return nil, err
 	}
@@ -568,13 +695,161 @@ func (s *TradeService) CancelOrders(ctx context.Context, user *models.User, orde
 			}).Error; err != nil {
 			logger.Error("Failed to update canceled orders for user %s: %v", user.ID, err)
 		}
+		s.reconcileGroups(ctx, user.ID, resp.Canceled)
 	}
 
 	return resp, nil
 }
 
-// ListOrders returns paginated order history for a user.
-func (s *TradeService) ListOrders(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.Order, int64, error) {
+// ErrInvalidCancel is returned for malformed cancel-all / cancel-market requests.
+var ErrInvalidCancel = errors.New("invalid cancel request")
+
+// CancelOutcome is the result of a bulk cancel for a single CLOB order.
+type CancelOutcome struct {
+	OrderID  string `json:"orderId"`
+	Canceled bool   `json:"canceled"`
+	Reason   string `json:"reason,omitempty"`
+	MarketID string `json:"marketId,omitempty"`
+	Outcome  string `json:"outcome,omitempty"`
+	Side     string `json:"side,omitempty"`
+	Tracked  bool   `json:"tracked"` // False when the order was never synced to Postgres
+}
+
+// BulkCancelResult summarizes a cancel-all or cancel-by-market request.
+type BulkCancelResult struct {
+	MarketID    string          `json:"marketId,omitempty"`
+	TokenID     string          `json:"tokenId,omitempty"`
+	Canceled    int             `json:"canceled"`
+	NotCanceled int             `json:"notCanceled"`
+	Orders      []CancelOutcome `json:"orders"`
+}
+
+// CancelAllOrders cancels every open order on the user's CLOB API key.
+// Credentials are required because the CLOB scopes cancel-all to the signing key.
+func (s *TradeService) CancelAllOrders(ctx context.Context, user *models.User, creds clob.APIKeyCredentials) (*BulkCancelResult, error) {
+	if user == nil {
+		return nil, errors.New("user context is required")
+	}
+	if err := requireCancelCredentials(creds); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.Clob.CancelAll(ctx, &creds)
+	if err != nil {
+		return nil, err
+	}
+	return s.applyBulkCancel(ctx, user, resp, &BulkCancelResult{})
+}
+
+// CancelMarketOrders cancels the user's open orders in one market, optionally narrowed to a single outcome token.
+func (s *TradeService) CancelMarketOrders(ctx context.Context, user *models.User, conditionID, tokenID string, creds clob.APIKeyCredentials) (*BulkCancelResult, error) {
+	if user == nil {
+		return nil, errors.New("user context is required")
+	}
+	conditionID = strings.TrimSpace(conditionID)
+	tokenID = strings.TrimSpace(tokenID)
+	if conditionID == "" {
+		return nil, fmt.Errorf("%w: condition_id is required", ErrInvalidCancel)
+	}
+	if err := requireCancelCredentials(creds); err != nil {
+		return nil, err
+	}
+
+	if tokenID != "" {
+		var count int64
+		if err := s.DB.WithContext(ctx).Model(&models.Market{}).
+			Where("condition_id = ? AND (token_id_yes = ? OR token_id_no = ?)", conditionID, tokenID, tokenID).
+			Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("failed to verify market token: %w", err)
+		}
+		if count == 0 {
+			return nil, fmt.Errorf("%w: token %s does not belong to market %s", ErrInvalidCancel, tokenID, conditionID)
+		}
+	}
+
+	resp, err := s.Clob.CancelMarketOrders(ctx, &clob.CancelMarketOrdersRequest{Market: conditionID, AssetID: tokenID}, &creds)
+	if err != nil {
+		return nil, err
+	}
+	return s.applyBulkCancel(ctx, user, resp, &BulkCancelResult{MarketID: conditionID, TokenID: tokenID})
+}
+
+// applyBulkCancel marks canceled orders in Postgres and builds per-order outcomes.
+func (s *TradeService) applyBulkCancel(ctx context.Context, user *models.User, resp *clob.CancelResponse, result *BulkCancelResult) (*BulkCancelResult, error) {
+	ids := bulkCancelIDs(resp)
+
+	tracked := make(map[string]models.Order)
+	if len(ids) > 0 {
+		var orders []models.Order
+		if err := s.DB.WithContext(ctx).
+			Where("user_id = ? AND clob_order_id IN ?", user.ID, ids).
+			Find(&orders).Error; err != nil {
+			logger.Error("Failed to load orders for bulk cancel for user %s: %v", user.ID, err)
+		}
+		for _, o := range orders {
+			tracked[o.CLOBOrderID] = o
+		}
+	}
+
+	if len(resp.Canceled) > 0 {
+		if err := s.DB.WithContext(ctx).Model(&models.Order{}).
+			Where("user_id = ? AND clob_order_id IN ?", user.ID, resp.Canceled).
+			Updates(map[string]interface{}{
+				"status":        models.OrderStatusCanceled,
+				"status_detail": "canceled",
+			}).Error; err != nil {
+			logger.Error("Failed to update canceled orders for user %s: %v", user.ID, err)
+		}
+		s.reconcileGroups(ctx, user.ID, resp.Canceled)
+	}
+
+	fillBulkCancelResult(result, resp, tracked)
+	return result, nil
+}
+
+// bulkCancelIDs lists the canceled order IDs in CLOB order, then the not-canceled ones sorted.
+func bulkCancelIDs(resp *clob.CancelResponse) []string {
+	ids := make([]string, 0, len(resp.Canceled)+len(resp.NotCanceled))
+	ids = append(ids, resp.Canceled...)
+	for id := range resp.NotCanceled {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids[len(resp.Canceled):])
+	return ids
+}
+
+// fillBulkCancelResult builds one outcome per order in resp, enriched from the tracked Postgres orders.
+func fillBulkCancelResult(result *BulkCancelResult, resp *clob.CancelResponse, tracked map[string]models.Order) {
+	ids := bulkCancelIDs(resp)
+	result.Orders = make([]CancelOutcome, 0, len(ids))
+	for i, id := range ids {
+		outcome := CancelOutcome{OrderID: id, Canceled: i < len(resp.Canceled)}
+		if !outcome.Canceled {
+			outcome.Reason = resp.NotCanceled[id]
+		}
+		if o, ok := tracked[id]; ok {
+			outcome.Tracked = true
+			outcome.MarketID = o.MarketID
+			outcome.Outcome = o.Outcome
+			outcome.Side = string(o.Side)
+		}
+		result.Orders = append(result.Orders, outcome)
+	}
+	result.Canceled = len(resp.Canceled)
+	result.NotCanceled = len(resp.NotCanceled)
+}
+
+func requireCancelCredentials(creds clob.APIKeyCredentials) error {
+	if strings.TrimSpace(creds.Key) == "" || strings.TrimSpace(creds.Secret) == "" || strings.TrimSpace(creds.Passphrase) == "" {
+		return fmt.Errorf("%w: credentials key, secret and passphrase are required", ErrInvalidCancel)
+	}
+	return nil
+}
+
+// ListOrders returns paginated order history for a user, optionally limited to orders made by makers.
+// includeUnattributed also keeps orders with no recorded maker; pass it when the primary wallet is selected,
+// since those orders predate multiple wallets or came from syncs without a maker.
+func (s *TradeService) ListOrders(ctx context.Context, userID uuid.UUID, makers []string, includeUnattributed bool, limit, offset int) ([]models.Order, int64, error) {
 	if limit <= 0 {
 		limit = 50
 	}
@@ -588,6 +863,17 @@ func (s *TradeService) ListOrders(ctx context.Context, userID uuid.UUID, limit,
 	var total int64
 	query := s.DB.WithContext(ctx).Model(&models.Order{}).
 		Where("user_id = ?", userID)
+	if len(makers) > 0 {
+		lowered := make([]string, 0, len(makers))
+		for _, m := range makers {
+			lowered = append(lowered, strings.ToLower(m))
+		}
+		if includeUnattributed {
+			query = query.Where("(LOWER(maker_address) IN ? OR maker_address IS NULL OR maker_address = '')", lowered)
+		} else {
+			query = query.Where("LOWER(maker_address) IN ?", lowered)
+		}
+	}
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, fmt.Errorf("failed to count orders: %w", err)
 	}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/polymarket/clob"
+	"github.com/google/uuid"
+)
+
+func TestFillBulkCancelResult(t *testing.T) {
+	resp := &clob.CancelResponse{
+		Canceled: []string{"0xc2", "0xc1"},
+		NotCanceled: map[string]string{
+			"0xn2": "order already matched",
+			"0xn1": "order not found",
+		},
+	}
+	tracked := map[string]models.Order{
+		"0xc1": {CLOBOrderID: "0xc1", MarketID: "0xm1", Outcome: "Yes", Side: models.OrderSideBuy},
+		"0xn2": {CLOBOrderID: "0xn2", MarketID: "0xm2", Outcome: "No", Side: models.OrderSideSell},
+	}
+
+	result := &BulkCancelResult{MarketID: "0xm1"}
+	fillBulkCancelResult(result, resp, tracked)
+
+	want := []CancelOutcome{
+		{OrderID: "0xc2", Canceled: true},
+		{OrderID: "0xc1", Canceled: true, MarketID: "0xm1", Outcome: "Yes", Side: "BUY", Tracked: true},
+		{OrderID: "0xn1", Reason: "order not found"},
+		{OrderID: "0xn2", Reason: "order already matched", MarketID: "0xm2", Outcome: "No", Side: "SELL", Tracked: true},
+	}
+	if !reflect.DeepEqual(result.Orders, want) {
+		t.Errorf("outcomes = %+v\nwant %+v", result.Orders, want)
+	}
+	if result.Canceled != 2 || result.NotCanceled != 2 || result.MarketID != "0xm1" {
+		t.Errorf("summary = %d canceled, %d not canceled, market %q", result.Canceled, result.NotCanceled, result.MarketID)
+	}
+
+	empty := &BulkCancelResult{}
+	fillBulkCancelResult(empty, &clob.CancelResponse{}, nil)
+	if empty.Orders == nil || len(empty.Orders) != 0 || empty.Canceled != 0 || empty.NotCanceled != 0 {
+		t.Errorf("empty response = %+v, want an empty outcome list", empty)
+	}
+}
+
+func TestOwnerOfAddressPrecedence(t *testing.T) {
+	t0 := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	userA, userB, userC := uuid.New(), uuid.New(), uuid.New()
+	lowID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	highID := uuid.MustParse("ffffffff-0000-0000-0000-000000000001")
+
+	cases := []struct {
+		name    string
+		wallets []models.UserWallet
+		want    uuid.UUID
+	}{
+		{"no wallets", nil, uuid.Nil},
+		{"single link", []models.UserWallet{{ID: uuid.New(), UserID: userA, CreatedAt: t0}}, userA},
+		{"primary link beats an earlier one", []models.UserWallet{
+			{ID: uuid.New(), UserID: userA, CreatedAt: t0},
+			{ID: uuid.New(), UserID: userB, CreatedAt: t0.Add(time.Hour), IsPrimary: true},
+		}, userB},
+		{"earliest link without a primary", []models.UserWallet{
+			{ID: uuid.New(), UserID: userA, CreatedAt: t0.Add(time.Hour)},
+			{ID: uuid.New(), UserID: userB, CreatedAt: t0},
+			{ID: uuid.New(), UserID: userC, CreatedAt: t0.Add(2 * time.Hour)},
+		}, userB},
+		{"earliest primary", []models.UserWallet{
+			{ID: uuid.New(), UserID: userA, CreatedAt: t0.Add(time.Hour), IsPrimary: true},
+			{ID: uuid.New(), UserID: userB, CreatedAt: t0},
+			{ID: uuid.New(), UserID: userC, CreatedAt: t0.Add(-time.Hour), IsPrimary: true},
+		}, userC},
+		{"same time falls back to the lowest id", []models.UserWallet{
+			{ID: highID, UserID: userA, CreatedAt: t0},
+			{ID: lowID, UserID: userB, CreatedAt: t0},
+		}, userB},
+	}
+	for _, tc := range cases {
+		if got := walletAddressOwner(tc.wallets); got != tc.want {
+			t.Errorf("%s: owner = %s, want %s", tc.name, got, tc.want)
+		}
+	}
+
+	users := []models.User{
+		{ID: highID, CreatedAt: t0},
+		{ID: userA, CreatedAt: t0.Add(time.Hour)},
+		{ID: lowID, CreatedAt: t0},
+	}
+	if got := earliestUser(users); got == nil || got.ID != lowID {
+		t.Errorf("earliest user = %v, want %s", got, lowID)
+	}
+	if got := earliestUser(nil); got != nil {
+		t.Errorf("earliest of none = %v, want nil", got)
+	}
+}
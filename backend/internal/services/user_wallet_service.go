@@ -0,0 +1,540 @@
+/**
+ * @description
+ * User Wallet Service.
+ * Manages the signer wallets linked to a user account: linking with a signed ownership challenge, labels,
+ * the primary flag and unlinking, and resolves the wallet selector that orders, portfolio and balance
+ * endpoints accept.
+ *
+ * @dependencies
+ * - gorm.io/gorm
+ * - github.com/redis/go-redis/v9
+ * - github.com/ethereum/go-ethereum/crypto
+ * - backend/internal/polymarket/relayer
+ *
+ * @notes
+ * - The primary wallet is mirrored onto users.eoa_address / vault_address / wallet_type, so code that reads
+ *   the user row keeps acting on the primary wallet.
+ * - Linking proves ownership with a personal_sign of a one-time challenge kept in Redis for walletLinkTTL.
+ * - An EOA belongs to one account at most (unique index on LOWER(eoa_address)), so deposits and synced orders
+ *   resolve to a single owner.
+ * - A vault supplied when linking is only attached when it is the Safe or proxy derived from the EOA.
+ * - Wallet rows are only created by the signed Link flow. User sync never links the unsigned connected EOA;
+ *   it only promotes a wallet the user already linked.
+ */
+
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bankai-project/backend/internal/logger"
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/bankai-project/backend/internal/polymarket/relayer"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+const (
+	walletLinkTTL     = 10 * time.Minute
+	walletLabelMaxLen = 64
+
+	// WalletSelectorAll selects every linked wallet
+	WalletSelectorAll = "all"
+)
+
+var (
+	ErrInvalidUserWallet      = errors.New("invalid wallet request")
+	ErrUserWalletNotFound     = errors.New("wallet not found")
+	ErrUserWalletExists       = errors.New("wallet is already linked")
+	ErrWalletChallengeExpired = errors.New("wallet link challenge not found or expired")
+)
+
+// UserWalletService manages the wallets linked to user accounts
+type UserWalletService struct {
+	db      *gorm.DB
+	redis   *redis.Client
+	relayer *relayer.Client
+}
+
+// NewUserWalletService creates a new UserWalletService
+func NewUserWalletService(db *gorm.DB, rdb *redis.Client, relayerClient *relayer.Client) *UserWalletService {
+	return &UserWalletService{
+		db:      db,
+		redis:   rdb,
+		relayer: relayerClient,
+	}
+}
+
+// WalletLinkChallenge is the message a wallet must personal_sign to be linked
+type WalletLinkChallenge struct {
+	Address   string    `json:"address"`
+	Message   string    `json:"message"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// LinkWalletInput links a signer wallet to the user
+type LinkWalletInput struct {
+	Address      string `json:"address"`
+	Signature    string `json:"signature"`
+	Label        string `json:"label"`
+	VaultAddress string `json:"vault_address"` // Optional; detected when empty
+	Primary      bool   `json:"primary"`
+}
+
+// UpdateWalletInput changes a linked wallet's label or makes it primary
+type UpdateWalletInput struct {
+	Label   *string `json:"label"`
+	Primary bool    `json:"primary"`
+}
+
+// List returns the user's linked wallets, primary first.
+func (s *UserWalletService) List(ctx context.Context, userID uuid.UUID) ([]models.UserWallet, error) {
+	var wallets []models.UserWallet
+	if err := s.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("is_primary DESC, created_at ASC").
+		Find(&wallets).Error; err != nil {
+		return nil, fmt.Errorf("failed to load wallets: %w", err)
+	}
+	return wallets, nil
+}
+
+// Challenge issues the one-time message address must sign to be linked to the user.
+func (s *UserWalletService) Challenge(ctx context.Context, user *models.User, address string) (*WalletLinkChallenge, error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("%w: invalid address %q", ErrInvalidUserWallet, address)
+	}
+	addr := common.HexToAddress(address).Hex()
+	if linked, err := s.findByEOA(ctx, s.db, addr); err != nil {
+		return nil, err
+	} else if linked != nil {
+		return nil, ErrUserWalletExists
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate challenge: %w", err)
+	}
+	expires := time.Now().Add(walletLinkTTL).UTC()
+	challenge := &WalletLinkChallenge{
+		Address: addr,
+		Message: fmt.Sprintf("Link wallet %s to Bankai account %s\nNonce: %s\nExpires: %s",
+			addr, user.ID, hex.EncodeToString(nonce), expires.Format(time.RFC3339)),
+		ExpiresAt: expires,
+	}
+	if err := s.redis.Set(ctx, walletLinkKey(user.ID, addr), challenge.Message, walletLinkTTL).Err(); err != nil {
+		return nil, fmt.Errorf("failed to store challenge: %w", err)
+	}
+	return challenge, nil
+}
+
+// Link verifies the signed challenge and links the wallet to the user.
+func (s *UserWalletService) Link(ctx context.Context, user *models.User, input LinkWalletInput) (*models.UserWallet, error) {
+	if !common.IsHexAddress(input.Address) {
+		return nil, fmt.Errorf("%w: invalid address %q", ErrInvalidUserWallet, input.Address)
+	}
+	addr := common.HexToAddress(input.Address).Hex()
+	label, err := normalizeWalletLabel(input.Label)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := s.redis.GetDel(ctx, walletLinkKey(user.ID, addr)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrWalletChallengeExpired
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load challenge: %w", err)
+	}
+	signer, err := recoverMessageSigner(message, input.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidUserWallet, err)
+	}
+	if !strings.EqualFold(signer, addr) {
+		return nil, fmt.Errorf("%w: signature is from %s, expected %s", ErrInvalidUserWallet, signer, addr)
+	}
+
+	wallet := &models.UserWallet{
+		UserID:     user.ID,
+		EOAAddress: addr,
+		Label:      label,
+	}
+	if err := s.attachVault(ctx, wallet, input.VaultAddress); err != nil {
+		return nil, err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if existing, err := s.findByEOA(ctx, tx, addr); err != nil {
+			return err
+		} else if existing != nil {
+			return ErrUserWalletExists
+		}
+		var linked int64
+		if err := tx.Model(&models.UserWallet{}).Where("user_id = ?", user.ID).Count(&linked).Error; err != nil {
+			return fmt.Errorf("failed to count wallets: %w", err)
+		}
+		if err := tx.Create(wallet).Error; err != nil {
+			if isUniqueViolation(err) {
+				return ErrUserWalletExists
+			}
+			return fmt.Errorf("failed to link wallet: %w", err)
+		}
+		if input.Primary || linked == 0 {
+			return setPrimaryWallet(tx, user.ID, wallet)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("UserWalletService: Linked wallet %s to user %s", addr, user.ID)
+	return wallet, nil
+}
+
+// Update changes a wallet's label and, when requested, makes it the primary wallet.
+func (s *UserWalletService) Update(ctx context.Context, user *models.User, walletID uuid.UUID, input UpdateWalletInput) (*models.UserWallet, error) {
+	wallet, err := s.Get(ctx, user.ID, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if input.Label != nil {
+			label, err := normalizeWalletLabel(*input.Label)
+			if err != nil {
+				return err
+			}
+			if err := tx.Model(wallet).Update("label", label).Error; err != nil {
+				return fmt.Errorf("failed to update wallet: %w", err)
+			}
+			wallet.Label = label
+		}
+		if input.Primary && !wallet.IsPrimary {
+			return setPrimaryWallet(tx, user.ID, wallet)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return wallet, nil
+}
+
+// Unlink removes a wallet. Unlinking the primary promotes the oldest remaining wallet, if any.
+func (s *UserWalletService) Unlink(ctx context.Context, user *models.User, walletID uuid.UUID) error {
+	wallet, err := s.Get(ctx, user.ID, walletID)
+	if err != nil {
+		return err
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(wallet).Error; err != nil {
+			return fmt.Errorf("failed to unlink wallet: %w", err)
+		}
+		if !wallet.IsPrimary {
+			return nil
+		}
+
+		var next models.UserWallet
+		err := tx.Where("user_id = ?", user.ID).Order("created_at ASC").Limit(1).Find(&next).Error
+		if err != nil {
+			return fmt.Errorf("failed to load remaining wallets: %w", err)
+		}
+		if next.ID != uuid.Nil {
+			return setPrimaryWallet(tx, user.ID, &next)
+		}
+		return tx.Model(&models.User{}).Where("id = ?", user.ID).Updates(map[string]interface{}{
+			"eoa_address":   "",
+			"vault_address": "",
+			"wallet_type":   gorm.Expr("NULL"),
+			"updated_at":    time.Now(),
+		}).Error
+	})
+}
+
+// Get returns one of the user's wallets.
+func (s *UserWalletService) Get(ctx context.Context, userID, walletID uuid.UUID) (*models.UserWallet, error) {
+	var wallet models.UserWallet
+	if err := s.db.WithContext(ctx).Where("id = ? AND user_id = ?", walletID, userID).First(&wallet).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserWalletNotFound
+		}
+		return nil, fmt.Errorf("failed to load wallet: %w", err)
+	}
+	return &wallet, nil
+}
+
+// Resolve turns a wallet selector into the user as seen through each selected wallet.
+// "" selects the primary wallet (the user row as is), "all" every linked wallet, anything else one wallet
+// by ID, EOA or vault address.
+func (s *UserWalletService) Resolve(ctx context.Context, user *models.User, selector string) ([]*models.User, error) {
+	selector = strings.TrimSpace(selector)
+	if selector == "" {
+		return []*models.User{user}, nil
+	}
+
+	wallets, err := s.List(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return resolveWalletSelector(user, wallets, selector)
+}
+
+// resolveWalletSelector applies a non-empty selector to the user's wallets (see Resolve).
+func resolveWalletSelector(user *models.User, wallets []models.UserWallet, selector string) ([]*models.User, error) {
+	if strings.EqualFold(selector, WalletSelectorAll) {
+		if len(wallets) == 0 {
+			return []*models.User{user}, nil
+		}
+		views := make([]*models.User, 0, len(wallets))
+		for i := range wallets {
+			views = append(views, wallets[i].ApplyTo(user))
+		}
+		return views, nil
+	}
+
+	for i := range wallets {
+		w := &wallets[i]
+		if w.ID.String() == selector || strings.EqualFold(w.EOAAddress, selector) ||
+			(w.VaultAddress != "" && strings.EqualFold(w.VaultAddress, selector)) {
+			return []*models.User{w.ApplyTo(user)}, nil
+		}
+	}
+	return nil, ErrUserWalletNotFound
+}
+
+// ForMaker returns the user as seen through the linked wallet whose vault or EOA is maker.
+func (s *UserWalletService) ForMaker(ctx context.Context, user *models.User, maker string) (*models.User, error) {
+	return userForMaker(ctx, s.db, user, maker)
+}
+
+// SyncPrimary keeps the linked wallets in step with the user's connected EOA. Wallet rows are only created by
+// Link, which proves ownership: a connected EOA the user has linked becomes primary, while an unlinked one
+// (or one linked to another account) only clears the primary flag. The user row's vault is attached to the
+// wallet when it is the Safe or proxy derived from the EOA; otherwise the wallet keeps the vault it has.
+func (s *UserWalletService) SyncPrimary(ctx context.Context, clerkID string) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.Where("clerk_id = ?", clerkID).First(&user).Error; err != nil {
+			return fmt.Errorf("failed to load user: %w", err)
+		}
+		clearPrimary := func() error {
+			return tx.Model(&models.UserWallet{}).
+				Where("user_id = ? AND is_primary", user.ID).
+				Update("is_primary", false).Error
+		}
+		if user.EOAAddress == "" {
+			return clearPrimary()
+		}
+
+		existing, err := s.findByEOA(ctx, tx, user.EOAAddress)
+		if err != nil {
+			return err
+		}
+		if existing == nil || existing.UserID != user.ID {
+			logger.Info("UserWalletService: EOA %s of user %s is not linked to the account; it must be linked with a signed challenge",
+				user.EOAAddress, user.ID)
+			return clearPrimary()
+		}
+
+		wallet := *existing
+		mirror := true
+		if user.VaultAddress != "" {
+			if wType, ok := deriveWalletType(wallet.EOAAddress, user.VaultAddress); ok {
+				wallet.VaultAddress = common.HexToAddress(user.VaultAddress).Hex()
+				wallet.WalletType = &wType
+			} else {
+				// Keep the user's vault on the user row, but never attach an unverified vault to the wallet.
+				logger.Info("UserWalletService: Vault %s is not the Safe or proxy of %s; not attaching it to the wallet",
+					user.VaultAddress, wallet.EOAAddress)
+				mirror = false
+			}
+		}
+		if err := tx.Save(&wallet).Error; err != nil {
+			return fmt.Errorf("failed to save wallet: %w", err)
+		}
+		if !mirror {
+			return markPrimaryWallet(tx, user.ID, &wallet)
+		}
+		return setPrimaryWallet(tx, user.ID, &wallet)
+	})
+}
+
+// attachVault sets the wallet's vault from the requested address, or detects a deployed Safe.
+func (s *UserWalletService) attachVault(ctx context.Context, wallet *models.UserWallet, vault string) error {
+	vault = strings.TrimSpace(vault)
+	if vault != "" {
+		wType, ok := deriveWalletType(wallet.EOAAddress, vault)
+		if !ok {
+			return fmt.Errorf("%w: vault %s is not the Safe or proxy of %s", ErrInvalidUserWallet, vault, wallet.EOAAddress)
+		}
+		wallet.VaultAddress = common.HexToAddress(vault).Hex()
+		wallet.WalletType = &wType
+		return nil
+	}
+
+	if s.relayer == nil {
+		return nil
+	}
+	safe, err := relayer.DeriveSafeAddress(wallet.EOAAddress)
+	if err != nil {
+		return nil
+	}
+	deployed, err := s.relayer.GetDeployed(ctx, safe)
+	if err != nil {
+		logger.Error("UserWalletService: Relayer deployed lookup failed for %s: %v", wallet.EOAAddress, err)
+		return nil
+	}
+	if deployed {
+		wType := models.WalletTypeSafe
+		wallet.VaultAddress = safe
+		wallet.WalletType = &wType
+	}
+	return nil
+}
+
+// findByEOA returns the wallet linked to eoa by any user, or nil.
+func (s *UserWalletService) findByEOA(ctx context.Context, db *gorm.DB, eoa string) (*models.UserWallet, error) {
+	var wallet models.UserWallet
+	if err := db.WithContext(ctx).
+		Where("LOWER(eoa_address) = LOWER(?)", eoa).
+		Limit(1).Find(&wallet).Error; err != nil {
+		return nil, fmt.Errorf("failed to load wallet: %w", err)
+	}
+	if wallet.ID == uuid.Nil {
+		return nil, nil
+	}
+	return &wallet, nil
+}
+
+// setPrimaryWallet flags wallet as the user's only primary wallet and mirrors it onto the users row.
+func setPrimaryWallet(tx *gorm.DB, userID uuid.UUID, wallet *models.UserWallet) error {
+	if err := markPrimaryWallet(tx, userID, wallet); err != nil {
+		return err
+	}
+
+	updates := map[string]interface{}{
+		"eoa_address":   wallet.EOAAddress,
+		"vault_address": wallet.VaultAddress,
+		"wallet_type":   gorm.Expr("NULL"),
+		"updated_at":    time.Now(),
+	}
+	if wallet.WalletType != nil {
+		updates["wallet_type"] = *wallet.WalletType
+	}
+	if err := tx.Model(&models.User{}).Where("id = ?", userID).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to update user wallet: %w", err)
+	}
+	return nil
+}
+
+// markPrimaryWallet flags wallet as the user's only primary wallet without touching the users row.
+func markPrimaryWallet(tx *gorm.DB, userID uuid.UUID, wallet *models.UserWallet) error {
+	if err := tx.Model(&models.UserWallet{}).
+		Where("user_id = ? AND is_primary AND id <> ?", userID, wallet.ID).
+		Update("is_primary", false).Error; err != nil {
+		return fmt.Errorf("failed to clear primary wallet: %w", err)
+	}
+	if err := tx.Model(wallet).Update("is_primary", true).Error; err != nil {
+		return fmt.Errorf("failed to set primary wallet: %w", err)
+	}
+	wallet.IsPrimary = true
+	return nil
+}
+
+// userForMaker returns user as seen through the wallet (primary or linked) whose vault or EOA is maker,
+// or nil when the user does not own maker.
+func userForMaker(ctx context.Context, db *gorm.DB, user *models.User, maker string) (*models.User, error) {
+	maker = strings.TrimSpace(maker)
+	if maker == "" {
+		return nil, nil
+	}
+	if strings.EqualFold(maker, user.VaultAddress) || strings.EqualFold(maker, user.EOAAddress) {
+		return user, nil
+	}
+
+	var wallet models.UserWallet
+	if err := db.WithContext(ctx).
+		Where("user_id = ? AND (LOWER(vault_address) = LOWER(?) OR LOWER(eoa_address) = LOWER(?))", user.ID, maker, maker).
+		Limit(1).Find(&wallet).Error; err != nil {
+		return nil, fmt.Errorf("failed to load wallet: %w", err)
+	}
+	if wallet.ID == uuid.Nil {
+		return nil, nil
+	}
+	return wallet.ApplyTo(user), nil
+}
+
+// userVaultAddresses returns the distinct vaults of the user's primary and linked wallets, primary first.
+func userVaultAddresses(ctx context.Context, db *gorm.DB, userID uuid.UUID) ([]string, error) {
+	var addresses []string
+	if err := db.WithContext(ctx).Model(&models.UserWallet{}).
+		Where("user_id = ? AND vault_address IS NOT NULL AND vault_address <> ''", userID).
+		Pluck("vault_address", &addresses).Error; err != nil {
+		return nil, fmt.Errorf("failed to load wallets: %w", err)
+	}
+	var primary []string
+	if err := db.WithContext(ctx).Model(&models.User{}).
+		Where("id = ? AND vault_address IS NOT NULL AND vault_address <> ''", userID).
+		Pluck("vault_address", &primary).Error; err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var vaults []string
+	for _, addr := range append(primary, addresses...) {
+		key := strings.ToLower(addr)
+		if !seen[key] {
+			seen[key] = true
+			vaults = append(vaults, addr)
+		}
+	}
+	return vaults, nil
+}
+
+// recoverMessageSigner recovers the address that personal_signed message.
+func recoverMessageSigner(message, signature string) (string, error) {
+	sig, err := hexutil.Decode(signature)
+	if err != nil || len(sig) != 65 {
+		return "", fmt.Errorf("invalid signature")
+	}
+	sig = append([]byte(nil), sig...)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	pub, err := crypto.SigToPub(accounts.TextHash([]byte(message)), sig)
+	if err != nil {
+		return "", fmt.Errorf("failed to recover signer: %w", err)
+	}
+	return crypto.PubkeyToAddress(*pub).Hex(), nil
+}
+
+func normalizeWalletLabel(label string) (string, error) {
+	label = strings.TrimSpace(label)
+	if len(label) > walletLabelMaxLen {
+		return "", fmt.Errorf("%w: label must be at most %d characters", ErrInvalidUserWallet, walletLabelMaxLen)
+	}
+	return label, nil
+}
+
+func walletLinkKey(userID uuid.UUID, address string) string {
+	return fmt.Sprintf("wallet:link:%s:%s", userID, strings.ToLower(address))
+}
+
+// isUniqueViolation reports whether err is a Postgres unique_violation (SQLSTATE 23505).
+func isUniqueViolation(err error) bool {
+	var pgErr interface{ SQLState() string }
+	return errors.As(err, &pgErr) && pgErr.SQLState() == "23505"
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bankai-project/backend/internal/models"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+)
+
+func TestRecoverMessageSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	signer := crypto.PubkeyToAddress(key.PublicKey).Hex()
+	const message = "Link this wallet to your account.\nNonce: 42"
+
+	raw, err := crypto.Sign(accounts.TextHash([]byte(message)), key)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	withV := func(offset byte) string {
+		sig := append([]byte(nil), raw...)
+		sig[64] += offset
+		return hexutil.Encode(sig)
+	}
+
+	for _, tc := range []struct {
+		name      string
+		signature string
+	}{
+		{"wallet style v (27/28)", withV(27)},
+		{"raw v (0/1)", withV(0)},
+	} {
+		got, err := recoverMessageSigner(message, tc.signature)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if got != signer {
+			t.Errorf("%s: signer = %s, want %s", tc.name, got, signer)
+		}
+	}
+
+	// A different message recovers a different address rather than failing.
+	if got, err := recoverMessageSigner(message+" ", withV(27)); err != nil || strings.EqualFold(got, signer) {
+		t.Errorf("tampered message: signer = %s, err = %v; want another address", got, err)
+	}
+
+	for _, bad := range []string{"", "0x", "not-hex", hexutil.Encode(raw[:64]), hexutil.Encode(append(raw, 0))} {
+		if _, err := recoverMessageSigner(message, bad); err == nil {
+			t.Errorf("signature %q: expected an error", bad)
+		}
+	}
+}
+
+func TestResolveWalletSelector(t *testing.T) {
+	safe := models.WalletTypeSafe
+	user := &models.User{ID: uuid.New(), EOAAddress: "0xPrimaryEOA", VaultAddress: "0xPrimaryVault"}
+	wallets := []models.UserWallet{
+		{ID: uuid.New(), UserID: user.ID, EOAAddress: "0xPrimaryEOA", VaultAddress: "0xPrimaryVault", IsPrimary: true},
+		{ID: uuid.New(), UserID: user.ID, EOAAddress: "0xSecondEOA", VaultAddress: "0xSecondVault", WalletType: &safe},
+		{ID: uuid.New(), UserID: user.ID, EOAAddress: "0xThirdEOA"},
+	}
+
+	cases := []struct {
+		name     string
+		wallets  []models.UserWallet
+		selector string
+		want     []string // EOA of each returned view
+		wantErr  error
+	}{
+		{"all", wallets, "all", []string{"0xPrimaryEOA", "0xSecondEOA", "0xThirdEOA"}, nil},
+		{"all is case-insensitive", wallets, "ALL", []string{"0xPrimaryEOA", "0xSecondEOA", "0xThirdEOA"}, nil},
+		{"all without linked wallets", nil, "all", []string{"0xPrimaryEOA"}, nil},
+		{"by id", wallets, wallets[1].ID.String(), []string{"0xSecondEOA"}, nil},
+		{"by eoa", wallets, "0xthirdeoa", []string{"0xThirdEOA"}, nil},
+		{"by vault", wallets, "0xSECONDVAULT", []string{"0xSecondEOA"}, nil},
+		{"unknown", wallets, "0xNobody", nil, ErrUserWalletNotFound},
+		{"empty vault never matches", wallets[2:], "", nil, ErrUserWalletNotFound},
+	}
+	for _, tc := range cases {
+		views, err := resolveWalletSelector(user, tc.wallets, tc.selector)
+		if tc.wantErr != nil {
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("%s: err = %v, want %v", tc.name, err, tc.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tc.name, err)
+			continue
+		}
+		if len(views) != len(tc.want) {
+			t.Errorf("%s: got %d views, want %d", tc.name, len(views), len(tc.want))
+			continue
+		}
+		for i, view := range views {
+			if view.EOAAddress != tc.want[i] || view.ID != user.ID {
+				t.Errorf("%s: view %d = %s (user %s), want %s", tc.name, i, view.EOAAddress, view.ID, tc.want[i])
+			}
+		}
+	}
+
+	second, _ := resolveWalletSelector(user, wallets, "0xSecondVault")
+	if second[0].WalletType == nil || *second[0].WalletType != safe || user.EOAAddress != "0xPrimaryEOA" {
+		t.Errorf("wallet view = %+v; the user row must be left unchanged", second[0])
+	}
+}
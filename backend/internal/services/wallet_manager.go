@@ -33,6 +33,7 @@ type WalletManager struct {
 	DB      *gorm.DB
 	Relayer *relayer.Client
 	Gamma   *gamma.Client
+	Wallets *UserWalletService // Optional; keeps the primary linked wallet in step with the user row
 }
 
 const (
@@ -83,6 +84,7 @@ func (s *WalletManager) EnsureWallet(ctx context.Context, clerkID string) (*mode
 				user.WalletType = nil
 			}
 		} else {
+			s.syncWalletType(ctx, &user)
 			return &user, nil
 		}
 	}
@@ -131,7 +133,7 @@ func (s *WalletManager) EnsureWallet(ctx context.Context, clerkID string) (*mode
 		logger.Error("Failed to derive safe address for user %s: %v", user.ClerkID, err)
 	}
 
-	logger.Info("🧐 User %s (EOA: %s) has no vault. Awaiting SAFE-CREATE signature.", user.ClerkID, user.EOAAddress)
+	logger.Info("🧐 User %s (EOA: %s) has no vault. Awaiting a Safe or proxy deployment signature.", user.ClerkID, user.EOAAddress)
 	return &user, nil
 }
 
@@ -149,9 +151,13 @@ func (s *WalletManager) UpdateVaultAddress(ctx context.Context, clerkID, vaultAd
 		updates["wallet_type"] = *wType
 	}
 
-	return s.DB.WithContext(ctx).Model(&models.User{}).
+	if err := s.DB.WithContext(ctx).Model(&models.User{}).
 		Where("clerk_id = ?", clerkID).
-		Updates(updates).Error
+		Updates(updates).Error; err != nil {
+		return err
+	}
+	s.syncPrimaryWallet(ctx, clerkID)
+	return nil
 }
 
 // ClearVaultAddress removes any cached vault metadata for the user (used when their EOA changes).
@@ -162,9 +168,22 @@ func (s *WalletManager) ClearVaultAddress(ctx context.Context, clerkID string) e
 		"updated_at":    time.Now(),
 	}
 
-	return s.DB.WithContext(ctx).Model(&models.User{}).
+	if err := s.DB.WithContext(ctx).Model(&models.User{}).
 		Where("clerk_id = ?", clerkID).
-		Updates(updates).Error
+		Updates(updates).Error; err != nil {
+		return err
+	}
+	s.syncPrimaryWallet(ctx, clerkID)
+	return nil
+}
+
+func (s *WalletManager) syncPrimaryWallet(ctx context.Context, clerkID string) {
+	if s.Wallets == nil {
+		return
+	}
+	if err := s.Wallets.SyncPrimary(ctx, clerkID); err != nil {
+		logger.Error("Failed to sync primary wallet for user %s: %v", clerkID, err)
+	}
 }
 
 // lookupVaultAddress attempts to find an existing vault by querying public Polymarket data (proxy wallets).
@@ -175,10 +194,15 @@ func (s *WalletManager) lookupVaultAddress(ctx context.Context, eoa string) (str
 	}
 
 	if addr, err := s.lookupProxyVault(ctx, eoa); err == nil && addr != "" {
-		wType := models.WalletTypeProxy
+		// Gamma reports Safes as proxyWallet too; derivation tells the two apart when it can.
+		wType, ok := deriveWalletType(eoa, addr)
+		if !ok {
+			logger.Info("Gamma vault %s is not derived from %s; keeping it as a proxy", addr, eoa)
+			wType = models.WalletTypeProxy
+		}
 		return addr, &wType, nil
 	} else if err != nil {
-		logger.Info("[WARN] Proxy vault lookup failed for %s: %v", eoa, err)
+		logger.Error("Proxy vault lookup failed for %s: %v", eoa, err)
 	}
 
 	return "", nil, nil
@@ -202,7 +226,7 @@ func (s *WalletManager) lookupProxyVault(ctx context.Context, eoa string) (strin
 		for attempt := 0; attempt < gammaRetryAttempts; attempt++ {
 			profiles, err := s.Gamma.SearchProfiles(ctx, q, 10)
 			if err != nil {
-				logger.Info("[WARN] Gamma search attempt %d failed for %s: %v", attempt+1, q, err)
+				logger.Error("Gamma search attempt %d failed for %s: %v", attempt+1, q, err)
 				select {
 				case <-ctx.Done():
 					return "", ctx.Err()
@@ -247,10 +271,42 @@ func (s *WalletManager) vaultMatchesEOA(user *models.User) bool {
 		return strings.EqualFold(derived, user.VaultAddress)
 	}
 
-	// For proxy wallets we cannot deterministically validate ownership, so assume valid.
+	// Proxy and untyped vaults (Gamma, /wallet/update) are trusted; derivation only classifies them.
 	return true
 }
 
+// syncWalletType sets the stored wallet type when derivation shows which kind the vault is.
+// Vaults derivation cannot place are kept as they are.
+func (s *WalletManager) syncWalletType(ctx context.Context, user *models.User) {
+	if user.EOAAddress == "" {
+		return
+	}
+	wType, ok := deriveWalletType(user.EOAAddress, user.VaultAddress)
+	if !ok {
+		logger.Info("Vault %s for user %s is not derived from EOA %s; keeping it", user.VaultAddress, user.ClerkID, user.EOAAddress)
+		return
+	}
+	if user.WalletType != nil && *user.WalletType == wType {
+		return
+	}
+	if err := s.UpdateVaultAddress(ctx, user.ClerkID, user.VaultAddress, &wType); err != nil {
+		logger.Error("Failed to correct wallet type for user %s: %v", user.ClerkID, err)
+		return
+	}
+	user.WalletType = &wType
+}
+
+// deriveWalletType reports whether vault is eoa's Safe or proxy wallet, and which.
+func deriveWalletType(eoa, vault string) (models.WalletType, bool) {
+	if proxy, err := relayer.DeriveProxyAddress(eoa); err == nil && strings.EqualFold(proxy, vault) {
+		return models.WalletTypeProxy, true
+	}
+	if safe, err := relayer.DeriveSafeAddress(eoa); err == nil && strings.EqualFold(safe, vault) {
+		return models.WalletTypeSafe, true
+	}
+	return "", false
+}
+
 // awaitVaultRegistration polls Gamma for a short duration after a successful relayer call.
 // This covers the short delay between relayer deployment and Gamma profile propagation.
 func (s *WalletManager) awaitVaultRegistration(ctx context.Context, eoa string) (string, error) {
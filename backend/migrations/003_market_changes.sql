@@ -0,0 +1,22 @@
+/**
+ * Migration: Market Change Log
+ *
+ * Adds tables for:
+ * - market_changes: Field-level history of rule, date and status edits detected during Gamma sync
+ *
+ * Note: Only tracked fields are diffed (see services.trackedMarketFields); volume/liquidity churn is ignored.
+ */
+
+-- 1. Market Changes Table
+-- One row per changed field per sync pass
+CREATE TABLE IF NOT EXISTS market_changes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    market_id VARCHAR(66) NOT NULL,
+    field VARCHAR(64) NOT NULL,
+    old_value TEXT,
+    new_value TEXT,
+    detected_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_market_changes_market ON market_changes(market_id, detected_at DESC);
+CREATE INDEX IF NOT EXISTS idx_market_changes_detected ON market_changes(detected_at DESC);
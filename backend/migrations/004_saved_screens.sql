@@ -0,0 +1,27 @@
+/**
+ * Migration: Market Screener
+ *
+ * Adds tables for:
+ * - saved_screens: Per-user screener expressions with optional new-match alerts
+ *
+ * Note: seen_markets maps each condition ID a screen has matched to when it last matched, so alerts only fire
+ * for new entrants and a market that briefly drops out of a screen does not alert again when it comes back.
+ */
+
+-- 1. Saved Screens Table
+CREATE TABLE IF NOT EXISTS saved_screens (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(128) NOT NULL,
+    expression TEXT NOT NULL,
+    sort VARCHAR(32),
+    alerts_enabled BOOLEAN DEFAULT FALSE,
+    seen_markets JSONB NOT NULL DEFAULT '{}',
+    last_match_count INTEGER DEFAULT 0,
+    last_checked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_screens_user ON saved_screens(user_id);
+CREATE INDEX IF NOT EXISTS idx_saved_screens_alerts ON saved_screens(alerts_enabled) WHERE alerts_enabled = TRUE;
@@ -0,0 +1,18 @@
+/**
+ * Migration: Market Calendar Feeds
+ *
+ * Adds tables for:
+ * - calendar_feeds: Secret per-user tokens for the iCalendar (.ics) feed of bookmarked and held markets
+ *
+ * Note: Calendar apps cannot send Clerk tokens, so the feed URL itself is the credential; rotating replaces the token.
+ */
+
+-- 1. Calendar Feeds Table
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    last_accessed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
@@ -0,0 +1,34 @@
+/**
+ * Migration: Incremental Market Sync
+ *
+ * Adds tables for:
+ * - market_sync_runs: One row per Gamma sync pass with timing, delta counts and errors
+ *
+ * Note: Deltas are detected from markets.market_updated_at; index it so the stored-state scan stays cheap.
+ */
+
+-- 1. Market Sync Runs Table
+CREATE TABLE IF NOT EXISTS market_sync_runs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    trigger VARCHAR(32) NOT NULL,
+    mode VARCHAR(16) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    dry_run BOOLEAN DEFAULT FALSE,
+    pages INTEGER DEFAULT 0,
+    events_seen INTEGER DEFAULT 0,
+    markets_seen INTEGER DEFAULT 0,
+    markets_created INTEGER DEFAULT 0,
+    markets_updated INTEGER DEFAULT 0,
+    markets_unchanged INTEGER DEFAULT 0,
+    markets_archived INTEGER DEFAULT 0,
+    error_count INTEGER DEFAULT 0,
+    errors TEXT[] DEFAULT '{}',
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ,
+    duration_ms BIGINT DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_market_sync_runs_started ON market_sync_runs(started_at DESC);
+
+-- 2. Delta detection support
+CREATE INDEX IF NOT EXISTS idx_markets_open_updated ON markets(condition_id, market_updated_at) WHERE archived = FALSE;
@@ -0,0 +1,37 @@
+/**
+ * Migration: Conditional Orders
+ *
+ * Adds tables for:
+ * - conditional_orders: Pre-signed FOK/FAK sell orders held server-side until a price trigger fires
+ *
+ * Note: encrypted_payload holds the signed order and the user's CLOB API credentials (AES-GCM, BANKAI_ENCRYPTION_KEY).
+ */
+
+-- 1. Conditional Orders Table
+CREATE TABLE IF NOT EXISTS conditional_orders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    market_id VARCHAR(66) NOT NULL,
+    token_id VARCHAR(255) NOT NULL,
+    outcome VARCHAR(64),
+    kind VARCHAR(16) NOT NULL,
+    trigger_source VARCHAR(16) NOT NULL,
+    trigger_price DECIMAL NOT NULL,
+    order_type VARCHAR(4) NOT NULL,
+    limit_price DECIMAL,
+    size DECIMAL,
+    encrypted_payload TEXT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'ARMED',
+    observed_price DECIMAL,
+    order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    clob_order_id VARCHAR(255),
+    error_msg TEXT,
+    expires_at TIMESTAMPTZ,
+    triggered_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_conditional_orders_user ON conditional_orders(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_conditional_orders_armed ON conditional_orders(token_id) WHERE status = 'ARMED';
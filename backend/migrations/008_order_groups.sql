@@ -0,0 +1,49 @@
+/**
+ * Migration: Order Groups
+ *
+ * Adds tables for:
+ * - order_groups: OCO and bracket groups linking an entry order with take-profit / stop legs
+ * - order_group_legs: One row per leg, pointing at a CLOB order or a conditional order
+ *
+ * Note: encrypted_credentials holds the user's CLOB API credentials so the worker can cancel sibling legs.
+ * Legs track size and filled_size so partial fills count: a partly matched OCO leg cancels its siblings, and
+ * bracket exits are only armed once the entry matched enough shares to cover them.
+ */
+
+-- 1. Order Groups Table
+CREATE TABLE IF NOT EXISTS order_groups (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(16) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    market_id VARCHAR(66),
+    encrypted_credentials TEXT NOT NULL,
+    fired_leg_id UUID,
+    status_reason TEXT,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_groups_user ON order_groups(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_order_groups_open ON order_groups(status) WHERE status IN ('PENDING_ENTRY', 'ACTIVE');
+
+-- 2. Order Group Legs Table
+CREATE TABLE IF NOT EXISTS order_group_legs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    group_id UUID NOT NULL REFERENCES order_groups(id) ON DELETE CASCADE,
+    role VARCHAR(16) NOT NULL,
+    clob_order_id VARCHAR(255),
+    conditional_order_id UUID REFERENCES conditional_orders(id) ON DELETE SET NULL,
+    status VARCHAR(16) NOT NULL,
+    size DECIMAL NOT NULL DEFAULT 0,
+    filled_size DECIMAL NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_group_legs_group ON order_group_legs(group_id);
+CREATE INDEX IF NOT EXISTS idx_order_group_legs_clob ON order_group_legs(clob_order_id) WHERE clob_order_id IS NOT NULL;
+
+-- 3. Link conditional orders to their group
+ALTER TABLE conditional_orders ADD COLUMN IF NOT EXISTS group_id UUID REFERENCES order_groups(id) ON DELETE SET NULL;
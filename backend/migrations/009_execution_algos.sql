@@ -0,0 +1,58 @@
+/**
+ * Migration: Execution Algos
+ *
+ * Adds tables for:
+ * - execution_algos: TWAP / iceberg schedulers that release pre-signed child orders over time
+ * - execution_algo_children: The encrypted child orders and their fills
+ */
+
+-- 1. Execution Algos Table
+CREATE TABLE IF NOT EXISTS execution_algos (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(16) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    market_id VARCHAR(66) NOT NULL,
+    token_id VARCHAR(255) NOT NULL,
+    outcome VARCHAR(64),
+    side VARCHAR(4) NOT NULL,
+    total_size DECIMAL NOT NULL,
+    filled_size DECIMAL NOT NULL DEFAULT 0,
+    filled_notional DECIMAL NOT NULL DEFAULT 0,
+    limit_price DECIMAL,
+    interval_seconds INTEGER NOT NULL,
+    encrypted_credentials TEXT NOT NULL,
+    children_submitted INTEGER NOT NULL DEFAULT 0,
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    status_reason TEXT,
+    next_release_at TIMESTAMPTZ,
+    ends_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_execution_algos_user ON execution_algos(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_execution_algos_due ON execution_algos(next_release_at) WHERE status IN ('RUNNING', 'PAUSED');
+
+-- 2. Execution Algo Children Table
+CREATE TABLE IF NOT EXISTS execution_algo_children (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    algo_id UUID NOT NULL REFERENCES execution_algos(id) ON DELETE CASCADE,
+    seq INTEGER NOT NULL,
+    size DECIMAL NOT NULL,
+    limit_price DECIMAL NOT NULL,
+    encrypted_payload TEXT NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    clob_order_id VARCHAR(255),
+    filled_size DECIMAL NOT NULL DEFAULT 0,
+    avg_price DECIMAL,
+    error_msg TEXT,
+    submitted_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (algo_id, seq)
+);
+
+CREATE INDEX IF NOT EXISTS idx_execution_algo_children_queue ON execution_algo_children(algo_id, seq) WHERE status = 'QUEUED';
@@ -0,0 +1,34 @@
+/**
+ * Migration: Risk Limits
+ *
+ * Adds tables for:
+ * - risk_limits: Per-user pre-trade limits (order notional, market/event exposure, daily loss, blocked categories)
+ *
+ * Also adds markets.event_id so exposure can be aggregated per Gamma event, and users.role so managed limits
+ * are set by an authenticated admin (risk_limits.managed_by records who last set them).
+ * Note: event_id is backfilled by the next full market sync. Roles are granted directly in the database, e.g.
+ *   UPDATE users SET role = 'ADMIN' WHERE email = '...';
+ */
+
+-- 1. Risk Limits Table
+CREATE TABLE IF NOT EXISTS risk_limits (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    max_order_notional DECIMAL,
+    max_market_exposure DECIMAL,
+    max_event_exposure DECIMAL,
+    daily_loss_limit DECIMAL,
+    blocked_categories TEXT[] NOT NULL DEFAULT '{}',
+    locked BOOLEAN NOT NULL DEFAULT FALSE,
+    locked_reason TEXT,
+    managed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- 2. Event grouping for markets
+ALTER TABLE markets ADD COLUMN IF NOT EXISTS event_id VARCHAR(64);
+CREATE INDEX IF NOT EXISTS idx_markets_event_id ON markets(event_id);
+
+-- 3. User Roles
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(16) NOT NULL DEFAULT 'USER';
@@ -0,0 +1,79 @@
+/**
+ * Migration: Paper Trading
+ *
+ * Adds tables for:
+ * - paper_accounts: One virtual USDC account per user
+ * - paper_orders: Simulated orders matched against the live order books
+ * - paper_fills: Individual simulated executions (taker or maker)
+ * - paper_positions: Virtual outcome token holdings with cost basis
+ */
+
+-- 1. Paper Accounts Table
+CREATE TABLE IF NOT EXISTS paper_accounts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    starting_balance DECIMAL NOT NULL,
+    cash_balance DECIMAL NOT NULL,
+    reserved_cash DECIMAL NOT NULL DEFAULT 0,
+    realized_pnl DECIMAL NOT NULL DEFAULT 0,
+    reset_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- 2. Paper Orders Table
+CREATE TABLE IF NOT EXISTS paper_orders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    market_id VARCHAR(66) NOT NULL,
+    token_id VARCHAR(255) NOT NULL,
+    outcome VARCHAR(64),
+    side VARCHAR(4) NOT NULL,
+    order_type VARCHAR(10) NOT NULL,
+    price DECIMAL NOT NULL,
+    size DECIMAL NOT NULL,
+    filled_size DECIMAL NOT NULL DEFAULT 0,
+    filled_notional DECIMAL NOT NULL DEFAULT 0,
+    queue_ahead DECIMAL NOT NULL DEFAULT 0,
+    status VARCHAR(16) NOT NULL,
+    status_reason TEXT,
+    expires_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_paper_orders_user ON paper_orders(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_paper_orders_open ON paper_orders(token_id) WHERE status = 'OPEN';
+
+-- 3. Paper Fills Table
+CREATE TABLE IF NOT EXISTS paper_fills (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES paper_orders(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    market_id VARCHAR(66) NOT NULL,
+    token_id VARCHAR(255) NOT NULL,
+    side VARCHAR(4) NOT NULL,
+    price DECIMAL NOT NULL,
+    size DECIMAL NOT NULL,
+    liquidity VARCHAR(5) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_paper_fills_user ON paper_fills(user_id, created_at DESC);
+
+-- 4. Paper Positions Table
+CREATE TABLE IF NOT EXISTS paper_positions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    market_id VARCHAR(66) NOT NULL,
+    token_id VARCHAR(255) NOT NULL,
+    outcome VARCHAR(64),
+    size DECIMAL NOT NULL DEFAULT 0,
+    reserved_size DECIMAL NOT NULL DEFAULT 0,
+    avg_price DECIMAL NOT NULL DEFAULT 0,
+    realized_pnl DECIMAL NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (user_id, token_id)
+);
@@ -0,0 +1,35 @@
+/**
+ * Migration: Market History
+ *
+ * Adds tables for:
+ * - market_trades: The public trade tape recorded from RTDS last_trade_price events
+ * - book_snapshots: Sampled order book snapshots recorded from RTDS book events
+ *
+ * Both feed the backtesting engine alongside price_history.
+ */
+
+-- 1. Market Trades Table
+CREATE TABLE IF NOT EXISTS market_trades (
+    id BIGSERIAL PRIMARY KEY,
+    market_id VARCHAR(66) NOT NULL,
+    token_id VARCHAR(255) NOT NULL,
+    price DECIMAL NOT NULL,
+    size DECIMAL NOT NULL,
+    side VARCHAR(4),
+    fee_rate_bps INTEGER NOT NULL DEFAULT 0,
+    traded_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_market_trades_market_time ON market_trades(market_id, traded_at);
+
+-- 2. Book Snapshots Table
+CREATE TABLE IF NOT EXISTS book_snapshots (
+    id BIGSERIAL PRIMARY KEY,
+    market_id VARCHAR(66) NOT NULL,
+    token_id VARCHAR(255) NOT NULL,
+    bids JSONB NOT NULL,
+    asks JSONB NOT NULL,
+    captured_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_book_snapshots_market_time ON book_snapshots(market_id, captured_at);
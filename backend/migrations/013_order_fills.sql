@@ -0,0 +1,15 @@
+/**
+ * Migration: Order Fill Details
+ *
+ * Adds columns to orders so tax-lot accounting can use actual fills:
+ * - size_matched: Shares matched so far (partial fills on canceled / open orders)
+ * - fee_rate_bps: Fee rate the order was signed with
+ *
+ * Note: rows synced before this migration fall back to size for FILLED orders and a zero fee rate.
+ */
+
+-- 1. Fill details on orders
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS size_matched DECIMAL NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS fee_rate_bps INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_orders_user_created ON orders(user_id, created_at);
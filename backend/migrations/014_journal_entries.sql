@@ -0,0 +1,35 @@
+/**
+ * Migration: Trade Journal
+ *
+ * Adds tables for:
+ * - journal_entries: Per-user trade notes attached to an order, a market or a position (market outcome token),
+ *   with tags, confidence, a planned exit and a post-mortem
+ *
+ * Note: realized outcomes are not stored; they are derived from the market once it resolves.
+ */
+
+-- 1. Journal Entries Table
+CREATE TABLE IF NOT EXISTS journal_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    subject VARCHAR(16) NOT NULL, -- ORDER, MARKET, POSITION
+    order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    market_id VARCHAR(255) NOT NULL,
+    token_id VARCHAR(255),
+    outcome VARCHAR(64),
+    side VARCHAR(4),
+    entry_price DECIMAL,
+    notes TEXT NOT NULL DEFAULT '',
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    confidence INTEGER CHECK (confidence BETWEEN 0 AND 100),
+    planned_exit_price DECIMAL,
+    planned_exit_at TIMESTAMPTZ,
+    post_mortem TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_journal_entries_user_created ON journal_entries(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_journal_entries_market ON journal_entries(user_id, market_id);
+CREATE INDEX IF NOT EXISTS idx_journal_entries_order ON journal_entries(order_id) WHERE order_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_journal_entries_tags ON journal_entries USING GIN (tags);
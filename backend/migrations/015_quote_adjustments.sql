@@ -0,0 +1,29 @@
+/**
+ * Migration: Quote Ladders
+ *
+ * Adds tables for:
+ * - quote_adjustments: History of market-maker ladder adjustments (parameters, mid, inventory, and the
+ *   cancels / new orders computed from the diff against resting orders)
+ *
+ * Note: dry runs are not recorded.
+ */
+
+-- 1. Quote Adjustments Table
+CREATE TABLE IF NOT EXISTS quote_adjustments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    market_id VARCHAR(255) NOT NULL,
+    token_id VARCHAR(255) NOT NULL,
+    params JSONB NOT NULL,
+    midpoint DECIMAL NOT NULL,
+    inventory DECIMAL NOT NULL DEFAULT 0,
+    skew DECIMAL NOT NULL DEFAULT 0,
+    desired JSONB NOT NULL DEFAULT '[]',
+    cancels JSONB NOT NULL DEFAULT '[]',
+    places JSONB NOT NULL DEFAULT '[]',
+    kept INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_quote_adjustments_user_created ON quote_adjustments(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_quote_adjustments_token ON quote_adjustments(user_id, token_id, created_at DESC);
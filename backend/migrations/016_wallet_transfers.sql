@@ -0,0 +1,40 @@
+/**
+ * Migration: Wallet Transfers
+ *
+ * Adds tables for:
+ * - chain_cursors: Last fully processed block per on-chain scanner, so restarts resume where they stopped
+ * - wallet_transfers: USDC / USDC.e transfers into user vaults detected from ERC20 Transfer logs
+ *   (kind separates user deposits from collateral paid out by Polymarket's contracts: sell fills, merges and
+ *   redemptions)
+ *
+ * Note: scanners stay a reorg safety margin behind the chain head; (tx_hash, log_index) keeps rescans idempotent.
+ */
+
+-- 1. Chain Cursors Table
+CREATE TABLE IF NOT EXISTS chain_cursors (
+    name VARCHAR(64) PRIMARY KEY,
+    block_number BIGINT NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- 2. Wallet Transfers Table
+CREATE TABLE IF NOT EXISTS wallet_transfers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    vault_address VARCHAR(42) NOT NULL,
+    direction VARCHAR(8) NOT NULL DEFAULT 'IN', -- IN (deposit)
+    kind VARCHAR(16) NOT NULL DEFAULT 'DEPOSIT', -- DEPOSIT, SETTLEMENT
+    token VARCHAR(16) NOT NULL, -- USDC, USDC.e
+    token_address VARCHAR(42) NOT NULL,
+    from_address VARCHAR(42) NOT NULL,
+    amount NUMERIC(78, 0) NOT NULL, -- Base units (6 decimals)
+    amount_usdc DECIMAL NOT NULL,
+    tx_hash VARCHAR(66) NOT NULL,
+    log_index INTEGER NOT NULL,
+    block_number BIGINT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (tx_hash, log_index)
+);
+
+CREATE INDEX IF NOT EXISTS idx_wallet_transfers_user_created ON wallet_transfers(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_wallet_transfers_vault ON wallet_transfers(vault_address);
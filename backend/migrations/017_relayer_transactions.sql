@@ -0,0 +1,34 @@
+/**
+ * Migration: Relayer Transactions
+ *
+ * Adds tables for:
+ * - relayer_transactions: Every gasless transaction submitted through the Polymarket relayer on a user's behalf
+ *   (Safe deployments, approvals, split / merge, redemptions, withdrawals) with its relayer and on-chain state
+ *
+ * Note: status is our own PENDING / CONFIRMED / FAILED summary; state is the raw relayer state.
+ */
+
+-- 1. Relayer Transactions Table
+CREATE TABLE IF NOT EXISTS relayer_transactions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(16) NOT NULL, -- DEPLOY, APPROVE, SPLIT, MERGE, REDEEM, WITHDRAW
+    relayer_type VARCHAR(16) NOT NULL, -- SAFE-CREATE, SAFE
+    payload_hash VARCHAR(66) NOT NULL,
+    relayer_tx_id VARCHAR(128),
+    transaction_hash VARCHAR(66),
+    from_address VARCHAR(42) NOT NULL,
+    proxy_address VARCHAR(42),
+    status VARCHAR(16) NOT NULL DEFAULT 'PENDING',
+    state VARCHAR(32),
+    error TEXT,
+    poll_attempts INTEGER NOT NULL DEFAULT 0,
+    last_polled_at TIMESTAMPTZ,
+    confirmed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_relayer_transactions_relayer_id ON relayer_transactions(relayer_tx_id) WHERE relayer_tx_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_relayer_transactions_user_created ON relayer_transactions(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_relayer_transactions_pending ON relayer_transactions(status, last_polled_at) WHERE status = 'PENDING';
@@ -0,0 +1,48 @@
+/**
+ * Migration: User Wallets
+ *
+ * Adds tables for:
+ * - user_wallets: Every signer wallet (EOA) linked to a user account, with its vault (Safe / proxy), a label and
+ *   a primary flag. The primary wallet is mirrored onto users.eoa_address / vault_address / wallet_type.
+ *
+ * Also adds orders.maker_address so order history can be filtered per wallet.
+ *
+ * Note: an EOA can be linked to one account only (idx_user_wallets_eoa_unique).
+ * Existing users are backfilled as their primary wallet, and their existing orders are attributed to
+ * the vault they had at migration time (the only wallet an account could have until now).
+ */
+
+-- 1. User Wallets Table
+CREATE TABLE IF NOT EXISTS user_wallets (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    eoa_address VARCHAR(42) NOT NULL,
+    vault_address VARCHAR(42),
+    wallet_type VARCHAR(16), -- PROXY, SAFE
+    label VARCHAR(64),
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_wallets_eoa_unique ON user_wallets(LOWER(eoa_address));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_wallets_primary ON user_wallets(user_id) WHERE is_primary;
+CREATE INDEX IF NOT EXISTS idx_user_wallets_vault ON user_wallets(LOWER(vault_address)) WHERE vault_address IS NOT NULL AND vault_address <> '';
+
+INSERT INTO user_wallets (user_id, eoa_address, vault_address, wallet_type, is_primary)
+SELECT id, eoa_address, NULLIF(vault_address, ''), wallet_type, TRUE
+FROM users
+WHERE eoa_address IS NOT NULL AND eoa_address <> ''
+ON CONFLICT DO NOTHING;
+
+-- 2. Order Maker Address
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS maker_address VARCHAR(42);
+
+UPDATE orders o
+SET maker_address = u.vault_address
+FROM users u
+WHERE o.user_id = u.id
+  AND o.maker_address IS NULL
+  AND u.vault_address IS NOT NULL AND u.vault_address <> '';
+
+CREATE INDEX IF NOT EXISTS idx_orders_user_maker ON orders(user_id, LOWER(maker_address));